@@ -2,17 +2,35 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+// EnvDevelopment is the App.Env of local setups
+const EnvDevelopment = "development"
+
 type (
 	// Config -.
 	Config struct {
-		App  `yaml:"app"`
-		HTTP `yaml:"http"`
-		Log  `yaml:"logger"`
-		PG   `yaml:"postgres"`
+		App         `yaml:"app"`
+		HTTP        `yaml:"http"`
+		Log         `yaml:"logger"`
+		PG          `yaml:"postgres"`
+		Auth        `yaml:"auth"`
+		Password    `yaml:"password"`
+		JWT         `yaml:"jwt"`
+		Mail        `yaml:"mail"`
+		Lockout     `yaml:"lockout"`
+		OIDC        `yaml:"oidc"`
+		Storage     `yaml:"storage"`
+		Export      `yaml:"export"`
+		Search      `yaml:"search"`
+		Discovery   `yaml:"discovery"`
+		Suggestions `yaml:"suggestions"`
+		Reports     `yaml:"reports"`
+		Presence    `yaml:"presence"`
+		Images      `yaml:"images"`
 		// RMQ  `yaml:"rabbitmq"`
 	}
 
@@ -20,6 +38,10 @@ type (
 	App struct {
 		Name    string `env-required:"true" yaml:"name"    env:"APP_NAME"`
 		Version string `env-required:"true" yaml:"version" env:"APP_VERSION"`
+		// PublicURL is the base URL of the web client, used to build links sent by email
+		PublicURL string `env-required:"true" yaml:"public_url" env:"APP_PUBLIC_URL"`
+		// Env is 'development' on local setups, anything else is treated as a production environment
+		Env string `yaml:"env" env:"APP_ENV" env-default:"production"`
 	}
 
 	// HTTP -.
@@ -38,6 +60,155 @@ type (
 		PostgresURL string `yaml:"PG_URL" env:"PG_URL"`
 	}
 
+	// Auth -.
+	Auth struct {
+		AccessTokenTTL   time.Duration `env-required:"true" yaml:"access_token_ttl"   env:"AUTH_ACCESS_TOKEN_TTL"`
+		RefreshTokenTTL  time.Duration `env-required:"true" yaml:"refresh_token_ttl"  env:"AUTH_REFRESH_TOKEN_TTL"`
+		PasswordResetTTL time.Duration `env-required:"true" yaml:"password_reset_ttl" env:"AUTH_PASSWORD_RESET_TTL"`
+		// PasswordResetRateLimit is how many reset emails can be requested for an email per PasswordResetRateWindow,
+		// PasswordResetRateLimitPerIP how many a client IP can request. A limit of zero disables it.
+		PasswordResetRateLimit      int           `yaml:"password_reset_rate_limit"        env:"AUTH_PASSWORD_RESET_RATE_LIMIT"`
+		PasswordResetRateLimitPerIP int           `yaml:"password_reset_rate_limit_per_ip" env:"AUTH_PASSWORD_RESET_RATE_LIMIT_PER_IP"`
+		PasswordResetRateWindow     time.Duration `yaml:"password_reset_rate_window"       env:"AUTH_PASSWORD_RESET_RATE_WINDOW"`
+		// EmailVerificationTTL is how long a verification link stays valid, a new one can be requested after VerificationResendCooldown
+		EmailVerificationTTL       time.Duration `env-required:"true" yaml:"email_verification_ttl"       env:"AUTH_EMAIL_VERIFICATION_TTL"`
+		VerificationResendCooldown time.Duration `env-required:"true" yaml:"verification_resend_cooldown" env:"AUTH_VERIFICATION_RESEND_COOLDOWN"`
+		// RequireVerifiedEmail refuses login until the email address is verified
+		RequireVerifiedEmail bool `yaml:"require_verified_email" env:"AUTH_REQUIRE_VERIFIED_EMAIL"`
+		// MFAIssuer is the name authenticator apps show next to the account
+		MFAIssuer string `env-required:"true" yaml:"mfa_issuer" env:"AUTH_MFA_ISSUER"`
+		// AccountDeletionGracePeriod delays erasing a deleted account, logging in again before it ends cancels the deletion.
+		// Accounts are erased right away if it is zero.
+		AccountDeletionGracePeriod time.Duration `yaml:"account_deletion_grace_period" env:"AUTH_ACCOUNT_DELETION_GRACE_PERIOD"`
+		// UsernameHoldPeriod is how long a username that was changed stays reserved for its previous owner
+		UsernameHoldPeriod time.Duration `env-required:"true" yaml:"username_hold_period" env:"AUTH_USERNAME_HOLD_PERIOD"`
+	}
+
+	// Password -.
+	// Algorithm is one of 'argon2id' or 'bcrypt' and is used for new hashes. Hashes of the other algorithm, or created
+	// with other parameters, are still accepted and upgraded on the next login. Argon2Memory is in KiB.
+	Password struct {
+		Algorithm         string `env-required:"true" yaml:"algorithm" env:"PASSWORD_ALGORITHM"`
+		BcryptCost        int    `yaml:"bcrypt_cost"        env:"PASSWORD_BCRYPT_COST"        env-default:"12"`
+		Argon2Memory      uint32 `yaml:"argon2_memory"      env:"PASSWORD_ARGON2_MEMORY"      env-default:"65536"`
+		Argon2Iterations  uint32 `yaml:"argon2_iterations"  env:"PASSWORD_ARGON2_ITERATIONS"  env-default:"3"`
+		Argon2Parallelism uint8  `yaml:"argon2_parallelism" env:"PASSWORD_ARGON2_PARALLELISM" env-default:"2"`
+	}
+
+	// JWT -.
+	// Keys are read from the config file. KeyID, KeyAlgorithm, KeySecret and KeyFile add one more key from the
+	// environment, which replaces the key of the config file with the same id.
+	JWT struct {
+		ActiveKeyID  string   `env-required:"true" yaml:"active_key_id" env:"JWT_ACTIVE_KEY_ID"`
+		Keys         []JWTKey `yaml:"keys"`
+		KeyID        string   `yaml:"-" env:"JWT_KEY_ID"`
+		KeyAlgorithm string   `yaml:"-" env:"JWT_KEY_ALGORITHM"`
+		KeySecret    string   `yaml:"-" env:"JWT_KEY_SECRET"`
+		KeyFile      string   `yaml:"-" env:"JWT_KEY_FILE"`
+	}
+
+	// JWTKey -.
+	// Secret holds the shared secret for HS256 keys. KeyFile points to a file holding the HS256 secret,
+	// or the PEM encoded key for RS256 and EdDSA keys.
+	// Dev marks a key shipped for local setups, it can only be the active key when App.Env is 'development'.
+	JWTKey struct {
+		ID        string `yaml:"id"`
+		Algorithm string `yaml:"algorithm"`
+		Secret    string `yaml:"secret"`
+		KeyFile   string `yaml:"key_file"`
+		Dev       bool   `yaml:"dev"`
+	}
+
+	// Mail -.
+	// Driver is one of 'smtp', 'file' or 'memory'.
+	Mail struct {
+		Driver       string `env-required:"true" yaml:"driver" env:"MAIL_DRIVER"`
+		From         string `env-required:"true" yaml:"from"   env:"MAIL_FROM"`
+		SMTPHost     string `yaml:"smtp_host"     env:"MAIL_SMTP_HOST"`
+		SMTPPort     string `yaml:"smtp_port"     env:"MAIL_SMTP_PORT"`
+		SMTPUsername string `yaml:"smtp_username" env:"MAIL_SMTP_USERNAME"`
+		SMTPPassword string `yaml:"smtp_password" env:"MAIL_SMTP_PASSWORD"`
+		FileDir      string `yaml:"file_dir"      env:"MAIL_FILE_DIR"`
+	}
+
+	// Lockout -.
+	// Store is one of 'postgres' or 'memory'. The memory store is not shared between instances.
+	Lockout struct {
+		Store            string        `env-required:"true" yaml:"store"               env:"LOCKOUT_STORE"`
+		MaxFailures      int           `env-required:"true" yaml:"max_failures"        env:"LOCKOUT_MAX_FAILURES"`
+		MaxFailuresPerIP int           `env-required:"true" yaml:"max_failures_per_ip" env:"LOCKOUT_MAX_FAILURES_PER_IP"`
+		FailureWindow    time.Duration `env-required:"true" yaml:"failure_window"      env:"LOCKOUT_FAILURE_WINDOW"`
+		BaseLockout      time.Duration `env-required:"true" yaml:"base_lockout"        env:"LOCKOUT_BASE_LOCKOUT"`
+		MaxLockout       time.Duration `env-required:"true" yaml:"max_lockout"         env:"LOCKOUT_MAX_LOCKOUT"`
+	}
+
+	// OIDC -.
+	// Single sign-on is only enabled when IssuerURL is set. RedirectURL must point to '/user/sso/callback'.
+	OIDC struct {
+		IssuerURL     string        `yaml:"issuer_url"      env:"OIDC_ISSUER_URL"`
+		ClientID      string        `yaml:"client_id"       env:"OIDC_CLIENT_ID"`
+		ClientSecret  string        `yaml:"client_secret"   env:"OIDC_CLIENT_SECRET"`
+		RedirectURL   string        `yaml:"redirect_url"    env:"OIDC_REDIRECT_URL"`
+		Scopes        []string      `yaml:"scopes"          env:"OIDC_SCOPES" env-separator:","`
+		LoginStateTTL time.Duration `yaml:"login_state_ttl" env:"OIDC_LOGIN_STATE_TTL" env-default:"10m"`
+	}
+
+	// Storage -.
+	// Driver is one of 'file' or 'memory'. The memory store is not shared between instances.
+	Storage struct {
+		Driver string `env-required:"true" yaml:"driver" env:"STORAGE_DRIVER"`
+		Dir    string `yaml:"dir"    env:"STORAGE_DIR"`
+	}
+
+	// Export -.
+	// TTL is how long a personal data export can be downloaded once it is ready.
+	Export struct {
+		TTL time.Duration `env-required:"true" yaml:"ttl" env:"EXPORT_TTL"`
+	}
+
+	// Search -.
+	// Each user can search the user directory RateLimit times per RateWindow, a RateLimit of zero disables the limit.
+	Search struct {
+		RateLimit  int           `yaml:"rate_limit"  env:"SEARCH_RATE_LIMIT"`
+		RateWindow time.Duration `env-required:"true" yaml:"rate_window" env:"SEARCH_RATE_WINDOW"`
+	}
+
+	// Discovery -.
+	// Each user can match their address book against registered users RateLimit times per RateWindow, a RateLimit of zero disables the limit.
+	Discovery struct {
+		RateLimit  int           `yaml:"rate_limit"  env:"DISCOVERY_RATE_LIMIT"`
+		RateWindow time.Duration `env-required:"true" yaml:"rate_window" env:"DISCOVERY_RATE_WINDOW"`
+	}
+
+	// Suggestions -.
+	// The contact suggestions of a user are recomputed once a change invalidated them, or once they are older than TTL.
+	Suggestions struct {
+		TTL time.Duration `env-required:"true" yaml:"ttl" env:"SUGGESTIONS_TTL"`
+	}
+
+	// Reports -.
+	// Each user can report abuse RateLimit times per RateWindow, a RateLimit of zero disables the limit.
+	Reports struct {
+		RateLimit  int           `yaml:"rate_limit"  env:"REPORTS_RATE_LIMIT"`
+		RateWindow time.Duration `env-required:"true" yaml:"rate_window" env:"REPORTS_RATE_WINDOW"`
+	}
+
+	// Presence -.
+	// Connected users are away after being idle for AwayAfter, and offline once disconnected for OfflineAfter.
+	Presence struct {
+		AwayAfter    time.Duration `env-required:"true" yaml:"away_after"    env:"PRESENCE_AWAY_AFTER"`
+		OfflineAfter time.Duration `env-required:"true" yaml:"offline_after" env:"PRESENCE_OFFLINE_AFTER"`
+	}
+
+	// Images -.
+	// Uploaded avatars and group pictures are limited to MaxUploadSize bytes and MaxPixels pixels,
+	// and stored as one square thumbnail per size of Sizes, the last size is served by default.
+	Images struct {
+		MaxUploadSize int64 `env-required:"true" yaml:"max_upload_size" env:"IMAGES_MAX_UPLOAD_SIZE"`
+		MaxPixels     int   `env-required:"true" yaml:"max_pixels"      env:"IMAGES_MAX_PIXELS"`
+		Sizes         []int `env-required:"true" yaml:"sizes"           env:"IMAGES_SIZES" env-separator:","`
+	}
+
 	// // RMQ -.
 	// RMQ struct {
 	// 	ServerExchange string `env-required:"false" yaml:"rpc_server_exchange" env:"RMQ_RPC_SERVER"`
@@ -65,5 +236,44 @@ func NewConfig() (*Config, error) {
 		return nil, err
 	}
 
+	cfg.JWT.addEnvKey()
+	err = cfg.JWT.validate(cfg.App.Env)
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
 	return cfg, nil
 }
+
+// addEnvKey adds the key set in the environment to the keys of the config file
+func (c *JWT) addEnvKey() {
+	if c.KeyID == "" {
+		return
+	}
+
+	envKey := JWTKey{ID: c.KeyID, Algorithm: c.KeyAlgorithm, Secret: c.KeySecret, KeyFile: c.KeyFile}
+	for i, k := range c.Keys {
+		if k.ID == envKey.ID {
+			c.Keys[i] = envKey
+			return
+		}
+	}
+	c.Keys = append(c.Keys, envKey)
+}
+
+// validate refuses to start without an active key, or with the development key outside a development environment
+func (c *JWT) validate(env string) error {
+	for _, k := range c.Keys {
+		if k.ID != c.ActiveKeyID {
+			continue
+		}
+		if k.Secret == "" && k.KeyFile == "" {
+			return fmt.Errorf("jwt active key %q has no secret or key file", k.ID)
+		}
+		if k.Dev && env != EnvDevelopment {
+			return fmt.Errorf("jwt active key %q is a development key and APP_ENV is %q", k.ID, env)
+		}
+		return nil
+	}
+	return fmt.Errorf("jwt active key %q is not configured", c.ActiveKeyID)
+}
@@ -2,23 +2,33 @@
 package app
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/maxyong7/chat-messaging-app/config"
 	v1 "github.com/maxyong7/chat-messaging-app/internal/controller/http/v1"
 	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase/mailer"
 	"github.com/maxyong7/chat-messaging-app/internal/usecase/repo"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase/storage"
 	"github.com/maxyong7/chat-messaging-app/pkg/httpserver"
+	"github.com/maxyong7/chat-messaging-app/pkg/jwtsigner"
 	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+	"github.com/maxyong7/chat-messaging-app/pkg/oidc"
+	"github.com/maxyong7/chat-messaging-app/pkg/password"
 	"github.com/maxyong7/chat-messaging-app/pkg/postgres"
 )
 
+const _maintenanceInterval = 10 * time.Minute
+
 // Run creates objects via constructors.
 func Run(cfg *config.Config) {
 	l := logger.New(cfg.Log.Level)
@@ -40,35 +50,161 @@ func Run(cfg *config.Config) {
 		}
 	}(pg)
 
+	tokenSigner, err := newTokenSigner(cfg.JWT)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newTokenSigner: %w", err))
+	}
+
 	userInfoRepo := repo.NewUserInfo(pg)
 	// Use case
 	// translationUseCase := usecase.New(
 	// 	repo.New(pg),
 	// 	webapi.New(),
 	// )
+	passwordHasher := newPasswordHasher(cfg.Password)
 	verificationUseCase := usecase.NewAuth(
 		userInfoRepo,
+		passwordHasher,
+		cfg.Auth.RequireVerifiedEmail,
+	)
+	sessionRepo := repo.NewSession(pg)
+	sessionUseCase := usecase.NewSession(
+		sessionRepo,
+		userInfoRepo,
+		cfg.Auth.AccessTokenTTL,
+		cfg.Auth.RefreshTokenTTL,
+	)
+	userTokenRepo := repo.NewUserToken(pg)
+	mailSender := newMailer(cfg.Mail)
+	passwordResetUseCase := usecase.NewPasswordReset(
+		userInfoRepo,
+		userTokenRepo,
+		sessionRepo,
+		mailSender,
+		passwordHasher,
+		cfg.App.PublicURL,
+		cfg.Auth.PasswordResetTTL,
+		cfg.Auth.PasswordResetRateLimit,
+		cfg.Auth.PasswordResetRateLimitPerIP,
+		cfg.Auth.PasswordResetRateWindow,
+	)
+	emailVerificationUseCase := usecase.NewEmailVerification(
+		userInfoRepo,
+		userTokenRepo,
+		mailSender,
+		cfg.App.PublicURL,
+		cfg.Auth.EmailVerificationTTL,
+		cfg.Auth.VerificationResendCooldown,
+	)
+	credentialsUseCase := usecase.NewCredentials(
+		userInfoRepo,
+		userTokenRepo,
+		sessionRepo,
+		mailSender,
+		passwordHasher,
+		cfg.App.PublicURL,
+		cfg.Auth.EmailVerificationTTL,
+		cfg.Auth.UsernameHoldPeriod,
+	)
+	mfaUseCase := usecase.NewMFA(
+		repo.NewMFA(pg),
+		userInfoRepo,
+		cfg.Auth.MFAIssuer,
+	)
+	loginThrottleUseCase := usecase.NewLoginThrottle(
+		newLoginAttemptRepo(cfg.Lockout, pg),
+		usecase.LockoutPolicy{
+			MaxFailures:      cfg.Lockout.MaxFailures,
+			MaxFailuresPerIP: cfg.Lockout.MaxFailuresPerIP,
+			FailureWindow:    cfg.Lockout.FailureWindow,
+			BaseLockout:      cfg.Lockout.BaseLockout,
+			MaxLockout:       cfg.Lockout.MaxLockout,
+		},
+	)
+	ssoUseCase := newSSOUseCase(cfg.OIDC, pg, userInfoRepo, cfg.Auth.RequireVerifiedEmail, l)
+	accountDeletionUseCase := usecase.NewAccountDeletion(
+		repo.NewAccountDeletion(pg),
+		userInfoRepo,
+		sessionRepo,
+		passwordHasher,
+		cfg.Auth.AccountDeletionGracePeriod,
+	)
+	blobStore := newBlobStore(cfg.Storage)
+	dataExportUseCase := usecase.NewDataExport(
+		repo.NewDataExport(pg),
+		userInfoRepo,
+		blobStore,
+		cfg.Export.TTL,
+	)
+	botUseCase := usecase.NewBot(
+		repo.NewBot(pg),
+		repo.NewGroupChat(pg),
+	)
+	userSearchUseCase := usecase.NewUserSearch(
+		repo.NewUserSearch(pg),
+		cfg.Search.RateLimit,
+		cfg.Search.RateWindow,
+	)
+	contactSuggestionUseCase := usecase.NewContactSuggestion(
+		repo.NewContactSuggestion(pg),
+		cfg.Suggestions.TTL,
+	)
+	privacyUseCase := usecase.NewPrivacy(
+		repo.NewPrivacy(pg),
+	)
+	presenceUseCase := usecase.NewPresence(
+		repo.NewPresence(pg),
+		repo.NewContacts(pg),
+		repo.NewPrivacy(pg),
+		cfg.Presence.AwayAfter,
+		cfg.Presence.OfflineAfter,
+	)
+	imageUseCase := usecase.NewImage(
+		blobStore,
+		userInfoRepo,
+		repo.NewGroupChat(pg),
+		cfg.Images.MaxUploadSize,
+		cfg.Images.MaxPixels,
+		cfg.Images.Sizes,
+	)
+	adminUseCase := usecase.NewAdmin(
+		repo.NewAdmin(pg),
+		sessionRepo,
+	)
+	reportUseCase := usecase.NewReport(
+		repo.NewReport(pg),
+		cfg.Reports.RateLimit,
+		cfg.Reports.RateWindow,
 	)
 	conversationUseCase := usecase.NewConversation(
 		repo.NewConversation(pg),
+		repo.NewContacts(pg),
 	)
 	contactUseCase := usecase.NewContacts(
 		repo.NewContacts(pg),
 		userInfoRepo,
+		repo.NewPrivacy(pg),
+		cfg.Discovery.RateLimit,
+		cfg.Discovery.RateWindow,
 	)
 	messageUseCase := usecase.NewMessage(
 		repo.NewMessage(pg),
 		repo.NewReaction(pg),
+		repo.NewPrivacy(pg),
 	)
 	groupChatUseCase := usecase.NewGroupChat(
 		repo.NewGroupChat(pg),
 		repo.NewUserInfo(pg),
+		repo.NewContacts(pg),
+		repo.NewPrivacy(pg),
 	)
 	userProfileUseCase := usecase.NewUserProfile(
 		repo.NewUserInfo(pg),
+		repo.NewContacts(pg),
 	)
 	reactionUseCase := usecase.NewReaction(
 		repo.NewReaction(pg),
+		repo.NewContacts(pg),
 	)
 
 	// // RabbitMQ RPC Server
@@ -82,18 +218,39 @@ func Run(cfg *config.Config) {
 	// HTTP Server
 	handler := gin.New()
 	routerUseCase := v1.RouterUseCases{
-		Verification: verificationUseCase,
-		Conversation: conversationUseCase,
-		Contact:      contactUseCase,
-		Message:      messageUseCase,
-		GroupChat:    groupChatUseCase,
-		UserProfile:  userProfileUseCase,
-		Reaction:     reactionUseCase,
+		Verification:      verificationUseCase,
+		Session:           sessionUseCase,
+		PasswordReset:     passwordResetUseCase,
+		EmailVerification: emailVerificationUseCase,
+		Credentials:       credentialsUseCase,
+		MFA:               mfaUseCase,
+		LoginThrottle:     loginThrottleUseCase,
+		SSO:               ssoUseCase,
+		AccountDeletion:   accountDeletionUseCase,
+		DataExport:        dataExportUseCase,
+		Bot:               botUseCase,
+		UserSearch:        userSearchUseCase,
+		ContactSuggestion: contactSuggestionUseCase,
+		Privacy:           privacyUseCase,
+		Presence:          presenceUseCase,
+		Image:             imageUseCase,
+		Admin:             adminUseCase,
+		Report:            reportUseCase,
+		Conversation:      conversationUseCase,
+		Contact:           contactUseCase,
+		Message:           messageUseCase,
+		GroupChat:         groupChatUseCase,
+		UserProfile:       userProfileUseCase,
+		Reaction:          reactionUseCase,
 	}
-	v1.NewRouter(handler, l, routerUseCase)
+	v1.NewRouter(handler, l, routerUseCase, tokenSigner, cfg.Images.MaxUploadSize)
 
 	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port))
 
+	// Erase accounts whose deletion grace period has ended and delete expired data exports
+	stopMaintenance := make(chan struct{})
+	go runMaintenance(accountDeletionUseCase, dataExportUseCase, l, stopMaintenance)
+
 	// Waiting signal
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
@@ -108,6 +265,7 @@ func Run(cfg *config.Config) {
 	}
 
 	// Shutdown
+	close(stopMaintenance)
 	err = httpServer.Shutdown()
 	if err != nil {
 		l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
@@ -118,3 +276,124 @@ func Run(cfg *config.Config) {
 	// 	l.Error(fmt.Errorf("app - Run - rmqServer.Shutdown: %w", err))
 	// }
 }
+
+// newTokenSigner loads the JWT signing keys from config
+func newTokenSigner(cfg config.JWT) (*jwtsigner.Signer, error) {
+	keys := make([]*jwtsigner.Key, 0, len(cfg.Keys))
+	for _, k := range cfg.Keys {
+		material := []byte(k.Secret)
+		if k.KeyFile != "" {
+			pem, err := os.ReadFile(k.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("os.ReadFile: %w", err)
+			}
+			material = pem
+			if k.Algorithm == jwtsigner.HS256 {
+				// Secret files usually end with a newline, which is not part of the secret
+				material = bytes.TrimSpace(pem)
+			}
+		}
+
+		key, err := jwtsigner.NewKey(k.ID, k.Algorithm, material)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return jwtsigner.New(cfg.ActiveKeyID, keys...)
+}
+
+// newMailer picks the mail sender configured by the mail driver
+func newMailer(cfg config.Mail) usecase.Mailer {
+	switch cfg.Driver {
+	case "smtp":
+		return mailer.NewSMTP(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	case "memory":
+		return mailer.NewMemory()
+	default:
+		return mailer.NewFile(cfg.FileDir, cfg.From)
+	}
+}
+
+// newLoginAttemptRepo picks the failed login counter store configured by the lockout store
+func newLoginAttemptRepo(cfg config.Lockout, pg *sql.DB) usecase.LoginAttemptRepo {
+	switch cfg.Store {
+	case "memory":
+		return repo.NewLoginAttemptMemory()
+	default:
+		return repo.NewLoginAttempt(pg)
+	}
+}
+
+// newSSOUseCase creates the single sign-on use case, or returns nil if no identity provider is configured
+func newSSOUseCase(cfg config.OIDC, pg *sql.DB, userRepo usecase.UserRepo, requireVerifiedEmail bool, l logger.Interface) usecase.SSO {
+	if cfg.IssuerURL == "" {
+		return nil
+	}
+
+	provider := oidc.New(oidc.Config{
+		IssuerURL:    cfg.IssuerURL,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       cfg.Scopes,
+	})
+	return usecase.NewSSO(provider, repo.NewSSO(pg), userRepo, cfg.LoginStateTTL, requireVerifiedEmail, l)
+}
+
+// newPasswordHasher hashes new passwords with the configured algorithm, hashes of the other algorithm are verified and upgraded on login
+func newPasswordHasher(cfg config.Password) usecase.PasswordHasher {
+	bcryptHasher := password.NewBcrypt(cfg.BcryptCost)
+	argon2idHasher := password.NewArgon2id(password.Argon2idParams{
+		Memory:      cfg.Argon2Memory,
+		Iterations:  cfg.Argon2Iterations,
+		Parallelism: cfg.Argon2Parallelism,
+	})
+
+	switch cfg.Algorithm {
+	case "bcrypt":
+		return password.New(bcryptHasher, argon2idHasher)
+	default:
+		return password.New(argon2idHasher, bcryptHasher)
+	}
+}
+
+// newBlobStore picks the blob store configured by the storage driver
+func newBlobStore(cfg config.Storage) usecase.BlobStore {
+	switch cfg.Driver {
+	case "memory":
+		return storage.NewMemory()
+	default:
+		return storage.NewFile(cfg.Dir)
+	}
+}
+
+// runMaintenance periodically erases the accounts that are due and deletes expired data exports until stop is closed
+func runMaintenance(accountDeletion usecase.AccountDeletion, dataExport usecase.DataExport, l logger.Interface, stop <-chan struct{}) {
+	ticker := time.NewTicker(_maintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			purged, err := accountDeletion.PurgeDueAccounts(context.Background())
+			if err != nil {
+				l.Error(fmt.Errorf("app - runMaintenance - accountDeletion.PurgeDueAccounts: %w", err))
+			}
+			if purged > 0 {
+				l.Info("app - runMaintenance - erased %d accounts", purged)
+			}
+
+			purged, err = dataExport.PurgeExpiredExports(context.Background())
+			if err != nil {
+				l.Error(fmt.Errorf("app - runMaintenance - dataExport.PurgeExpiredExports: %w", err))
+			}
+			if purged > 0 {
+				l.Info("app - runMaintenance - deleted %d expired data exports", purged)
+			}
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package boundary
+
+import (
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+type DeleteAccountForm struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type AccountDeletionResponse struct {
+	Deleted     bool       `json:"deleted"`
+	DeleteAfter *time.Time `json:"delete_after,omitempty"`
+}
+
+func ToAccountDeletionResponse(deletion entity.AccountDeletion) AccountDeletionResponse {
+	return AccountDeletionResponse{
+		Deleted:     deletion.Deleted,
+		DeleteAfter: deletion.DeleteAfter,
+	}
+}
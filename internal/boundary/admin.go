@@ -0,0 +1,105 @@
+package boundary
+
+import (
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// AdminActionForm -.
+// The reason is optional, it is stored in the audit log.
+type AdminActionForm struct {
+	Reason string `json:"reason"`
+}
+
+type AdminUserResponse struct {
+	UserUUID         string     `json:"user_uuid"`
+	Username         string     `json:"username"`
+	Email            string     `json:"email"`
+	FirstName        string     `json:"first_name"`
+	LastName         string     `json:"last_name"`
+	Role             string     `json:"role"`
+	VerifiedAt       *time.Time `json:"verified_at,omitempty"`
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty"`
+	SuspensionReason string     `json:"suspension_reason,omitempty"`
+	DeleteAfter      *time.Time `json:"delete_after,omitempty"`
+}
+
+type AdminUsersResponse struct {
+	Users      []AdminUserResponse `json:"users"`
+	Pagination Pagination          `json:"pagination"`
+}
+
+type AdminAuditEntryResponse struct {
+	AuditUUID  string    `json:"audit_uuid"`
+	AdminUUID  string    `json:"admin_uuid"`
+	Action     string    `json:"action"`
+	TargetType string    `json:"target_type"`
+	TargetUUID string    `json:"target_uuid"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AdminAuditLogResponse struct {
+	Entries    []AdminAuditEntryResponse `json:"entries"`
+	Pagination Pagination                `json:"pagination"`
+}
+
+func (r AdminActionForm) ToAdminAction(adminUUID string, targetUUID string) entity.AdminAction {
+	return entity.AdminAction{
+		AdminUUID:  adminUUID,
+		TargetUUID: targetUUID,
+		Reason:     r.Reason,
+	}
+}
+
+// ToAdminUsersResponse -.
+// cursor is empty on the last page.
+func ToAdminUsersResponse(page entity.AdminUserPage, cursor string) AdminUsersResponse {
+	resp := AdminUsersResponse{
+		Users: make([]AdminUserResponse, 0, len(page.Users)),
+		Pagination: Pagination{
+			Cursor: cursor,
+			Limit:  page.Limit,
+		},
+	}
+	for _, user := range page.Users {
+		resp.Users = append(resp.Users, AdminUserResponse{
+			UserUUID:         user.UserUUID,
+			Username:         user.Username,
+			Email:            user.Email,
+			FirstName:        user.FirstName,
+			LastName:         user.LastName,
+			Role:             user.Role,
+			VerifiedAt:       user.VerifiedAt,
+			SuspendedAt:      user.SuspendedAt,
+			SuspensionReason: user.SuspensionReason,
+			DeleteAfter:      user.DeleteAfter,
+		})
+	}
+	return resp
+}
+
+// ToAdminAuditLogResponse -.
+// cursor is empty on the last page.
+func ToAdminAuditLogResponse(page entity.AdminAuditPage, cursor string) AdminAuditLogResponse {
+	resp := AdminAuditLogResponse{
+		Entries: make([]AdminAuditEntryResponse, 0, len(page.Entries)),
+		Pagination: Pagination{
+			Cursor: cursor,
+			Limit:  page.Limit,
+		},
+	}
+	for _, entry := range page.Entries {
+		resp.Entries = append(resp.Entries, AdminAuditEntryResponse{
+			AuditUUID:  entry.AuditUUID,
+			AdminUUID:  entry.AdminUUID,
+			Action:     entry.Action,
+			TargetType: entry.TargetType,
+			TargetUUID: entry.TargetUUID,
+			Reason:     entry.Reason,
+			CreatedAt:  entry.CreatedAt,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,94 @@
+package boundary
+
+import (
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+type CreateBotForm struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type CreateAPIKeyForm struct {
+	Name              string     `json:"name"`
+	Scopes            []string   `json:"scopes" binding:"required"`
+	ConversationUUIDs []string   `json:"conversation_uuids" binding:"required"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+}
+
+type BotMessageForm struct {
+	Content string `json:"content" binding:"required"`
+}
+
+type BotResponse struct {
+	BotUUID   string    `json:"bot_uuid"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BotsResponse struct {
+	Bots []BotResponse `json:"bots"`
+}
+
+type APIKeyResponse struct {
+	KeyUUID string `json:"key_uuid"`
+	Name    string `json:"name"`
+	// Key is only returned when the key is created
+	Key               string     `json:"key,omitempty"`
+	Scopes            []string   `json:"scopes"`
+	ConversationUUIDs []string   `json:"conversation_uuids"`
+	CreatedAt         time.Time  `json:"created_at"`
+	LastUsedAt        *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+}
+
+type APIKeysResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
+
+func (r CreateAPIKeyForm) ToAPIKeyRequest() entity.APIKeyRequest {
+	return entity.APIKeyRequest{
+		Name:              r.Name,
+		Scopes:            r.Scopes,
+		ConversationUUIDs: r.ConversationUUIDs,
+		ExpiresAt:         r.ExpiresAt,
+	}
+}
+
+func ToBotResponse(bot entity.Bot) BotResponse {
+	return BotResponse{
+		BotUUID:   bot.BotUUID,
+		Name:      bot.Name,
+		CreatedAt: bot.CreatedAt,
+	}
+}
+
+func ToBotsResponse(bots []entity.Bot) BotsResponse {
+	resp := BotsResponse{Bots: make([]BotResponse, 0, len(bots))}
+	for _, bot := range bots {
+		resp.Bots = append(resp.Bots, ToBotResponse(bot))
+	}
+	return resp
+}
+
+func ToAPIKeyResponse(key entity.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		KeyUUID:           key.KeyUUID,
+		Name:              key.Name,
+		Key:               key.Key,
+		Scopes:            key.Scopes,
+		ConversationUUIDs: key.ConversationUUIDs,
+		CreatedAt:         key.CreatedAt,
+		LastUsedAt:        key.LastUsedAt,
+		ExpiresAt:         key.ExpiresAt,
+	}
+}
+
+func ToAPIKeysResponse(keys []entity.APIKey) APIKeysResponse {
+	resp := APIKeysResponse{APIKeys: make([]APIKeyResponse, 0, len(keys))}
+	for _, key := range keys {
+		resp.APIKeys = append(resp.APIKeys, ToAPIKeyResponse(key))
+	}
+	return resp
+}
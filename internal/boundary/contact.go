@@ -1,6 +1,162 @@
 package boundary
 
+import (
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
 type ContactsScreen struct {
 	Username string
 	Blocked  bool
 }
+
+// ContactDetailsForm -.
+// Omitted fields are left unchanged, an empty nickname or labels list clears them.
+type ContactDetailsForm struct {
+	Nickname  *string   `json:"nickname"`
+	Favourite *bool     `json:"favourite"`
+	Labels    *[]string `json:"labels"`
+}
+
+type ContactsResponse struct {
+	Contacts   []entity.Contacts `json:"contacts"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+// ContactRequestResponse -.
+// User is the other user of the request, it is only set when requests are listed.
+type ContactRequestResponse struct {
+	RequestUUID      string              `json:"request_uuid"`
+	FromUserUUID     string              `json:"from_user_uuid"`
+	ToUserUUID       string              `json:"to_user_uuid"`
+	Status           string              `json:"status"`
+	ConversationUUID string              `json:"conversation_uuid,omitempty"`
+	CreatedAt        time.Time           `json:"created_at"`
+	RespondedAt      *time.Time          `json:"responded_at,omitempty"`
+	User             *ContactRequestUser `json:"user,omitempty"`
+}
+
+type ContactRequestUser struct {
+	UserUUID  string `json:"user_uuid"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Avatar    string `json:"avatar"`
+}
+
+// ContactRequestResponseData is pushed over the websockets of the other user of the request, the user who acted on it is the sender of the message.
+// The conversation of the message is the new direct conversation once the request is accepted.
+type ContactRequestResponseData struct {
+	RequestUUID   string `json:"request_uuid,omitempty"`
+	RequestStatus string `json:"request_status,omitempty"`
+}
+
+func (r ContactDetailsForm) ToContactDetails() entity.ContactDetails {
+	return entity.ContactDetails{
+		Nickname:  r.Nickname,
+		Favourite: r.Favourite,
+		Labels:    r.Labels,
+	}
+}
+
+// ToContactsResponse -.
+// The cursor is empty on the last page.
+func ToContactsResponse(page entity.ContactsPage) ContactsResponse {
+	contacts := page.Contacts
+	if contacts == nil {
+		contacts = []entity.Contacts{}
+	}
+	return ContactsResponse{
+		Contacts: contacts,
+		Pagination: Pagination{
+			Cursor: page.NextCursor,
+			Limit:  page.Limit,
+		},
+	}
+}
+
+func ToContactRequestResponse(request entity.ContactRequest) ContactRequestResponse {
+	resp := ContactRequestResponse{
+		RequestUUID:      request.RequestUUID,
+		FromUserUUID:     request.FromUserUUID,
+		ToUserUUID:       request.ToUserUUID,
+		Status:           request.Status,
+		ConversationUUID: request.ConversationUUID,
+		CreatedAt:        request.CreatedAt,
+		RespondedAt:      request.RespondedAt,
+	}
+	if request.User.UserUUID != "" {
+		resp.User = &ContactRequestUser{
+			UserUUID:  request.User.UserUUID,
+			Username:  request.Username,
+			FirstName: request.User.FirstName,
+			LastName:  request.User.LastName,
+			Avatar:    request.User.Avatar,
+		}
+	}
+	return resp
+}
+
+func ToContactRequestsResponse(requests []entity.ContactRequest) []ContactRequestResponse {
+	resp := make([]ContactRequestResponse, 0, len(requests))
+	for _, request := range requests {
+		resp = append(resp, ToContactRequestResponse(request))
+	}
+	return resp
+}
+
+// ContactImportForm -.
+// Hashes are the hex encoded SHA-256 hashes of the salt followed by the trimmed and lower cased email addresses.
+type ContactImportForm struct {
+	Salt   string   `json:"salt" binding:"required"`
+	Hashes []string `json:"hashes" binding:"required"`
+	Add    bool     `json:"add"`
+}
+
+type ContactMatchResponse struct {
+	Hash      string                  `json:"hash"`
+	UserUUID  string                  `json:"user_uuid"`
+	Username  string                  `json:"username"`
+	FirstName string                  `json:"first_name"`
+	LastName  string                  `json:"last_name"`
+	Avatar    string                  `json:"avatar"`
+	Contact   bool                    `json:"contact"`
+	Request   *ContactRequestResponse `json:"request,omitempty"`
+}
+
+type ContactImportResponse struct {
+	Matches []ContactMatchResponse `json:"matches"`
+}
+
+func (r ContactImportForm) ToContactImport(userUUID string) entity.ContactImport {
+	return entity.ContactImport{
+		UserUUID: userUUID,
+		Salt:     r.Salt,
+		Hashes:   r.Hashes,
+		Add:      r.Add,
+	}
+}
+
+func ToContactImportResponse(matches []entity.ContactMatch) ContactImportResponse {
+	resp := ContactImportResponse{
+		Matches: make([]ContactMatchResponse, 0, len(matches)),
+	}
+	for _, match := range matches {
+		matchResp := ContactMatchResponse{
+			Hash:      match.Hash,
+			UserUUID:  match.User.UserUUID,
+			Username:  match.Username,
+			FirstName: match.User.FirstName,
+			LastName:  match.User.LastName,
+			Avatar:    match.User.Avatar,
+			Contact:   match.Contact,
+		}
+		if match.Request != nil {
+			request := ToContactRequestResponse(*match.Request)
+			matchResp.Request = &request
+		}
+		resp.Matches = append(resp.Matches, matchResp)
+	}
+	return resp
+}
@@ -0,0 +1,29 @@
+package boundary
+
+import "github.com/maxyong7/chat-messaging-app/internal/entity"
+
+type ContactSuggestionResponse struct {
+	UserUUID       string `json:"user_uuid"`
+	Username       string `json:"username"`
+	FirstName      string `json:"first_name"`
+	LastName       string `json:"last_name"`
+	Avatar         string `json:"avatar"`
+	MutualContacts int    `json:"mutual_contacts"`
+	SharedGroups   int    `json:"shared_groups"`
+}
+
+func ToContactSuggestionsResponse(suggestions []entity.ContactSuggestion) []ContactSuggestionResponse {
+	resp := make([]ContactSuggestionResponse, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		resp = append(resp, ContactSuggestionResponse{
+			UserUUID:       suggestion.User.UserUUID,
+			Username:       suggestion.Username,
+			FirstName:      suggestion.User.FirstName,
+			LastName:       suggestion.User.LastName,
+			Avatar:         suggestion.User.Avatar,
+			MutualContacts: suggestion.MutualContacts,
+			SharedGroups:   suggestion.SharedGroups,
+		})
+	}
+	return resp
+}
@@ -38,6 +38,10 @@ type ConversationResponseData struct {
 	MessageDeletionConfirmation
 	ReactionResponseData
 	ErrorResponseData
+	PresenceResponseData
+	StatusResponseData
+	ContactRequestResponseData
+	ReportResponseData
 }
 
 type ErrorResponseData struct {
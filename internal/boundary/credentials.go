@@ -0,0 +1,47 @@
+package boundary
+
+import "github.com/maxyong7/chat-messaging-app/internal/entity"
+
+type ChangePasswordForm struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type ChangeEmailForm struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewEmail        string `json:"new_email" binding:"required"`
+}
+
+type ChangeUsernameForm struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewUsername     string `json:"new_username" binding:"required"`
+}
+
+type ConfirmEmailChangeForm struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func (r ChangePasswordForm) ToPasswordChange(userUUID string, sessionUUID string) entity.PasswordChange {
+	return entity.PasswordChange{
+		UserUUID:        userUUID,
+		SessionUUID:     sessionUUID,
+		CurrentPassword: r.CurrentPassword,
+		NewPassword:     r.NewPassword,
+	}
+}
+
+func (r ChangeEmailForm) ToEmailChange(userUUID string) entity.EmailChange {
+	return entity.EmailChange{
+		UserUUID:        userUUID,
+		CurrentPassword: r.CurrentPassword,
+		NewEmail:        r.NewEmail,
+	}
+}
+
+func (r ChangeUsernameForm) ToUsernameChange(userUUID string) entity.UsernameChange {
+	return entity.UsernameChange{
+		UserUUID:        userUUID,
+		CurrentPassword: r.CurrentPassword,
+		NewUsername:     r.NewUsername,
+	}
+}
@@ -0,0 +1,31 @@
+package boundary
+
+import (
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+type DataExportResponse struct {
+	ExportID    string     `json:"export_id"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	// DownloadURL is only set once the archive is ready
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+func ToDataExportResponse(export entity.DataExport, downloadURL string) DataExportResponse {
+	resp := DataExportResponse{
+		ExportID:    export.ExportUUID,
+		Status:      export.Status,
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+	}
+	if export.Status == entity.DataExportReady {
+		resp.DownloadURL = downloadURL
+	}
+	return resp
+}
@@ -0,0 +1,33 @@
+package boundary
+
+import (
+	"fmt"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+type ImageResponse struct {
+	ImageUUID  string              `json:"image_uuid"`
+	URL        string              `json:"url"`
+	Thumbnails []ThumbnailResponse `json:"thumbnails"`
+}
+
+type ThumbnailResponse struct {
+	Size int    `json:"size"`
+	URL  string `json:"url"`
+}
+
+func ToImageResponse(img entity.Image) ImageResponse {
+	resp := ImageResponse{
+		ImageUUID:  img.ImageUUID,
+		URL:        img.URL,
+		Thumbnails: make([]ThumbnailResponse, 0, len(img.Sizes)),
+	}
+	for _, size := range img.Sizes {
+		resp.Thumbnails = append(resp.Thumbnails, ThumbnailResponse{
+			Size: size,
+			URL:  fmt.Sprintf("%s/%d", img.URL, size),
+		})
+	}
+	return resp
+}
@@ -18,6 +18,7 @@ type SendMessageResponseData struct {
 	SenderFirstName string    `json:"sender_first_name"`
 	SenderLastName  string    `json:"sender_last_name"`
 	SenderAvatar    string    `json:"sender_avatar"`
+	SenderIsBot     bool      `json:"sender_is_bot"`
 	Content         string    `json:"content"`
 	MessageUUID     string    `json:"message_uuid"`
 	CreatedAt       time.Time `json:"created_at"`
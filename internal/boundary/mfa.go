@@ -0,0 +1,23 @@
+package boundary
+
+import "github.com/maxyong7/chat-messaging-app/internal/entity"
+
+type MFAEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+type MFACodeForm struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func ToMFAEnrollmentResponse(enrollment entity.MFAEnrollment) MFAEnrollmentResponse {
+	return MFAEnrollmentResponse{
+		Secret: enrollment.Secret,
+		URI:    enrollment.URI,
+	}
+}
@@ -0,0 +1,37 @@
+package boundary
+
+import (
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// PresenceResponseData is pushed over the websockets of the contacts, the user is the sender of the message.
+type PresenceResponseData struct {
+	Status     string     `json:"status,omitempty"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+}
+
+type ContactsPresenceResponse struct {
+	Contacts []PresenceResponse `json:"contacts"`
+}
+
+type PresenceResponse struct {
+	UserUUID   string     `json:"user_uuid"`
+	Status     string     `json:"status"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+}
+
+func ToContactsPresenceResponse(presences []entity.Presence) ContactsPresenceResponse {
+	resp := ContactsPresenceResponse{
+		Contacts: make([]PresenceResponse, 0, len(presences)),
+	}
+	for _, presence := range presences {
+		resp.Contacts = append(resp.Contacts, PresenceResponse{
+			UserUUID:   presence.UserUUID,
+			Status:     presence.Status,
+			LastSeenAt: presence.LastSeenAt,
+		})
+	}
+	return resp
+}
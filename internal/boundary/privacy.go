@@ -0,0 +1,41 @@
+package boundary
+
+import "github.com/maxyong7/chat-messaging-app/internal/entity"
+
+// UpdatePrivacySettingsForm -.
+// Settings that are left out are not changed.
+type UpdatePrivacySettingsForm struct {
+	Discoverable      *bool   `json:"discoverable"`
+	WhoCanAddContact  *string `json:"who_can_add_contact"`
+	WhoCanAddToGroups *string `json:"who_can_add_to_groups"`
+	ReadReceipts      *bool   `json:"read_receipts"`
+	ShowPresence      *bool   `json:"show_presence"`
+}
+
+type PrivacySettingsResponse struct {
+	Discoverable      bool   `json:"discoverable"`
+	WhoCanAddContact  string `json:"who_can_add_contact"`
+	WhoCanAddToGroups string `json:"who_can_add_to_groups"`
+	ReadReceipts      bool   `json:"read_receipts"`
+	ShowPresence      bool   `json:"show_presence"`
+}
+
+func (r UpdatePrivacySettingsForm) ToPrivacySettingsUpdate() entity.PrivacySettingsUpdate {
+	return entity.PrivacySettingsUpdate{
+		Discoverable:      r.Discoverable,
+		WhoCanAddContact:  r.WhoCanAddContact,
+		WhoCanAddToGroups: r.WhoCanAddToGroups,
+		ReadReceipts:      r.ReadReceipts,
+		ShowPresence:      r.ShowPresence,
+	}
+}
+
+func ToPrivacySettingsResponse(settings entity.PrivacySettings) PrivacySettingsResponse {
+	return PrivacySettingsResponse{
+		Discoverable:      settings.Discoverable,
+		WhoCanAddContact:  settings.WhoCanAddContact,
+		WhoCanAddToGroups: settings.WhoCanAddToGroups,
+		ReadReceipts:      settings.ReadReceipts,
+		ShowPresence:      settings.ShowPresence,
+	}
+}
@@ -0,0 +1,119 @@
+package boundary
+
+import (
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// ReportForm -.
+// TargetType is 'user', 'message' or 'conversation'.
+// ConversationUUID is optional for user reports, it tells in which conversation the abuse happened.
+type ReportForm struct {
+	TargetType       string `json:"target_type" binding:"required"`
+	TargetUUID       string `json:"target_uuid" binding:"required"`
+	ConversationUUID string `json:"conversation_uuid"`
+	Reason           string `json:"reason" binding:"required"`
+	Details          string `json:"details"`
+}
+
+// ReportStatusForm -.
+// The note is optional, it is kept with the report and stored in the audit log.
+type ReportStatusForm struct {
+	Status string `json:"status" binding:"required"`
+	Note   string `json:"note"`
+}
+
+type ReportedMessageResponse struct {
+	SenderUUID string    `json:"sender_uuid"`
+	Content    string    `json:"content"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ReportResponse struct {
+	ReportUUID       string                   `json:"report_uuid"`
+	ReporterUUID     string                   `json:"reporter_uuid"`
+	TargetType       string                   `json:"target_type"`
+	TargetUUID       string                   `json:"target_uuid"`
+	ConversationUUID string                   `json:"conversation_uuid,omitempty"`
+	Reason           string                   `json:"reason"`
+	Details          string                   `json:"details,omitempty"`
+	Message          *ReportedMessageResponse `json:"message,omitempty"`
+	Status           string                   `json:"status"`
+	ReviewerUUID     string                   `json:"reviewer_uuid,omitempty"`
+	ResolutionNote   string                   `json:"resolution_note,omitempty"`
+	CreatedAt        time.Time                `json:"created_at"`
+	UpdatedAt        time.Time                `json:"updated_at"`
+}
+
+type ReportsResponse struct {
+	Reports    []ReportResponse `json:"reports"`
+	Pagination Pagination       `json:"pagination"`
+}
+
+// ReportResponseData is sent to the reporter only, once a report made through the websocket is stored
+type ReportResponseData struct {
+	ReportUUID   string `json:"report_uuid,omitempty"`
+	ReportStatus string `json:"report_status,omitempty"`
+}
+
+func (r ReportForm) ToReport(reporterUUID string) entity.Report {
+	return entity.Report{
+		ReporterUUID:     reporterUUID,
+		TargetType:       r.TargetType,
+		TargetUUID:       r.TargetUUID,
+		ConversationUUID: r.ConversationUUID,
+		Reason:           r.Reason,
+		Details:          r.Details,
+	}
+}
+
+func (r ReportStatusForm) ToReportStatusUpdate(adminUUID string, reportUUID string) entity.ReportStatusUpdate {
+	return entity.ReportStatusUpdate{
+		AdminUUID:  adminUUID,
+		ReportUUID: reportUUID,
+		Status:     r.Status,
+		Note:       r.Note,
+	}
+}
+
+func ToReportResponse(report entity.Report) ReportResponse {
+	resp := ReportResponse{
+		ReportUUID:       report.ReportUUID,
+		ReporterUUID:     report.ReporterUUID,
+		TargetType:       report.TargetType,
+		TargetUUID:       report.TargetUUID,
+		ConversationUUID: report.ConversationUUID,
+		Reason:           report.Reason,
+		Details:          report.Details,
+		Status:           report.Status,
+		ReviewerUUID:     report.ReviewerUUID,
+		ResolutionNote:   report.ResolutionNote,
+		CreatedAt:        report.CreatedAt,
+		UpdatedAt:        report.UpdatedAt,
+	}
+	if report.Message != nil {
+		resp.Message = &ReportedMessageResponse{
+			SenderUUID: report.Message.SenderUUID,
+			Content:    report.Message.Content,
+			CreatedAt:  report.Message.CreatedAt,
+		}
+	}
+	return resp
+}
+
+// ToReportsResponse -.
+// cursor is empty on the last page.
+func ToReportsResponse(page entity.ReportPage, cursor string) ReportsResponse {
+	resp := ReportsResponse{
+		Reports: make([]ReportResponse, 0, len(page.Reports)),
+		Pagination: Pagination{
+			Cursor: cursor,
+			Limit:  page.Limit,
+		},
+	}
+	for _, report := range page.Reports {
+		resp.Reports = append(resp.Reports, ToReportResponse(report))
+	}
+	return resp
+}
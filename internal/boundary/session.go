@@ -0,0 +1,41 @@
+package boundary
+
+import (
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+type SessionResponse struct {
+	SessionUUID string    `json:"session_uuid"`
+	DeviceName  string    `json:"device_name"`
+	UserAgent   string    `json:"user_agent"`
+	IPAddress   string    `json:"ip_address"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	Current     bool      `json:"current"`
+}
+
+type SessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+type RevokedSessionsResponse struct {
+	RevokedSessions []string `json:"revoked_sessions"`
+}
+
+func ToSessionsResponse(sessions []entity.SessionInfo) SessionsResponse {
+	resp := SessionsResponse{Sessions: make([]SessionResponse, 0, len(sessions))}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, SessionResponse{
+			SessionUUID: session.SessionUUID,
+			DeviceName:  session.DeviceName,
+			UserAgent:   session.UserAgent,
+			IPAddress:   session.IP,
+			CreatedAt:   session.CreatedAt,
+			LastSeenAt:  session.LastSeenAt,
+			Current:     session.Current,
+		})
+	}
+	return resp
+}
@@ -1,6 +1,10 @@
 package boundary
 
-import "github.com/maxyong7/chat-messaging-app/internal/entity"
+import (
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
 
 type RegistrationForm struct {
 	Username  string `json:"username" binding:"required"`
@@ -14,10 +18,47 @@ type RegistrationForm struct {
 type LoginForm struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	// DeviceName is an optional label shown in the list of active sessions, e.g. "Alice's laptop"
+	DeviceName string `json:"device_name,omitempty"`
+}
+
+type LoginScreen struct {
+	Token        string    `json:"token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	RefreshToken string    `json:"refresh_token"`
+}
+
+type MFAChallengeScreen struct {
+	MFARequired bool      `json:"mfa_required"`
+	MFAToken    string    `json:"mfa_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type MFALoginForm struct {
+	MFAToken   string `json:"mfa_token" binding:"required"`
+	Code       string `json:"code" binding:"required"`
+	DeviceName string `json:"device_name,omitempty"`
 }
 
-type LogoutScreen struct {
-	Token string `json:"token,omitempty"`
+type RefreshTokenForm struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type ForgotPasswordForm struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type ResetPasswordForm struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+type ConfirmEmailForm struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResendVerificationForm struct {
+	Email string `json:"email" binding:"required"`
 }
 
 func (r RegistrationForm) ToUserRegistration() entity.UserRegistration {
@@ -39,3 +80,33 @@ func (r LoginForm) ToUserCredentials() entity.UserCredentials {
 		Password: r.Password,
 	}
 }
+
+func ToLoginScreen(token string, session entity.Session) LoginScreen {
+	return LoginScreen{
+		Token:        token,
+		ExpiresAt:    session.AccessTokenExpiresAt,
+		RefreshToken: session.RefreshToken,
+	}
+}
+
+func (r ForgotPasswordForm) ToPasswordResetRequest(ip string) entity.PasswordResetRequest {
+	return entity.PasswordResetRequest{
+		Email: r.Email,
+		IP:    ip,
+	}
+}
+
+func (r ResetPasswordForm) ToPasswordReset() entity.PasswordReset {
+	return entity.PasswordReset{
+		Token:       r.Token,
+		NewPassword: r.NewPassword,
+	}
+}
+
+func ToMFAChallengeScreen(token string, expiresAt time.Time) MFAChallengeScreen {
+	return MFAChallengeScreen{
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresAt:   expiresAt,
+	}
+}
@@ -0,0 +1,38 @@
+package boundary
+
+import "github.com/maxyong7/chat-messaging-app/internal/entity"
+
+type UserSearchResponse struct {
+	Users      []UserSearchResultResponse `json:"users"`
+	Pagination Pagination                 `json:"pagination"`
+}
+
+type UserSearchResultResponse struct {
+	UserUUID  string `json:"user_uuid"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Avatar    string `json:"avatar"`
+}
+
+// ToUserSearchResponse -.
+// cursor is empty on the last page.
+func ToUserSearchResponse(page entity.UserSearchPage, cursor string) UserSearchResponse {
+	resp := UserSearchResponse{
+		Users: make([]UserSearchResultResponse, 0, len(page.Results)),
+		Pagination: Pagination{
+			Cursor: cursor,
+			Limit:  page.Limit,
+		},
+	}
+	for _, result := range page.Results {
+		resp.Users = append(resp.Users, UserSearchResultResponse{
+			UserUUID:  result.UserUUID,
+			Username:  result.Username,
+			FirstName: result.FirstName,
+			LastName:  result.LastName,
+			Avatar:    result.Avatar,
+		})
+	}
+	return resp
+}
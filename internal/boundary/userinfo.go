@@ -1,6 +1,10 @@
 package boundary
 
-import "github.com/maxyong7/chat-messaging-app/internal/entity"
+import (
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
 
 type ProfileSettingScreen struct {
 	FirstName string `json:"first_name" binding:"required"`
@@ -16,3 +20,38 @@ func (r ProfileSettingScreen) ToUserInfo(userUUID string) entity.UserProfile {
 		Avatar:    r.Avatar,
 	}
 }
+
+// SetStatusForm -.
+// A status without expires_at is kept until it is cleared.
+type SetStatusForm struct {
+	Emoji     string     `json:"emoji"`
+	Text      string     `json:"text"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (r SetStatusForm) ToUserStatus() entity.UserStatus {
+	return entity.UserStatus{
+		Emoji:     r.Emoji,
+		Text:      r.Text,
+		ExpiresAt: r.ExpiresAt,
+	}
+}
+
+// StatusResponseData is pushed over the websockets of the contacts, the user is the sender of the message.
+// Every field is empty if the status was cleared.
+type StatusResponseData struct {
+	StatusEmoji     string     `json:"status_emoji,omitempty"`
+	StatusText      string     `json:"status_text,omitempty"`
+	StatusExpiresAt *time.Time `json:"status_expires_at,omitempty"`
+}
+
+func ToStatusResponseData(status *entity.UserStatus) StatusResponseData {
+	if status == nil {
+		return StatusResponseData{}
+	}
+	return StatusResponseData{
+		StatusEmoji:     status.Emoji,
+		StatusText:      status.Text,
+		StatusExpiresAt: status.ExpiresAt,
+	}
+}
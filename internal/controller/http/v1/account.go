@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type accountRoutes struct {
+	d   usecase.AccountDeletion
+	hub *Hub
+	l   logger.Interface
+}
+
+// Handles api routes for account functionality
+func newAccountRoute(handler *gin.RouterGroup, d usecase.AccountDeletion, hub *Hub, l logger.Interface) {
+	r := &accountRoutes{d, hub, l}
+
+	// Define the endpoints for the account functionality.
+	handler.DELETE("/user", r.deleteAccount)
+}
+
+// deleteAccount deletes the user's account after the password was confirmed again.
+func (r *accountRoutes) deleteAccount(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Bind the incoming JSON request body to the DeleteAccountForm struct.
+	var request boundary.DeleteAccountForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - deleteAccount")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call DeleteAccount method from account deletion entity object
+	deletion, err := r.d.DeleteAccount(c.Request.Context(), userUUID, request.Password)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - deleteAccount - DeleteAccount")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Every session was revoked, close the websockets opened with them
+	r.hub.DisconnectUser <- userUUID
+
+	// Return a status code of 200 (OK) if the account was erased,
+	// or 202 (Accepted) if it will be erased after the grace period.
+	status := http.StatusOK
+	if !deletion.Deleted {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, boundary.ToAccountDeletionResponse(deletion))
+}
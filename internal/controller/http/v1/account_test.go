@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+func TestDeleteAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountDeletionUsecase := mocks.NewMockAccountDeletion(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+	hub := NewHub()
+	go hub.Run()
+
+	r := &accountRoutes{d: mockAccountDeletionUsecase, hub: hub, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	router.DELETE("/user", r.deleteAccount)
+
+	t.Run("Success", func(t *testing.T) {
+		// The user's websocket is closed whichever session it was opened with
+		client := registerTestClient(hub, "conversation-1", "other-session")
+		mockAccountDeletionUsecase.EXPECT().DeleteAccount(gomock.Any(), "some-uuid", "password123").Return(entity.AccountDeletion{Deleted: true}, nil)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/user", bytes.NewBufferString(`{"password":"password123"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"deleted":true}`, w.Body.String())
+		assertClientDisconnected(t, client, true)
+	})
+
+	t.Run("Scheduled", func(t *testing.T) {
+		deleteAfter := time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)
+		mockAccountDeletionUsecase.EXPECT().DeleteAccount(gomock.Any(), "some-uuid", "password123").Return(entity.AccountDeletion{DeleteAfter: &deleteAfter}, nil)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/user", bytes.NewBufferString(`{"password":"password123"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.JSONEq(t, `{"deleted":false,"delete_after":"2026-10-23T00:00:00Z"}`, w.Body.String())
+	})
+
+	t.Run("IncorrectPassword", func(t *testing.T) {
+		client := registerTestClient(hub, "conversation-1", "current-session")
+		mockAccountDeletionUsecase.EXPECT().DeleteAccount(gomock.Any(), "some-uuid", "wrongpassword").Return(entity.AccountDeletion{}, entity.ErrIncorrectPassword)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/user", bytes.NewBufferString(`{"password":"wrongpassword"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assertClientDisconnected(t, client, false)
+	})
+
+	t.Run("MissingPassword", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, "/user", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("EntityObjectFailure", func(t *testing.T) {
+		mockAccountDeletionUsecase.EXPECT().DeleteAccount(gomock.Any(), "some-uuid", "password123").Return(entity.AccountDeletion{}, errors.New("test_error"))
+
+		req, _ := http.NewRequest(http.MethodDelete, "/user", bytes.NewBufferString(`{"password":"password123"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestDeleteAccountPreflight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CORSMiddleware())
+	router.DELETE("/user", func(c *gin.Context) {})
+
+	// Browsers only send the DELETE request if the preflight allows the method
+	req, _ := http.NewRequest(http.MethodOptions, "/user", nil)
+	req.Header.Set("Origin", "https://chat.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodDelete)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), http.MethodDelete)
+}
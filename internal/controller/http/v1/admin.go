@@ -0,0 +1,280 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type adminRoutes struct {
+	a   usecase.Admin
+	hub *Hub
+	l   logger.Interface
+}
+
+// Handles api routes for moderating users and conversations, they are only served to admins
+func newAdminRoute(handler *gin.RouterGroup, a usecase.Admin, hub *Hub, l logger.Interface) {
+	r := &adminRoutes{a, hub, l}
+
+	// Group the routes under the "/admin" path.
+	h := handler.Group("/admin")
+	h.Use(requireRole(entity.RoleAdmin))
+	{
+		// Define the endpoints for the admin functionality.
+		h.GET("/users", r.listUsers)
+		h.POST("/users/:userId/suspend", r.suspendUser)
+		h.POST("/users/:userId/unsuspend", r.unsuspendUser)
+		h.POST("/users/:userId/logout", r.forceLogout)
+		h.DELETE("/messages/:messageId", r.deleteMessage)
+		h.DELETE("/conversations/:conversationId", r.deleteConversation)
+		h.GET("/audit-log", r.getAuditLog)
+	}
+}
+
+// listUsers returns a page of the users matching the 'q' query parameter, every user if it is empty.
+func (r *adminRoutes) listUsers(c *gin.Context) {
+	// Get 'cursor' value from URL query, it is empty for the first page
+	cursor, err := queryParamIDCursor(c)
+	if err != nil {
+		r.l.Error(err, "http - v1 - listUsers - cursor validation error")
+		errorResponse(c, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+
+	// Get 'limit' value from URL query, the entity object applies the default and the maximum
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	// Call ListUsers method from admin entity object
+	page, err := r.a.ListUsers(c.Request.Context(), entity.AdminUserQuery{
+		Query:  c.Query("q"),
+		Cursor: cursor,
+		Limit:  limit,
+	})
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - listUsers - ListUsers")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the users as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToAdminUsersResponse(page, encodeIDCursor(page.NextCursor)))
+}
+
+// suspendUser suspends the user, logs them out and closes their websocket connections.
+func (r *adminRoutes) suspendUser(c *gin.Context) {
+	action, ok := r.bindAdminAction(c, c.Param("userId"))
+	if !ok {
+		return
+	}
+
+	// Call SuspendUser method from admin entity object
+	err := r.a.SuspendUser(c.Request.Context(), action)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - suspendUser - SuspendUser")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// The websockets were authenticated before the suspension, so they are closed right away
+	r.hub.DisconnectUser <- action.TargetUUID
+
+	// Return a status code of 204 (No Content).
+	c.Status(http.StatusNoContent)
+}
+
+// unsuspendUser lifts the suspension of the user.
+func (r *adminRoutes) unsuspendUser(c *gin.Context) {
+	action, ok := r.bindAdminAction(c, c.Param("userId"))
+	if !ok {
+		return
+	}
+
+	// Call UnsuspendUser method from admin entity object
+	err := r.a.UnsuspendUser(c.Request.Context(), action)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - unsuspendUser - UnsuspendUser")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return a status code of 204 (No Content).
+	c.Status(http.StatusNoContent)
+}
+
+// forceLogout logs the user out of every session and closes their websocket connections.
+func (r *adminRoutes) forceLogout(c *gin.Context) {
+	action, ok := r.bindAdminAction(c, c.Param("userId"))
+	if !ok {
+		return
+	}
+
+	// Call ForceLogout method from admin entity object
+	err := r.a.ForceLogout(c.Request.Context(), action)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - forceLogout - ForceLogout")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Close the websockets of every session of the user
+	r.hub.DisconnectUser <- action.TargetUUID
+
+	// Return a status code of 204 (No Content).
+	c.Status(http.StatusNoContent)
+}
+
+// deleteMessage deletes any message and tells the clients connected to its conversation.
+func (r *adminRoutes) deleteMessage(c *gin.Context) {
+	action, ok := r.bindAdminAction(c, c.Param("messageId"))
+	if !ok {
+		return
+	}
+
+	// Call DeleteMessage method from admin entity object
+	conversationUUID, err := r.a.DeleteMessage(c.Request.Context(), action)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - deleteMessage - DeleteMessage")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Clients remove the message the same way as when its author deletes it
+	r.hub.Broadcast <- buildDeleteMessageResponse(entity.Message{
+		SenderUUID:  action.AdminUUID,
+		MessageUUID: action.TargetUUID,
+	}, conversationUUID)
+
+	// Return a status code of 204 (No Content).
+	c.Status(http.StatusNoContent)
+}
+
+// deleteConversation deletes a conversation with its messages and closes its websocket connections.
+func (r *adminRoutes) deleteConversation(c *gin.Context) {
+	action, ok := r.bindAdminAction(c, c.Param("conversationId"))
+	if !ok {
+		return
+	}
+
+	// Call DeleteConversation method from admin entity object
+	err := r.a.DeleteConversation(c.Request.Context(), action)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - deleteConversation - DeleteConversation")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	r.hub.DisconnectConversation <- action.TargetUUID
+
+	// Return a status code of 204 (No Content).
+	c.Status(http.StatusNoContent)
+}
+
+// getAuditLog returns a page of the admin audit log from the newest entry, optionally of one target only.
+func (r *adminRoutes) getAuditLog(c *gin.Context) {
+	// Get 'cursor' value from URL query, it is empty for the first page
+	cursor, err := queryParamIDCursor(c)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getAuditLog - cursor validation error")
+		errorResponse(c, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+
+	// Get 'limit' value from URL query, the entity object applies the default and the maximum
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	// Call GetAuditLog method from admin entity object
+	page, err := r.a.GetAuditLog(c.Request.Context(), entity.AdminAuditQuery{
+		TargetUUID: c.Query("target_uuid"),
+		Cursor:     cursor,
+		Limit:      limit,
+	})
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - getAuditLog - GetAuditLog")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the entries as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToAdminAuditLogResponse(page, encodeIDCursor(page.NextCursor)))
+}
+
+// bindAdminAction builds the action of the admin on the target, it writes the error response if it can not.
+// The request body with the reason is optional.
+func (r *adminRoutes) bindAdminAction(c *gin.Context, targetUUID string) (entity.AdminAction, bool) {
+	// Get user_uuid from context
+	adminUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return entity.AdminAction{}, false
+	}
+
+	var request boundary.AdminActionForm
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			// If there is an error in binding JSON, log the error and return a bad request response.
+			r.l.Error(err, "http - v1 - bindAdminAction")
+			errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+			return entity.AdminAction{}, false
+		}
+	}
+
+	return request.ToAdminAction(adminUUID, targetUUID), true
+}
+
+// queryParamIDCursor reads the id of the last item of the previous page, it is zero for the first page
+func queryParamIDCursor(c *gin.Context) (int, error) {
+	cursor := c.Query("cursor")
+	if cursor == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.Atoi(cursor)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return id, nil
+}
+
+// encodeIDCursor is the inverse of queryParamIDCursor, it is empty on the last page
+func encodeIDCursor(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+// newAdminTestRouter serves the admin routes to a user with the given role
+func newAdminTestRouter(mockAdminUsecase *mocks.MockAdmin, hub *Hub, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := router.Group("/v1")
+	handler.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Set("user_role", role)
+		c.Next()
+	})
+	newAdminRoute(handler, mockAdminUsecase, hub, logger.New(logLevelDebug))
+	return router
+}
+
+func TestAdminRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdminUsecase := mocks.NewMockAdmin(ctrl)
+
+	hub := NewHub()
+	go hub.Run()
+
+	router := newAdminTestRouter(mockAdminUsecase, hub, entity.RoleAdmin)
+
+	t.Run("NotAnAdmin", func(t *testing.T) {
+		userRouter := newAdminTestRouter(mockAdminUsecase, hub, entity.RoleUser)
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/admin/users/target-uuid/suspend", nil)
+		w := httptest.NewRecorder()
+		userRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("ListUsers", func(t *testing.T) {
+		mockAdminUsecase.EXPECT().ListUsers(gomock.Any(), entity.AdminUserQuery{Query: "alice", Cursor: 3, Limit: 2}).
+			Return(entity.AdminUserPage{Users: []entity.AdminUser{{ID: 4, UserUUID: "alice-uuid"}, {ID: 9, UserUUID: "alicia-uuid"}}, NextCursor: 9, Limit: 2}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/admin/users?q=alice&cursor=3&limit=2", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.AdminUsersResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Users, 2)
+		assert.Equal(t, "9", response.Pagination.Cursor)
+	})
+
+	t.Run("ListUsersInvalidCursor", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/v1/admin/users?cursor=abc", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("SuspendUserDisconnectsWebsockets", func(t *testing.T) {
+		client := NewClient("conv-uuid", entity.UserProfile{UserUUID: "target-uuid"}, nil, hub, nil)
+		hub.Register <- client
+
+		mockAdminUsecase.EXPECT().SuspendUser(gomock.Any(), entity.AdminAction{AdminUUID: "some-uuid", TargetUUID: "target-uuid", Reason: "spam"}).Return(nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/admin/users/target-uuid/suspend", strings.NewReader(`{"reason": "spam"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assertClientDisconnected(t, client, true)
+	})
+
+	t.Run("SuspendUserNotFound", func(t *testing.T) {
+		mockAdminUsecase.EXPECT().SuspendUser(gomock.Any(), gomock.Any()).Return(entity.ErrUserNotFound)
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/admin/users/unknown-uuid/suspend", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("ForceLogoutSelf", func(t *testing.T) {
+		mockAdminUsecase.EXPECT().ForceLogout(gomock.Any(), entity.AdminAction{AdminUUID: "some-uuid", TargetUUID: "some-uuid"}).Return(entity.ErrAdminSelfAction)
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/admin/users/some-uuid/logout", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("DeleteMessageIsBroadcast", func(t *testing.T) {
+		client := NewClient("conv-uuid", entity.UserProfile{UserUUID: "member-uuid"}, nil, hub, nil)
+		hub.Register <- client
+
+		mockAdminUsecase.EXPECT().DeleteMessage(gomock.Any(), entity.AdminAction{AdminUUID: "some-uuid", TargetUUID: "msg-uuid"}).Return("conv-uuid", nil)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/v1/admin/messages/msg-uuid", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		message := <-client.send
+		assert.Equal(t, deleteMessageType, message.MessageType)
+		assert.Equal(t, "msg-uuid", message.Data.MessageDeletionConfirmation.MessageUUID)
+	})
+
+	t.Run("DeleteConversationDisconnectsWebsockets", func(t *testing.T) {
+		client := NewClient("group-uuid", entity.UserProfile{UserUUID: "member-uuid"}, nil, hub, nil)
+		hub.Register <- client
+
+		mockAdminUsecase.EXPECT().DeleteConversation(gomock.Any(), entity.AdminAction{AdminUUID: "some-uuid", TargetUUID: "group-uuid"}).Return(nil)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/v1/admin/conversations/group-uuid", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assertClientDisconnected(t, client, true)
+	})
+
+	t.Run("GetAuditLog", func(t *testing.T) {
+		mockAdminUsecase.EXPECT().GetAuditLog(gomock.Any(), entity.AdminAuditQuery{TargetUUID: "target-uuid"}).
+			Return(entity.AdminAuditPage{Entries: []entity.AdminAuditDTO{{ID: 1, Action: entity.AdminActionSuspendUser, TargetUUID: "target-uuid"}}, Limit: 50}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/admin/audit-log?target_uuid=target-uuid", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.AdminAuditLogResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Entries, 1)
+		assert.Empty(t, response.Pagination.Cursor)
+	})
+
+	t.Run("EntityObjectFailure", func(t *testing.T) {
+		mockAdminUsecase.EXPECT().UnsuspendUser(gomock.Any(), gomock.Any()).Return(errors.New("test_error"))
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/admin/users/target-uuid/unsuspend", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
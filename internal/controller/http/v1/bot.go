@@ -0,0 +1,286 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type botRoutes struct {
+	b    usecase.Bot
+	conv usecase.Conversation
+	up   usecase.UserProfile
+	hub  *Hub
+	l    logger.Interface
+}
+
+// Handles api routes for users to manage their bots and the bots' API keys
+func newBotRoute(handler *gin.RouterGroup, b usecase.Bot, l logger.Interface) {
+	r := &botRoutes{b: b, l: l}
+
+	// Group the routes under the "/bots" path.
+	h := handler.Group("/bots")
+	{
+		// Define the endpoints for the bot management functionality.
+		h.POST("", r.createBot)
+		h.GET("", r.getBots)
+		h.DELETE("/:botId", r.deleteBot)
+		h.POST("/:botId/keys", r.createAPIKey)
+		h.GET("/:botId/keys", r.getAPIKeys)
+		h.DELETE("/:botId/keys/:keyId", r.revokeAPIKey)
+	}
+}
+
+// Handles the api routes that bots call with their API key
+func newBotMessageRoute(handler *gin.RouterGroup, b usecase.Bot, conv usecase.Conversation, up usecase.UserProfile, hub *Hub, l logger.Interface) {
+	r := &botRoutes{b, conv, up, hub, l}
+
+	handler.POST("/bot/conversations/:conversationId/messages", r.sendMessage)
+}
+
+// createBot creates a bot account owned by the user.
+func (r *botRoutes) createBot(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Bind the incoming JSON request body to the CreateBotForm struct.
+	var request boundary.CreateBotForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - createBot")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call CreateBot method from bot entity object
+	bot, err := r.b.CreateBot(c.Request.Context(), userUUID, request.Name)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - createBot - CreateBot")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the bot as JSON with a status code of 201 (Created).
+	c.JSON(http.StatusCreated, boundary.ToBotResponse(bot))
+}
+
+// getBots lists the bots owned by the user.
+func (r *botRoutes) getBots(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Call GetBots method from bot entity object
+	bots, err := r.b.GetBots(c.Request.Context(), userUUID)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - getBots - GetBots")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the bots as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToBotsResponse(bots))
+}
+
+// deleteBot deletes a bot and revokes all of its API keys.
+func (r *botRoutes) deleteBot(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Call DeleteBot method from bot entity object
+	err = r.b.DeleteBot(c.Request.Context(), userUUID, c.Param("botId"))
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - deleteBot - DeleteBot")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return a "No Content" status code to indicate the bot was deleted.
+	c.Writer.WriteHeader(http.StatusNoContent)
+}
+
+// createAPIKey issues an API key for a bot. The key is only shown in this response.
+func (r *botRoutes) createAPIKey(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Bind the incoming JSON request body to the CreateAPIKeyForm struct.
+	var request boundary.CreateAPIKeyForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - createAPIKey")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call CreateAPIKey method from bot entity object
+	key, err := r.b.CreateAPIKey(c.Request.Context(), userUUID, c.Param("botId"), request.ToAPIKeyRequest())
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - createAPIKey - CreateAPIKey")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the key as JSON with a status code of 201 (Created).
+	c.JSON(http.StatusCreated, boundary.ToAPIKeyResponse(key))
+}
+
+// getAPIKeys lists the API keys of a bot that were not revoked.
+func (r *botRoutes) getAPIKeys(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Call GetAPIKeys method from bot entity object
+	keys, err := r.b.GetAPIKeys(c.Request.Context(), userUUID, c.Param("botId"))
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - getAPIKeys - GetAPIKeys")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the keys as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToAPIKeysResponse(keys))
+}
+
+// revokeAPIKey revokes an API key of a bot, requests made with it are rejected from then on.
+func (r *botRoutes) revokeAPIKey(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Call RevokeAPIKey method from bot entity object
+	err = r.b.RevokeAPIKey(c.Request.Context(), userUUID, c.Param("botId"), c.Param("keyId"))
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - revokeAPIKey - RevokeAPIKey")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return a "No Content" status code to indicate the key was revoked.
+	c.Writer.WriteHeader(http.StatusNoContent)
+}
+
+// sendMessage posts a message from a bot into a conversation and broadcasts it to the connected participants.
+func (r *botRoutes) sendMessage(c *gin.Context) {
+	// Only requests authenticated with an API key are allowed here
+	apiKey, ok := getAPIKeyFromContext(c)
+	if !ok {
+		errorResponse(c, http.StatusForbidden, entity.ErrAPIKeyForbidden.Error())
+		return
+	}
+
+	// Bind the incoming JSON request body to the BotMessageForm struct.
+	var request boundary.BotMessageForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - bot - sendMessage")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	conversationUUID := c.Param("conversationId")
+
+	// Call AuthorizeConversation method from bot entity object
+	err := r.b.AuthorizeConversation(c.Request.Context(), apiKey, conversationUUID, entity.ScopeMessagesWrite)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - bot - sendMessage - AuthorizeConversation")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Get the bot's profile, it is shown as the sender of the message
+	userInfo, err := r.up.GetUserProfile(c.Request.Context(), apiKey.BotUUID)
+	if err != nil {
+		r.l.Error(err, "http - v1 - bot - sendMessage - GetUserProfile")
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Create a new conversation entity with the provided data.
+	conv := entity.Conversation{
+		SenderUUID:       apiKey.BotUUID,
+		ConversationUUID: conversationUUID,
+		MessageUUID:      uuid.New().String(),
+		Content:          request.Content,
+		CreatedAt:        time.Now(),
+	}
+
+	// Store the conversation and message by calling conversation entity object's StoreConversationAndMessage method
+	err = r.conv.StoreConversationAndMessage(c.Request.Context(), conv)
+	if err != nil {
+		r.l.Error(err, "http - v1 - bot - sendMessage - StoreConversationAndMessage")
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Build a response message and broadcast it to the participants connected to the conversation.
+	sendMsgResponse := buildSendMessageResponse(conv, userInfo)
+	r.hub.Broadcast <- sendMsgResponse
+
+	// Return the message as JSON with a status code of 201 (Created).
+	c.JSON(http.StatusCreated, sendMsgResponse)
+}
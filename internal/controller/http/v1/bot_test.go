@@ -0,0 +1,252 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+func TestBotRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBotUsecase := mocks.NewMockBot(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	protected := router.Group("/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	newBotRoute(protected, mockBotUsecase, mockLogger)
+
+	t.Run("CreateBot", func(t *testing.T) {
+		mockBotUsecase.EXPECT().CreateBot(gomock.Any(), "some-uuid", "Deploy bot").
+			Return(entity.Bot{BotUUID: "bot-uuid", OwnerUUID: "some-uuid", Name: "Deploy bot"}, nil)
+
+		body := []byte(`{"name":"Deploy bot"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/v1/bots", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), "bot-uuid")
+	})
+
+	t.Run("CreateBotInvalidBody", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/v1/bots", bytes.NewBuffer([]byte(`{}`)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("DeleteBotNotFound", func(t *testing.T) {
+		mockBotUsecase.EXPECT().DeleteBot(gomock.Any(), "some-uuid", "bot-uuid").Return(entity.ErrBotNotFound)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/v1/bots/bot-uuid", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("CreateAPIKey", func(t *testing.T) {
+		mockBotUsecase.EXPECT().CreateAPIKey(gomock.Any(), "some-uuid", "bot-uuid", entity.APIKeyRequest{
+			Name:              "ci",
+			Scopes:            []string{entity.ScopeMessagesWrite},
+			ConversationUUIDs: []string{"conv-uuid"},
+		}).Return(entity.APIKey{KeyUUID: "key-uuid", Key: "cma_secret"}, nil)
+
+		body := []byte(`{"name":"ci","scopes":["messages:write"],"conversation_uuids":["conv-uuid"]}`)
+		req, _ := http.NewRequest(http.MethodPost, "/v1/bots/bot-uuid/keys", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), "cma_secret")
+	})
+
+	t.Run("CreateAPIKeyInvalidScope", func(t *testing.T) {
+		mockBotUsecase.EXPECT().CreateAPIKey(gomock.Any(), "some-uuid", "bot-uuid", gomock.Any()).
+			Return(entity.APIKey{}, entity.ErrInvalidAPIKeyScope)
+
+		body := []byte(`{"name":"ci","scopes":["admin"],"conversation_uuids":["conv-uuid"]}`)
+		req, _ := http.NewRequest(http.MethodPost, "/v1/bots/bot-uuid/keys", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("GetAPIKeys", func(t *testing.T) {
+		mockBotUsecase.EXPECT().GetAPIKeys(gomock.Any(), "some-uuid", "bot-uuid").
+			Return([]entity.APIKey{{KeyUUID: "key-uuid", Name: "ci", CreatedAt: time.Now()}}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/bots/bot-uuid/keys", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "key-uuid")
+		assert.NotContains(t, w.Body.String(), `"key":`)
+	})
+
+	t.Run("RevokeAPIKey", func(t *testing.T) {
+		mockBotUsecase.EXPECT().RevokeAPIKey(gomock.Any(), "some-uuid", "bot-uuid", "key-uuid").Return(nil)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/v1/bots/bot-uuid/keys/key-uuid", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("RevokeAPIKeyFailure", func(t *testing.T) {
+		mockBotUsecase.EXPECT().RevokeAPIKey(gomock.Any(), "some-uuid", "bot-uuid", "key-uuid").Return(errors.New("test_error"))
+
+		req, _ := http.NewRequest(http.MethodDelete, "/v1/bots/bot-uuid/keys/key-uuid", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestBotMessageRoute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionUsecase := mocks.NewMockSession(ctrl)
+	mockAdminUsecase := mocks.NewMockAdmin(ctrl)
+	mockAdminUsecase.EXPECT().GetUserAccess(gomock.Any(), gomock.Any()).Return(entity.UserAccess{Role: entity.RoleUser}, nil).AnyTimes()
+	mockBotUsecase := mocks.NewMockBot(ctrl)
+	mockConversationUsecase := mocks.NewMockConversation(ctrl)
+	mockUserProfileUsecase := mocks.NewMockUserProfile(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	hub := NewHub()
+	go hub.Run()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	botHandler := router.Group("/v1")
+	botHandler.Use(authMiddleware(testSigner, mockSessionUsecase, mockAdminUsecase, mockBotUsecase, entity.ScopeMessagesWrite))
+	newBotMessageRoute(botHandler, mockBotUsecase, mockConversationUsecase, mockUserProfileUsecase, hub, mockLogger)
+
+	// Routes that do not list any scope reject API keys
+	userHandler := router.Group("/v1")
+	userHandler.Use(authMiddleware(testSigner, mockSessionUsecase, mockAdminUsecase, mockBotUsecase))
+	userHandler.GET("/protected", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	principal := entity.APIKeyPrincipal{
+		KeyUUID:           "key-uuid",
+		BotUUID:           "bot-uuid",
+		Scopes:            []string{entity.ScopeMessagesWrite},
+		ConversationUUIDs: []string{"conv-uuid"},
+	}
+
+	t.Run("SendMessage", func(t *testing.T) {
+		client := registerTestClient(hub, "conv-uuid", "some-session")
+
+		mockBotUsecase.EXPECT().AuthenticateAPIKey(gomock.Any(), "cma_valid").Return(principal, nil)
+		mockBotUsecase.EXPECT().AuthorizeConversation(gomock.Any(), principal, "conv-uuid", entity.ScopeMessagesWrite).Return(nil)
+		mockUserProfileUsecase.EXPECT().GetUserProfile(gomock.Any(), "bot-uuid").
+			Return(entity.UserProfile{UserUUID: "bot-uuid", FirstName: "Deploy bot", IsBot: true}, nil)
+		mockConversationUsecase.EXPECT().StoreConversationAndMessage(gomock.Any(), gomock.Any()).Return(nil)
+
+		body := []byte(`{"content":"build passed"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/v1/bot/conversations/conv-uuid/messages", bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer cma_valid")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"sender_is_bot":true`)
+
+		// Participants connected to the conversation receive the message
+		select {
+		case msg := <-client.send:
+			assert.Equal(t, "bot-uuid", msg.Data.SenderUUID)
+			assert.Equal(t, "build passed", msg.Data.Content)
+		case <-time.After(100 * time.Millisecond):
+			t.Error("message was not broadcast")
+		}
+	})
+
+	t.Run("SendMessageForbiddenConversation", func(t *testing.T) {
+		mockBotUsecase.EXPECT().AuthenticateAPIKey(gomock.Any(), "cma_valid").Return(principal, nil)
+		mockBotUsecase.EXPECT().AuthorizeConversation(gomock.Any(), principal, "other-conv", entity.ScopeMessagesWrite).
+			Return(entity.ErrAPIKeyForbidden)
+
+		body := []byte(`{"content":"build passed"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/v1/bot/conversations/other-conv/messages", bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer cma_valid")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("MissingScope", func(t *testing.T) {
+		mockBotUsecase.EXPECT().AuthenticateAPIKey(gomock.Any(), "cma_readonly").
+			Return(entity.APIKeyPrincipal{KeyUUID: "key-uuid", BotUUID: "bot-uuid"}, nil)
+
+		body := []byte(`{"content":"build passed"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/v1/bot/conversations/conv-uuid/messages", bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer cma_readonly")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("InvalidAPIKey", func(t *testing.T) {
+		mockBotUsecase.EXPECT().AuthenticateAPIKey(gomock.Any(), "cma_revoked").
+			Return(entity.APIKeyPrincipal{}, entity.ErrInvalidAPIKey)
+
+		body := []byte(`{"content":"build passed"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/v1/bot/conversations/conv-uuid/messages", bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer cma_revoked")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("UserRouteRejectsAPIKey", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/v1/protected", nil)
+		req.Header.Set("Authorization", "Bearer cma_valid")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("AccessTokenRejected", func(t *testing.T) {
+		token, err := createToken(testSigner, testSession)
+		assert.NoError(t, err)
+		mockSessionUsecase.EXPECT().IsTokenRevoked(gomock.Any(), testSession.AccessTokenID).Return(false, nil)
+
+		body := []byte(`{"content":"build passed"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/v1/bot/conversations/conv-uuid/messages", bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
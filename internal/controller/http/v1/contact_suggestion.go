@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type contactSuggestionRoutes struct {
+	cs usecase.ContactSuggestion
+	l  logger.Interface
+}
+
+// Handles api routes for the people the user may know
+func newContactSuggestionRoute(handler *gin.RouterGroup, cs usecase.ContactSuggestion, l logger.Interface) {
+	r := &contactSuggestionRoutes{cs, l}
+
+	// Group the routes under the "/contact-suggestions" path.
+	// They can not be under "/contact", because its routes start with the username.
+	h := handler.Group("/contact-suggestions")
+	{
+		// Define the endpoints for the contact suggestions functionality.
+		h.GET("", r.getSuggestions)
+	}
+}
+
+// getSuggestions returns the users the user may know, best ranked first.
+func (r *contactSuggestionRoutes) getSuggestions(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get 'limit' value from URL query, the entity object applies the default and the maximum
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	// Call GetSuggestions method from contact suggestion entity object
+	suggestions, err := r.cs.GetSuggestions(c.Request.Context(), userUUID, limit)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - getSuggestions - GetSuggestions")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the suggestions as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToContactSuggestionsResponse(suggestions))
+}
@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+func TestContactSuggestionRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockContactSuggestion(ctrl)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	protected := router.Group("/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	newContactSuggestionRoute(protected, mockUsecase, logger.New(logLevelDebug))
+
+	t.Run("GetSuggestions", func(t *testing.T) {
+		mockUsecase.EXPECT().GetSuggestions(gomock.Any(), "some-uuid", 10).Return([]entity.ContactSuggestion{{
+			User:           entity.UserProfile{UserUUID: "suggested-uuid", FirstName: "Suggested"},
+			Username:       "suggested",
+			MutualContacts: 3,
+			SharedGroups:   1,
+		}}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/contact-suggestions?limit=10", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response []boundary.ContactSuggestionResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response, 1)
+		assert.Equal(t, "suggested", response[0].Username)
+		assert.Equal(t, 3, response[0].MutualContacts)
+	})
+
+	t.Run("NoSuggestions", func(t *testing.T) {
+		mockUsecase.EXPECT().GetSuggestions(gomock.Any(), "some-uuid", 0).Return(nil, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/contact-suggestions", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, "[]", w.Body.String())
+	})
+
+	t.Run("EntityObjectFailure", func(t *testing.T) {
+		mockUsecase.EXPECT().GetSuggestions(gomock.Any(), "some-uuid", 0).Return(nil, errors.New("test_error"))
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/contact-suggestions", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
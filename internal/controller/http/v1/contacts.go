@@ -1,23 +1,29 @@
 package v1
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
 	"github.com/maxyong7/chat-messaging-app/internal/usecase"
 	"github.com/maxyong7/chat-messaging-app/pkg/logger"
 )
 
 type contactRoute struct {
-	t usecase.Contact
-	l logger.Interface
+	t   usecase.Contact
+	hub *Hub
+	l   logger.Interface
 }
 
 // Handles api routes for contacts functionality
-func newContactRoute(handler *gin.RouterGroup, t usecase.Contact, l logger.Interface) {
-	route := &contactRoute{t, l}
+func newContactRoute(handler *gin.RouterGroup, t usecase.Contact, hub *Hub, l logger.Interface) {
+	route := &contactRoute{t, hub, l}
 
 	// Group the routes under the "/contact" path.
 	h := handler.Group("/contact")
@@ -27,7 +33,23 @@ func newContactRoute(handler *gin.RouterGroup, t usecase.Contact, l logger.Inter
 		h.POST("/:username/add", route.addContact)
 		h.POST("/:username/remove", route.removeContact)
 		h.PATCH("/:username", route.updateBlockContact)
+		h.PATCH("/:username/details", route.updateContactDetails)
 	}
+
+	// Group the routes under the "/contact-requests" path.
+	// They can not be under "/contact", because its routes start with the username.
+	requests := handler.Group("/contact-requests")
+	{
+		// Define the endpoints for the contact requests functionality.
+		requests.GET("/incoming", route.getIncomingContactRequests)
+		requests.GET("/outgoing", route.getOutgoingContactRequests)
+		requests.POST("/:requestId/accept", route.acceptContactRequest)
+		requests.POST("/:requestId/decline", route.declineContactRequest)
+		requests.POST("/:requestId/cancel", route.cancelContactRequest)
+	}
+
+	// Matches the address book of the user against registered users, without uploading the email addresses
+	handler.POST("/contact-discovery", route.importContacts)
 }
 
 func (r *contactRoute) getContacts(c *gin.Context) {
@@ -39,8 +61,29 @@ func (r *contactRoute) getContacts(c *gin.Context) {
 		return
 	}
 
+	// Get 'favourite' value from URL query, all contacts are listed if it is missing
+	var favourite bool
+	if favouriteString := c.Query("favourite"); favouriteString != "" {
+		favourite, err = strconv.ParseBool(favouriteString)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "invalid favourite query")
+			return
+		}
+	}
+
+	// Get 'limit' value from URL query, the entity object applies the default and the maximum
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
 	// Calls GetContacts method from contact entity object
-	contacts, err := r.t.GetContacts(c.Request.Context(), userId)
+	// The cursor is the user UUID of the last contact of the previous page
+	page, err := r.t.GetContacts(c.Request.Context(), entity.ContactsQuery{
+		UserUUID:  userId,
+		Label:     c.Query("label"),
+		Favourite: favourite,
+		Sort:      c.Query("sort"),
+		Cursor:    c.Query("cursor"),
+		Limit:     limit,
+	})
 	if err != nil {
 		// Logs the error
 		r.l.Error(err, "http - v1 - getContacts - GetContacts")
@@ -52,8 +95,8 @@ func (r *contactRoute) getContacts(c *gin.Context) {
 	}
 
 	// Writes the status code provided in the argument.
-	// It also writes a JSON body returned by the contact entity object.
-	c.JSON(http.StatusOK, contacts)
+	// It also writes a JSON body with the page of contacts returned by the contact entity object.
+	c.JSON(http.StatusOK, boundary.ToContactsResponse(page))
 }
 
 func (r *contactRoute) addContact(c *gin.Context) {
@@ -73,7 +116,7 @@ func (r *contactRoute) addContact(c *gin.Context) {
 	}
 
 	// Calls AddContact method from contact entity object
-	err = r.t.AddContact(c.Request.Context(), contactUserName, userId)
+	request, err := r.t.AddContact(c.Request.Context(), contactUserName, userId)
 	if err != nil {
 		// Logs the error
 		r.l.Error(err, "http - v1 - addContact - AddContacts")
@@ -84,8 +127,18 @@ func (r *contactRoute) addContact(c *gin.Context) {
 		return
 	}
 
-	// Sends an HTTP response header with the provided status code.
-	c.Writer.WriteHeader(http.StatusCreated)
+	// The contact had already sent a request to the user, so theirs was accepted instead
+	if request.Status == entity.ContactRequestAccepted {
+		r.notifyContactRequest(request, request.FromUserUUID)
+		c.JSON(http.StatusOK, boundary.ToContactRequestResponse(request))
+		return
+	}
+
+	r.notifyContactRequest(request, request.ToUserUUID)
+
+	// Writes the status code provided in the argument.
+	// It also writes a JSON body with the pending contact request.
+	c.JSON(http.StatusCreated, boundary.ToContactRequestResponse(request))
 }
 
 func (r *contactRoute) removeContact(c *gin.Context) {
@@ -168,3 +221,200 @@ func (r *contactRoute) updateBlockContact(c *gin.Context) {
 	// Sends an HTTP response header with the provided status code.
 	c.Writer.WriteHeader(http.StatusNoContent)
 }
+
+func (r *contactRoute) updateContactDetails(c *gin.Context) {
+	// Get user_uuid from context
+	userId, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get username from URL parameter
+	contactUserName := c.Param("username")
+	if contactUserName == "" {
+		errorResponse(c, http.StatusUnprocessableEntity, "missing username in parameter")
+		return
+	}
+
+	// Bind the incoming JSON request body to the ContactDetailsForm struct.
+	var request boundary.ContactDetailsForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If there is an error in binding JSON, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - updateContactDetails")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Calls UpdateContactDetails method from contact entity object
+	err = r.t.UpdateContactDetails(c.Request.Context(), contactUserName, userId, request.ToContactDetails())
+	if err != nil {
+		// Logs the error
+		r.l.Error(err, "http - v1 - updateContactDetails - UpdateContactDetails")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Sends an HTTP response header with the provided status code.
+	c.Writer.WriteHeader(http.StatusNoContent)
+}
+
+func (r *contactRoute) getIncomingContactRequests(c *gin.Context) {
+	r.getContactRequests(c, entity.ContactRequestsIncoming)
+}
+
+func (r *contactRoute) getOutgoingContactRequests(c *gin.Context) {
+	r.getContactRequests(c, entity.ContactRequestsOutgoing)
+}
+
+func (r *contactRoute) getContactRequests(c *gin.Context, direction string) {
+	// Get user_uuid from context
+	userId, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Calls GetContactRequests method from contact entity object
+	requests, err := r.t.GetContactRequests(c.Request.Context(), userId, direction)
+	if err != nil {
+		// Logs the error
+		r.l.Error(err, "http - v1 - getContactRequests - GetContactRequests")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Writes the status code provided in the argument.
+	// It also writes a JSON body with the pending contact requests.
+	c.JSON(http.StatusOK, boundary.ToContactRequestsResponse(requests))
+}
+
+func (r *contactRoute) acceptContactRequest(c *gin.Context) {
+	// Calls AcceptContactRequest method from contact entity object, then tells the sender
+	r.respondToContactRequest(c, "acceptContactRequest", r.t.AcceptContactRequest, func(request entity.ContactRequest) {
+		r.notifyContactRequest(request, request.FromUserUUID)
+	})
+}
+
+func (r *contactRoute) declineContactRequest(c *gin.Context) {
+	// Calls DeclineContactRequest method from contact entity object, the sender is not told about it
+	r.respondToContactRequest(c, "declineContactRequest", r.t.DeclineContactRequest, nil)
+}
+
+func (r *contactRoute) cancelContactRequest(c *gin.Context) {
+	// Calls CancelContactRequest method from contact entity object, then tells the recipient
+	r.respondToContactRequest(c, "cancelContactRequest", r.t.CancelContactRequest, func(request entity.ContactRequest) {
+		r.notifyContactRequest(request, request.ToUserUUID)
+	})
+}
+
+func (r *contactRoute) respondToContactRequest(c *gin.Context, handlerName string,
+	respond func(ctx context.Context, requestUUID string, userUUID string) (entity.ContactRequest, error),
+	notify func(entity.ContactRequest),
+) {
+	// Get user_uuid from context
+	userId, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	request, err := respond(c.Request.Context(), c.Param("requestId"), userId)
+	if err != nil {
+		// Logs the error
+		r.l.Error(err, fmt.Sprintf("http - v1 - %s", handlerName))
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	if notify != nil {
+		notify(request)
+	}
+
+	// Writes the status code provided in the argument.
+	// It also writes a JSON body with the updated contact request.
+	c.JSON(http.StatusOK, boundary.ToContactRequestResponse(request))
+}
+
+func (r *contactRoute) importContacts(c *gin.Context) {
+	// Get user_uuid from context
+	userId, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Bind the incoming JSON request body to the ContactImportForm struct.
+	var request boundary.ContactImportForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If there is an error in binding JSON, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - importContacts")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Calls ImportContacts method from contact entity object
+	matches, err := r.t.ImportContacts(c.Request.Context(), request.ToContactImport(userId))
+	if err != nil {
+		// Logs the error
+		r.l.Error(err, "http - v1 - importContacts - ImportContacts")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Tell every user who was sent a contact request by the import
+	for _, match := range matches {
+		if match.Request != nil {
+			r.notifyContactRequest(*match.Request, match.Request.ToUserUUID)
+		}
+	}
+
+	// Writes the status code provided in the argument.
+	// It also writes a JSON body with the matching users.
+	c.JSON(http.StatusOK, boundary.ToContactImportResponse(matches))
+}
+
+// notifyContactRequest tells the other user of the request about it over their websockets.
+func (r *contactRoute) notifyContactRequest(request entity.ContactRequest, userUUID string) {
+	r.hub.Notify <- Notification{
+		UserUUIDs: []string{userUUID},
+		Message:   buildContactRequestResponse(request, userUUID),
+	}
+}
+
+// Method to build contact request response body
+func buildContactRequestResponse(request entity.ContactRequest, recipientUUID string) boundary.ConversationResponseModel {
+	// The sender of the message is whoever acted on the request
+	senderUUID := request.FromUserUUID
+	if recipientUUID == request.FromUserUUID {
+		senderUUID = request.ToUserUUID
+	}
+
+	return boundary.ConversationResponseModel{
+		MessageType: contactRequestMessageType,
+		Data: boundary.ConversationResponseData{
+			SenderUUID:       senderUUID,
+			ConversationUUID: request.ConversationUUID,
+			ContactRequestResponseData: boundary.ContactRequestResponseData{
+				RequestUUID:   request.RequestUUID,
+				RequestStatus: request.Status,
+			},
+		},
+	}
+}
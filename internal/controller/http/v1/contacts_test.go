@@ -1,15 +1,18 @@
 package v1
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
 	"github.com/maxyong7/chat-messaging-app/internal/entity"
 	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
 	"github.com/maxyong7/chat-messaging-app/pkg/logger"
@@ -42,7 +45,7 @@ func TestGetContacts(t *testing.T) {
 
 		// Mock the expected behavior
 		userId := "some-uuid"
-		mockUsecase.EXPECT().GetContacts(gomock.Any(), userId).Return([]entity.Contacts{}, nil)
+		mockUsecase.EXPECT().GetContacts(gomock.Any(), entity.ContactsQuery{UserUUID: userId}).Return(entity.ContactsPage{Limit: 50}, nil)
 
 		// Perform the request
 		req, _ := http.NewRequest(http.MethodGet, "/contact", nil)
@@ -50,7 +53,51 @@ func TestGetContacts(t *testing.T) {
 		router.ServeHTTP(w, req)
 
 		// Assert the results
+		var response boundary.ContactsResponse
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.NotNil(t, response.Contacts)
+		assert.Empty(t, response.Pagination.Cursor)
+	})
+
+	t.Run("FilteredNextPage", func(t *testing.T) {
+
+		// Mock the expected behavior
+		mockUsecase.EXPECT().GetContacts(gomock.Any(), entity.ContactsQuery{
+			UserUUID:  "some-uuid",
+			Label:     "family",
+			Favourite: true,
+			Sort:      entity.ContactsSortFavourite,
+			Cursor:    "cursor-uuid",
+			Limit:     1,
+		}).Return(entity.ContactsPage{
+			Contacts:   []entity.Contacts{{UserProfile: entity.UserProfile{UserUUID: "contact-uuid"}, Nickname: "Mum", Favourite: true, Labels: []string{"family"}}},
+			NextCursor: "contact-uuid",
+			Limit:      1,
+		}, nil)
+
+		// Perform the request
+		req, _ := http.NewRequest(http.MethodGet, "/contact?label=family&favourite=true&sort=favourite&cursor=cursor-uuid&limit=1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert the results
+		var response boundary.ContactsResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Contacts, 1)
+		assert.Equal(t, "Mum", response.Contacts[0].Nickname)
+		assert.Equal(t, "contact-uuid", response.Pagination.Cursor)
+	})
+
+	t.Run("InvalidFavourite", func(t *testing.T) {
+		// Perform the request
+		req, _ := http.NewRequest(http.MethodGet, "/contact?favourite=maybe", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert the results
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("Unauthorized", func(t *testing.T) {
@@ -72,7 +119,7 @@ func TestGetContacts(t *testing.T) {
 
 		// Mock the expected behavior
 		userId := "some-uuid"
-		mockUsecase.EXPECT().GetContacts(gomock.Any(), userId).Return([]entity.Contacts{}, errors.New("test_error"))
+		mockUsecase.EXPECT().GetContacts(gomock.Any(), entity.ContactsQuery{UserUUID: userId}).Return(entity.ContactsPage{}, errors.New("test_error"))
 
 		// Perform the request
 		req, _ := http.NewRequest(http.MethodGet, "/contact", nil)
@@ -91,7 +138,10 @@ func TestAddContact(t *testing.T) {
 	mockUsecase := mocks.NewMockContact(ctrl)
 	mockLogger := logger.New(logLevelDebug)
 
-	r := &contactRoute{t: mockUsecase, l: mockLogger}
+	hub := NewHub()
+	go hub.Run()
+
+	r := &contactRoute{t: mockUsecase, hub: hub, l: mockLogger}
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -106,13 +156,57 @@ func TestAddContact(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		userId := "some-uuid"
 		username := "testuser"
-		mockUsecase.EXPECT().AddContact(gomock.Any(), username, userId).Return(nil)
+		contact := NewClient("conv-uuid", entity.UserProfile{UserUUID: "testuser-uuid"}, nil, hub, nil)
+		hub.Register <- contact
+
+		mockUsecase.EXPECT().AddContact(gomock.Any(), username, userId).Return(entity.ContactRequest{
+			RequestUUID:  "request-uuid",
+			FromUserUUID: userId,
+			ToUserUUID:   "testuser-uuid",
+			Status:       entity.ContactRequestPending,
+		}, nil)
 
 		req, _ := http.NewRequest(http.MethodPost, "/contact/testuser/add", nil)
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusCreated, w.Code)
+
+		// The contact is told about the request
+		message := <-contact.send
+		assert.Equal(t, contactRequestMessageType, message.MessageType)
+		assert.Equal(t, userId, message.Data.SenderUUID)
+		assert.Equal(t, "request-uuid", message.Data.RequestUUID)
+		assert.Equal(t, entity.ContactRequestPending, message.Data.RequestStatus)
+	})
+
+	t.Run("AcceptsRequestOfContact", func(t *testing.T) {
+		userId := "some-uuid"
+		username := "testuser"
+		contact := NewClient("conv-uuid", entity.UserProfile{UserUUID: "testuser-uuid"}, nil, hub, nil)
+		hub.Register <- contact
+
+		mockUsecase.EXPECT().AddContact(gomock.Any(), username, userId).Return(entity.ContactRequest{
+			RequestUUID:      "request-uuid",
+			FromUserUUID:     "testuser-uuid",
+			ToUserUUID:       userId,
+			Status:           entity.ContactRequestAccepted,
+			ConversationUUID: "dm-uuid",
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/contact/testuser/add", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.ContactRequestResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "dm-uuid", response.ConversationUUID)
+
+		// The contact is told their request was accepted
+		message := <-contact.send
+		assert.Equal(t, entity.ContactRequestAccepted, message.Data.RequestStatus)
+		assert.Equal(t, "dm-uuid", message.Data.ConversationUUID)
 	})
 
 	t.Run("MissingUsername", func(t *testing.T) {
@@ -135,10 +229,22 @@ func TestAddContact(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 
+	t.Run("RequestAlreadyPending", func(t *testing.T) {
+		userId := "some-uuid"
+		username := "testuser"
+		mockUsecase.EXPECT().AddContact(gomock.Any(), username, userId).Return(entity.ContactRequest{}, entity.ErrContactRequestExists)
+
+		req, _ := http.NewRequest(http.MethodPost, "/contact/testuser/add", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
 	t.Run("Entity object failure - error calling AddContact", func(t *testing.T) {
 		userId := "some-uuid"
 		username := "testuser"
-		mockUsecase.EXPECT().AddContact(gomock.Any(), username, userId).Return(errors.New("test_error"))
+		mockUsecase.EXPECT().AddContact(gomock.Any(), username, userId).Return(entity.ContactRequest{}, errors.New("test_error"))
 
 		req, _ := http.NewRequest(http.MethodPost, "/contact/testuser/add", nil)
 		w := httptest.NewRecorder()
@@ -148,6 +254,108 @@ func TestAddContact(t *testing.T) {
 	})
 }
 
+func TestContactRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockContact(ctrl)
+
+	hub := NewHub()
+	go hub.Run()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := router.Group("/v1")
+	handler.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	newContactRoute(handler, mockUsecase, hub, logger.New(logLevelDebug))
+
+	t.Run("GetIncoming", func(t *testing.T) {
+		mockUsecase.EXPECT().GetContactRequests(gomock.Any(), "some-uuid", entity.ContactRequestsIncoming).Return([]entity.ContactRequest{{
+			RequestUUID:  "request-uuid",
+			FromUserUUID: "sender-uuid",
+			ToUserUUID:   "some-uuid",
+			Status:       entity.ContactRequestPending,
+			User:         entity.UserProfile{UserUUID: "sender-uuid", FirstName: "Sender"},
+			Username:     "sender",
+		}}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/contact-requests/incoming", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response []boundary.ContactRequestResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response, 1)
+		assert.Equal(t, "sender", response[0].User.Username)
+	})
+
+	t.Run("AcceptNotifiesSender", func(t *testing.T) {
+		sender := NewClient("conv-uuid", entity.UserProfile{UserUUID: "sender-uuid"}, nil, hub, nil)
+		hub.Register <- sender
+
+		mockUsecase.EXPECT().AcceptContactRequest(gomock.Any(), "request-uuid", "some-uuid").Return(entity.ContactRequest{
+			RequestUUID:      "request-uuid",
+			FromUserUUID:     "sender-uuid",
+			ToUserUUID:       "some-uuid",
+			Status:           entity.ContactRequestAccepted,
+			ConversationUUID: "dm-uuid",
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/contact-requests/request-uuid/accept", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		message := <-sender.send
+		assert.Equal(t, "some-uuid", message.Data.SenderUUID)
+		assert.Equal(t, "dm-uuid", message.Data.ConversationUUID)
+		assert.Equal(t, entity.ContactRequestAccepted, message.Data.RequestStatus)
+	})
+
+	t.Run("DeclineIsSilent", func(t *testing.T) {
+		sender := NewClient("conv-uuid", entity.UserProfile{UserUUID: "sender-uuid"}, nil, hub, nil)
+		hub.Register <- sender
+
+		mockUsecase.EXPECT().DeclineContactRequest(gomock.Any(), "request-uuid", "some-uuid").Return(entity.ContactRequest{
+			RequestUUID:  "request-uuid",
+			FromUserUUID: "sender-uuid",
+			ToUserUUID:   "some-uuid",
+			Status:       entity.ContactRequestDeclined,
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/contact-requests/request-uuid/decline", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assertClientDisconnected(t, sender, false)
+	})
+
+	t.Run("CancelNotFound", func(t *testing.T) {
+		mockUsecase.EXPECT().CancelContactRequest(gomock.Any(), "unknown-uuid", "some-uuid").Return(entity.ContactRequest{}, entity.ErrContactRequestNotFound)
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/contact-requests/unknown-uuid/cancel", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("AlreadyAnswered", func(t *testing.T) {
+		mockUsecase.EXPECT().AcceptContactRequest(gomock.Any(), "request-uuid", "some-uuid").Return(entity.ContactRequest{}, entity.ErrContactRequestNotPending)
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/contact-requests/request-uuid/accept", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
 func TestRemoveContact(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -274,3 +482,139 @@ func TestUpdateBlockContact(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 }
+
+func TestImportContacts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockContact(ctrl)
+
+	hub := NewHub()
+	go hub.Run()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := router.Group("/v1")
+	handler.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	newContactRoute(handler, mockUsecase, hub, logger.New(logLevelDebug))
+
+	t.Run("AddNotifiesMatches", func(t *testing.T) {
+		recipient := NewClient("conv-uuid", entity.UserProfile{UserUUID: "match-uuid"}, nil, hub, nil)
+		hub.Register <- recipient
+
+		mockUsecase.EXPECT().ImportContacts(gomock.Any(), entity.ContactImport{
+			UserUUID: "some-uuid",
+			Salt:     "some-salt",
+			Hashes:   []string{"some-hash", "other-hash"},
+			Add:      true,
+		}).Return([]entity.ContactMatch{
+			{
+				Hash:     "some-hash",
+				User:     entity.UserProfile{UserUUID: "match-uuid", FirstName: "Match"},
+				Username: "match",
+				Request: &entity.ContactRequest{
+					RequestUUID:  "request-uuid",
+					FromUserUUID: "some-uuid",
+					ToUserUUID:   "match-uuid",
+					Status:       entity.ContactRequestPending,
+				},
+			},
+			{
+				Hash:    "other-hash",
+				User:    entity.UserProfile{UserUUID: "contact-uuid"},
+				Contact: true,
+			},
+		}, nil)
+
+		body := `{"salt": "some-salt", "hashes": ["some-hash", "other-hash"], "add": true}`
+		req, _ := http.NewRequest(http.MethodPost, "/v1/contact-discovery", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.ContactImportResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Matches, 2)
+		assert.Equal(t, "request-uuid", response.Matches[0].Request.RequestUUID)
+		assert.Nil(t, response.Matches[1].Request)
+
+		message := <-recipient.send
+		assert.Equal(t, contactRequestMessageType, message.MessageType)
+		assert.Equal(t, "some-uuid", message.Data.SenderUUID)
+	})
+
+	t.Run("InvalidRequestBody", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/v1/contact-discovery", strings.NewReader(`{"add": true}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("TooManyRequests", func(t *testing.T) {
+		mockUsecase.EXPECT().ImportContacts(gomock.Any(), gomock.Any()).Return(nil, entity.ErrTooManyRequests)
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/contact-discovery", strings.NewReader(`{"salt": "some-salt", "hashes": ["some-hash"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+}
+
+func TestUpdateContactDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockContact(ctrl)
+	r := &contactRoute{t: mockUsecase, l: logger.New(logLevelDebug)}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	router.PATCH("/contact/:username/details", r.updateContactDetails)
+
+	t.Run("Success", func(t *testing.T) {
+		nickname := "Mum"
+		labels := []string{"family"}
+		mockUsecase.EXPECT().UpdateContactDetails(gomock.Any(), "contact", "some-uuid", entity.ContactDetails{Nickname: &nickname, Labels: &labels}).Return(nil)
+
+		req, _ := http.NewRequest(http.MethodPatch, "/contact/contact/details", strings.NewReader(`{"nickname": "Mum", "labels": ["family"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("InvalidDetails", func(t *testing.T) {
+		mockUsecase.EXPECT().UpdateContactDetails(gomock.Any(), "contact", "some-uuid", gomock.Any()).Return(entity.ErrInvalidContactDetails)
+
+		req, _ := http.NewRequest(http.MethodPatch, "/contact/contact/details", strings.NewReader(`{"labels": [""]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("NotAContact", func(t *testing.T) {
+		mockUsecase.EXPECT().UpdateContactDetails(gomock.Any(), "stranger", "some-uuid", gomock.Any()).Return(entity.ErrContactDoesNotExists)
+
+		req, _ := http.NewRequest(http.MethodPatch, "/contact/stranger/details", strings.NewReader(`{"favourite": true}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
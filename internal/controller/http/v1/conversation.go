@@ -24,15 +24,15 @@ type conversationRoutes struct {
 	up       usecase.UserProfile
 	msg      usecase.Message
 	reaction usecase.Reaction
+	presence usecase.Presence
+	report   usecase.Report
 	l        logger.Interface
 }
 
 // Handles api routes for conversation functionality
-func newConversationRoute(handler *gin.RouterGroup, c usecase.Conversation, up usecase.UserProfile, msg usecase.Message, reaction usecase.Reaction, l logger.Interface) {
-	route := &conversationRoutes{c, up, msg, reaction, l}
-	// Initialize a hub and run it with a new thread for websocket connection
-	hub := NewHub()
-	go hub.Run()
+// The hub is shared with other routes, e.g. to disconnect the websockets of a revoked session
+func newConversationRoute(handler *gin.RouterGroup, hub *Hub, c usecase.Conversation, up usecase.UserProfile, msg usecase.Message, reaction usecase.Reaction, presence usecase.Presence, report usecase.Report, l logger.Interface) {
+	route := &conversationRoutes{c, up, msg, reaction, presence, report, l}
 
 	// Group the routes under the "/conversation" path.
 	h := handler.Group("/conversation")
@@ -108,10 +108,12 @@ func (r *conversationRoutes) getConversations(c *gin.Context) {
 type Client struct {
 	ID       string
 	UserInfo entity.UserProfile
-	Conn     *websocket.Conn
-	send     chan boundary.ConversationResponseModel
-	hub      *Hub
-	route    *conversationRoutes
+	// SessionUUID is the session of the access token the websocket was opened with
+	SessionUUID string
+	Conn        *websocket.Conn
+	send        chan boundary.ConversationResponseModel
+	hub         *Hub
+	route       *conversationRoutes
 }
 
 // Method that act as a websocket controller
@@ -144,9 +146,13 @@ func (r *conversationRoutes) serveWsController(hub *Hub) gin.HandlerFunc {
 		clientId := c.Param("conversationId")
 		// Creates a new client
 		client := NewClient(clientId, userInfo, conn, hub, r)
+		// Keep the session so the connection can be closed when the session is revoked
+		client.SessionUUID, _ = getSessionUUIDFromContext(c)
 		// Register the new client to the hub.
 		// It checks if room exists based on the conversationId, create it if doesn't exist and add client to it
 		hub.Register <- client
+		// The user is online from their first connection on, their contacts are told if they were not
+		r.connectPresence(hub, userUUID)
 
 		// Create a new thread to write and send messages
 		go client.writePump()
@@ -161,17 +167,35 @@ type Hub struct {
 	Unregister  chan *Client
 	Broadcast   chan boundary.ConversationResponseModel
 	HandleError chan boundary.ConversationResponseModel
-	mu          sync.Mutex
+	// Disconnect receives session uuids whose websocket connections have to be closed
+	Disconnect chan string
+	// DisconnectUser receives user uuids whose websocket connections have to be closed
+	DisconnectUser chan string
+	// DisconnectConversation receives conversation uuids whose websocket connections have to be closed
+	DisconnectConversation chan string
+	// Notify receives messages for users, whichever conversation their websockets are connected to
+	Notify chan Notification
+	mu     sync.Mutex
+}
+
+// Notification is a message sent to every websocket connection of the users
+type Notification struct {
+	UserUUIDs []string
+	Message   boundary.ConversationResponseModel
 }
 
 // Method to initialize a new hub
 func NewHub() *Hub {
 	return &Hub{
-		Clients:     make(map[string]map[*Client]bool),
-		Register:    make(chan *Client),
-		Unregister:  make(chan *Client),
-		Broadcast:   make(chan boundary.ConversationResponseModel),
-		HandleError: make(chan boundary.ConversationResponseModel),
+		Clients:                make(map[string]map[*Client]bool),
+		Register:               make(chan *Client),
+		Unregister:             make(chan *Client),
+		Broadcast:              make(chan boundary.ConversationResponseModel),
+		HandleError:            make(chan boundary.ConversationResponseModel),
+		Disconnect:             make(chan string),
+		DisconnectUser:         make(chan string),
+		DisconnectConversation: make(chan string),
+		Notify:                 make(chan Notification),
 	}
 }
 
@@ -200,14 +224,8 @@ func (h *Hub) Run() {
 			// Locks mutex
 			h.mu.Lock()
 
-			// Find the client based on client's ID
-			if _, ok := h.Clients[client.ID]; ok {
-				// If found delete the client id key stored in the hub
-				delete(h.Clients, client.ID)
-
-				// Closes the client websocket channel
-				close(client.send)
-			}
+			// Remove only this client from its room, other clients of the same conversation stay connected
+			h.removeClient(client)
 
 			// Unlocks mutex
 			h.mu.Unlock()
@@ -215,6 +233,48 @@ func (h *Hub) Run() {
 			// Logs when a client has disconnected from the hub
 			fmt.Printf("Client %s disconnected\n", client.ID)
 
+		// Close every connection of a session if 'Disconnect' is called
+		case sessionUUID := <-h.Disconnect:
+			// Locks mutex
+			h.mu.Lock()
+
+			h.DisconnectSession(sessionUUID)
+
+			// Unlocks mutex
+			h.mu.Unlock()
+
+		// Close every connection of a user if 'DisconnectUser' is called
+		case userUUID := <-h.DisconnectUser:
+			// Locks mutex
+			h.mu.Lock()
+
+			h.DisconnectUserClients(userUUID)
+
+			// Unlocks mutex
+			h.mu.Unlock()
+
+		// Close every connection of a conversation if 'DisconnectConversation' is called
+		case conversationUUID := <-h.DisconnectConversation:
+			// Locks mutex
+			h.mu.Lock()
+
+			for client := range h.Clients[conversationUUID] {
+				h.removeClient(client)
+			}
+
+			// Unlocks mutex
+			h.mu.Unlock()
+
+		// Send messages to the connections of users if 'Notify' is called
+		case notification := <-h.Notify:
+			// Locks mutex
+			h.mu.Lock()
+
+			h.NotifyUsers(notification)
+
+			// Unlocks mutex
+			h.mu.Unlock()
+
 		// Broadcast messages to client(s) if 'Broadcast' is called
 		case message := <-h.Broadcast:
 			// Locks mutex
@@ -246,6 +306,72 @@ func (h *Hub) RegisterNewClient(client *Client) {
 	fmt.Println("Size of Clients: ", len(h.Clients[client.ID]))
 }
 
+// DisconnectSession closes every websocket connection that was authenticated with the given session.
+// Closing the send channel makes writePump send a close message, after which readPump unregisters the client.
+func (h *Hub) DisconnectSession(sessionUUID string) {
+	for _, clients := range h.Clients {
+		for client := range clients {
+			if client.SessionUUID == sessionUUID {
+				h.removeClient(client)
+			}
+		}
+	}
+}
+
+// DisconnectUserClients closes every websocket connection of the given user, whichever session it was opened with.
+func (h *Hub) DisconnectUserClients(userUUID string) {
+	for _, clients := range h.Clients {
+		for client := range clients {
+			if client.UserInfo.UserUUID == userUUID {
+				h.removeClient(client)
+			}
+		}
+	}
+}
+
+// NotifyUsers sends the message to every websocket connection of the users of the notification.
+func (h *Hub) NotifyUsers(notification Notification) {
+	userUUIDs := make(map[string]bool, len(notification.UserUUIDs))
+	for _, userUUID := range notification.UserUUIDs {
+		userUUIDs[userUUID] = true
+	}
+
+	for _, clients := range h.Clients {
+		for client := range clients {
+			if !userUUIDs[client.UserInfo.UserUUID] {
+				continue
+			}
+			select {
+			case client.send <- notification.Message:
+
+			// For any unexpected case, close websocket and delete client from hub
+			default:
+				h.removeClient(client)
+			}
+		}
+	}
+}
+
+// removeClient deletes the client from its room, and the room once it is empty.
+// It does nothing if the client was already removed, so it is safe to call more than once for the same client.
+func (h *Hub) removeClient(client *Client) {
+	clients, ok := h.Clients[client.ID]
+	if !ok {
+		return
+	}
+	if _, ok := clients[client]; !ok {
+		return
+	}
+
+	delete(clients, client)
+	// Closes the client websocket channel
+	close(client.send)
+
+	if len(clients) == 0 {
+		delete(h.Clients, client.ID)
+	}
+}
+
 // Method to handle broadcasting message based on type of message
 func (h *Hub) HandleBroadcast(message boundary.ConversationResponseModel) {
 	// Logs message that being processed
@@ -254,8 +380,9 @@ func (h *Hub) HandleBroadcast(message boundary.ConversationResponseModel) {
 	// Get all the clients connected to the same ConversationUUID
 	clients := h.Clients[message.Data.ConversationUUID]
 
-	// If message type is 'error', broadcast the error message only to the sender
-	if message.MessageType == "error" {
+	// If message type is 'error' or 'report', broadcast the message only to the sender
+	// The other participants must not learn who reported them
+	if message.MessageType == errorMessageType || message.MessageType == reportMessageType {
 		// Loops through the list of clients to find the sender
 		for client := range clients {
 			if client.UserInfo.UserUUID == message.Data.SenderUUID {
@@ -306,9 +433,16 @@ const (
 	addReactionMessageType    = "add_reaction"
 	removeReactionMessageType = "remove_reaction"
 	deleteMessageType         = "delete_message"
+	activityMessageType       = "activity"
+	presenceMessageType       = "presence"
+	statusMessageType         = "status"
+	contactRequestMessageType = "contact_request"
+	reportMessageType         = "report"
 	errProcessingMessage      = "error processing message"
 	errProcessingReaction     = "error processing reaction"
+	errProcessingReport       = "error processing report"
 	errOnlyAuthorCanDeleteMsg = "cannot delete because user is not message author"
+	errConversationBlocked    = "cannot send because the conversation is blocked"
 )
 
 // readPump handles reading messages from the WebSocket connection.
@@ -317,6 +451,8 @@ func (c *Client) readPump() {
 	defer func() {
 		c.hub.Unregister <- c
 		c.Conn.Close()
+		// The user stays online for the grace period, in case they reconnect
+		c.route.disconnectPresence(c.UserInfo.UserUUID)
 	}()
 
 	// Set the maximum size for incoming messages.
@@ -410,7 +546,7 @@ var upgrader = websocket.Upgrader{
 }
 
 // handleConversation processes different types of conversation requests such as sending, deleting,
-// adding a reaction to a message, or removing a reaction. It also handles errors and broadcasts
+// adding a reaction to a message, removing a reaction, or reporting abuse. It also handles errors and broadcasts
 // appropriate messages to the hub.
 func (c *Client) handleConversation(convReq boundary.ConversationRequestModel, userInfo entity.UserProfile) {
 	// Extract sender and conversation identifiers from userInfo and the client instance.
@@ -420,8 +556,14 @@ func (c *Client) handleConversation(convReq boundary.ConversationRequestModel, u
 	// Create a background context
 	ctx := context.Background()
 
+	// Any message from the user counts as activity, an away user is online again
+	c.route.touchPresence(c.hub, senderUUID)
+
 	// Handle different types of conversation requests based on the MessageType in convReq.
 	switch convReq.MessageType {
+	// Sent by clients to tell the user is still active without doing anything else
+	case activityMessageType:
+
 	case sendMessageType:
 		// Unmarshal the data in convReq into a ChatInterface boundary object.
 		var sendMessageRequest boundary.ChatInterface
@@ -445,6 +587,12 @@ func (c *Client) handleConversation(convReq boundary.ConversationRequestModel, u
 
 		// Store the conversation and message by calling conversation entity object's StoreConversationAndMessage method
 		err = c.route.conv.StoreConversationAndMessage(ctx, conv)
+		if err == entity.ErrConversationBlocked {
+			// If one of the users of the direct conversation blocked the other, tell the sender only.
+			errorMsg := c.buildErrorMessage(senderUUID, conversationUUID, errConversationBlocked)
+			c.hub.Broadcast <- errorMsg
+			break
+		}
 		if err != nil {
 			// If there's an error storing the message, log it and broadcast an error message.
 			fmt.Println("Conversation - handleConversation - StoreConversation err: ", err)
@@ -515,6 +663,12 @@ func (c *Client) handleConversation(convReq boundary.ConversationRequestModel, u
 
 		// Store the reaction by calling reaction entity object's StoreReaction method.
 		err = c.route.reaction.StoreReaction(ctx, reaction)
+		if err == entity.ErrConversationBlocked {
+			// If one of the users of the direct conversation blocked the other, tell the sender only.
+			errorMsg := c.buildErrorMessage(senderUUID, conversationUUID, errConversationBlocked)
+			c.hub.Broadcast <- errorMsg
+			break
+		}
 		if err != nil {
 			// If there's an error storing the reaction, log it and broadcast an error message.
 			fmt.Println("Conversation - readPump - StoreReaction err: ", err)
@@ -558,6 +712,65 @@ func (c *Client) handleConversation(convReq boundary.ConversationRequestModel, u
 		// Build a response for removing the reaction and broadcast it.
 		removeReactionResponse := buildReactionResponse(removeReactionMessageType, reaction, conversationUUID)
 		c.hub.Broadcast <- removeReactionResponse
+
+	case reportMessageType:
+		// Unmarshal the data in convReq into a ReportForm object.
+		var reportRequest boundary.ReportForm
+		err := json.Unmarshal(convReq.Data, &reportRequest)
+		if err != nil {
+			// If there's an error in unmarshalling, log it and broadcast an error message.
+			fmt.Println("handleConversation - unmarshall error for reportRequest", err)
+			errorMsg := c.buildErrorMessage(senderUUID, conversationUUID, errProcessingReport)
+			c.hub.Broadcast <- errorMsg
+			break
+		}
+
+		// Reports made through the websocket are about the conversation of the client
+		report := reportRequest.ToReport(senderUUID)
+		report.ConversationUUID = conversationUUID
+		if report.TargetType == entity.AdminTargetConversation {
+			report.TargetUUID = conversationUUID
+		}
+
+		// Store the report by calling report entity object's ReportAbuse method.
+		report, err = c.route.report.ReportAbuse(ctx, report)
+		if err != nil {
+			// If the report is invalid, tell the sender why, else log it and broadcast an error message.
+			fmt.Println("Conversation - handleConversation - ReportAbuse err: ", err)
+			errorMsg := c.buildErrorMessage(senderUUID, conversationUUID, reportErrorMessage(err))
+			c.hub.Broadcast <- errorMsg
+			break
+		}
+
+		// Build a response for the report and broadcast it to the sender only.
+		reportResponse := buildReportResponse(report, conversationUUID)
+		c.hub.Broadcast <- reportResponse
+	}
+}
+
+// Method to build report response body
+func buildReportResponse(report entity.Report, conversationUUID string) boundary.ConversationResponseModel {
+	return boundary.ConversationResponseModel{
+		MessageType: reportMessageType,
+		Data: boundary.ConversationResponseData{
+			SenderUUID:       report.ReporterUUID,
+			ConversationUUID: conversationUUID,
+			ReportResponseData: boundary.ReportResponseData{
+				ReportUUID:   report.ReportUUID,
+				ReportStatus: report.Status,
+			},
+		},
+	}
+}
+
+// reportErrorMessage tells the sender why their report was rejected, unexpected errors are not shown
+func reportErrorMessage(err error) string {
+	switch err {
+	case entity.ErrInvalidReport, entity.ErrReportExists, entity.ErrTooManyRequests,
+		entity.ErrUserNotFound, entity.ErrMessageNotFound, entity.ErrConversationNotFound:
+		return err.Error()
+	default:
+		return errProcessingReport
 	}
 }
 
@@ -572,6 +785,7 @@ func buildSendMessageResponse(conv entity.Conversation, userInfo entity.UserProf
 				SenderFirstName: userInfo.FirstName,
 				SenderLastName:  userInfo.LastName,
 				SenderAvatar:    userInfo.Avatar,
+				SenderIsBot:     userInfo.IsBot,
 				Content:         conv.Content,
 				MessageUUID:     conv.MessageUUID,
 				CreatedAt:       conv.CreatedAt,
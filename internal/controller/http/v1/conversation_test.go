@@ -356,3 +356,54 @@ func TestHandleConversation(t *testing.T) {
 		assert.Equal(t, errorMessageType, msg.MessageType)
 	})
 }
+
+func TestBlockedConversation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConvUsecase := mocks.NewMockConversation(ctrl)
+	mockReactionUsecase := mocks.NewMockReaction(ctrl)
+
+	hub := NewHub()
+	go hub.Run()
+
+	r := &conversationRoutes{
+		conv:     mockConvUsecase,
+		reaction: mockReactionUsecase,
+		l:        logger.New(logLevelDebug),
+	}
+
+	// Both users of the direct conversation are connected to it
+	sender := NewClient("dm-uuid", entity.UserProfile{UserUUID: "some-uuid"}, nil, hub, r)
+	contact := NewClient("dm-uuid", entity.UserProfile{UserUUID: "contact-uuid"}, nil, hub, nil)
+	hub.Register <- sender
+	hub.Register <- contact
+
+	t.Run("SendMessage", func(t *testing.T) {
+		mockConvUsecase.EXPECT().StoreConversationAndMessage(gomock.Any(), gomock.Any()).Return(entity.ErrConversationBlocked)
+
+		sender.handleConversation(boundary.ConversationRequestModel{
+			MessageType: sendMessageType,
+			Data:        json.RawMessage(`{"content": "hello"}`),
+		}, sender.UserInfo)
+
+		message := <-sender.send
+		assert.Equal(t, errorMessageType, message.MessageType)
+		assert.Equal(t, errConversationBlocked, message.Data.ErrorMessage)
+		assertClientDisconnected(t, contact, false)
+	})
+
+	t.Run("AddReaction", func(t *testing.T) {
+		mockReactionUsecase.EXPECT().StoreReaction(gomock.Any(), gomock.Any()).Return(entity.ErrConversationBlocked)
+
+		sender.handleConversation(boundary.ConversationRequestModel{
+			MessageType: addReactionMessageType,
+			Data:        json.RawMessage(`{"message_uuid": "msg-uuid", "reaction_type": "like"}`),
+		}, sender.UserInfo)
+
+		message := <-sender.send
+		assert.Equal(t, errorMessageType, message.MessageType)
+		assert.Equal(t, errConversationBlocked, message.Data.ErrorMessage)
+		assertClientDisconnected(t, contact, false)
+	})
+}
@@ -0,0 +1,183 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type credentialsRoutes struct {
+	cr  usecase.Credentials
+	hub *Hub
+	l   logger.Interface
+}
+
+// Handles api routes for credential management functionality
+func newCredentialsRoute(handler *gin.RouterGroup, cr usecase.Credentials, hub *Hub, l logger.Interface) {
+	r := &credentialsRoutes{cr, hub, l}
+
+	// Group the routes under the "/user" path.
+	h := handler.Group("/user")
+	{
+		// Define the endpoints for the credential management functionality.
+		h.PUT("/password", r.changePassword)
+		h.PUT("/email", r.changeEmail)
+		h.PUT("/username", r.changeUsername)
+	}
+}
+
+// Handles the api route that confirms an email change, it is called with the token from the email instead of an access token
+func newEmailChangeRoute(handler *gin.RouterGroup, cr usecase.Credentials, l logger.Interface) {
+	r := &credentialsRoutes{cr: cr, l: l}
+
+	handler.POST("/user/email/change/confirm", r.confirmEmailChange)
+}
+
+// changePassword sets a new password and logs out the user's other sessions.
+func (r *credentialsRoutes) changePassword(c *gin.Context) {
+	// Get user_uuid and session_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	sessionUUID, err := getSessionUUIDFromContext(c)
+	if err != nil {
+		// Without the current session every session would be revoked, including the one making the request
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Bind the incoming JSON request body to the ChangePasswordForm struct.
+	var request boundary.ChangePasswordForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - changePassword")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call ChangePassword method from credentials entity object
+	revoked, err := r.cr.ChangePassword(c.Request.Context(), request.ToPasswordChange(userUUID, sessionUUID))
+
+	// Close the websockets of the sessions that were revoked, even if revoking the rest failed
+	for _, revokedSessionUUID := range revoked {
+		r.hub.Disconnect <- revokedSessionUUID
+	}
+
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - changePassword - ChangePassword")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the revoked sessions as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.RevokedSessionsResponse{RevokedSessions: revoked})
+}
+
+// changeEmail sends a confirmation link to the new email address.
+func (r *credentialsRoutes) changeEmail(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Bind the incoming JSON request body to the ChangeEmailForm struct.
+	var request boundary.ChangeEmailForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - changeEmail")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call RequestEmailChange method from credentials entity object
+	err = r.cr.RequestEmailChange(c.Request.Context(), request.ToEmailChange(userUUID))
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - changeEmail - RequestEmailChange")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return an "Accepted" status code, the email is changed once the new address is confirmed.
+	c.Writer.WriteHeader(http.StatusAccepted)
+}
+
+// confirmEmailChange changes the email using the token sent to the new address.
+func (r *credentialsRoutes) confirmEmailChange(c *gin.Context) {
+	// Bind the incoming JSON request body to the ConfirmEmailChangeForm struct.
+	var request boundary.ConfirmEmailChangeForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - confirmEmailChange")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call ConfirmEmailChange method from credentials entity object
+	err := r.cr.ConfirmEmailChange(c.Request.Context(), request.Token)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - confirmEmailChange - ConfirmEmailChange")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return a "No Content" status code to indicate the email was changed.
+	c.Writer.WriteHeader(http.StatusNoContent)
+}
+
+// changeUsername sets a new username.
+func (r *credentialsRoutes) changeUsername(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Bind the incoming JSON request body to the ChangeUsernameForm struct.
+	var request boundary.ChangeUsernameForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - changeUsername")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call ChangeUsername method from credentials entity object
+	err = r.cr.ChangeUsername(c.Request.Context(), request.ToUsernameChange(userUUID))
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - changeUsername - ChangeUsername")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return a "No Content" status code to indicate the username was changed.
+	c.Writer.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+func TestCredentialsRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCredentialsUsecase := mocks.NewMockCredentials(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	hub := NewHub()
+	go hub.Run()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	newEmailChangeRoute(router.Group(""), mockCredentialsUsecase, mockLogger)
+	protected := router.Group("/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Set("session_uuid", "current-session")
+		c.Next()
+	})
+	newCredentialsRoute(protected, mockCredentialsUsecase, hub, mockLogger)
+
+	t.Run("ChangePassword", func(t *testing.T) {
+		otherClient := registerTestClient(hub, "conv-uuid", "other-session")
+		currentClient := registerTestClient(hub, "conv-uuid", "current-session")
+
+		mockCredentialsUsecase.EXPECT().ChangePassword(gomock.Any(), entity.PasswordChange{
+			UserUUID:        "some-uuid",
+			SessionUUID:     "current-session",
+			CurrentPassword: "password123",
+			NewPassword:     "newpassword",
+		}).Return([]string{"other-session"}, nil)
+
+		body := []byte(`{"current_password":"password123","new_password":"newpassword"}`)
+		req, _ := http.NewRequest(http.MethodPut, "/v1/user/password", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "other-session")
+
+		assertClientDisconnected(t, otherClient, true)
+		assertClientDisconnected(t, currentClient, false)
+	})
+
+	t.Run("ChangePasswordIncorrectPassword", func(t *testing.T) {
+		mockCredentialsUsecase.EXPECT().ChangePassword(gomock.Any(), gomock.Any()).Return(nil, entity.ErrIncorrectPassword)
+
+		body := []byte(`{"current_password":"wrongpassword","new_password":"newpassword"}`)
+		req, _ := http.NewRequest(http.MethodPut, "/v1/user/password", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("ChangePasswordInvalidBody", func(t *testing.T) {
+		body := []byte(`{"new_password":"newpassword"}`)
+		req, _ := http.NewRequest(http.MethodPut, "/v1/user/password", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ChangeEmail", func(t *testing.T) {
+		mockCredentialsUsecase.EXPECT().RequestEmailChange(gomock.Any(), entity.EmailChange{
+			UserUUID:        "some-uuid",
+			CurrentPassword: "password123",
+			NewEmail:        "new@example.com",
+		}).Return(nil)
+
+		body := []byte(`{"current_password":"password123","new_email":"new@example.com"}`)
+		req, _ := http.NewRequest(http.MethodPut, "/v1/user/email", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+
+	t.Run("ChangeEmailUnavailable", func(t *testing.T) {
+		mockCredentialsUsecase.EXPECT().RequestEmailChange(gomock.Any(), gomock.Any()).Return(entity.ErrEmailUnavailable)
+
+		body := []byte(`{"current_password":"password123","new_email":"taken@example.com"}`)
+		req, _ := http.NewRequest(http.MethodPut, "/v1/user/email", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("ConfirmEmailChange", func(t *testing.T) {
+		mockCredentialsUsecase.EXPECT().ConfirmEmailChange(gomock.Any(), "some-token").Return(nil)
+
+		body := []byte(`{"token":"some-token"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/user/email/change/confirm", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("ConfirmEmailChangeInvalidToken", func(t *testing.T) {
+		mockCredentialsUsecase.EXPECT().ConfirmEmailChange(gomock.Any(), "bad-token").Return(entity.ErrInvalidVerificationToken)
+
+		body := []byte(`{"token":"bad-token"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/user/email/change/confirm", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ChangeUsername", func(t *testing.T) {
+		mockCredentialsUsecase.EXPECT().ChangeUsername(gomock.Any(), entity.UsernameChange{
+			UserUUID:        "some-uuid",
+			CurrentPassword: "password123",
+			NewUsername:     "newuser",
+		}).Return(nil)
+
+		body := []byte(`{"current_password":"password123","new_username":"newuser"}`)
+		req, _ := http.NewRequest(http.MethodPut, "/v1/user/username", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("ChangeUsernameUnavailable", func(t *testing.T) {
+		mockCredentialsUsecase.EXPECT().ChangeUsername(gomock.Any(), gomock.Any()).Return(entity.ErrUsernameUnavailable)
+
+		body := []byte(`{"current_password":"password123","new_username":"takenuser"}`)
+		req, _ := http.NewRequest(http.MethodPut, "/v1/user/username", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("ChangeUsernameFailure", func(t *testing.T) {
+		mockCredentialsUsecase.EXPECT().ChangeUsername(gomock.Any(), gomock.Any()).Return(errors.New("test_error"))
+
+		body := []byte(`{"current_password":"password123","new_username":"newuser"}`)
+		req, _ := http.NewRequest(http.MethodPut, "/v1/user/username", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
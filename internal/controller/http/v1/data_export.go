@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/httpserver"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+const (
+	// An archive download may take as long as the slowest supported client needs to receive it,
+	// plus a grace period, instead of the server's write timeout.
+	_exportDownloadMinRate = 32 << 10 // bytes per second
+	_exportDownloadGrace   = 30 * time.Second
+	// Used when the size of the archive can't be told
+	_exportDownloadMaxTime = 30 * time.Minute
+)
+
+type dataExportRoutes struct {
+	e usecase.DataExport
+	l logger.Interface
+}
+
+// Handles api routes for personal data export functionality
+func newDataExportRoute(handler *gin.RouterGroup, e usecase.DataExport, l logger.Interface) {
+	r := &dataExportRoutes{e, l}
+
+	// Group the routes under the "/user/export" path.
+	h := handler.Group("/user/export")
+	{
+		// Define the endpoints for the personal data export functionality.
+		h.POST("", r.requestExport)
+		h.GET("/:exportId", r.getExport)
+		h.GET("/:exportId/download", r.downloadExport)
+	}
+}
+
+// requestExport starts building an archive of the user's data in the background.
+func (r *dataExportRoutes) requestExport(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Call RequestExport method from data export entity object
+	export, err := r.e.RequestExport(c.Request.Context(), userUUID)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - requestExport - RequestExport")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the export as JSON with a status code of 202 (Accepted), its status can be polled until it is ready.
+	c.JSON(http.StatusAccepted, boundary.ToDataExportResponse(export, ""))
+}
+
+// getExport returns the status of an export.
+func (r *dataExportRoutes) getExport(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Call GetExport method from data export entity object
+	export, err := r.e.GetExport(c.Request.Context(), userUUID, c.Param("exportId"))
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - getExport - GetExport")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the export as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToDataExportResponse(export, c.Request.URL.Path+"/download"))
+}
+
+// downloadExport streams a finished archive.
+func (r *dataExportRoutes) downloadExport(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	exportUUID := c.Param("exportId")
+
+	// Call OpenExport method from data export entity object
+	archive, err := r.e.OpenExport(c.Request.Context(), userUUID, exportUUID)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - downloadExport - OpenExport")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+	defer archive.Close()
+
+	// Give the client enough time to receive the whole archive, the server's write timeout would cut it off
+	size := archiveSize(archive)
+	err = httpserver.SetWriteDeadline(c.Request, time.Now().Add(exportDownloadTime(size)))
+	if err != nil {
+		// Logs error message, the download can still finish within the server's write timeout
+		r.l.Warn("http - v1 - downloadExport - httpserver.SetWriteDeadline: %v", err)
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="data-export-%s.zip"`, exportUUID))
+	if size >= 0 {
+		c.Header("Content-Length", fmt.Sprint(size))
+	}
+	c.Status(http.StatusOK)
+	_, err = io.Copy(c.Writer, archive)
+	if err != nil {
+		// The status was already written, the client sees a truncated download
+		r.l.Error(err, "http - v1 - downloadExport - io.Copy")
+	}
+}
+
+// archiveSize returns the size of a seekable archive, or -1 if it can't be told.
+func archiveSize(archive io.Reader) int64 {
+	seeker, ok := archive.(io.Seeker)
+	if !ok {
+		return -1
+	}
+	size, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return -1
+	}
+	_, err = seeker.Seek(0, io.SeekStart)
+	if err != nil {
+		return -1
+	}
+	return size
+}
+
+// exportDownloadTime returns how long sending an archive of the given size may take.
+func exportDownloadTime(size int64) time.Duration {
+	if size < 0 {
+		return _exportDownloadMaxTime
+	}
+	return _exportDownloadGrace + time.Duration(size/_exportDownloadMinRate)*time.Second
+}
@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/httpserver"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+func TestDataExportRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataExportUsecase := mocks.NewMockDataExport(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	newDataExportRoute(router.Group("/v1"), mockDataExportUsecase, mockLogger)
+
+	createdAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	t.Run("RequestExport", func(t *testing.T) {
+		mockDataExportUsecase.EXPECT().RequestExport(gomock.Any(), "some-uuid").Return(entity.DataExport{
+			ExportUUID: "some-export", Status: entity.DataExportPending, CreatedAt: createdAt,
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/user/export", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.DataExportResponse
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "some-export", response.ExportID)
+		assert.Equal(t, entity.DataExportPending, response.Status)
+		assert.Empty(t, response.DownloadURL)
+	})
+
+	t.Run("RequestExportFailure", func(t *testing.T) {
+		mockDataExportUsecase.EXPECT().RequestExport(gomock.Any(), "some-uuid").Return(entity.DataExport{}, errors.New("test_error"))
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/user/export", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("GetReadyExport", func(t *testing.T) {
+		mockDataExportUsecase.EXPECT().GetExport(gomock.Any(), "some-uuid", "some-export").Return(entity.DataExport{
+			ExportUUID: "some-export", Status: entity.DataExportReady, CreatedAt: createdAt,
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/user/export/some-export", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.DataExportResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "/v1/user/export/some-export/download", response.DownloadURL)
+	})
+
+	t.Run("GetUnknownExport", func(t *testing.T) {
+		mockDataExportUsecase.EXPECT().GetExport(gomock.Any(), "some-uuid", "other-export").Return(entity.DataExport{}, entity.ErrDataExportNotFound)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/user/export/other-export", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		mockDataExportUsecase.EXPECT().OpenExport(gomock.Any(), "some-uuid", "some-export").Return(io.NopCloser(strings.NewReader("zip-content")), nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/user/export/some-export/download", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "data-export-some-export.zip")
+		assert.Equal(t, "zip-content", w.Body.String())
+	})
+
+	t.Run("DownloadNotReady", func(t *testing.T) {
+		mockDataExportUsecase.EXPECT().OpenExport(gomock.Any(), "some-uuid", "some-export").Return(nil, entity.ErrDataExportNotReady)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/user/export/some-export/download", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+// slowArchive hands out its content in small chunks with a pause between them, like a large archive
+// sent to a slow client.
+type slowArchive struct {
+	content *bytes.Reader
+}
+
+func (a slowArchive) Read(p []byte) (int, error) {
+	time.Sleep(5 * time.Millisecond)
+	if len(p) > 32<<10 {
+		p = p[:32<<10]
+	}
+	return a.content.Read(p)
+}
+
+func (a slowArchive) Seek(offset int64, whence int) (int64, error) {
+	return a.content.Seek(offset, whence)
+}
+
+func (a slowArchive) Close() error {
+	return nil
+}
+
+func TestDataExportDownloadOutlastsWriteTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataExportUsecase := mocks.NewMockDataExport(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	newDataExportRoute(router.Group("/v1"), mockDataExportUsecase, mockLogger)
+
+	// Sending the archive takes about 320ms, well past the server's write timeout
+	content := bytes.Repeat([]byte("zip-content"), 2<<20/len("zip-content"))
+	mockDataExportUsecase.EXPECT().OpenExport(gomock.Any(), "some-uuid", "some-export").Return(slowArchive{bytes.NewReader(content)}, nil)
+
+	server := httptest.NewUnstartedServer(httpserver.ExposeResponseWriter(router))
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/v1/user/export/some-export/download")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(len(content)), resp.ContentLength)
+	assert.Equal(t, len(content), len(body))
+	assert.True(t, bytes.Equal(content, body))
+}
@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type emailVerificationRoutes struct {
+	v usecase.EmailVerification
+	l logger.Interface
+}
+
+// Handles api routes for email verification functionality
+func newEmailVerificationRoute(handler *gin.RouterGroup, v usecase.EmailVerification, l logger.Interface) {
+	r := &emailVerificationRoutes{v, l}
+
+	// Group the routes under the "/user/email" path.
+	h := handler.Group("/user/email")
+	{
+		// Define the endpoints for the email verification functionality.
+		h.POST("/verify", r.confirmEmail)
+		h.POST("/verify/resend", r.resendVerificationEmail)
+	}
+}
+
+// confirmEmail marks the email address as verified using the token from the verification email.
+func (r *emailVerificationRoutes) confirmEmail(c *gin.Context) {
+	// Bind the incoming JSON request body to the ConfirmEmailForm struct.
+	var request boundary.ConfirmEmailForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - confirmEmail")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call ConfirmEmail method from email verification entity object
+	err := r.v.ConfirmEmail(c.Request.Context(), request.Token)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - confirmEmail - ConfirmEmail")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return a "No Content" status code to indicate the email was verified.
+	c.Writer.WriteHeader(http.StatusNoContent)
+}
+
+// resendVerificationEmail sends a new verification email to an unverified account.
+func (r *emailVerificationRoutes) resendVerificationEmail(c *gin.Context) {
+	// Bind the incoming JSON request body to the ResendVerificationForm struct.
+	var request boundary.ResendVerificationForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - resendVerificationEmail")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call ResendVerificationEmail method from email verification entity object
+	err := r.v.ResendVerificationEmail(c.Request.Context(), request.Email)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - resendVerificationEmail - ResendVerificationEmail")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return an "Accepted" status code whether or not the email belongs to an account.
+	c.Writer.WriteHeader(http.StatusAccepted)
+}
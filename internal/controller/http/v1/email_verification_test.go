@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+func TestConfirmEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEmailVerification(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	r := &emailVerificationRoutes{v: mockUsecase, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/user/email/verify", r.confirmEmail)
+
+	requestBody := `{"token": "verification-token"}`
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().ConfirmEmail(gomock.Any(), "verification-token").Return(nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/email/verify", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("InvalidRequestBody", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/user/email/verify", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("InvalidVerificationToken", func(t *testing.T) {
+		mockUsecase.EXPECT().ConfirmEmail(gomock.Any(), "verification-token").Return(entity.ErrInvalidVerificationToken)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/email/verify", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestResendVerificationEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEmailVerification(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	r := &emailVerificationRoutes{v: mockUsecase, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/user/email/verify/resend", r.resendVerificationEmail)
+
+	requestBody := `{"email": "test@example.com"}`
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().ResendVerificationEmail(gomock.Any(), "test@example.com").Return(nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/email/verify/resend", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		mockUsecase.EXPECT().ResendVerificationEmail(gomock.Any(), "test@example.com").Return(entity.ErrTooManyRequests)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/email/verify/resend", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+
+	t.Run("EntityObjectFailure", func(t *testing.T) {
+		mockUsecase.EXPECT().ResendVerificationEmail(gomock.Any(), "test@example.com").Return(errors.New("test_error"))
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/email/verify/resend", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
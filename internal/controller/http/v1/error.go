@@ -13,11 +13,34 @@ type response struct {
 
 func handleCustomErrors(c *gin.Context, err error) {
 	switch err {
-	case entity.ErrUserAlreadyExists, entity.ErrContactAlreadyExists:
+	case entity.ErrInvalidResetToken, entity.ErrInvalidVerificationToken, entity.ErrMFANotEnrolled,
+		entity.ErrInvalidSSOState, entity.ErrInvalidAPIKeyScope, entity.ErrInvalidSearchQuery, entity.ErrInvalidStatus,
+		entity.ErrInvalidImage, entity.ErrInvalidAvatar, entity.ErrInvalidPrivacySetting, entity.ErrAdminSelfAction,
+		entity.ErrInvalidContactRequest, entity.ErrInvalidContactImport,
+		entity.ErrInvalidContactDetails, entity.ErrInvalidContactsQuery, entity.ErrInvalidReport,
+		entity.ErrInvalidReportsQuery:
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case entity.ErrEmailNotVerified, entity.ErrAPIKeyForbidden, entity.ErrUserNotInGroupChat,
+		entity.ErrContactNotAllowed, entity.ErrGroupAddNotAllowed, entity.ErrReadReceiptsDisabled, entity.ErrAccountSuspended,
+		entity.ErrInsufficientRole, entity.ErrConversationBlocked:
+		errorResponse(c, http.StatusForbidden, err.Error())
+	case entity.ErrImageTooLarge:
+		errorResponse(c, http.StatusRequestEntityTooLarge, err.Error())
+	case entity.ErrUnsupportedImageType:
+		errorResponse(c, http.StatusUnsupportedMediaType, err.Error())
+	case entity.ErrTooManyRequests, entity.ErrAccountLocked:
+		errorResponse(c, http.StatusTooManyRequests, err.Error())
+	case entity.ErrUserAlreadyExists, entity.ErrContactAlreadyExists, entity.ErrMFAAlreadyEnabled,
+		entity.ErrDataExportNotReady, entity.ErrUsernameUnavailable, entity.ErrEmailUnavailable,
+		entity.ErrContactRequestExists, entity.ErrContactRequestNotPending, entity.ErrReportExists, entity.ErrInvalidReportTransition:
 		errorResponse(c, http.StatusConflict, err.Error())
-	case entity.ErrUserNameNotFound, entity.ErrContactDoesNotExists, entity.ErrUserNotFound:
+	case entity.ErrUserNameNotFound, entity.ErrContactDoesNotExists, entity.ErrUserNotFound,
+		entity.ErrSessionNotFound, entity.ErrDataExportNotFound, entity.ErrBotNotFound, entity.ErrAPIKeyNotFound, entity.ErrImageNotFound,
+		entity.ErrMessageNotFound, entity.ErrConversationNotFound, entity.ErrContactRequestNotFound,
+		entity.ErrReportNotFound:
 		errorResponse(c, http.StatusNotFound, err.Error())
-	case entity.ErrIncorrectPassword:
+	case entity.ErrIncorrectPassword, entity.ErrInvalidRefreshToken, entity.ErrRefreshTokenReused,
+		entity.ErrInvalidMFACode, entity.ErrInvalidMFAToken, entity.ErrSSOLoginFailed, entity.ErrInvalidAPIKey:
 		errorResponse(c, http.StatusUnauthorized, err.Error())
 	default:
 		errorResponse(c, http.StatusInternalServerError, "internal server error")
@@ -0,0 +1,190 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+const (
+	// _imageFormField is the multipart form field of uploaded images
+	_imageFormField = "image"
+	// _multipartOverhead is allowed on top of the image size for the boundaries and headers of the multipart form
+	_multipartOverhead = 64 << 10
+	// Images never change once uploaded, a new upload gets a new image uuid
+	_imageCacheControl = "public, max-age=31536000, immutable"
+)
+
+type imageRoutes struct {
+	i             usecase.Image
+	maxUploadSize int64
+	l             logger.Interface
+}
+
+// Handles api routes for serving uploaded images.
+// They are public so that clients can use the URLs directly, image uuids can not be guessed.
+func newImageRoute(handler *gin.RouterGroup, i usecase.Image, l logger.Interface) {
+	r := &imageRoutes{i: i, l: l}
+
+	// Group the routes under the "/images" path.
+	h := handler.Group("/images")
+	{
+		// Define the endpoints for serving images.
+		h.GET("/:imageId", r.getImage)
+		h.GET("/:imageId/:size", r.getImage)
+	}
+}
+
+// Handles api routes for uploading avatars and group pictures
+func newImageUploadRoute(handler *gin.RouterGroup, i usecase.Image, maxUploadSize int64, l logger.Interface) {
+	r := &imageRoutes{i: i, maxUploadSize: maxUploadSize, l: l}
+
+	// Define the endpoints for uploading images.
+	handler.PUT("/profile/avatar", r.uploadAvatar)
+	handler.PUT("/groupchat/picture/:conversationId", r.uploadGroupPicture)
+}
+
+// uploadAvatar replaces the user's avatar with the uploaded image.
+func (r *imageRoutes) uploadAvatar(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	file, ok := r.formImage(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	// Call UploadAvatar method from image entity object
+	img, err := r.i.UploadAvatar(c.Request.Context(), userUUID, file)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - uploadAvatar - UploadAvatar")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the stored image as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToImageResponse(img))
+}
+
+// uploadGroupPicture replaces the picture of a group chat with the uploaded image.
+func (r *imageRoutes) uploadGroupPicture(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	file, ok := r.formImage(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	// Call UploadGroupPicture method from image entity object
+	img, err := r.i.UploadGroupPicture(c.Request.Context(), userUUID, c.Param("conversationId"), file)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - uploadGroupPicture - UploadGroupPicture")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the stored image as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToImageResponse(img))
+}
+
+// getImage serves a thumbnail of an uploaded image, the largest one if no size is given.
+func (r *imageRoutes) getImage(c *gin.Context) {
+	imageUUID := c.Param("imageId")
+
+	size := 0
+	if c.Param("size") != "" {
+		var err error
+		size, err = strconv.Atoi(c.Param("size"))
+		if err != nil {
+			errorResponse(c, http.StatusNotFound, entity.ErrImageNotFound.Error())
+			return
+		}
+	}
+
+	// Thumbnails never change, clients can keep what they already have
+	etag := fmt.Sprintf(`"%s-%d"`, imageUUID, size)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Header("Cache-Control", _imageCacheControl)
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	// Call OpenImage method from image entity object
+	thumbnail, err := r.i.OpenImage(c.Request.Context(), imageUUID, size)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - getImage - OpenImage")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+	defer thumbnail.Close()
+
+	c.Header("Content-Type", "image/jpeg")
+	c.Header("Cache-Control", _imageCacheControl)
+	c.Header("ETag", etag)
+	c.Status(http.StatusOK)
+	_, err = io.Copy(c.Writer, thumbnail)
+	if err != nil {
+		// The status was already written, the client sees a truncated image
+		r.l.Error(err, "http - v1 - getImage - io.Copy")
+	}
+}
+
+// formImage opens the image of a multipart upload, it writes the error response if there is none.
+// The body is limited before it is parsed, the use case checks the exact size of the image.
+func (r *imageRoutes) formImage(c *gin.Context) (io.ReadCloser, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, r.maxUploadSize+_multipartOverhead)
+
+	header, err := c.FormFile(_imageFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			errorResponse(c, http.StatusRequestEntityTooLarge, entity.ErrImageTooLarge.Error())
+			return nil, false
+		}
+		r.l.Error(err, "http - v1 - formImage")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return nil, false
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		r.l.Error(err, "http - v1 - formImage - header.Open")
+		errorResponse(c, http.StatusInternalServerError, "internal server error")
+		return nil, false
+	}
+	return file, true
+}
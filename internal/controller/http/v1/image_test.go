@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+// newImageUploadRequest returns a multipart request uploading content in the image field
+func newImageUploadRequest(t *testing.T, url string, content []byte) *http.Request {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile(_imageFormField, "avatar.png")
+	if err != nil {
+		t.Fatalf("CreateFormFile() error = %v", err)
+	}
+	part.Write(content)
+	mw.Close()
+
+	req, _ := http.NewRequest(http.MethodPut, url, &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestImageRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockImageUsecase := mocks.NewMockImage(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	newImageRoute(router.Group("/v1"), mockImageUsecase, mockLogger)
+	protected := router.Group("/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	newImageUploadRoute(protected, mockImageUsecase, 1024, mockLogger)
+
+	imageUUID := "6f1c2a4e-1111-4a3b-9c2d-000000000001"
+	img := entity.Image{ImageUUID: imageUUID, URL: entity.ImageURL(imageUUID), Sizes: []int{64, 128}}
+
+	t.Run("UploadAvatar", func(t *testing.T) {
+		mockImageUsecase.EXPECT().UploadAvatar(gomock.Any(), "some-uuid", gomock.Any()).
+			DoAndReturn(func(_ interface{}, _ string, r io.Reader) (entity.Image, error) {
+				content, _ := io.ReadAll(r)
+				assert.Equal(t, "image content", string(content))
+				return img, nil
+			})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImageUploadRequest(t, "/v1/profile/avatar", []byte("image content")))
+
+		var response boundary.ImageResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "/images/"+imageUUID, response.URL)
+		assert.Len(t, response.Thumbnails, 2)
+		assert.Equal(t, "/images/"+imageUUID+"/64", response.Thumbnails[0].URL)
+	})
+
+	t.Run("UploadAvatarTooLarge", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImageUploadRequest(t, "/v1/profile/avatar", make([]byte, 128<<10)))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	t.Run("UploadAvatarMissingFile", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPut, "/v1/profile/avatar", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("UploadGroupPictureUnsupportedType", func(t *testing.T) {
+		mockImageUsecase.EXPECT().UploadGroupPicture(gomock.Any(), "some-uuid", "group_1", gomock.Any()).
+			Return(entity.Image{}, entity.ErrUnsupportedImageType)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImageUploadRequest(t, "/v1/groupchat/picture/group_1", []byte("not an image")))
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("UploadGroupPictureNotInGroupChat", func(t *testing.T) {
+		mockImageUsecase.EXPECT().UploadGroupPicture(gomock.Any(), "some-uuid", "group_1", gomock.Any()).
+			Return(entity.Image{}, entity.ErrUserNotInGroupChat)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImageUploadRequest(t, "/v1/groupchat/picture/group_1", []byte("image content")))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("GetImage", func(t *testing.T) {
+		mockImageUsecase.EXPECT().OpenImage(gomock.Any(), imageUUID, 64).Return(io.NopCloser(strings.NewReader("jpeg")), nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/images/"+imageUUID+"/64", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+		assert.Equal(t, _imageCacheControl, w.Header().Get("Cache-Control"))
+		assert.Equal(t, "jpeg", w.Body.String())
+
+		// The same thumbnail is not sent again
+		req, _ = http.NewRequest(http.MethodGet, "/v1/images/"+imageUUID+"/64", nil)
+		req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("GetImageNotFound", func(t *testing.T) {
+		mockImageUsecase.EXPECT().OpenImage(gomock.Any(), imageUUID, 0).Return(nil, entity.ErrImageNotFound)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/images/"+imageUUID, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
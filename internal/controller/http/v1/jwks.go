@@ -0,0 +1,27 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/pkg/jwtsigner"
+)
+
+type jwksRoute struct {
+	signer *jwtsigner.Signer
+}
+
+// Handles the JSON Web Key Set route, which publishes the public signing keys
+func newJWKSRoute(handler *gin.Engine, signer *jwtsigner.Signer) {
+	r := &jwksRoute{signer}
+
+	handler.GET("/.well-known/jwks.json", r.getJWKS)
+}
+
+// getJWKS returns the public part of every asymmetric signing key.
+func (r *jwksRoute) getJWKS(c *gin.Context) {
+	// Keys only change on deploy, so verifiers are allowed to cache them for a while
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, r.signer.JWKS())
+}
@@ -0,0 +1,148 @@
+package v1
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/jwtsigner"
+)
+
+// newRotatedSigners returns a signer for the retired HMAC key and a signer that signs with a new RSA key
+// while still accepting tokens from the retired key and an Ed25519 verification-only key.
+func newRotatedSigners(t *testing.T) (*jwtsigner.Signer, *jwtsigner.Signer) {
+	oldKey, err := jwtsigner.NewKey("old-hs256", jwtsigner.HS256, []byte("old-secret"))
+	assert.NoError(t, err)
+	oldSigner, err := jwtsigner.New(oldKey.ID, oldKey)
+	assert.NoError(t, err)
+
+	rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	rsaPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaPrivateKey)})
+	rsaKey, err := jwtsigner.NewKey("new-rs256", jwtsigner.RS256, rsaPEM)
+	assert.NoError(t, err)
+
+	edPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	edDER, err := x509.MarshalPKIXPublicKey(edPublicKey)
+	assert.NoError(t, err)
+	edKey, err := jwtsigner.NewKey("partner-eddsa", jwtsigner.EdDSA, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: edDER}))
+	assert.NoError(t, err)
+
+	newSigner, err := jwtsigner.New(rsaKey.ID, oldKey, rsaKey, edKey)
+	assert.NoError(t, err)
+
+	return oldSigner, newSigner
+}
+
+func TestGetJWKS(t *testing.T) {
+	_, signer := newRotatedSigners(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	newJWKSRoute(router, signer)
+
+	req, _ := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var set jwtsigner.JWKSet
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &set))
+
+	// The HMAC secret must never be published
+	assert.Len(t, set.Keys, 2)
+	assert.Equal(t, "new-rs256", set.Keys[0].Kid)
+	assert.Equal(t, "RSA", set.Keys[0].Kty)
+	assert.NotEmpty(t, set.Keys[0].N)
+	assert.Equal(t, "partner-eddsa", set.Keys[1].Kid)
+	assert.Equal(t, "OKP", set.Keys[1].Kty)
+	assert.Equal(t, "Ed25519", set.Keys[1].Crv)
+	assert.NotContains(t, w.Body.String(), "old-hs256")
+}
+
+func TestAuthMiddlewareKeyRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionUsecase := mocks.NewMockSession(ctrl)
+	mockAdminUsecase := mocks.NewMockAdmin(ctrl)
+	mockAdminUsecase.EXPECT().GetUserAccess(gomock.Any(), gomock.Any()).Return(entity.UserAccess{Role: entity.RoleUser}, nil).AnyTimes()
+	oldSigner, newSigner := newRotatedSigners(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(authMiddleware(newSigner, mockSessionUsecase, mockAdminUsecase, nil))
+	router.GET("/protected", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	t.Run("ActiveKey", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().IsTokenRevoked(gomock.Any(), testSession.AccessTokenID).Return(false, nil)
+
+		token, err := createToken(newSigner, testSession)
+		assert.NoError(t, err)
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("RetiredKey", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().IsTokenRevoked(gomock.Any(), testSession.AccessTokenID).Return(false, nil)
+
+		token, err := createToken(oldSigner, testSession)
+		assert.NoError(t, err)
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownKey", func(t *testing.T) {
+		token, err := createToken(testSigner, testSession)
+		assert.NoError(t, err)
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("AlgorithmMismatch", func(t *testing.T) {
+		// An HMAC token claiming to be signed by the RSA key must be rejected
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"user_uuid": testSession.UserUUID, "jti": testSession.AccessTokenID})
+		token.Header["kid"] = "new-rs256"
+		tokenString, err := token.SignedString([]byte("old-secret"))
+		assert.NoError(t, err)
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -167,8 +167,16 @@ func (r *messageRoute) getMessageStatus(c *gin.Context) {
 		return
 	}
 
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
 	// Call GetSeenStatus method from message entity object
-	seenStatus, err := r.t.GetSeenStatus(c.Request.Context(), msgUUID)
+	seenStatus, err := r.t.GetSeenStatus(c.Request.Context(), userUUID, msgUUID)
 	if err != nil {
 		// Logs error message
 		r.l.Error(err, "http - v1 - getMessagesFromConversation - GetMessagesFromConversation")
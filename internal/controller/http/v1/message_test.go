@@ -167,7 +167,7 @@ func TestGetMessageStatus(t *testing.T) {
 				SeenTimestamp: "2024-01-01T00:00:00Z",
 			},
 		}
-		mockUsecase.EXPECT().GetSeenStatus(gomock.Any(), msgUUID).Return(seenStatus, nil)
+		mockUsecase.EXPECT().GetSeenStatus(gomock.Any(), "some-uuid", msgUUID).Return(seenStatus, nil)
 
 		req, _ := http.NewRequest(http.MethodGet, "/message/status/msg-uuid", nil)
 		w := httptest.NewRecorder()
@@ -178,7 +178,7 @@ func TestGetMessageStatus(t *testing.T) {
 
 	t.Run("EntityObjectFailure", func(t *testing.T) {
 		msgUUID := "msg-uuid"
-		mockUsecase.EXPECT().GetSeenStatus(gomock.Any(), msgUUID).Return([]entity.GetSeenStatusDTO{}, errors.New("test_error"))
+		mockUsecase.EXPECT().GetSeenStatus(gomock.Any(), "some-uuid", msgUUID).Return([]entity.GetSeenStatusDTO{}, errors.New("test_error"))
 
 		req, _ := http.NewRequest(http.MethodGet, "/message/status/msg-uuid", nil)
 		w := httptest.NewRecorder()
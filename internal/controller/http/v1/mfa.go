@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type mfaRoute struct {
+	m usecase.MFA
+	l logger.Interface
+}
+
+// Handles api routes for two-factor authentication functionality
+func newMFARoute(handler *gin.RouterGroup, m usecase.MFA, l logger.Interface) {
+	route := &mfaRoute{m, l}
+
+	// Group the routes under the "/user/mfa" path.
+	h := handler.Group("/user/mfa")
+	{
+		// Define the endpoints for the two-factor authentication functionality.
+		h.POST("/enroll", route.enroll)
+		h.POST("/confirm", route.confirmEnrollment)
+	}
+}
+
+// enroll generates a new TOTP secret for the user to add to their authenticator app.
+func (r *mfaRoute) enroll(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Call Enroll method from mfa entity object
+	enrollment, err := r.m.Enroll(c.Request.Context(), userUUID)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - enroll - Enroll")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the secret and otpauth URI as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToMFAEnrollmentResponse(enrollment))
+}
+
+// confirmEnrollment enables two-factor authentication once the user proves their app generates valid codes.
+func (r *mfaRoute) confirmEnrollment(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Bind the incoming JSON request body to the MFACodeForm struct.
+	var request boundary.MFACodeForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - confirmEnrollment")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call ConfirmEnrollment method from mfa entity object
+	recoveryCodes, err := r.m.ConfirmEnrollment(c.Request.Context(), userUUID, request.Code)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - confirmEnrollment - ConfirmEnrollment")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the one-time recovery codes as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.MFARecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
@@ -0,0 +1,278 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+	"github.com/maxyong7/chat-messaging-app/pkg/totp"
+)
+
+func TestMFARoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMFAUsecase := mocks.NewMockMFA(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	newMFARoute(router.Group(""), mockMFAUsecase, mockLogger)
+
+	t.Run("Enroll", func(t *testing.T) {
+		mockMFAUsecase.EXPECT().Enroll(gomock.Any(), "some-uuid").Return(entity.MFAEnrollment{
+			Secret: "SOMESECRET", URI: "otpauth://totp/chat:john?secret=SOMESECRET",
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/mfa/enroll", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.MFAEnrollmentResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "SOMESECRET", response.Secret)
+		assert.Equal(t, "otpauth://totp/chat:john?secret=SOMESECRET", response.URI)
+	})
+
+	t.Run("EnrollAlreadyEnabled", func(t *testing.T) {
+		mockMFAUsecase.EXPECT().Enroll(gomock.Any(), "some-uuid").Return(entity.MFAEnrollment{}, entity.ErrMFAAlreadyEnabled)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/mfa/enroll", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("EnrollFailure", func(t *testing.T) {
+		mockMFAUsecase.EXPECT().Enroll(gomock.Any(), "some-uuid").Return(entity.MFAEnrollment{}, errors.New("test_error"))
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/mfa/enroll", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("Confirm", func(t *testing.T) {
+		mockMFAUsecase.EXPECT().ConfirmEnrollment(gomock.Any(), "some-uuid", "123456").Return([]string{"abcd-efgh", "ijkl-mnop"}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/mfa/confirm", strings.NewReader(`{"code": "123456"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.MFARecoveryCodesResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []string{"abcd-efgh", "ijkl-mnop"}, response.RecoveryCodes)
+	})
+
+	t.Run("ConfirmInvalidCode", func(t *testing.T) {
+		mockMFAUsecase.EXPECT().ConfirmEnrollment(gomock.Any(), "some-uuid", "000000").Return(nil, entity.ErrInvalidMFACode)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/mfa/confirm", strings.NewReader(`{"code": "000000"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("ConfirmNotEnrolled", func(t *testing.T) {
+		mockMFAUsecase.EXPECT().ConfirmEnrollment(gomock.Any(), "some-uuid", "123456").Return(nil, entity.ErrMFANotEnrolled)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/mfa/confirm", strings.NewReader(`{"code": "123456"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ConfirmMissingCode", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/user/mfa/confirm", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestMFARoutesUnauthorized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMFAUsecase := mocks.NewMockMFA(ctrl)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	newMFARoute(router.Group(""), mockMFAUsecase, logger.New(logLevelDebug))
+
+	for _, path := range []string{"/user/mfa/enroll", "/user/mfa/confirm"} {
+		req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(`{"code": "123456"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code, path)
+	}
+}
+
+// TestMFALoginWithUseCase enrolls a user and logs them in through the pending token with the real MFA use case.
+// The mfa repository is mocked the way the database behaves: a time step or recovery code is only accepted once.
+func TestMFALoginWithUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMFARepo := mocks.NewMockMFARepo(ctrl)
+	mockUserRepo := mocks.NewMockUserRepo(ctrl)
+	mockUsecase := mocks.NewMockUser(ctrl)
+	mockSessionUsecase := mocks.NewMockSession(ctrl)
+	mockThrottleUsecase := mocks.NewMockLoginThrottle(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+	mfa := usecase.NewMFA(mockMFARepo, mockUserRepo, "chat")
+
+	var stored *entity.MFADTO
+	consumedRecoveryCodes := map[string]bool{}
+	var recoveryCodeHashes []string
+	mockMFARepo.EXPECT().GetMFA(gomock.Any(), "some-uuid").DoAndReturn(func(context.Context, string) (*entity.MFADTO, error) {
+		return stored, nil
+	}).AnyTimes()
+	mockMFARepo.EXPECT().StorePendingMFA(gomock.Any(), "some-uuid", gomock.Any()).DoAndReturn(func(_ context.Context, userUUID string, secret string) error {
+		stored = &entity.MFADTO{UserUUID: userUUID, Secret: secret}
+		return nil
+	})
+	mockMFARepo.EXPECT().ConfirmMFA(gomock.Any(), "some-uuid", gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ string, step int64, hashes []string) error {
+		confirmedAt := time.Now()
+		stored.ConfirmedAt = &confirmedAt
+		stored.LastUsedStep = step
+		recoveryCodeHashes = hashes
+		return nil
+	})
+	mockMFARepo.EXPECT().UpdateLastUsedStep(gomock.Any(), "some-uuid", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, step int64) (bool, error) {
+		if step <= stored.LastUsedStep {
+			return false, nil
+		}
+		stored.LastUsedStep = step
+		return true, nil
+	}).AnyTimes()
+	mockMFARepo.EXPECT().ConsumeRecoveryCode(gomock.Any(), "some-uuid", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, codeHash string) (bool, error) {
+		for _, hash := range recoveryCodeHashes {
+			if hash == codeHash && !consumedRecoveryCodes[codeHash] {
+				consumedRecoveryCodes[codeHash] = true
+				return true, nil
+			}
+		}
+		return false, nil
+	}).AnyTimes()
+	mockUserRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), "some-uuid").Return(&entity.UserCredentialsDTO{UserUuid: "some-uuid", Username: "john"}, nil)
+
+	attempt := entity.LoginAttempt{Identifier: "some-uuid", IP: "192.0.2.1"}
+	mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).AnyTimes()
+	mockThrottleUsecase.EXPECT().RecordSuccess(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	u := &userRoutes{t: mockUsecase, s: mockSessionUsecase, m: mfa, lt: mockThrottleUsecase, signer: testSigner, l: mockLogger}
+	router.POST("/user/login", u.loginUser)
+	router.POST("/user/login/mfa", u.loginUserMFA)
+	authenticated := router.Group("", func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	newMFARoute(authenticated, mfa, mockLogger)
+
+	post := func(path string, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	// Enroll and confirm with a first code, which returns the recovery codes
+	w := post("/user/mfa/enroll", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	confirmationCode, err := totp.Code(stored.Secret, totp.Step(time.Now()))
+	assert.NoError(t, err)
+	w = post("/user/mfa/confirm", fmt.Sprintf(`{"code": "%s"}`, confirmationCode))
+	var recovery boundary.MFARecoveryCodesResponse
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &recovery))
+	assert.Len(t, recovery.RecoveryCodes, 10)
+
+	// The password alone only gives a pending token, not a session
+	mockUsecase.EXPECT().VerifyCredentials(gomock.Any(), gomock.Any()).Return("some-uuid", true, nil)
+	w = post("/user/login", `{"username": "john", "password": "password123"}`)
+	var challenge boundary.MFAChallengeScreen
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &challenge))
+	assert.True(t, challenge.MFARequired)
+	assert.NotContains(t, w.Body.String(), "refresh_token")
+
+	t.Run("CodeOfConfirmationIsNotAcceptedAgain", func(t *testing.T) {
+		// The time step of the code was used up by the confirmation, so sending it again is a replay
+		mockThrottleUsecase.EXPECT().RecordFailure(gomock.Any(), attempt).Return(nil)
+
+		w := post("/user/login/mfa", fmt.Sprintf(`{"mfa_token": "%s", "code": "%s"}`, challenge.MFAToken, confirmationCode))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("WrongCode", func(t *testing.T) {
+		mockThrottleUsecase.EXPECT().RecordFailure(gomock.Any(), attempt).Return(nil)
+
+		w := post("/user/login/mfa", fmt.Sprintf(`{"mfa_token": "%s", "code": "not-a-recovery-code"}`, challenge.MFAToken))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("RecoveryCodeOnlyWorksOnce", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().CreateSession(gomock.Any(), "some-uuid", gomock.Any()).Return(testSession, nil)
+
+		body := fmt.Sprintf(`{"mfa_token": "%s", "code": "%s"}`, challenge.MFAToken, strings.ToUpper(recovery.RecoveryCodes[0]))
+		w := post("/user/login/mfa", body)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), testSession.RefreshToken)
+
+		mockThrottleUsecase.EXPECT().RecordFailure(gomock.Any(), attempt).Return(nil)
+		w = post("/user/login/mfa", body)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("NextCodeThenReplay", func(t *testing.T) {
+		// A code of the next time step is still accepted for clock drift, but only once
+		code, err := totp.Code(stored.Secret, stored.LastUsedStep+1)
+		assert.NoError(t, err)
+		body := fmt.Sprintf(`{"mfa_token": "%s", "code": "%s"}`, challenge.MFAToken, code)
+
+		mockSessionUsecase.EXPECT().CreateSession(gomock.Any(), "some-uuid", gomock.Any()).Return(testSession, nil)
+		w := post("/user/login/mfa", body)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		mockThrottleUsecase.EXPECT().RecordFailure(gomock.Any(), attempt).Return(nil)
+		w = post("/user/login/mfa", body)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
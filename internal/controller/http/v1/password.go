@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type passwordRoutes struct {
+	p usecase.PasswordReset
+	l logger.Interface
+}
+
+// Handles api routes for password recovery functionality
+func newPasswordRoute(handler *gin.RouterGroup, p usecase.PasswordReset, l logger.Interface) {
+	r := &passwordRoutes{p, l}
+
+	// Group the routes under the "/user/password" path.
+	h := handler.Group("/user/password")
+	{
+		// Define the endpoints for the password recovery functionality.
+		h.POST("/forgot", r.forgotPassword)
+		h.POST("/reset", r.resetPassword)
+	}
+}
+
+// forgotPassword emails a password reset link to the user.
+func (r *passwordRoutes) forgotPassword(c *gin.Context) {
+	// Bind the incoming JSON request body to the ForgotPasswordForm struct.
+	var request boundary.ForgotPasswordForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - forgotPassword")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call RequestPasswordReset method from password reset entity object
+	err := r.p.RequestPasswordReset(c.Request.Context(), request.ToPasswordResetRequest(c.ClientIP()))
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - forgotPassword - RequestPasswordReset")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return an "Accepted" status code whether or not the email belongs to an account.
+	c.Writer.WriteHeader(http.StatusAccepted)
+}
+
+// resetPassword sets a new password using the token from the reset email.
+func (r *passwordRoutes) resetPassword(c *gin.Context) {
+	// Bind the incoming JSON request body to the ResetPasswordForm struct.
+	var request boundary.ResetPasswordForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - resetPassword")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call ResetPassword method from password reset entity object
+	err := r.p.ResetPassword(c.Request.Context(), request.ToPasswordReset())
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - resetPassword - ResetPassword")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return a "No Content" status code to indicate the password was changed.
+	c.Writer.WriteHeader(http.StatusNoContent)
+}
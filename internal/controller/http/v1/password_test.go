@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+func TestForgotPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockPasswordReset(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	r := &passwordRoutes{p: mockUsecase, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/user/password/forgot", r.forgotPassword)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().RequestPasswordReset(gomock.Any(), entity.PasswordResetRequest{Email: "test@example.com", IP: "192.0.2.1"}).Return(nil)
+
+		requestBody := `{"email": "test@example.com"}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/password/forgot", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+
+	t.Run("TooManyRequests", func(t *testing.T) {
+		mockUsecase.EXPECT().RequestPasswordReset(gomock.Any(), gomock.Any()).Return(entity.ErrTooManyRequests)
+
+		requestBody := `{"email": "test@example.com"}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/password/forgot", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+
+	t.Run("InvalidRequestBody", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/user/password/forgot", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid request body")
+	})
+
+	t.Run("EntityObjectFailure", func(t *testing.T) {
+		mockUsecase.EXPECT().RequestPasswordReset(gomock.Any(), gomock.Any()).Return(errors.New("test_error"))
+
+		requestBody := `{"email": "test@example.com"}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/password/forgot", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockPasswordReset(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	r := &passwordRoutes{p: mockUsecase, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/user/password/reset", r.resetPassword)
+
+	requestBody := `{"token": "reset-token", "new_password": "newpassword123"}`
+	passwordReset := entity.PasswordReset{Token: "reset-token", NewPassword: "newpassword123"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().ResetPassword(gomock.Any(), passwordReset).Return(nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/password/reset", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("InvalidRequestBody", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/user/password/reset", strings.NewReader(`{"token": "reset-token"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("InvalidResetToken", func(t *testing.T) {
+		mockUsecase.EXPECT().ResetPassword(gomock.Any(), passwordReset).Return(entity.ErrInvalidResetToken)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/password/reset", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), entity.ErrInvalidResetToken.Error())
+	})
+}
@@ -0,0 +1,145 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+// _presenceSweepInterval is how often idle and disconnected users are checked
+const _presenceSweepInterval = 15 * time.Second
+
+type presenceRoutes struct {
+	p usecase.Presence
+	l logger.Interface
+}
+
+// Handles api routes for the presence of contacts
+func newPresenceRoute(handler *gin.RouterGroup, p usecase.Presence, l logger.Interface) {
+	r := &presenceRoutes{p, l}
+
+	// Group the routes under the "/presence" path.
+	h := handler.Group("/presence")
+	{
+		// Define the endpoints for the presence functionality.
+		h.GET("/contacts", r.getContactsPresence)
+	}
+}
+
+func (r *presenceRoutes) getContactsPresence(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Call GetContactsPresence method from presence entity object
+	presences, err := r.p.GetContactsPresence(c.Request.Context(), userUUID)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - getContactsPresence - GetContactsPresence")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the presence of the contacts as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToContactsPresenceResponse(presences))
+}
+
+// runPresenceSweep periodically pushes the users who became away or offline to their contacts
+func runPresenceSweep(hub *Hub, p usecase.Presence, l logger.Interface) {
+	ticker := time.NewTicker(_presenceSweepInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		changes, err := p.Sweep(context.Background())
+		if err != nil {
+			l.Error(err, "http - v1 - runPresenceSweep - Sweep")
+		}
+		for _, change := range changes {
+			notifyPresenceChange(hub, change)
+		}
+	}
+}
+
+// connectPresence marks the user online, the presence is optional for the websocket to work
+func (r *conversationRoutes) connectPresence(hub *Hub, userUUID string) {
+	if r == nil || r.presence == nil {
+		return
+	}
+
+	change, err := r.presence.Connect(context.Background(), userUUID)
+	if err != nil {
+		r.l.Error(err, "http - v1 - connectPresence - Connect")
+		return
+	}
+	if change != nil {
+		notifyPresenceChange(hub, *change)
+	}
+}
+
+// disconnectPresence starts the grace period of the user once their last connection is closed
+func (r *conversationRoutes) disconnectPresence(userUUID string) {
+	if r == nil || r.presence == nil {
+		return
+	}
+
+	err := r.presence.Disconnect(context.Background(), userUUID)
+	if err != nil {
+		r.l.Error(err, "http - v1 - disconnectPresence - Disconnect")
+	}
+}
+
+// touchPresence records an activity of the user
+func (r *conversationRoutes) touchPresence(hub *Hub, userUUID string) {
+	if r == nil || r.presence == nil {
+		return
+	}
+
+	change, err := r.presence.Touch(context.Background(), userUUID)
+	if err != nil {
+		r.l.Error(err, "http - v1 - touchPresence - Touch")
+		return
+	}
+	if change != nil {
+		notifyPresenceChange(hub, *change)
+	}
+}
+
+// notifyPresenceChange pushes the presence to the websockets of the contacts of the user
+func notifyPresenceChange(hub *Hub, change entity.PresenceChange) {
+	if len(change.ContactUUIDs) == 0 {
+		return
+	}
+
+	hub.Notify <- Notification{
+		UserUUIDs: change.ContactUUIDs,
+		Message:   buildPresenceResponse(change.Presence),
+	}
+}
+
+// Method to build presence response body
+func buildPresenceResponse(presence entity.Presence) boundary.ConversationResponseModel {
+	return boundary.ConversationResponseModel{
+		MessageType: presenceMessageType,
+		Data: boundary.ConversationResponseData{
+			SenderUUID: presence.UserUUID,
+			PresenceResponseData: boundary.PresenceResponseData{
+				Status:     presence.Status,
+				LastSeenAt: presence.LastSeenAt,
+			},
+		},
+	}
+}
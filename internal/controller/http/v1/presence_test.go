@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+func TestGetContactsPresence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPresenceUsecase := mocks.NewMockPresence(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	r := &presenceRoutes{p: mockPresenceUsecase, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	router.GET("/presence/contacts", r.getContactsPresence)
+
+	t.Run("Success", func(t *testing.T) {
+		lastSeenAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		mockPresenceUsecase.EXPECT().GetContactsPresence(gomock.Any(), "some-uuid").Return([]entity.Presence{
+			{UserUUID: "online-uuid", Status: entity.PresenceOnline},
+			{UserUUID: "offline-uuid", Status: entity.PresenceOffline, LastSeenAt: &lastSeenAt},
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/presence/contacts", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.ContactsPresenceResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Contacts, 2)
+		assert.Nil(t, response.Contacts[0].LastSeenAt)
+		assert.Equal(t, entity.PresenceOffline, response.Contacts[1].Status)
+		assert.True(t, lastSeenAt.Equal(*response.Contacts[1].LastSeenAt))
+	})
+
+	t.Run("EntityObjectFailure", func(t *testing.T) {
+		mockPresenceUsecase.EXPECT().GetContactsPresence(gomock.Any(), "some-uuid").Return(nil, errors.New("test_error"))
+
+		req, _ := http.NewRequest(http.MethodGet, "/presence/contacts", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestNotifyPresenceChange(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	// The contact is connected to a conversation the user is not part of
+	contact := NewClient("other-conversation", entity.UserProfile{UserUUID: "contact-uuid"}, nil, hub, nil)
+	hub.Register <- contact
+	stranger := NewClient("other-conversation", entity.UserProfile{UserUUID: "stranger-uuid"}, nil, hub, nil)
+	hub.Register <- stranger
+
+	notifyPresenceChange(hub, entity.PresenceChange{
+		Presence:     entity.Presence{UserUUID: "some-uuid", Status: entity.PresenceOnline},
+		ContactUUIDs: []string{"contact-uuid"},
+	})
+
+	select {
+	case message := <-contact.send:
+		assert.Equal(t, presenceMessageType, message.MessageType)
+		assert.Equal(t, "some-uuid", message.Data.SenderUUID)
+		assert.Equal(t, entity.PresenceOnline, message.Data.Status)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("presence was not pushed to the contact")
+	}
+
+	select {
+	case message := <-stranger.send:
+		t.Fatalf("presence was pushed to a user who is not a contact: %+v", message)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
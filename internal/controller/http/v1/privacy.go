@@ -0,0 +1,90 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type privacyRoutes struct {
+	p usecase.Privacy
+	l logger.Interface
+}
+
+// Handles api routes for the user's privacy settings
+func newPrivacyRoute(handler *gin.RouterGroup, p usecase.Privacy, l logger.Interface) {
+	r := &privacyRoutes{p, l}
+
+	// Group the routes under the "/user" path.
+	h := handler.Group("/user")
+	{
+		// Define the endpoints for the privacy settings functionality.
+		h.GET("/privacy", r.getPrivacySettings)
+		h.PUT("/privacy", r.updatePrivacySettings)
+	}
+}
+
+func (r *privacyRoutes) getPrivacySettings(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Call GetPrivacySettings method from privacy entity object
+	settings, err := r.p.GetPrivacySettings(c.Request.Context(), userUUID)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - getPrivacySettings - GetPrivacySettings")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the settings as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToPrivacySettingsResponse(settings))
+}
+
+// updatePrivacySettings changes the settings that are in the request body, the others are kept.
+func (r *privacyRoutes) updatePrivacySettings(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Bind the incoming JSON request body to the UpdatePrivacySettingsForm struct.
+	var request boundary.UpdatePrivacySettingsForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - updatePrivacySettings")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call UpdatePrivacySettings method from privacy entity object
+	settings, err := r.p.UpdatePrivacySettings(c.Request.Context(), userUUID, request.ToPrivacySettingsUpdate())
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - updatePrivacySettings - UpdatePrivacySettings")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the resulting settings as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToPrivacySettingsResponse(settings))
+}
@@ -0,0 +1,173 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+func TestPrivacyRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPrivacyUsecase := mocks.NewMockPrivacy(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	newPrivacyRoute(router.Group(""), mockPrivacyUsecase, mockLogger)
+
+	t.Run("GetPrivacySettings", func(t *testing.T) {
+		mockPrivacyUsecase.EXPECT().GetPrivacySettings(gomock.Any(), "some-uuid").Return(entity.PrivacySettings{
+			Discoverable: false, WhoCanAddContact: entity.PrivacyContactsOfContacts, WhoCanAddToGroups: entity.PrivacyContacts,
+			ReadReceipts: true, ShowPresence: false,
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/user/privacy", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.PrivacySettingsResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, boundary.PrivacySettingsResponse{
+			Discoverable: false, WhoCanAddContact: "contacts_of_contacts", WhoCanAddToGroups: "contacts",
+			ReadReceipts: true, ShowPresence: false,
+		}, response)
+	})
+
+	t.Run("GetPrivacySettingsFailure", func(t *testing.T) {
+		mockPrivacyUsecase.EXPECT().GetPrivacySettings(gomock.Any(), "some-uuid").Return(entity.PrivacySettings{}, errors.New("test_error"))
+
+		req, _ := http.NewRequest(http.MethodGet, "/user/privacy", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("UpdateOnlyGivenSettings", func(t *testing.T) {
+		nobody := entity.PrivacyNobody
+		readReceipts := false
+		mockPrivacyUsecase.EXPECT().UpdatePrivacySettings(gomock.Any(), "some-uuid", entity.PrivacySettingsUpdate{
+			WhoCanAddContact: &nobody, ReadReceipts: &readReceipts,
+		}).Return(entity.PrivacySettings{
+			Discoverable: true, WhoCanAddContact: entity.PrivacyNobody, WhoCanAddToGroups: entity.PrivacyEveryone,
+			ReadReceipts: false, ShowPresence: true,
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodPut, "/user/privacy", strings.NewReader(`{"who_can_add_contact": "nobody", "read_receipts": false}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.PrivacySettingsResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "nobody", response.WhoCanAddContact)
+		assert.False(t, response.ReadReceipts)
+		assert.True(t, response.ShowPresence)
+	})
+
+	t.Run("UpdateWrongType", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPut, "/user/privacy", strings.NewReader(`{"discoverable": "yes"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPrivacyRoutesUnauthorized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPrivacyUsecase := mocks.NewMockPrivacy(ctrl)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	newPrivacyRoute(router.Group(""), mockPrivacyUsecase, logger.New(logLevelDebug))
+
+	for _, method := range []string{http.MethodGet, http.MethodPut} {
+		req, _ := http.NewRequest(method, "/user/privacy", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code, method)
+	}
+}
+
+// TestUpdatePrivacySettingsWithUseCase sends option values to the real privacy use case,
+// each audience only accepts its own options and nothing invalid is stored.
+func TestUpdatePrivacySettingsWithUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPrivacyRepo := mocks.NewMockPrivacyRepo(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	newPrivacyRoute(router.Group(""), usecase.NewPrivacy(mockPrivacyRepo), mockLogger)
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPut, "/user/privacy", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	invalid := map[string]string{
+		"UnknownContactOption":             `{"who_can_add_contact": "friends"}`,
+		"GroupOptionForContacts":           `{"who_can_add_contact": "contacts"}`,
+		"ContactOptionForGroups":           `{"who_can_add_to_groups": "contacts_of_contacts"}`,
+		"EmptyGroupOption":                 `{"who_can_add_to_groups": ""}`,
+		"OptionIsCaseSensitive":            `{"who_can_add_contact": "Everyone"}`,
+		"InvalidOptionWithValidOtherField": `{"show_presence": false, "who_can_add_to_groups": "all"}`,
+	}
+	for name, body := range invalid {
+		t.Run(name, func(t *testing.T) {
+			// No repository call is expected, an invalid option is refused before anything is read or stored
+			w := put(body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), entity.ErrInvalidPrivacySetting.Error())
+		})
+	}
+
+	t.Run("ValidOptions", func(t *testing.T) {
+		mockPrivacyRepo.EXPECT().GetPrivacySettings(gomock.Any(), "some-uuid").Return(nil, nil)
+		mockPrivacyRepo.EXPECT().StorePrivacySettings(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, settings entity.PrivacySettingsDTO) error {
+			assert.Equal(t, entity.PrivacyContactsOfContacts, settings.WhoCanAddContact)
+			assert.Equal(t, entity.PrivacyContacts, settings.WhoCanAddToGroups)
+			return nil
+		})
+
+		w := put(`{"who_can_add_contact": "contacts_of_contacts", "who_can_add_to_groups": "contacts"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type reportRoutes struct {
+	r usecase.Report
+	l logger.Interface
+}
+
+// Handles api routes for reporting abuse, and for the moderation queue of the reports which is only served to admins
+func newReportRoute(handler *gin.RouterGroup, r usecase.Report, l logger.Interface) {
+	route := &reportRoutes{r, l}
+
+	// Group the routes under the "/reports" path.
+	h := handler.Group("/reports")
+	{
+		// Define the endpoints for the report functionality.
+		h.POST("", route.reportAbuse)
+	}
+
+	// Group the moderation queue under the "/admin/reports" path.
+	a := handler.Group("/admin/reports")
+	a.Use(requireRole(entity.RoleAdmin))
+	{
+		// Define the endpoints for the moderation queue.
+		a.GET("", route.listReports)
+		a.GET("/:reportId", route.getReport)
+		a.PATCH("/:reportId", route.updateReportStatus)
+	}
+}
+
+// reportAbuse reports a user, a message or a conversation to the admins.
+func (r *reportRoutes) reportAbuse(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var request boundary.ReportForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If there is an error in binding JSON, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - reportAbuse")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Calls ReportAbuse method from report entity object
+	report, err := r.r.ReportAbuse(c.Request.Context(), request.ToReport(userUUID))
+	if err != nil {
+		// Logs the error
+		r.l.Error(err, "http - v1 - reportAbuse - ReportAbuse")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the report as JSON with a status code of 201 (Created).
+	c.JSON(http.StatusCreated, boundary.ToReportResponse(report))
+}
+
+// listReports returns a page of the moderation queue from the oldest report, optionally of one status or target type only.
+func (r *reportRoutes) listReports(c *gin.Context) {
+	// Get 'cursor' value from URL query, it is empty for the first page
+	cursor, err := queryParamIDCursor(c)
+	if err != nil {
+		r.l.Error(err, "http - v1 - listReports - cursor validation error")
+		errorResponse(c, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+
+	// Get 'limit' value from URL query, the entity object applies the default and the maximum
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	// Call ListReports method from report entity object
+	page, err := r.r.ListReports(c.Request.Context(), entity.ReportQuery{
+		Status:     c.Query("status"),
+		TargetType: c.Query("target_type"),
+		Cursor:     cursor,
+		Limit:      limit,
+	})
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - listReports - ListReports")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the reports as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToReportsResponse(page, encodeIDCursor(page.NextCursor)))
+}
+
+// getReport returns the report with the copy of the reported message.
+func (r *reportRoutes) getReport(c *gin.Context) {
+	// Call GetReport method from report entity object
+	report, err := r.r.GetReport(c.Request.Context(), c.Param("reportId"))
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - getReport - GetReport")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the report as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToReportResponse(report))
+}
+
+// updateReportStatus moves the report to another status of the moderation queue.
+func (r *reportRoutes) updateReportStatus(c *gin.Context) {
+	// Get user_uuid from context
+	adminUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var request boundary.ReportStatusForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If there is an error in binding JSON, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - updateReportStatus")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call UpdateReportStatus method from report entity object
+	report, err := r.r.UpdateReportStatus(c.Request.Context(), request.ToReportStatusUpdate(adminUUID, c.Param("reportId")))
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - updateReportStatus - UpdateReportStatus")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the report as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToReportResponse(report))
+}
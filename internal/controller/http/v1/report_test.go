@@ -0,0 +1,222 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+// newReportTestRouter serves the report routes to a user with the given role
+func newReportTestRouter(mockReportUsecase *mocks.MockReport, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := router.Group("/v1")
+	handler.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Set("user_role", role)
+		c.Next()
+	})
+	newReportRoute(handler, mockReportUsecase, logger.New(logLevelDebug))
+	return router
+}
+
+func TestReportRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportUsecase := mocks.NewMockReport(ctrl)
+	router := newReportTestRouter(mockReportUsecase, entity.RoleUser)
+	adminRouter := newReportTestRouter(mockReportUsecase, entity.RoleAdmin)
+
+	t.Run("ReportMessage", func(t *testing.T) {
+		mockReportUsecase.EXPECT().ReportAbuse(gomock.Any(), entity.Report{
+			ReporterUUID: "some-uuid",
+			TargetType:   entity.AdminTargetMessage,
+			TargetUUID:   "msg-uuid",
+			Reason:       entity.ReportReasonHarassment,
+		}).Return(entity.Report{
+			ReportUUID:   "report-uuid",
+			ReporterUUID: "some-uuid",
+			TargetType:   entity.AdminTargetMessage,
+			TargetUUID:   "msg-uuid",
+			Reason:       entity.ReportReasonHarassment,
+			Message:      &entity.ReportedMessage{SenderUUID: "sender-uuid", Content: "go away"},
+			Status:       entity.ReportStatusOpen,
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/reports",
+			strings.NewReader(`{"target_type": "message", "target_uuid": "msg-uuid", "reason": "harassment"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.ReportResponse
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "report-uuid", response.ReportUUID)
+		assert.Equal(t, "go away", response.Message.Content)
+	})
+
+	t.Run("ReportMissingReason", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/v1/reports", strings.NewReader(`{"target_type": "user", "target_uuid": "other-uuid"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ReportAlreadyPending", func(t *testing.T) {
+		mockReportUsecase.EXPECT().ReportAbuse(gomock.Any(), gomock.Any()).Return(entity.Report{}, entity.ErrReportExists)
+
+		req, _ := http.NewRequest(http.MethodPost, "/v1/reports",
+			strings.NewReader(`{"target_type": "user", "target_uuid": "other-uuid", "reason": "spam"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("QueueNotServedToUsers", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/v1/admin/reports", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("ListReports", func(t *testing.T) {
+		mockReportUsecase.EXPECT().ListReports(gomock.Any(), entity.ReportQuery{Status: entity.ReportStatusOpen, Cursor: 3, Limit: 1}).
+			Return(entity.ReportPage{Reports: []entity.Report{{ID: 5, ReportUUID: "report-uuid"}}, NextCursor: 5, Limit: 1}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/admin/reports?status=open&cursor=3&limit=1", nil)
+		w := httptest.NewRecorder()
+		adminRouter.ServeHTTP(w, req)
+
+		var response boundary.ReportsResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Reports, 1)
+		assert.Equal(t, "5", response.Pagination.Cursor)
+	})
+
+	t.Run("GetReportNotFound", func(t *testing.T) {
+		mockReportUsecase.EXPECT().GetReport(gomock.Any(), "unknown-uuid").Return(entity.Report{}, entity.ErrReportNotFound)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/admin/reports/unknown-uuid", nil)
+		w := httptest.NewRecorder()
+		adminRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("UpdateReportStatus", func(t *testing.T) {
+		mockReportUsecase.EXPECT().UpdateReportStatus(gomock.Any(), entity.ReportStatusUpdate{
+			AdminUUID:  "some-uuid",
+			ReportUUID: "report-uuid",
+			Status:     entity.ReportStatusResolved,
+			Note:       "user suspended",
+		}).Return(entity.Report{ReportUUID: "report-uuid", Status: entity.ReportStatusResolved, ReviewerUUID: "some-uuid"}, nil)
+
+		req, _ := http.NewRequest(http.MethodPatch, "/v1/admin/reports/report-uuid",
+			strings.NewReader(`{"status": "resolved", "note": "user suspended"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		adminRouter.ServeHTTP(w, req)
+
+		var response boundary.ReportResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, entity.ReportStatusResolved, response.Status)
+	})
+
+	t.Run("UpdateReportInvalidTransition", func(t *testing.T) {
+		mockReportUsecase.EXPECT().UpdateReportStatus(gomock.Any(), gomock.Any()).Return(entity.Report{}, entity.ErrInvalidReportTransition)
+
+		req, _ := http.NewRequest(http.MethodPatch, "/v1/admin/reports/report-uuid", strings.NewReader(`{"status": "in_review"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		adminRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+// assertNoMessage fails if the client is sent anything, e.g. a report about them
+func assertNoMessage(t *testing.T, client *Client) {
+	select {
+	case message := <-client.send:
+		t.Errorf("client was sent %+v", message)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestReportOverWebsocket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReportUsecase := mocks.NewMockReport(ctrl)
+
+	hub := NewHub()
+	go hub.Run()
+
+	r := &conversationRoutes{
+		report: mockReportUsecase,
+		l:      logger.New(logLevelDebug),
+	}
+
+	// The reporter and the reported user are connected to the same conversation
+	reporter := NewClient("conv-uuid", entity.UserProfile{UserUUID: "some-uuid"}, nil, hub, r)
+	reported := NewClient("conv-uuid", entity.UserProfile{UserUUID: "other-uuid"}, nil, hub, nil)
+	hub.Register <- reporter
+	hub.Register <- reported
+
+	t.Run("ReportConversation", func(t *testing.T) {
+		// Conversation reports are about the conversation of the client, whatever target is sent
+		mockReportUsecase.EXPECT().ReportAbuse(gomock.Any(), entity.Report{
+			ReporterUUID:     "some-uuid",
+			TargetType:       entity.AdminTargetConversation,
+			TargetUUID:       "conv-uuid",
+			ConversationUUID: "conv-uuid",
+			Reason:           entity.ReportReasonSpam,
+		}).Return(entity.Report{ReportUUID: "report-uuid", ReporterUUID: "some-uuid", Status: entity.ReportStatusOpen}, nil)
+
+		reporter.handleConversation(boundary.ConversationRequestModel{
+			MessageType: reportMessageType,
+			Data:        json.RawMessage(`{"target_type": "conversation", "target_uuid": "other-conv-uuid", "reason": "spam"}`),
+		}, reporter.UserInfo)
+
+		message := <-reporter.send
+		assert.Equal(t, reportMessageType, message.MessageType)
+		assert.Equal(t, "report-uuid", message.Data.ReportUUID)
+		assert.Equal(t, entity.ReportStatusOpen, message.Data.ReportStatus)
+		assertNoMessage(t, reported)
+	})
+
+	t.Run("ReportRejected", func(t *testing.T) {
+		mockReportUsecase.EXPECT().ReportAbuse(gomock.Any(), gomock.Any()).Return(entity.Report{}, entity.ErrReportExists)
+
+		reporter.handleConversation(boundary.ConversationRequestModel{
+			MessageType: reportMessageType,
+			Data:        json.RawMessage(`{"target_type": "user", "target_uuid": "other-uuid", "reason": "spam"}`),
+		}, reporter.UserInfo)
+
+		message := <-reporter.send
+		assert.Equal(t, errorMessageType, message.MessageType)
+		assert.Equal(t, entity.ErrReportExists.Error(), message.Data.ErrorMessage)
+		assertNoMessage(t, reported)
+	})
+}
@@ -11,18 +11,37 @@ import (
 
 	// Swagger docs.
 	_ "github.com/maxyong7/chat-messaging-app/docs"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
 	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/jwtsigner"
 	"github.com/maxyong7/chat-messaging-app/pkg/logger"
 )
 
 type RouterUseCases struct {
-	Verification usecase.User
-	Conversation usecase.Conversation
-	Contact      usecase.Contact
-	Message      usecase.Message
-	GroupChat    usecase.GroupChat
-	UserProfile  usecase.UserProfile
-	Reaction     usecase.Reaction
+	Verification      usecase.User
+	Session           usecase.Session
+	PasswordReset     usecase.PasswordReset
+	EmailVerification usecase.EmailVerification
+	Credentials       usecase.Credentials
+	MFA               usecase.MFA
+	LoginThrottle     usecase.LoginThrottle
+	SSO               usecase.SSO
+	AccountDeletion   usecase.AccountDeletion
+	DataExport        usecase.DataExport
+	Bot               usecase.Bot
+	UserSearch        usecase.UserSearch
+	ContactSuggestion usecase.ContactSuggestion
+	Privacy           usecase.Privacy
+	Presence          usecase.Presence
+	Image             usecase.Image
+	Admin             usecase.Admin
+	Report            usecase.Report
+	Conversation      usecase.Conversation
+	Contact           usecase.Contact
+	Message           usecase.Message
+	GroupChat         usecase.GroupChat
+	UserProfile       usecase.UserProfile
+	Reaction          usecase.Reaction
 }
 
 // NewRouter -.
@@ -32,7 +51,7 @@ type RouterUseCases struct {
 // @version     1.0
 // @host        localhost:8080
 // @BasePath    /v1
-func NewRouter(handler *gin.Engine, l logger.Interface, uc RouterUseCases) {
+func NewRouter(handler *gin.Engine, l logger.Interface, uc RouterUseCases, signer *jwtsigner.Signer, maxImageUploadSize int64) {
 	// Options
 	handler.Use(gin.Logger())
 	handler.Use(gin.Recovery())
@@ -49,22 +68,67 @@ func NewRouter(handler *gin.Engine, l logger.Interface, uc RouterUseCases) {
 	// Prometheus metrics
 	handler.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	// Public keys for other services to verify access tokens
+	newJWKSRoute(handler, signer)
+
+	// Initialize a hub and run it with a new thread for websocket connection
+	hub := NewHub()
+	go hub.Run()
+	// Push the users who became away or offline to their contacts
+	go runPresenceSweep(hub, uc.Presence, l)
+	// Clear the expired custom statuses and push the removals to the contacts
+	go runStatusExpiry(hub, uc.UserProfile, l)
+
 	publicHandler := handler.Group("")
 	{
 		// Home route
 		handler.GET("/", serveHome)
-		newUserVerificationRoute(publicHandler, uc.Verification, l)
+		newUserVerificationRoute(publicHandler, uc.Verification, uc.Session, uc.EmailVerification, uc.MFA, uc.LoginThrottle, hub, signer, l)
+		newPasswordRoute(publicHandler, uc.PasswordReset, l)
+		newImageRoute(publicHandler, uc.Image, l)
+		newEmailVerificationRoute(publicHandler, uc.EmailVerification, l)
+		newEmailChangeRoute(publicHandler, uc.Credentials, l)
+		// Single sign-on is optional and only served if an identity provider is configured
+		if uc.SSO != nil {
+			newSSORoute(publicHandler, uc.SSO, uc.Session, uc.MFA, uc.LoginThrottle, signer, l)
+		}
+	}
+
+	// Routers that can be called without a valid access token
+	publicV1Handler := handler.Group("/v1")
+	{
+		newTokenRoute(publicV1Handler, uc.Session, signer, l)
 	}
 
 	// Routers
 	protectedHandler := handler.Group("/v1")
-	protectedHandler.Use(authMiddleware)
+	protectedHandler.Use(authMiddleware(signer, uc.Session, uc.Admin, uc.Bot))
 	{
-		newConversationRoute(protectedHandler, uc.Conversation, uc.UserProfile, uc.Message, uc.Reaction, l)
-		newContactRoute(protectedHandler, uc.Contact, l)
+		newConversationRoute(protectedHandler, hub, uc.Conversation, uc.UserProfile, uc.Message, uc.Reaction, uc.Presence, uc.Report, l)
+		newContactRoute(protectedHandler, uc.Contact, hub, l)
 		newMessageRoute(protectedHandler, uc.Message, l)
 		newGroupChatRoute(protectedHandler, uc.GroupChat, l)
-		newUserProfile(protectedHandler, uc.UserProfile, l)
+		newUserProfile(protectedHandler, uc.UserProfile, hub, l)
+		newMFARoute(protectedHandler, uc.MFA, l)
+		newSessionRoute(protectedHandler, uc.Session, hub, l)
+		newCredentialsRoute(protectedHandler, uc.Credentials, hub, l)
+		newAccountRoute(protectedHandler, uc.AccountDeletion, hub, l)
+		newDataExportRoute(protectedHandler, uc.DataExport, l)
+		newBotRoute(protectedHandler, uc.Bot, l)
+		newUserSearchRoute(protectedHandler, uc.UserSearch, l)
+		newContactSuggestionRoute(protectedHandler, uc.ContactSuggestion, l)
+		newPrivacyRoute(protectedHandler, uc.Privacy, l)
+		newPresenceRoute(protectedHandler, uc.Presence, l)
+		newImageUploadRoute(protectedHandler, uc.Image, maxImageUploadSize, l)
+		newAdminRoute(protectedHandler, uc.Admin, hub, l)
+		newReportRoute(protectedHandler, uc.Report, l)
+	}
+
+	// Routers that bots call with an API key
+	botHandler := handler.Group("/v1")
+	botHandler.Use(authMiddleware(signer, uc.Session, uc.Admin, uc.Bot, entity.ScopeMessagesWrite))
+	{
+		newBotMessageRoute(botHandler, uc.Bot, uc.Conversation, uc.UserProfile, hub, l)
 	}
 
 }
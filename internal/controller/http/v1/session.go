@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type sessionRoute struct {
+	s   usecase.Session
+	hub *Hub
+	l   logger.Interface
+}
+
+// Handles api routes for active session functionality
+func newSessionRoute(handler *gin.RouterGroup, s usecase.Session, hub *Hub, l logger.Interface) {
+	route := &sessionRoute{s, hub, l}
+
+	// Group the routes under the "/user/sessions" path.
+	h := handler.Group("/user/sessions")
+	{
+		// Define the endpoints for the active session functionality.
+		h.GET("", route.getSessions)
+		h.DELETE("/:sessionId", route.revokeSession)
+		h.POST("/revoke-others", route.revokeOtherSessions)
+	}
+}
+
+// getSessions lists the devices the user is currently logged in on.
+func (r *sessionRoute) getSessions(c *gin.Context) {
+	// Get user_uuid and session_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	sessionUUID, _ := getSessionUUIDFromContext(c)
+
+	// Call ListSessions method from session entity object
+	sessions, err := r.s.ListSessions(c.Request.Context(), userUUID, sessionUUID)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - getSessions - ListSessions")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the sessions as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToSessionsResponse(sessions))
+}
+
+// revokeSession logs out one of the user's sessions and closes its websocket connections.
+func (r *sessionRoute) revokeSession(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get sessionId from URL parameter
+	sessionUUID := c.Param("sessionId")
+
+	// Call RevokeUserSession method from session entity object
+	err = r.s.RevokeUserSession(c.Request.Context(), userUUID, sessionUUID)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - revokeSession - RevokeUserSession")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// The access token of a revoked session is rejected on the next request, but open websockets have to be closed
+	r.hub.Disconnect <- sessionUUID
+
+	// Return a "No Content" status code to indicate the session was revoked.
+	c.Writer.WriteHeader(http.StatusNoContent)
+}
+
+// revokeOtherSessions logs out every session of the user except the one making the request.
+func (r *sessionRoute) revokeOtherSessions(c *gin.Context) {
+	// Get user_uuid and session_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	sessionUUID, err := getSessionUUIDFromContext(c)
+	if err != nil {
+		// Without the current session every session would be revoked, including the one making the request
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Call RevokeOtherSessions method from session entity object
+	revoked, err := r.s.RevokeOtherSessions(c.Request.Context(), userUUID, sessionUUID)
+
+	// Close the websockets of the sessions that were revoked, even if revoking the rest failed
+	for _, revokedSessionUUID := range revoked {
+		r.hub.Disconnect <- revokedSessionUUID
+	}
+
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - revokeOtherSessions - RevokeOtherSessions")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the revoked sessions as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.RevokedSessionsResponse{RevokedSessions: revoked})
+}
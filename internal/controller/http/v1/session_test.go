@@ -0,0 +1,186 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+// registerTestClient adds a client of the given session to the hub, without a websocket connection
+func registerTestClient(hub *Hub, conversationUUID string, sessionUUID string) *Client {
+	client := NewClient(conversationUUID, entity.UserProfile{UserUUID: "some-uuid"}, nil, hub, nil)
+	client.SessionUUID = sessionUUID
+	hub.Register <- client
+	return client
+}
+
+// assertClientDisconnected checks that the hub closed the client's send channel
+func assertClientDisconnected(t *testing.T, client *Client, want bool) {
+	select {
+	case _, ok := <-client.send:
+		assert.Equal(t, want, !ok)
+	case <-time.After(100 * time.Millisecond):
+		assert.False(t, want, "client was not disconnected")
+	}
+}
+
+func TestGetSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionUsecase := mocks.NewMockSession(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	r := &sessionRoute{s: mockSessionUsecase, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Set("session_uuid", "current-session")
+		c.Next()
+	})
+	router.GET("/user/sessions", r.getSessions)
+
+	t.Run("Success", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().ListSessions(gomock.Any(), "some-uuid", "current-session").Return([]entity.SessionInfo{
+			{SessionUUID: "current-session", DeviceName: "laptop", IP: "192.0.2.1", Current: true},
+			{SessionUUID: "other-session", DeviceName: "phone", IP: "192.0.2.2"},
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/user/sessions", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response boundary.SessionsResponse
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Sessions, 2)
+		assert.True(t, response.Sessions[0].Current)
+		assert.Equal(t, "phone", response.Sessions[1].DeviceName)
+	})
+
+	t.Run("EntityObjectFailure", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().ListSessions(gomock.Any(), "some-uuid", "current-session").Return(nil, errors.New("test_error"))
+
+		req, _ := http.NewRequest(http.MethodGet, "/user/sessions", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionUsecase := mocks.NewMockSession(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	hub := NewHub()
+	go hub.Run()
+
+	r := &sessionRoute{s: mockSessionUsecase, hub: hub, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Set("session_uuid", "current-session")
+		c.Next()
+	})
+	router.DELETE("/user/sessions/:sessionId", r.revokeSession)
+
+	t.Run("Success", func(t *testing.T) {
+		revokedClient := registerTestClient(hub, "conv-uuid", "other-session")
+		currentClient := registerTestClient(hub, "conv-uuid", "current-session")
+
+		mockSessionUsecase.EXPECT().RevokeUserSession(gomock.Any(), "some-uuid", "other-session").Return(nil)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/user/sessions/other-session", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		// Only the websocket of the revoked session is closed
+		assertClientDisconnected(t, revokedClient, true)
+		assertClientDisconnected(t, currentClient, false)
+	})
+
+	t.Run("SessionNotFound", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().RevokeUserSession(gomock.Any(), "some-uuid", "unknown-session").Return(entity.ErrSessionNotFound)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/user/sessions/unknown-session", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestRevokeOtherSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionUsecase := mocks.NewMockSession(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	hub := NewHub()
+	go hub.Run()
+
+	r := &sessionRoute{s: mockSessionUsecase, hub: hub, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Set("session_uuid", "current-session")
+		c.Next()
+	})
+	router.POST("/user/sessions/revoke-others", r.revokeOtherSessions)
+
+	t.Run("Success", func(t *testing.T) {
+		phoneClient := registerTestClient(hub, "conv-uuid", "phone-session")
+		tabletClient := registerTestClient(hub, "other-conv-uuid", "tablet-session")
+		currentClient := registerTestClient(hub, "conv-uuid", "current-session")
+
+		mockSessionUsecase.EXPECT().RevokeOtherSessions(gomock.Any(), "some-uuid", "current-session").
+			Return([]string{"phone-session", "tablet-session"}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/sessions/revoke-others", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "tablet-session")
+
+		assertClientDisconnected(t, phoneClient, true)
+		assertClientDisconnected(t, tabletClient, true)
+		assertClientDisconnected(t, currentClient, false)
+	})
+
+	t.Run("EntityObjectFailure", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().RevokeOtherSessions(gomock.Any(), "some-uuid", "current-session").
+			Return(nil, errors.New("test_error"))
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/sessions/revoke-others", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,125 @@
+package v1
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/jwtsigner"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+const (
+	_ssoStateCookie = "sso_state"
+	_ssoDeviceName  = "Single sign-on"
+)
+
+type ssoRoutes struct {
+	sso    usecase.SSO
+	s      usecase.Session
+	m      usecase.MFA
+	lt     usecase.LoginThrottle
+	signer *jwtsigner.Signer
+	l      logger.Interface
+}
+
+// Handles api routes for single sign-on functionality
+func newSSORoute(handler *gin.RouterGroup, sso usecase.SSO, s usecase.Session, m usecase.MFA, lt usecase.LoginThrottle,
+	signer *jwtsigner.Signer, l logger.Interface) {
+	r := &ssoRoutes{sso, s, m, lt, signer, l}
+
+	// Group the routes under the "/user/sso" path.
+	h := handler.Group("/user/sso")
+	{
+		// Define the endpoints for the single sign-on functionality.
+		h.GET("/login", r.beginLogin)
+		h.GET("/callback", r.callback)
+	}
+}
+
+// beginLogin redirects the browser to the identity provider.
+func (r *ssoRoutes) beginLogin(c *gin.Context) {
+	// Call BeginLogin method from sso entity object
+	login, err := r.sso.BeginLogin(c.Request.Context())
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - beginLogin - BeginLogin")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Bind the state to this browser, so that a callback started by someone else is refused
+	r.setStateCookie(c, login.State, 0)
+
+	c.Redirect(http.StatusFound, login.AuthURL)
+}
+
+// callback completes the login after the identity provider redirected the browser back.
+func (r *ssoRoutes) callback(c *gin.Context) {
+	state := c.Query("state")
+	cookieState, _ := c.Cookie(_ssoStateCookie)
+
+	// The state cookie is single use
+	r.setStateCookie(c, "", -1)
+
+	// The identity provider reports a denied or failed login with an 'error' query parameter
+	if providerErr := c.Query("error"); providerErr != "" {
+		r.l.Error(fmt.Errorf("identity provider error: %s", providerErr), "http - v1 - callback")
+		handleCustomErrors(c, entity.ErrSSOLoginFailed)
+		return
+	}
+
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(cookieState)) != 1 {
+		handleCustomErrors(c, entity.ErrInvalidSSOState)
+		return
+	}
+
+	// Call CompleteLogin method from sso entity object
+	userUUID, err := r.sso.CompleteLogin(c.Request.Context(), state, c.Query("code"))
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - callback - CompleteLogin")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Refuse the login if the user or the client IP is locked, keyed the same as the second factor step
+	if !checkLoginThrottle(c, r.lt, r.l, entity.LoginAttempt{Identifier: userUUID, IP: c.ClientIP()}) {
+		return
+	}
+
+	// Call IsEnabled method from mfa entity object
+	mfaEnabled, err := r.m.IsEnabled(c.Request.Context(), userUUID)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - callback - IsEnabled")
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// The identity provider doesn't replace the authenticator the user enrolled here, so users with
+	// two-factor authentication only get a pending token to be exchanged at '/user/login/mfa'
+	if mfaEnabled {
+		issueMFAToken(c, r.signer, r.l, userUUID)
+		return
+	}
+
+	issueSessionTokens(c, r.s, r.signer, r.l, userUUID, _ssoDeviceName)
+}
+
+// setStateCookie stores the login state in an HttpOnly cookie, a negative maxAge deletes it
+func (r *ssoRoutes) setStateCookie(c *gin.Context, state string, maxAge int) {
+	secure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(_ssoStateCookie, state, maxAge, "/user/sso", "", secure, true)
+}
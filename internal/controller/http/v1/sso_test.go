@@ -0,0 +1,245 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+	"github.com/maxyong7/chat-messaging-app/pkg/oidc"
+)
+
+func TestSSOBeginLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSSOUsecase := mocks.NewMockSSO(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	r := &ssoRoutes{sso: mockSSOUsecase, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/user/sso/login", r.beginLogin)
+
+	t.Run("Success", func(t *testing.T) {
+		mockSSOUsecase.EXPECT().BeginLogin(gomock.Any()).Return(entity.SSOLogin{
+			AuthURL: "https://idp.example.com/authorize?state=some-state",
+			State:   "some-state",
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/user/sso/login", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "https://idp.example.com/authorize?state=some-state", w.Header().Get("Location"))
+
+		cookies := w.Result().Cookies()
+		assert.Len(t, cookies, 1)
+		assert.Equal(t, _ssoStateCookie, cookies[0].Name)
+		assert.Equal(t, "some-state", cookies[0].Value)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+	})
+
+	t.Run("EntityObjectFailure", func(t *testing.T) {
+		mockSSOUsecase.EXPECT().BeginLogin(gomock.Any()).Return(entity.SSOLogin{}, errors.New("test_error"))
+
+		req, _ := http.NewRequest(http.MethodGet, "/user/sso/login", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestSSOCallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSSOUsecase := mocks.NewMockSSO(ctrl)
+	mockSessionUsecase := mocks.NewMockSession(ctrl)
+	mockMFAUsecase := mocks.NewMockMFA(ctrl)
+	mockThrottleUsecase := mocks.NewMockLoginThrottle(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	r := &ssoRoutes{sso: mockSSOUsecase, s: mockSessionUsecase, m: mockMFAUsecase, lt: mockThrottleUsecase, signer: testSigner, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/user/sso/callback", r.callback)
+
+	newCallbackRequest := func(query string, cookieState string) *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, "/user/sso/callback?"+query, nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		if cookieState != "" {
+			req.AddCookie(&http.Cookie{Name: _ssoStateCookie, Value: cookieState})
+		}
+		return req
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockSSOUsecase.EXPECT().CompleteLogin(gomock.Any(), "some-state", "some-code").Return("some-uuid", nil)
+		mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), entity.LoginAttempt{Identifier: "some-uuid", IP: "192.0.2.1"}).Return(time.Duration(0), nil)
+		mockMFAUsecase.EXPECT().IsEnabled(gomock.Any(), "some-uuid").Return(false, nil)
+		mockSessionUsecase.EXPECT().
+			CreateSession(gomock.Any(), "some-uuid", entity.SessionDevice{DeviceName: _ssoDeviceName, IP: "192.0.2.1"}).
+			Return(testSession, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newCallbackRequest("state=some-state&code=some-code", "some-state"))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), testSession.RefreshToken)
+	})
+
+	t.Run("MFARequired", func(t *testing.T) {
+		mockSSOUsecase.EXPECT().CompleteLogin(gomock.Any(), "some-state", "some-code").Return("some-uuid", nil)
+		mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), entity.LoginAttempt{Identifier: "some-uuid", IP: "192.0.2.1"}).Return(time.Duration(0), nil)
+		mockMFAUsecase.EXPECT().IsEnabled(gomock.Any(), "some-uuid").Return(true, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newCallbackRequest("state=some-state&code=some-code", "some-state"))
+
+		// No session is created until the second factor is verified
+		var response boundary.MFAChallengeScreen
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.MFARequired)
+		assert.NotContains(t, w.Body.String(), "refresh_token")
+
+		// The pending token is the one the second factor step accepts
+		userUUID, err := parseMFAToken(testSigner, response.MFAToken)
+		assert.NoError(t, err)
+		assert.Equal(t, "some-uuid", userUUID)
+	})
+
+	t.Run("AccountLocked", func(t *testing.T) {
+		mockSSOUsecase.EXPECT().CompleteLogin(gomock.Any(), "some-state", "some-code").Return("some-uuid", nil)
+		mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), entity.LoginAttempt{Identifier: "some-uuid", IP: "192.0.2.1"}).Return(90*time.Second, entity.ErrAccountLocked)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newCallbackRequest("state=some-state&code=some-code", "some-state"))
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "90", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("AccountSuspended", func(t *testing.T) {
+		mockSSOUsecase.EXPECT().CompleteLogin(gomock.Any(), "some-state", "some-code").Return("", entity.ErrAccountSuspended)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newCallbackRequest("state=some-state&code=some-code", "some-state"))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("StateCookieMismatch", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newCallbackRequest("state=some-state&code=some-code", "other-state"))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("MissingStateCookie", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newCallbackRequest("state=some-state&code=some-code", ""))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ProviderError", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newCallbackRequest("state=some-state&error=access_denied", "some-state"))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("LoginFailed", func(t *testing.T) {
+		mockSSOUsecase.EXPECT().CompleteLogin(gomock.Any(), "some-state", "some-code").Return("", entity.ErrSSOLoginFailed)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newCallbackRequest("state=some-state&code=some-code", "some-state"))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("UnverifiedEmailOfExistingUser", func(t *testing.T) {
+		mockSSOUsecase.EXPECT().CompleteLogin(gomock.Any(), "some-state", "some-code").Return("", entity.ErrUserAlreadyExists)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newCallbackRequest("state=some-state&code=some-code", "some-state"))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+// The errors of the sso entity object are mapped by equality, so the callback is also tested with the real entity object
+func TestSSOCallbackWithUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProvider := mocks.NewMockIdentityProvider(ctrl)
+	mockSSORepo := mocks.NewMockSSORepo(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+	sso := usecase.NewSSO(mockProvider, mockSSORepo, nil, time.Minute, false, mockLogger)
+
+	r := &ssoRoutes{sso: sso, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/user/sso/callback", r.callback)
+
+	newCallbackRequest := func() *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, "/user/sso/callback?state=some-state&code=some-code", nil)
+		req.AddCookie(&http.Cookie{Name: _ssoStateCookie, Value: "some-state"})
+		return req
+	}
+	loginState := &entity.SSOLoginStateDTO{Nonce: "some-nonce", CodeVerifier: "some-verifier", ExpiresAt: time.Now().Add(time.Minute)}
+
+	t.Run("CodeRejectedByProvider", func(t *testing.T) {
+		mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), gomock.Any()).Return(loginState, nil)
+		mockProvider.EXPECT().Exchange(gomock.Any(), "some-code", "some-verifier", "some-nonce").
+			Return(nil, fmt.Errorf("oidc - Exchange - status 400: %w", oidc.ErrTokenExchange))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newCallbackRequest())
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("InvalidIDToken", func(t *testing.T) {
+		mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), gomock.Any()).Return(loginState, nil)
+		mockProvider.EXPECT().Exchange(gomock.Any(), "some-code", "some-verifier", "some-nonce").
+			Return(nil, fmt.Errorf("oidc - VerifyIDToken - nonce mismatch: %w", oidc.ErrInvalidIDToken))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newCallbackRequest())
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("NoEmailClaim", func(t *testing.T) {
+		mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), gomock.Any()).Return(loginState, nil)
+		mockProvider.EXPECT().Exchange(gomock.Any(), "some-code", "some-verifier", "some-nonce").
+			Return(&oidc.Claims{Subject: "provider-subject"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newCallbackRequest())
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/jwtsigner"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type tokenRoute struct {
+	s      usecase.Session
+	signer *jwtsigner.Signer
+	l      logger.Interface
+}
+
+// Handles api routes for token functionality
+func newTokenRoute(handler *gin.RouterGroup, s usecase.Session, signer *jwtsigner.Signer, l logger.Interface) {
+	route := &tokenRoute{s, signer, l}
+
+	// Group the routes under the "/user/token" path.
+	h := handler.Group("/user/token")
+	{
+		// Define the endpoints for the token functionality.
+		h.POST("/refresh", route.refreshToken)
+	}
+}
+
+// refreshToken rotates the refresh token and issues a new access token for the same session.
+func (r *tokenRoute) refreshToken(c *gin.Context) {
+	// Bind the incoming JSON request body to the RefreshTokenForm struct.
+	var request boundary.RefreshTokenForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - refreshToken")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call RefreshSession method from session entity object
+	session, err := r.s.RefreshSession(c.Request.Context(), request.RefreshToken, sessionDevice(c, ""))
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - refreshToken - RefreshSession")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	token, err := createToken(r.signer, session)
+	if err != nil {
+		// If an error occurs while creating the token, logs error message
+		r.l.Error(err, "http - v1 - createToken")
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the rotated tokens as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToLoginScreen(token, session))
+}
@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/jwtsigner"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+var testSession = entity.Session{
+	SessionUUID:           "session-uuid",
+	UserUUID:              "some-uuid",
+	AccessTokenID:         "access-token-id",
+	AccessTokenExpiresAt:  time.Now().Add(15 * time.Minute),
+	RefreshToken:          "new-refresh-token",
+	RefreshTokenExpiresAt: time.Now().Add(24 * time.Hour),
+}
+
+var testSigner = newTestSigner()
+
+func newTestSigner() *jwtsigner.Signer {
+	key, err := jwtsigner.NewKey("test-hs256", jwtsigner.HS256, []byte("test-secret"))
+	if err != nil {
+		panic(err)
+	}
+	signer, err := jwtsigner.New(key.ID, key)
+	if err != nil {
+		panic(err)
+	}
+	return signer
+}
+
+func TestRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionUsecase := mocks.NewMockSession(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	r := &tokenRoute{s: mockSessionUsecase, signer: testSigner, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/user/token/refresh", r.refreshToken)
+
+	t.Run("Success", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().RefreshSession(gomock.Any(), "old-refresh-token", gomock.Any()).Return(testSession, nil)
+
+		requestBody := `{"refresh_token": "old-refresh-token"}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/token/refresh", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), testSession.RefreshToken)
+	})
+
+	t.Run("InvalidRequestBody", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/user/token/refresh", strings.NewReader(`{"refresh_token": }`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ReusedRefreshToken", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().RefreshSession(gomock.Any(), "old-refresh-token", gomock.Any()).Return(entity.Session{}, entity.ErrRefreshTokenReused)
+
+		requestBody := `{"refresh_token": "old-refresh-token"}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/token/refresh", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("EntityObjectFailure", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().RefreshSession(gomock.Any(), "old-refresh-token", gomock.Any()).Return(entity.Session{}, errors.New("test_error"))
+
+		requestBody := `{"refresh_token": "old-refresh-token"}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/token/refresh", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionUsecase := mocks.NewMockSession(ctrl)
+	mockAdminUsecase := mocks.NewMockAdmin(ctrl)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(authMiddleware(testSigner, mockSessionUsecase, mockAdminUsecase, nil))
+	router.GET("/protected", func(c *gin.Context) {
+		userUUID, _ := getUserUUIDFromContext(c)
+		c.String(http.StatusOK, userUUID)
+	})
+
+	token, err := createToken(testSigner, testSession)
+	assert.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().IsTokenRevoked(gomock.Any(), testSession.AccessTokenID).Return(false, nil)
+		mockAdminUsecase.EXPECT().GetUserAccess(gomock.Any(), testSession.UserUUID).Return(entity.UserAccess{Role: entity.RoleUser}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, testSession.UserUUID, w.Body.String())
+	})
+
+	t.Run("SuspendedUser", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().IsTokenRevoked(gomock.Any(), testSession.AccessTokenID).Return(false, nil)
+		mockAdminUsecase.EXPECT().GetUserAccess(gomock.Any(), testSession.UserUUID).Return(entity.UserAccess{Role: entity.RoleUser, Suspended: true}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), entity.ErrAccountSuspended.Error())
+	})
+
+	t.Run("DeletedUser", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().IsTokenRevoked(gomock.Any(), testSession.AccessTokenID).Return(false, nil)
+		mockAdminUsecase.EXPECT().GetUserAccess(gomock.Any(), testSession.UserUUID).Return(entity.UserAccess{}, entity.ErrUserNotFound)
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("RevokedToken", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().IsTokenRevoked(gomock.Any(), testSession.AccessTokenID).Return(true, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("ExpiredToken", func(t *testing.T) {
+		expiredSession := testSession
+		expiredSession.AccessTokenExpiresAt = time.Now().Add(-time.Minute)
+		expiredToken, err := createToken(testSigner, expiredSession)
+		assert.NoError(t, err)
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+expiredToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("MFAPendingToken", func(t *testing.T) {
+		mfaToken, err := createMFAToken(testSigner, testSession.UserUUID, time.Now().Add(time.Minute))
+		assert.NoError(t, err)
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+mfaToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("MissingToken", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+type userSearchRoutes struct {
+	us usecase.UserSearch
+	l  logger.Interface
+}
+
+// Handles api routes for the user directory
+func newUserSearchRoute(handler *gin.RouterGroup, us usecase.UserSearch, l logger.Interface) {
+	r := &userSearchRoutes{us, l}
+
+	// Group the routes under the "/users" path.
+	h := handler.Group("/users")
+	{
+		// Define the endpoints for the user directory functionality.
+		h.GET("/search", r.searchUsers)
+	}
+}
+
+// searchUsers returns a page of discoverable users matching the 'q' query parameter.
+func (r *userSearchRoutes) searchUsers(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get decoded 'cursor' value from URL query, it is empty for the first page
+	cursor, err := queryParamSearchCursor(c)
+	if err != nil {
+		r.l.Error(err, "http - v1 - searchUsers - cursor validation error")
+		errorResponse(c, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+
+	// Get 'limit' value from URL query, the entity object applies the default and the maximum
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	// Call SearchUsers method from user search entity object
+	page, err := r.us.SearchUsers(c.Request.Context(), entity.UserSearchQuery{
+		UserUUID: userUUID,
+		Query:    c.Query("q"),
+		Cursor:   cursor,
+		Limit:    limit,
+	})
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - searchUsers - SearchUsers")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	var encodedCursor string
+	if page.NextCursor != nil {
+		// Boundary object will later provide this value to get the next page
+		encodedCursor = encodeSearchCursor(*page.NextCursor)
+	}
+
+	// Return the results as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToUserSearchResponse(page, encodedCursor))
+}
+
+func queryParamSearchCursor(c *gin.Context) (*entity.UserSearchCursor, error) {
+	cursor := c.Query("cursor")
+	if cursor == "" {
+		return nil, nil
+	}
+
+	decodedCursor, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var cur entity.UserSearchCursor
+	if err := json.Unmarshal(decodedCursor, &cur); err != nil {
+		return nil, err
+	}
+	return &cur, nil
+}
+
+func encodeSearchCursor(cursor entity.UserSearchCursor) string {
+	serializedCursor, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.URLEncoding.EncodeToString(serializedCursor)
+}
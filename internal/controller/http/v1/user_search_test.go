@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+func TestUserSearchRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserSearchUsecase := mocks.NewMockUserSearch(ctrl)
+	mockPrivacyUsecase := mocks.NewMockPrivacy(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	protected := router.Group("/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	newUserSearchRoute(protected, mockUserSearchUsecase, mockLogger)
+	newPrivacyRoute(protected, mockPrivacyUsecase, mockLogger)
+
+	nextCursor := entity.UserSearchCursor{Score: 1.25, UserUUID: "user-2"}
+
+	t.Run("SearchUsers", func(t *testing.T) {
+		mockUserSearchUsecase.EXPECT().SearchUsers(gomock.Any(), entity.UserSearchQuery{
+			UserUUID: "some-uuid",
+			Query:    "john",
+			Limit:    2,
+		}).Return(entity.UserSearchPage{
+			Results:    []entity.UserSearchResult{{UserUUID: "user-1", Username: "johnny"}, {UserUUID: "user-2", Username: "johnson"}},
+			NextCursor: &nextCursor,
+			Limit:      2,
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/users/search?q=john&limit=2", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "johnny")
+		assert.Contains(t, w.Body.String(), encodeSearchCursor(nextCursor))
+	})
+
+	t.Run("SearchUsersNextPage", func(t *testing.T) {
+		mockUserSearchUsecase.EXPECT().SearchUsers(gomock.Any(), entity.UserSearchQuery{
+			UserUUID: "some-uuid",
+			Query:    "john",
+			Cursor:   &nextCursor,
+		}).Return(entity.UserSearchPage{Limit: 20}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/users/search?q=john&cursor="+encodeSearchCursor(nextCursor), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"users":[]`)
+		assert.Contains(t, w.Body.String(), `"cursor":""`)
+	})
+
+	t.Run("SearchUsersInvalidCursor", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/v1/users/search?q=john&cursor=not-a-cursor", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("SearchUsersQueryTooShort", func(t *testing.T) {
+		mockUserSearchUsecase.EXPECT().SearchUsers(gomock.Any(), gomock.Any()).Return(entity.UserSearchPage{}, entity.ErrInvalidSearchQuery)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/users/search?q=j", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("SearchUsersRateLimited", func(t *testing.T) {
+		mockUserSearchUsecase.EXPECT().SearchUsers(gomock.Any(), gomock.Any()).Return(entity.UserSearchPage{}, entity.ErrTooManyRequests)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/users/search?q=john", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+
+	t.Run("UpdatePrivacySettings", func(t *testing.T) {
+		mockPrivacyUsecase.EXPECT().UpdatePrivacySettings(gomock.Any(), "some-uuid", gomock.Any()).
+			DoAndReturn(func(_ interface{}, _ string, update entity.PrivacySettingsUpdate) (entity.PrivacySettings, error) {
+				assert.NotNil(t, update.Discoverable)
+				return entity.PrivacySettings{Discoverable: *update.Discoverable}, nil
+			})
+
+		req, _ := http.NewRequest(http.MethodPut, "/v1/user/privacy", bytes.NewBuffer([]byte(`{"discoverable":false}`)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"discoverable":false`)
+	})
+
+	t.Run("GetPrivacySettings", func(t *testing.T) {
+		mockPrivacyUsecase.EXPECT().GetPrivacySettings(gomock.Any(), "some-uuid").Return(entity.DefaultPrivacySettings(), nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/v1/user/privacy", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"discoverable":true`)
+		assert.Contains(t, w.Body.String(), `"who_can_add_contact":"everyone"`)
+	})
+
+	t.Run("UpdatePrivacySettingsInvalid", func(t *testing.T) {
+		mockPrivacyUsecase.EXPECT().UpdatePrivacySettings(gomock.Any(), "some-uuid", gomock.Any()).
+			Return(entity.PrivacySettings{}, entity.ErrInvalidPrivacySetting)
+
+		req, _ := http.NewRequest(http.MethodPut, "/v1/user/privacy", bytes.NewBuffer([]byte(`{"who_can_add_to_groups":"friends"}`)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -1,24 +1,32 @@
 package v1
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
 	"github.com/maxyong7/chat-messaging-app/internal/usecase"
 	"github.com/maxyong7/chat-messaging-app/pkg/logger"
 )
 
+// _statusExpiryInterval is how often expired custom statuses are cleared
+const _statusExpiryInterval = time.Minute
+
 type userProfileRoute struct {
-	t usecase.UserProfile
-	l logger.Interface
+	t   usecase.UserProfile
+	hub *Hub
+	l   logger.Interface
 }
 
 // Handles api routes for user profile functionality
-func newUserProfile(handler *gin.RouterGroup, t usecase.UserProfile, l logger.Interface) {
-	route := &userProfileRoute{t, l}
+// The hub is used to push status changes to the contacts of the user
+func newUserProfile(handler *gin.RouterGroup, t usecase.UserProfile, hub *Hub, l logger.Interface) {
+	route := &userProfileRoute{t, hub, l}
 
 	// Group the routes under the "/profile" path.
 	h := handler.Group("/profile")
@@ -26,6 +34,8 @@ func newUserProfile(handler *gin.RouterGroup, t usecase.UserProfile, l logger.In
 		// Define the endpoints for the user profile functionality.
 		h.GET("", route.getUserProfile)
 		h.PATCH("", route.updateUserProfile)
+		h.PUT("/status", route.setStatus)
+		h.DELETE("/status", route.clearStatus)
 	}
 }
 
@@ -89,3 +99,105 @@ func (r *userProfileRoute) updateUserProfile(c *gin.Context) {
 	// Return an "OK" status code to indicate the profile was successfully updated.
 	c.Writer.WriteHeader(http.StatusOK)
 }
+
+// setStatus replaces the user's custom status and pushes it to their contacts.
+func (r *userProfileRoute) setStatus(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Bind the incoming JSON request body to the SetStatusForm struct.
+	var request boundary.SetStatusForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - setStatus")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Call SetStatus method from user profile entity object
+	change, err := r.t.SetStatus(c.Request.Context(), userUUID, request.ToUserStatus())
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - setStatus - SetStatus")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+	notifyStatusChange(r.hub, change)
+
+	// Return the stored status as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, change.Status)
+}
+
+// clearStatus removes the user's custom status and pushes the removal to their contacts.
+func (r *userProfileRoute) clearStatus(c *gin.Context) {
+	// Get user_uuid from context
+	userUUID, err := getUserUUIDFromContext(c)
+	if err != nil {
+		// If the user UUID cannot be retrieved, return an unauthorized error response.
+		errorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Call ClearStatus method from user profile entity object
+	change, err := r.t.ClearStatus(c.Request.Context(), userUUID)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - clearStatus - ClearStatus")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+	notifyStatusChange(r.hub, change)
+
+	// Return a "No Content" status code to indicate the status was cleared.
+	c.Writer.WriteHeader(http.StatusNoContent)
+}
+
+// runStatusExpiry periodically clears the expired custom statuses and pushes the removals to the contacts of their users
+func runStatusExpiry(hub *Hub, t usecase.UserProfile, l logger.Interface) {
+	ticker := time.NewTicker(_statusExpiryInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		changes, err := t.ClearExpiredStatuses(context.Background())
+		if err != nil {
+			l.Error(err, "http - v1 - runStatusExpiry - ClearExpiredStatuses")
+		}
+		for _, change := range changes {
+			notifyStatusChange(hub, change)
+		}
+	}
+}
+
+// notifyStatusChange pushes the status to the websockets of the contacts of the user
+func notifyStatusChange(hub *Hub, change entity.StatusChange) {
+	if len(change.ContactUUIDs) == 0 {
+		return
+	}
+
+	hub.Notify <- Notification{
+		UserUUIDs: change.ContactUUIDs,
+		Message:   buildStatusResponse(change),
+	}
+}
+
+// Method to build status response body
+func buildStatusResponse(change entity.StatusChange) boundary.ConversationResponseModel {
+	return boundary.ConversationResponseModel{
+		MessageType: statusMessageType,
+		Data: boundary.ConversationResponseData{
+			SenderUUID:         change.UserUUID,
+			StatusResponseData: boundary.ToStatusResponseData(change.Status),
+		},
+	}
+}
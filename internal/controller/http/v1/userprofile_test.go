@@ -164,3 +164,70 @@ func TestUpdateUserProfile(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 }
+
+func TestSetStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUserProfile(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	hub := NewHub()
+	go hub.Run()
+	r := &userProfileRoute{t: mockUsecase, hub: hub, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_uuid", "some-uuid")
+		c.Next()
+	})
+	router.PUT("/profile/status", r.setStatus)
+	router.DELETE("/profile/status", r.clearStatus)
+
+	t.Run("Success", func(t *testing.T) {
+		contact := NewClient("other-conversation", entity.UserProfile{UserUUID: "contact-uuid"}, nil, hub, nil)
+		hub.Register <- contact
+
+		status := entity.UserStatus{Emoji: "📅", Text: "In a meeting"}
+		mockUsecase.EXPECT().SetStatus(gomock.Any(), "some-uuid", status).
+			Return(entity.StatusChange{UserUUID: "some-uuid", Status: &status, ContactUUIDs: []string{"contact-uuid"}}, nil)
+
+		req, _ := http.NewRequest(http.MethodPut, "/profile/status", strings.NewReader(`{"emoji": "📅", "text": "In a meeting"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response entity.UserStatus
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "In a meeting", response.Text)
+
+		// The status is pushed to the contact
+		message := <-contact.send
+		assert.Equal(t, statusMessageType, message.MessageType)
+		assert.Equal(t, "some-uuid", message.Data.SenderUUID)
+		assert.Equal(t, "In a meeting", message.Data.StatusText)
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		mockUsecase.EXPECT().SetStatus(gomock.Any(), "some-uuid", gomock.Any()).Return(entity.StatusChange{}, entity.ErrInvalidStatus)
+
+		req, _ := http.NewRequest(http.MethodPut, "/profile/status", strings.NewReader(`{"text": ""}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		mockUsecase.EXPECT().ClearStatus(gomock.Any(), "some-uuid").Return(entity.StatusChange{UserUUID: "some-uuid"}, nil)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/profile/status", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,6 +12,20 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
+
+	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/jwtsigner"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+)
+
+// Token types, set in the 'typ' claim
+const (
+	_accessTokenType = "access"
+	_mfaTokenType    = "mfa_pending"
+
+	_mfaTokenTTL = 5 * time.Minute
 )
 
 func getUserUUIDFromContext(c *gin.Context) (string, error) {
@@ -27,6 +42,27 @@ func getUserUUIDFromContext(c *gin.Context) (string, error) {
 	return userIDStr, nil
 }
 
+func getSessionUUIDFromContext(c *gin.Context) (string, error) {
+	sessionID, ok := c.Get("session_uuid")
+	if !ok {
+		return "", fmt.Errorf("session ID not found in context")
+	}
+	sessionIDStr, ok := sessionID.(string)
+	if !ok || sessionIDStr == "" {
+		return "", fmt.Errorf("session ID is invalid type")
+	}
+	return sessionIDStr, nil
+}
+
+// sessionDevice describes the client making the request, to be stored with its session
+func sessionDevice(c *gin.Context, deviceName string) entity.SessionDevice {
+	return entity.SessionDevice{
+		DeviceName: deviceName,
+		UserAgent:  c.Request.UserAgent(),
+		IP:         c.ClientIP(),
+	}
+}
+
 func queryParamInt(c *gin.Context, name string, defaultvalue int) int {
 	param := c.Param(name)
 	result, err := strconv.Atoi(param)
@@ -54,53 +90,253 @@ func queryParamCursor(c *gin.Context) (time.Time, error) {
 	return cur, nil
 }
 
-func authMiddleware(c *gin.Context) {
-	tokenString := c.GetHeader("Authorization")
-	if tokenString == "" {
-		tokenString = c.GetHeader("Sec-Websocket-Protocol")
+// authMiddleware validates the access token against the configured signing keys and rejects it if it has been revoked
+// or if its user has been suspended.
+// Bot API keys are accepted as well on routes that list the scopes they require, other routes only accept access tokens.
+func authMiddleware(signer *jwtsigner.Signer, s usecase.Session, a usecase.Admin, b usecase.Bot, scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := c.GetHeader("Authorization")
 		if tokenString == "" {
+			tokenString = c.GetHeader("Sec-Websocket-Protocol")
+			if tokenString == "" {
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+			tokenString = strings.Replace(tokenString, "access_token, ", "", 1)
+		} else {
+			tokenString = strings.Replace(tokenString, "Bearer ", "", 1)
+		}
+
+		if strings.HasPrefix(tokenString, entity.APIKeyPrefix) {
+			authenticateAPIKey(c, b, tokenString, scopes)
+			return
+		}
+
+		// The key is picked by the 'kid' header, so tokens signed with any configured key are accepted during rotation
+		token, err := signer.Parse(tokenString, jwt.MapClaims{})
+
+		if err != nil || !token.Valid {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		// Only access tokens are accepted, not the pending tokens of a login waiting for its second factor
+		if typ, _ := claims["typ"].(string); typ != _accessTokenType {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		userID, ok := claims["user_uuid"].(string)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		// Tokens without an id cannot be revoked, so they are not accepted
+		jti, ok := claims["jti"].(string)
+		if !ok || jti == "" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		// Reject access tokens that were revoked on logout
+		revoked, err := s.IsTokenRevoked(c.Request.Context(), jti)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		if revoked {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		// Reject users suspended by an admin, this also covers tokens refreshed while the suspension was being stored
+		access, err := a.GetUserAccess(c.Request.Context(), userID)
+		if err == entity.ErrUserNotFound {
 			c.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
-		tokenString = strings.Replace(tokenString, "access_token, ", "", 1)
-	} else {
-		tokenString = strings.Replace(tokenString, "Bearer ", "", 1)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		if access.Suspended {
+			errorResponse(c, http.StatusForbidden, entity.ErrAccountSuspended.Error())
+			return
+		}
+
+		sessionID, _ := claims["sid"].(string)
+
+		// c.Request.SetPathValue("user_uuid", userID)
+		c.Set("user_uuid", userID)
+		c.Set("session_uuid", sessionID)
+		c.Set("user_role", access.Role)
+		c.Next()
 	}
+}
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method")
+// requireRole only lets users with the given role through, it has to run after authMiddleware.
+// Bots never have a role, so their API keys are rejected as well.
+func requireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userRole, _ := c.Get("user_role")
+		if userRole != role {
+			errorResponse(c, http.StatusForbidden, entity.ErrInsufficientRole.Error())
+			return
 		}
-		return []byte("secret"), nil
-	})
+		c.Next()
+	}
+}
 
-	if err != nil || !token.Valid {
+// authenticateAPIKey lets a bot through if its API key was granted every scope the route requires
+func authenticateAPIKey(c *gin.Context, b usecase.Bot, key string, scopes []string) {
+	// Routes that do not list any scope are only for users
+	if b == nil || len(scopes) == 0 {
 		c.AbortWithStatus(http.StatusUnauthorized)
 		return
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
+	principal, err := b.AuthenticateAPIKey(c.Request.Context(), key)
+	if err == entity.ErrInvalidAPIKey {
 		c.AbortWithStatus(http.StatusUnauthorized)
 		return
 	}
+	if err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
 
-	userID, ok := claims["user_uuid"].(string)
+	for _, scope := range scopes {
+		if !principal.HasScope(scope) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+	}
+
+	c.Set("user_uuid", principal.BotUUID)
+	c.Set("api_key", principal)
+	c.Next()
+}
+
+func getAPIKeyFromContext(c *gin.Context) (entity.APIKeyPrincipal, bool) {
+	principal, ok := c.Get("api_key")
 	if !ok {
-		c.AbortWithStatus(http.StatusUnauthorized)
+		return entity.APIKeyPrincipal{}, false
+	}
+	apiKey, ok := principal.(entity.APIKeyPrincipal)
+	return apiKey, ok
+}
+
+// issueSessionTokens creates a new session for the user and returns its access and refresh token.
+// It is shared by every way of logging in.
+func issueSessionTokens(c *gin.Context, s usecase.Session, signer *jwtsigner.Signer, l logger.Interface, userUUID string, deviceName string) {
+	// Call CreateSession method from session entity object
+	session, err := s.CreateSession(c.Request.Context(), userUUID, sessionDevice(c, deviceName))
+	if err != nil {
+		// Logs error message
+		l.Error(err, "http - v1 - issueSessionTokens - CreateSession")
+		handleCustomErrors(c, err)
 		return
 	}
-	// c.Request.SetPathValue("user_uuid", userID)
-	c.Set("user_uuid", userID)
-	c.Next()
+
+	token, err := createToken(signer, session)
+	if err != nil {
+		// If an error occurs while creating the token, logs error message
+		l.Error(err, "http - v1 - createToken")
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the tokens as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToLoginScreen(token, session))
 }
 
-func createToken(userUuid string, expirationHour time.Duration) (string, error) {
+// issueMFAToken returns a short-lived token to be exchanged for a session once the second factor is verified.
+// It is shared by every way of logging in.
+func issueMFAToken(c *gin.Context, signer *jwtsigner.Signer, l logger.Interface, userUUID string) {
+	expiresAt := time.Now().Add(_mfaTokenTTL)
+	token, err := createMFAToken(signer, userUUID, expiresAt)
+	if err != nil {
+		// If an error occurs while creating the token, logs error message
+		l.Error(err, "http - v1 - createMFAToken")
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Return the pending token as JSON with a status code of 200 (OK).
+	c.JSON(http.StatusOK, boundary.ToMFAChallengeScreen(token, expiresAt))
+}
+
+// checkLoginThrottle writes a 429 response with a Retry-After header and returns false if the attempt is locked.
+func checkLoginThrottle(c *gin.Context, lt usecase.LoginThrottle, l logger.Interface, attempt entity.LoginAttempt) bool {
+	// Call CheckLogin method from login throttle entity object
+	retryAfter, err := lt.CheckLogin(c.Request.Context(), attempt)
+	if err != nil {
+		// Logs error message
+		l.Error(err, "http - v1 - checkLoginThrottle - CheckLogin")
+
+		if err == entity.ErrAccountLocked {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		}
+		handleCustomErrors(c, err)
+		return false
+	}
+	return true
+}
+
+// createToken signs an access token for the given session with the active signing key
+func createToken(signer *jwtsigner.Signer, session entity.Session) (string, error) {
+	claims := jwt.MapClaims{}
+	claims["user_uuid"] = session.UserUUID
+	claims["sid"] = session.SessionUUID
+	claims["jti"] = session.AccessTokenID
+	claims["typ"] = _accessTokenType
+	claims["iat"] = time.Now().Unix()
+	claims["exp"] = session.AccessTokenExpiresAt.Unix()
+	return signer.Sign(claims)
+}
+
+// createMFAToken signs a short-lived token proving the password was verified, to be exchanged for an access token
+// together with a second factor
+func createMFAToken(signer *jwtsigner.Signer, userUUID string, expiresAt time.Time) (string, error) {
 	claims := jwt.MapClaims{}
-	claims["user_uuid"] = userUuid
-	claims["exp"] = time.Now().Add(time.Hour * expirationHour).Unix()
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte("secret"))
+	claims["user_uuid"] = userUUID
+	claims["typ"] = _mfaTokenType
+	claims["iat"] = time.Now().Unix()
+	claims["exp"] = expiresAt.Unix()
+	return signer.Sign(claims)
+}
+
+// parseMFAToken validates a token created by createMFAToken and returns its user
+func parseMFAToken(signer *jwtsigner.Signer, tokenString string) (string, error) {
+	token, err := signer.Parse(tokenString, jwt.MapClaims{})
+	if err != nil || !token.Valid {
+		return "", entity.ErrInvalidMFAToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", entity.ErrInvalidMFAToken
+	}
+
+	if typ, _ := claims["typ"].(string); typ != _mfaTokenType {
+		return "", entity.ErrInvalidMFAToken
+	}
+
+	userID, ok := claims["user_uuid"].(string)
+	if !ok || userID == "" {
+		return "", entity.ErrInvalidMFAToken
+	}
+	return userID, nil
 }
 
 func encodeCursor(cursor *time.Time) string {
@@ -123,7 +359,7 @@ func CORSMiddleware() gin.HandlerFunc {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -7,24 +7,34 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/maxyong7/chat-messaging-app/internal/boundary"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
 	"github.com/maxyong7/chat-messaging-app/internal/usecase"
+	"github.com/maxyong7/chat-messaging-app/pkg/jwtsigner"
 	"github.com/maxyong7/chat-messaging-app/pkg/logger"
 )
 
 type userRoutes struct {
-	t usecase.User
-	l logger.Interface
+	t      usecase.User
+	s      usecase.Session
+	v      usecase.EmailVerification
+	m      usecase.MFA
+	lt     usecase.LoginThrottle
+	hub    *Hub
+	signer *jwtsigner.Signer
+	l      logger.Interface
 }
 
 // Handles api routes for user functionality
-func newUserVerificationRoute(handler *gin.RouterGroup, t usecase.User, l logger.Interface) {
-	r := &userRoutes{t, l}
+func newUserVerificationRoute(handler *gin.RouterGroup, t usecase.User, s usecase.Session, v usecase.EmailVerification, m usecase.MFA,
+	lt usecase.LoginThrottle, hub *Hub, signer *jwtsigner.Signer, l logger.Interface) {
+	r := &userRoutes{t, s, v, m, lt, hub, signer, l}
 
 	// Group the routes under the "/user" path.
 	h := handler.Group("/user")
 	{
 		// Define the endpoints for the user functionality.
 		h.POST("/login", r.loginUser)
+		h.POST("/login/mfa", r.loginUserMFA)
 		h.POST("/register", r.registerUser)
 		h.POST("/logout", r.logoutUser)
 	}
@@ -41,34 +51,50 @@ func (r *userRoutes) loginUser(c *gin.Context) {
 		return
 	}
 
+	// Refuse the attempt before comparing the password if the username or the client IP is locked
+	attempt := entity.LoginAttempt{Identifier: request.Username, IP: c.ClientIP()}
+	if !checkLoginThrottle(c, r.lt, r.l, attempt) {
+		return
+	}
+
 	// Verify user credentials by calling VerifyCredentials method from user entity object
 	userUuid, isValid, err := r.t.VerifyCredentials(c.Request.Context(), request.ToUserCredentials())
 	if err != nil {
 		// Logs error message
 		r.l.Error(err, "http - v1 - loginUser")
 
+		// Unknown usernames are counted too, so that probing for usernames is throttled as well
+		if err == entity.ErrIncorrectPassword || err == entity.ErrUserNotFound {
+			r.recordLoginFailure(c, attempt)
+		}
+
 		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
 		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
 		handleCustomErrors(c, err)
 		return
 	}
 
-	// If the credentials are valid and a user UUID is returned, create a session token.
+	// If the credentials are valid and a user UUID is returned, start a new session.
 	if isValid && userUuid != "" {
-		token, err := createToken(userUuid, 24)
-		if err != nil {
-			// If an error occurs while creating the token, logs error message
-			r.l.Error(err, "http - v1 - createToken")
+		// The password is correct, clear the failed attempts of the username
+		r.recordLoginSuccess(c, attempt)
 
-			// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
-			// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		// Call IsEnabled method from mfa entity object
+		mfaEnabled, err := r.m.IsEnabled(c.Request.Context(), userUuid)
+		if err != nil {
+			// Logs error message
+			r.l.Error(err, "http - v1 - loginUser - IsEnabled")
 			handleCustomErrors(c, err)
 			return
 		}
-		// Return the token as JSON with a status code of 200 (OK).
-		c.JSON(http.StatusOK, boundary.LogoutScreen{
-			Token: token,
-		})
+
+		// Users with two-factor authentication only get a pending token until they send their second factor
+		if mfaEnabled {
+			issueMFAToken(c, r.signer, r.l, userUuid)
+			return
+		}
+
+		issueSessionTokens(c, r.s, r.signer, r.l, userUuid, request.DeviceName)
 		return
 	}
 
@@ -77,6 +103,51 @@ func (r *userRoutes) loginUser(c *gin.Context) {
 	handleCustomErrors(c, err)
 }
 
+// loginUserMFA completes the login of users with two-factor authentication.
+func (r *userRoutes) loginUserMFA(c *gin.Context) {
+	// Bind the incoming JSON request body to the MFALoginForm struct.
+	var request boundary.MFALoginForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - loginUserMFA")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// The pending token proves the password was already verified
+	userUuid, err := parseMFAToken(r.signer, request.MFAToken)
+	if err != nil {
+		r.l.Error(err, "http - v1 - loginUserMFA - parseMFAToken")
+		handleCustomErrors(c, err)
+		return
+	}
+
+	// Codes are throttled per user, a new pending token must not give an attacker a fresh set of guesses
+	attempt := entity.LoginAttempt{Identifier: userUuid, IP: c.ClientIP()}
+	if !checkLoginThrottle(c, r.lt, r.l, attempt) {
+		return
+	}
+
+	// Verify the authenticator or recovery code by calling Verify method from mfa entity object
+	err = r.m.Verify(c.Request.Context(), userUuid, request.Code)
+	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - loginUserMFA - Verify")
+
+		if err == entity.ErrInvalidMFACode {
+			r.recordLoginFailure(c, attempt)
+		}
+
+		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
+		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
+		handleCustomErrors(c, err)
+		return
+	}
+
+	r.recordLoginSuccess(c, attempt)
+	issueSessionTokens(c, r.s, r.signer, r.l, userUuid, request.DeviceName)
+}
+
 // registerUser handles the registration process for new users.
 func (r *userRoutes) registerUser(c *gin.Context) {
 	// Bind the incoming JSON request body to the RegistrationForm struct.
@@ -100,24 +171,59 @@ func (r *userRoutes) registerUser(c *gin.Context) {
 		return
 	}
 
+	// Send the verification email. The account is already created, so a failure here is only logged
+	// and the user can ask for a new email through the resend endpoint.
+	err = r.v.SendVerificationEmail(c.Request.Context(), request.Email)
+	if err != nil {
+		r.l.Error(err, "http - v1 - registerUser - SendVerificationEmail")
+	}
+
 	// Return a "Created" status code to indicate successful registration.
 	c.Writer.WriteHeader(http.StatusCreated)
 }
 
 // logoutUser handles the logout process for users.
 func (r *userRoutes) logoutUser(c *gin.Context) {
-	// Invalidate the current session token by creating an expired token.
-	token, err := createToken("", 0)
+	// Bind the incoming JSON request body to the RefreshTokenForm struct.
+	var request boundary.RefreshTokenForm
+	if err := c.ShouldBindJSON(&request); err != nil {
+		// If the request body is invalid, log the error and return a bad request response.
+		r.l.Error(err, "http - v1 - logoutUser")
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	// Revoke the session the refresh token belongs to, including every access token issued from it.
+	sessionUUID, err := r.s.RevokeSession(c.Request.Context(), request.RefreshToken)
 	if err != nil {
+		// Logs error message
+		r.l.Error(err, "http - v1 - logoutUser - RevokeSession")
+
 		// If its a known defined error, it writes the status code and return a JSON body with error field accordingly.
 		// Else, it defaults to 500 status code and returns 'internal server error' in error field of the JSON body
-		r.l.Error(err, "http - v1 - createToken")
 		handleCustomErrors(c, err)
 		return
 	}
 
-	// Return the invalidated token as JSON with a status code of 200 (OK).
-	c.JSON(http.StatusOK, boundary.LogoutScreen{
-		Token: token,
-	})
+	// Close the websockets that were opened with the session
+	r.hub.Disconnect <- sessionUUID
+
+	// Return a "No Content" status code to indicate the session was revoked.
+	c.Writer.WriteHeader(http.StatusNoContent)
+}
+
+// recordLoginFailure counts a failed attempt. The response is already decided, so a failure here is only logged.
+func (r *userRoutes) recordLoginFailure(c *gin.Context, attempt entity.LoginAttempt) {
+	err := r.lt.RecordFailure(c.Request.Context(), attempt)
+	if err != nil {
+		r.l.Error(err, "http - v1 - recordLoginFailure - RecordFailure")
+	}
+}
+
+// recordLoginSuccess clears the failed attempts. A failure here is only logged so that it doesn't block the login.
+func (r *userRoutes) recordLoginSuccess(c *gin.Context, attempt entity.LoginAttempt) {
+	err := r.lt.RecordSuccess(c.Request.Context(), attempt)
+	if err != nil {
+		r.l.Error(err, "http - v1 - recordLoginSuccess - RecordSuccess")
+	}
 }
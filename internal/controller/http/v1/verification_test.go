@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -25,16 +27,23 @@ func TestLoginUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUsecase := mocks.NewMockUser(ctrl)
+	mockSessionUsecase := mocks.NewMockSession(ctrl)
+	mockMFAUsecase := mocks.NewMockMFA(ctrl)
+	mockThrottleUsecase := mocks.NewMockLoginThrottle(ctrl)
 	mockLogger := logger.New(logLevelDebug)
 
-	r := &userRoutes{t: mockUsecase, l: mockLogger}
+	r := &userRoutes{t: mockUsecase, s: mockSessionUsecase, m: mockMFAUsecase, lt: mockThrottleUsecase, signer: testSigner, l: mockLogger}
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	router.POST("/user/login", r.loginUser)
 
 	t.Run("Success", func(t *testing.T) {
+		mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), entity.LoginAttempt{Identifier: "testjohndoe", IP: "192.0.2.1"}).Return(time.Duration(0), nil)
 		mockUsecase.EXPECT().VerifyCredentials(gomock.Any(), gomock.Any()).Return("some-uuid", true, nil)
+		mockThrottleUsecase.EXPECT().RecordSuccess(gomock.Any(), entity.LoginAttempt{Identifier: "testjohndoe", IP: "192.0.2.1"}).Return(nil)
+		mockMFAUsecase.EXPECT().IsEnabled(gomock.Any(), "some-uuid").Return(false, nil)
+		mockSessionUsecase.EXPECT().CreateSession(gomock.Any(), "some-uuid", gomock.Any()).Return(testSession, nil)
 
 		request := boundary.LoginForm{
 			Username: "testjohndoe",
@@ -48,18 +57,62 @@ func TestLoginUser(t *testing.T) {
 
 		req, _ := http.NewRequest(http.MethodPost, "/user/login", &buf)
 		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
 		w := httptest.NewRecorder()
 
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Contains(t, w.Body.String(), "token")
+		assert.Contains(t, w.Body.String(), testSession.RefreshToken)
+	})
+
+	t.Run("MFARequired", func(t *testing.T) {
+		mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), entity.LoginAttempt{Identifier: "testjohndoe", IP: "192.0.2.1"}).Return(time.Duration(0), nil)
+		mockUsecase.EXPECT().VerifyCredentials(gomock.Any(), gomock.Any()).Return("some-uuid", true, nil)
+		mockThrottleUsecase.EXPECT().RecordSuccess(gomock.Any(), entity.LoginAttempt{Identifier: "testjohndoe", IP: "192.0.2.1"}).Return(nil)
+		mockMFAUsecase.EXPECT().IsEnabled(gomock.Any(), "some-uuid").Return(true, nil)
+
+		requestBody := `{"username": "testjohndoe", "password": "password123"}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/login", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		// No session is created until the second factor is verified
+		var response boundary.MFAChallengeScreen
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.MFARequired)
+		assert.NotEmpty(t, response.MFAToken)
+		assert.NotContains(t, w.Body.String(), "refresh_token")
+	})
+
+	t.Run("SessionCreationFailure", func(t *testing.T) {
+		mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), entity.LoginAttempt{Identifier: "testjohndoe", IP: "192.0.2.1"}).Return(time.Duration(0), nil)
+		mockUsecase.EXPECT().VerifyCredentials(gomock.Any(), gomock.Any()).Return("some-uuid", true, nil)
+		mockThrottleUsecase.EXPECT().RecordSuccess(gomock.Any(), entity.LoginAttempt{Identifier: "testjohndoe", IP: "192.0.2.1"}).Return(nil)
+		mockMFAUsecase.EXPECT().IsEnabled(gomock.Any(), "some-uuid").Return(false, nil)
+		mockSessionUsecase.EXPECT().CreateSession(gomock.Any(), "some-uuid", gomock.Any()).Return(entity.Session{}, errors.New("test_error"))
+
+		requestBody := `{"username": "testjohndoe", "password": "password123"}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/login", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
 	t.Run("InvalidRequestBody", func(t *testing.T) {
 		requestBody := `{"username": "testjohndoe", "password": }` // Invalid JSON
 		req, _ := http.NewRequest(http.MethodPost, "/user/login", strings.NewReader(requestBody))
 		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
 		w := httptest.NewRecorder()
 
 		router.ServeHTTP(w, req)
@@ -79,9 +132,12 @@ func TestLoginUser(t *testing.T) {
 			log.Fatal(err)
 		}
 
+		mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), entity.LoginAttempt{Identifier: "testjohndoe", IP: "192.0.2.1"}).Return(time.Duration(0), nil)
 		mockUsecase.EXPECT().VerifyCredentials(gomock.Any(), gomock.Any()).Return("", false, entity.ErrIncorrectPassword)
+		mockThrottleUsecase.EXPECT().RecordFailure(gomock.Any(), entity.LoginAttempt{Identifier: "testjohndoe", IP: "192.0.2.1"}).Return(nil)
 		req, _ := http.NewRequest(http.MethodPost, "/user/login", &buf)
 		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
 		w := httptest.NewRecorder()
 
 		router.ServeHTTP(w, req)
@@ -89,6 +145,21 @@ func TestLoginUser(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 
+	t.Run("EmailNotVerified", func(t *testing.T) {
+		mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), entity.LoginAttempt{Identifier: "testjohndoe", IP: "192.0.2.1"}).Return(time.Duration(0), nil)
+		mockUsecase.EXPECT().VerifyCredentials(gomock.Any(), gomock.Any()).Return("", false, entity.ErrEmailNotVerified)
+
+		requestBody := `{"username": "testjohndoe", "password": "password123"}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/login", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
 	t.Run("EntityObjectFailure", func(t *testing.T) {
 		request := boundary.LoginForm{
 			Username: "testjohndoe",
@@ -100,15 +171,116 @@ func TestLoginUser(t *testing.T) {
 			log.Fatal(err)
 		}
 
+		mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), entity.LoginAttempt{Identifier: "testjohndoe", IP: "192.0.2.1"}).Return(time.Duration(0), nil)
 		mockUsecase.EXPECT().VerifyCredentials(gomock.Any(), gomock.Any()).Return("", false, errors.New("test_error"))
 		req, _ := http.NewRequest(http.MethodPost, "/user/login", &buf)
 		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
 		w := httptest.NewRecorder()
 
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
+
+	t.Run("AccountLocked", func(t *testing.T) {
+		// The password is not checked while the account is locked
+		mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), entity.LoginAttempt{Identifier: "testjohndoe", IP: "192.0.2.1"}).Return(90*time.Second, entity.ErrAccountLocked)
+
+		requestBody := `{"username": "testjohndoe", "password": "password123"}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/login", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "90", w.Header().Get("Retry-After"))
+	})
+}
+
+func TestLoginUserMFA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionUsecase := mocks.NewMockSession(ctrl)
+	mockMFAUsecase := mocks.NewMockMFA(ctrl)
+	mockThrottleUsecase := mocks.NewMockLoginThrottle(ctrl)
+	mockLogger := logger.New(logLevelDebug)
+
+	r := &userRoutes{s: mockSessionUsecase, m: mockMFAUsecase, lt: mockThrottleUsecase, signer: testSigner, l: mockLogger}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/user/login/mfa", r.loginUserMFA)
+
+	mfaToken, err := createMFAToken(testSigner, "some-uuid", time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), entity.LoginAttempt{Identifier: "some-uuid", IP: "192.0.2.1"}).Return(time.Duration(0), nil)
+		mockMFAUsecase.EXPECT().Verify(gomock.Any(), "some-uuid", "123456").Return(nil)
+		mockThrottleUsecase.EXPECT().RecordSuccess(gomock.Any(), entity.LoginAttempt{Identifier: "some-uuid", IP: "192.0.2.1"}).Return(nil)
+		mockSessionUsecase.EXPECT().CreateSession(gomock.Any(), "some-uuid", gomock.Any()).Return(testSession, nil)
+
+		requestBody := fmt.Sprintf(`{"mfa_token": "%s", "code": "123456"}`, mfaToken)
+		req, _ := http.NewRequest(http.MethodPost, "/user/login/mfa", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), testSession.RefreshToken)
+	})
+
+	t.Run("InvalidCode", func(t *testing.T) {
+		mockThrottleUsecase.EXPECT().CheckLogin(gomock.Any(), entity.LoginAttempt{Identifier: "some-uuid", IP: "192.0.2.1"}).Return(time.Duration(0), nil)
+		mockMFAUsecase.EXPECT().Verify(gomock.Any(), "some-uuid", "000000").Return(entity.ErrInvalidMFACode)
+		mockThrottleUsecase.EXPECT().RecordFailure(gomock.Any(), entity.LoginAttempt{Identifier: "some-uuid", IP: "192.0.2.1"}).Return(nil)
+
+		requestBody := fmt.Sprintf(`{"mfa_token": "%s", "code": "000000"}`, mfaToken)
+		req, _ := http.NewRequest(http.MethodPost, "/user/login/mfa", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("AccessTokenIsNotAnMFAToken", func(t *testing.T) {
+		accessToken, err := createToken(testSigner, testSession)
+		assert.NoError(t, err)
+
+		requestBody := fmt.Sprintf(`{"mfa_token": "%s", "code": "123456"}`, accessToken)
+		req, _ := http.NewRequest(http.MethodPost, "/user/login/mfa", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("ExpiredMFAToken", func(t *testing.T) {
+		expiredToken, err := createMFAToken(testSigner, "some-uuid", time.Now().Add(-time.Minute))
+		assert.NoError(t, err)
+
+		requestBody := fmt.Sprintf(`{"mfa_token": "%s", "code": "123456"}`, expiredToken)
+		req, _ := http.NewRequest(http.MethodPost, "/user/login/mfa", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
 }
 
 func TestRegisterUser(t *testing.T) {
@@ -116,9 +288,10 @@ func TestRegisterUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUsecase := mocks.NewMockUser(ctrl)
+	mockVerificationUsecase := mocks.NewMockEmailVerification(ctrl)
 	mockLogger := logger.New(logLevelDebug)
 
-	r := &userRoutes{t: mockUsecase, l: mockLogger}
+	r := &userRoutes{t: mockUsecase, v: mockVerificationUsecase, l: mockLogger}
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -140,6 +313,33 @@ func TestRegisterUser(t *testing.T) {
 		}
 
 		mockUsecase.EXPECT().RegisterUser(gomock.Any(), gomock.Any()).Return(nil)
+		mockVerificationUsecase.EXPECT().SendVerificationEmail(gomock.Any(), "test@example.com").Return(nil)
+		req, _ := http.NewRequest(http.MethodPost, "/user/register", &buf)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("VerificationEmailFailure", func(t *testing.T) {
+		request := boundary.RegistrationForm{
+			Username:  "testjohndoe",
+			Password:  "password123",
+			Email:     "test@example.com",
+			FirstName: "John",
+			LastName:  "Doe",
+		}
+		var buf bytes.Buffer
+		err := json.NewEncoder(&buf).Encode(request)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// The account is created even if the verification email could not be sent
+		mockUsecase.EXPECT().RegisterUser(gomock.Any(), gomock.Any()).Return(nil)
+		mockVerificationUsecase.EXPECT().SendVerificationEmail(gomock.Any(), "test@example.com").Return(errors.New("test_error"))
 		req, _ := http.NewRequest(http.MethodPost, "/user/register", &buf)
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
@@ -191,35 +391,53 @@ func TestLogoutUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	mockSessionUsecase := mocks.NewMockSession(ctrl)
 	mockLogger := logger.New(logLevelDebug)
 
-	r := &userRoutes{l: mockLogger}
+	hub := NewHub()
+	go hub.Run()
+
+	r := &userRoutes{s: mockSessionUsecase, hub: hub, l: mockLogger}
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	router.POST("/user/logout", r.logoutUser)
 
 	t.Run("Success", func(t *testing.T) {
-		req, _ := http.NewRequest(http.MethodPost, "/user/logout", nil)
+		wsClient := registerTestClient(hub, "conv-uuid", "some-session")
+		mockSessionUsecase.EXPECT().RevokeSession(gomock.Any(), "some-refresh-token").Return("some-session", nil)
+
+		requestBody := `{"refresh_token": "some-refresh-token"}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/logout", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusOK, w.Code)
-		assert.Contains(t, w.Body.String(), "token")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assertClientDisconnected(t, wsClient, true)
+	})
+
+	t.Run("InvalidRequestBody", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/user/logout", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
-	// t.Run("TokenCreationFailure", func(t *testing.T) {
-	// 	// Mock the failure in token creation
-	// 	createToken = func(uuid string, hours int) (string, error) {
-	// 		return "", errors.New("token creation error")
-	// 	}
+	t.Run("InvalidRefreshToken", func(t *testing.T) {
+		mockSessionUsecase.EXPECT().RevokeSession(gomock.Any(), "unknown-refresh-token").Return("", entity.ErrInvalidRefreshToken)
 
-	// 	req, _ := http.NewRequest(http.MethodPost, "/user/logout", nil)
-	// 	w := httptest.NewRecorder()
+		requestBody := `{"refresh_token": "unknown-refresh-token"}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/logout", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
 
-	// 	router.ServeHTTP(w, req)
+		router.ServeHTTP(w, req)
 
-	// 	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	// })
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
 }
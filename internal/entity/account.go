@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// Deleted accounts keep an anonymised profile so that the conversations they took part in still render
+const (
+	DeletedUserFirstName  = "Deleted"
+	DeletedUserLastName   = "User"
+	DeletedMessageContent = "This message was deleted"
+)
+
+type AccountDeletion struct {
+	// Deleted is true if the account was erased right away
+	Deleted bool
+	// DeleteAfter is when the account will be erased if it is not logged into again before
+	DeleteAfter *time.Time
+}
@@ -0,0 +1,100 @@
+package entity
+
+import "time"
+
+// Roles of user accounts
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Actions recorded in the admin audit log
+const (
+	AdminActionSuspendUser        = "suspend_user"
+	AdminActionUnsuspendUser      = "unsuspend_user"
+	AdminActionForceLogout        = "force_logout"
+	AdminActionDeleteMessage      = "delete_message"
+	AdminActionDeleteConversation = "delete_conversation"
+	AdminActionUpdateReport       = "update_report"
+)
+
+// Kinds of targets of admin actions
+const (
+	AdminTargetUser         = "user"
+	AdminTargetMessage      = "message"
+	AdminTargetConversation = "conversation"
+	AdminTargetReport       = "report"
+)
+
+// UserAccess is what has to be known about a user to authorize their requests
+type UserAccess struct {
+	Role      string
+	Suspended bool
+}
+
+// AdminUserQuery -.
+// Cursor is the id of the last user of the previous page, zero for the first page.
+type AdminUserQuery struct {
+	Query  string
+	Cursor int
+	Limit  int
+}
+
+// AdminUserPage -.
+// NextCursor is zero on the last page.
+type AdminUserPage struct {
+	Users      []AdminUser
+	NextCursor int
+	Limit      int
+}
+
+type AdminUser struct {
+	ID               int
+	UserUUID         string
+	Username         string
+	Email            string
+	FirstName        string
+	LastName         string
+	Role             string
+	VerifiedAt       *time.Time
+	SuspendedAt      *time.Time
+	SuspensionReason string
+	DeleteAfter      *time.Time
+}
+
+// AdminAction is an action of an admin on a user, a message or a conversation
+type AdminAction struct {
+	AdminUUID  string
+	TargetUUID string
+	Reason     string
+}
+
+// AdminAuditDTO -.
+// It is stored in the same transaction as the change it records.
+type AdminAuditDTO struct {
+	ID         int
+	AuditUUID  string
+	AdminUUID  string
+	Action     string
+	TargetType string
+	TargetUUID string
+	Reason     string
+	CreatedAt  time.Time
+}
+
+// AdminAuditQuery -.
+// Entries are listed from the newest, Cursor is the id of the last entry of the previous page, zero for the first page.
+// TargetUUID only lists the entries of one user, message or conversation if set.
+type AdminAuditQuery struct {
+	TargetUUID string
+	Cursor     int
+	Limit      int
+}
+
+// AdminAuditPage -.
+// NextCursor is zero on the last page.
+type AdminAuditPage struct {
+	Entries    []AdminAuditDTO
+	NextCursor int
+	Limit      int
+}
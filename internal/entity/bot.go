@@ -0,0 +1,96 @@
+package entity
+
+import "time"
+
+// API keys start with this prefix, so that they can be told apart from access tokens
+const APIKeyPrefix = "cma_"
+
+// Scopes an API key can be granted
+const (
+	// ScopeMessagesWrite allows sending messages to the conversations of the key
+	ScopeMessagesWrite = "messages:write"
+)
+
+// APIKeyScopes lists every scope an API key can be granted
+var APIKeyScopes = []string{ScopeMessagesWrite}
+
+type Bot struct {
+	BotUUID   string
+	OwnerUUID string
+	Name      string
+	CreatedAt time.Time
+}
+
+type BotDTO struct {
+	BotUUID   string
+	OwnerUUID string
+	Name      string
+	CreatedAt time.Time
+	DeletedAt *time.Time
+}
+
+type APIKeyRequest struct {
+	Name   string
+	Scopes []string
+	// ConversationUUIDs are the only conversations the key can be used for
+	ConversationUUIDs []string
+	ExpiresAt         *time.Time
+}
+
+type APIKey struct {
+	KeyUUID string
+	BotUUID string
+	Name    string
+	// Key is only set when the key is created, only its hash is stored
+	Key               string
+	Scopes            []string
+	ConversationUUIDs []string
+	CreatedAt         time.Time
+	LastUsedAt        *time.Time
+	ExpiresAt         *time.Time
+}
+
+type APIKeyDTO struct {
+	KeyUUID           string
+	BotUUID           string
+	Name              string
+	KeyHash           string
+	Scopes            []string
+	ConversationUUIDs []string
+	CreatedAt         time.Time
+	LastUsedAt        *time.Time
+	ExpiresAt         *time.Time
+	RevokedAt         *time.Time
+	// State of the bot and of its owner, only set when the key is looked up to authenticate a request
+	BotDeletedAt     *time.Time
+	OwnerSuspendedAt *time.Time
+	OwnerDeleteAfter *time.Time
+}
+
+// APIKeyPrincipal is the bot a request was authenticated as, together with what its API key allows
+type APIKeyPrincipal struct {
+	KeyUUID           string
+	BotUUID           string
+	Scopes            []string
+	ConversationUUIDs []string
+}
+
+// HasScope reports whether the API key was granted the scope
+func (p APIKeyPrincipal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsConversation reports whether the API key can be used for the conversation
+func (p APIKeyPrincipal) AllowsConversation(conversationUUID string) bool {
+	for _, c := range p.ConversationUUIDs {
+		if c == conversationUUID {
+			return true
+		}
+	}
+	return false
+}
@@ -1,9 +1,51 @@
 package entity
 
+import "time"
+
 type Contacts struct {
 	UserProfile
 	ConversationUUID string `json:"conversation_uuid"`
 	Blocked          bool   `json:"blocked"`
+	// Nickname, Favourite and Labels are private to the user, the contact does not see them
+	Nickname  string   `json:"nickname"`
+	Favourite bool     `json:"favourite"`
+	Labels    []string `json:"labels"`
+}
+
+// Orders of the contacts of a user
+const (
+	// ContactsSortName orders by nickname, or by name for contacts without a nickname
+	ContactsSortName = "name"
+	// ContactsSortFavourite lists the favourites first, each ordered by name
+	ContactsSortFavourite = "favourite"
+)
+
+// ContactsQuery -.
+// Label and Favourite filter the contacts, only favourites are listed if Favourite is set.
+// Cursor is the user UUID of the last contact of the previous page, empty for the first page.
+type ContactsQuery struct {
+	UserUUID  string
+	Label     string
+	Favourite bool
+	Sort      string
+	Cursor    string
+	Limit     int
+}
+
+// ContactsPage -.
+// NextCursor is empty on the last page.
+type ContactsPage struct {
+	Contacts   []Contacts
+	NextCursor string
+	Limit      int
+}
+
+// ContactDetails -.
+// Nil fields are left unchanged, an empty nickname or labels list clears them.
+type ContactDetails struct {
+	Nickname  *string
+	Favourite *bool
+	Labels    *[]string
 }
 
 type ContactsDTO struct {
@@ -15,3 +57,79 @@ type ContactsDTO struct {
 }
 
 const DirectMessageConversationType = "direct_message"
+
+// Statuses of contact requests, only pending requests can be answered
+const (
+	ContactRequestPending   = "pending"
+	ContactRequestAccepted  = "accepted"
+	ContactRequestDeclined  = "declined"
+	ContactRequestCancelled = "cancelled"
+)
+
+// Directions of the contact requests of a user
+const (
+	ContactRequestsIncoming = "incoming"
+	ContactRequestsOutgoing = "outgoing"
+)
+
+// ContactRequest -.
+// User is the other user of the request, it is only set when requests are listed.
+type ContactRequest struct {
+	RequestUUID  string
+	FromUserUUID string
+	ToUserUUID   string
+	Status       string
+	// ConversationUUID is the direct conversation of both users, it is only set once the request is accepted
+	ConversationUUID string
+	CreatedAt        time.Time
+	RespondedAt      *time.Time
+	User             UserProfile
+	Username         string
+}
+
+type ContactRequestDTO struct {
+	RequestUUID      string
+	FromUserUUID     string
+	ToUserUUID       string
+	Status           string
+	ConversationUUID string
+	CreatedAt        time.Time
+	RespondedAt      *time.Time
+}
+
+// ContactImport -.
+// Hashes are the hex encoded SHA-256 hashes of Salt followed by the trimmed and lower cased email addresses.
+// The salt is chosen by the client for each import, so that the hashes can not be matched against other uploads.
+type ContactImport struct {
+	UserUUID string
+	Salt     string
+	Hashes   []string
+	// Add sends a contact request to every match that is not a contact yet
+	Add bool
+}
+
+// ContactMatch is a registered user whose email address matches one of the uploaded hashes.
+type ContactMatch struct {
+	Hash     string
+	User     UserProfile
+	Username string
+	// Contact is whether the user already has them as a contact
+	Contact bool
+	// Request is the contact request sent to them by the import, it is nil if none was sent
+	Request *ContactRequest
+}
+
+// ContactSuggestion is a user the user may know, they are ranked by their mutual contacts, then by their shared group chats.
+type ContactSuggestion struct {
+	User           UserProfile
+	Username       string
+	MutualContacts int
+	SharedGroups   int
+}
+
+// ContactSuggestionState tells when the cached suggestions of a user were computed.
+// InvalidatedAt is the last time a contact or group change could have changed them, they are stale if it is after ComputedAt.
+type ContactSuggestionState struct {
+	ComputedAt    time.Time
+	InvalidatedAt *time.Time
+}
@@ -25,4 +25,5 @@ type ConversationList struct {
 	LastSentUser         UserProfile `json:"last_sent_user"`
 	LastMessageCreatedAt *time.Time  `json:"last_message_created_at"`
 	Type                 *string     `json:"type"`
+	Picture              *string     `json:"picture"`
 }
@@ -0,0 +1,21 @@
+package entity
+
+type PasswordChange struct {
+	UserUUID string
+	// SessionUUID is the session making the request, every other session is logged out
+	SessionUUID     string
+	CurrentPassword string
+	NewPassword     string
+}
+
+type EmailChange struct {
+	UserUUID        string
+	CurrentPassword string
+	NewEmail        string
+}
+
+type UsernameChange struct {
+	UserUUID        string
+	CurrentPassword string
+	NewUsername     string
+}
@@ -0,0 +1,64 @@
+package entity
+
+import "time"
+
+// Data export statuses
+const (
+	DataExportPending = "pending"
+	DataExportReady   = "ready"
+	DataExportFailed  = "failed"
+	DataExportExpired = "expired"
+)
+
+type DataExport struct {
+	ExportUUID  string
+	Status      string
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time
+}
+
+type DataExportDTO struct {
+	ExportUUID  string
+	UserUUID    string
+	Status      string
+	StorageKey  string
+	Error       string
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time
+}
+
+// The types below are written to the export archive as JSON
+
+type ExportContact struct {
+	ContactUserUUID  string `json:"contact_user_uuid"`
+	ConversationUUID string `json:"conversation_uuid"`
+	Blocked          bool   `json:"blocked"`
+	Removed          bool   `json:"removed"`
+}
+
+type ExportGroupMembership struct {
+	ConversationUUID string     `json:"conversation_uuid"`
+	Title            string     `json:"title"`
+	JoinDate         time.Time  `json:"join_date"`
+	LeftDate         *time.Time `json:"left_date,omitempty"`
+}
+
+type ExportMessage struct {
+	MessageUUID      string    `json:"message_uuid"`
+	ConversationUUID string    `json:"conversation_uuid"`
+	Content          string    `json:"content"`
+	CreatedAt        time.Time `json:"created_at"`
+	Deleted          bool      `json:"deleted"`
+}
+
+type ExportReaction struct {
+	MessageUUID  string `json:"message_uuid"`
+	ReactionType string `json:"reaction_type"`
+}
+
+type ExportSeenReceipt struct {
+	MessageUUID   string    `json:"message_uuid"`
+	SeenTimestamp time.Time `json:"seen_timestamp"`
+}
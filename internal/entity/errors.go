@@ -13,4 +13,57 @@ var (
 	ErrParticipantAlrdInGroupChat = errors.New("one or more participant(s) is already in groupchat")
 	ErrParticipantNotInGroupChat  = errors.New("one or more participant(s) is not in groupchat")
 	ErrIncorrectPassword          = errors.New("incorrect password")
+	ErrInvalidRefreshToken        = errors.New("invalid refresh token")
+	ErrRefreshTokenReused         = errors.New("refresh token reused, session revoked")
+	ErrInvalidResetToken          = errors.New("invalid or expired reset token")
+	ErrInvalidVerificationToken   = errors.New("invalid or expired verification token")
+	ErrEmailNotVerified           = errors.New("email not verified")
+	ErrTooManyRequests            = errors.New("too many requests, try again later")
+	ErrMFAAlreadyEnabled          = errors.New("two-factor authentication already enabled")
+	ErrMFANotEnrolled             = errors.New("two-factor authentication not enrolled")
+	ErrInvalidMFACode             = errors.New("invalid two-factor authentication code")
+	ErrInvalidMFAToken            = errors.New("invalid or expired mfa token")
+	ErrAccountLocked              = errors.New("too many failed login attempts, try again later")
+	ErrSessionNotFound            = errors.New("session not found")
+	ErrInvalidSSOState            = errors.New("invalid or expired sso login state")
+	ErrSSOLoginFailed             = errors.New("sso login failed")
+	ErrDataExportNotFound         = errors.New("data export not found")
+	ErrDataExportNotReady         = errors.New("data export is not ready")
+	ErrBlobNotFound               = errors.New("blob not found")
+	ErrUsernameUnavailable        = errors.New("username is taken or reserved")
+	ErrEmailUnavailable           = errors.New("email is already in use")
+	ErrBotNotFound                = errors.New("bot not found")
+	ErrAPIKeyNotFound             = errors.New("api key not found")
+	ErrInvalidAPIKey              = errors.New("invalid, expired or revoked api key")
+	ErrInvalidAPIKeyScope         = errors.New("invalid api key scope")
+	ErrAPIKeyForbidden            = errors.New("api key is not allowed to access this conversation")
+	ErrInvalidSearchQuery         = errors.New("search query is too short")
+	ErrInvalidStatus              = errors.New("status needs an emoji or a text within the length limits and an expiry in the future")
+	ErrImageTooLarge              = errors.New("image is too large")
+	ErrUnsupportedImageType       = errors.New("image must be a jpeg, png or gif")
+	ErrInvalidImage               = errors.New("image could not be decoded")
+	ErrImageNotFound              = errors.New("image not found")
+	ErrInvalidAvatar              = errors.New("uploaded images can only be set as avatar through an upload")
+	ErrInvalidPrivacySetting      = errors.New("invalid privacy setting")
+	ErrContactNotAllowed          = errors.New("user does not accept contacts from you")
+	ErrGroupAddNotAllowed         = errors.New("user can not be added to group chats by you")
+	ErrReadReceiptsDisabled       = errors.New("read receipts are turned off in your privacy settings")
+	ErrAccountSuspended           = errors.New("account is suspended")
+	ErrInsufficientRole           = errors.New("your role does not allow this")
+	ErrAdminSelfAction            = errors.New("admins can not suspend or log out themselves")
+	ErrMessageNotFound            = errors.New("message not found")
+	ErrConversationNotFound       = errors.New("conversation not found")
+	ErrInvalidContactRequest      = errors.New("can not send a contact request to yourself")
+	ErrContactRequestExists       = errors.New("contact request already pending")
+	ErrContactRequestNotFound     = errors.New("contact request not found")
+	ErrContactRequestNotPending   = errors.New("contact request was already answered or cancelled")
+	ErrConversationBlocked        = errors.New("conversation is blocked")
+	ErrInvalidContactImport       = errors.New("invalid salt or email hashes")
+	ErrInvalidContactDetails      = errors.New("invalid nickname or labels")
+	ErrInvalidContactsQuery       = errors.New("invalid contacts sort or label")
+	ErrInvalidReport              = errors.New("invalid report target, reason or details")
+	ErrReportExists               = errors.New("you already reported this and it is not handled yet")
+	ErrReportNotFound             = errors.New("report not found")
+	ErrInvalidReportTransition    = errors.New("report can not be moved to this status")
+	ErrInvalidReportsQuery        = errors.New("invalid report status or target type")
 )
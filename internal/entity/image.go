@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ImageURLPrefix is the path images are served from.
+// Avatars and group pictures that were uploaded reference their image as '/images/<image_uuid>',
+// a thumbnail size can be appended as '/images/<image_uuid>/<size>'.
+const ImageURLPrefix = "/images/"
+
+// Image is an uploaded picture, stored as one square JPEG thumbnail per size.
+type Image struct {
+	ImageUUID string
+	URL       string
+	Sizes     []int
+}
+
+// ImageURL -.
+func ImageURL(imageUUID string) string {
+	return ImageURLPrefix + imageUUID
+}
+
+// ImageUUIDFromURL returns the image an avatar or group picture references, or false if it references an image hosted elsewhere.
+func ImageUUIDFromURL(url string) (string, bool) {
+	if !strings.HasPrefix(url, ImageURLPrefix) {
+		return "", false
+	}
+	imageUUID := strings.TrimPrefix(url, ImageURLPrefix)
+	return imageUUID, imageUUID != "" && !strings.Contains(imageUUID, "/")
+}
+
+// IsImageURL reports whether the url references an uploaded image.
+// Such avatars are only set by uploads, a user who could set them freely could point their avatar at
+// the image of someone else and have it deleted by their next upload.
+func IsImageURL(url string) bool {
+	return strings.HasPrefix(url, ImageURLPrefix)
+}
+
+// ImageKey is the blob store key of a thumbnail.
+func ImageKey(imageUUID string, size int) string {
+	return fmt.Sprintf("images/%s/%d.jpg", imageUUID, size)
+}
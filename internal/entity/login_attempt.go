@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// LoginAttempt identifies who is trying to log in. Identifier is the username for the password step
+// and the user uuid for the second factor step.
+type LoginAttempt struct {
+	Identifier string
+	IP         string
+}
+
+type LoginAttemptDTO struct {
+	Key          string
+	Failures     int
+	LastFailedAt time.Time
+	LockedUntil  *time.Time
+}
+
+type LoginLockoutDTO struct {
+	Key         string
+	Identifier  string
+	IP          string
+	Failures    int
+	LockedUntil time.Time
+}
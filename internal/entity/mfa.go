@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type MFAEnrollment struct {
+	Secret string
+	URI    string
+}
+
+type MFADTO struct {
+	UserUUID     string
+	Secret       string
+	LastUsedStep int64
+	ConfirmedAt  *time.Time
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// Presence statuses.
+// Users are online while they have a websocket connection and are active, away while they are connected but idle,
+// and offline once they have been disconnected for longer than the grace period.
+const (
+	PresenceOnline  = "online"
+	PresenceAway    = "away"
+	PresenceOffline = "offline"
+)
+
+// Presence -.
+// LastSeenAt is nil while the user is online, or if they were never seen.
+type Presence struct {
+	UserUUID   string
+	Status     string
+	LastSeenAt *time.Time
+}
+
+// PresenceChange is a presence that has to be pushed to the contacts of the user.
+type PresenceChange struct {
+	Presence
+	ContactUUIDs []string
+}
+
+type LastSeenDTO struct {
+	UserUUID   string
+	LastSeenAt time.Time
+}
@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// Audiences of the privacy settings that restrict who can add the user
+const (
+	PrivacyEveryone           = "everyone"
+	PrivacyContacts           = "contacts"
+	PrivacyContactsOfContacts = "contacts_of_contacts"
+	PrivacyNobody             = "nobody"
+)
+
+// PrivacySettings -.
+// Discoverable users can be found by the user search.
+// WhoCanAddContact is everyone, contacts_of_contacts or nobody, WhoCanAddToGroups is everyone, contacts or nobody.
+// Users who do not share read receipts can not see the read receipts of others either.
+type PrivacySettings struct {
+	Discoverable      bool
+	WhoCanAddContact  string
+	WhoCanAddToGroups string
+	ReadReceipts      bool
+	ShowPresence      bool
+}
+
+type PrivacySettingsDTO struct {
+	UserUUID          string
+	Discoverable      bool
+	WhoCanAddContact  string
+	WhoCanAddToGroups string
+	ReadReceipts      bool
+	ShowPresence      bool
+	UpdatedAt         time.Time
+}
+
+// DefaultPrivacySettings are used for users who never changed their settings.
+func DefaultPrivacySettings() PrivacySettings {
+	return PrivacySettings{
+		Discoverable:      true,
+		WhoCanAddContact:  PrivacyEveryone,
+		WhoCanAddToGroups: PrivacyEveryone,
+		ReadReceipts:      true,
+		ShowPresence:      true,
+	}
+}
+
+// PrivacySettingsUpdate -.
+// Only the settings that are not nil are changed.
+type PrivacySettingsUpdate struct {
+	Discoverable      *bool
+	WhoCanAddContact  *string
+	WhoCanAddToGroups *string
+	ReadReceipts      *bool
+	ShowPresence      *bool
+}
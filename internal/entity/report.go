@@ -0,0 +1,79 @@
+package entity
+
+import "time"
+
+// Reasons users can give when reporting abuse
+const (
+	ReportReasonSpam          = "spam"
+	ReportReasonHarassment    = "harassment"
+	ReportReasonHateSpeech    = "hate_speech"
+	ReportReasonSexualContent = "sexual_content"
+	ReportReasonViolence      = "violence"
+	ReportReasonImpersonation = "impersonation"
+	ReportReasonOther         = "other"
+)
+
+// Statuses of reports in the moderation queue.
+// Open reports are waiting for an admin, resolved and dismissed reports were handled and can only be reopened.
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusInReview  = "in_review"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+// Report is a report of a user, a message or a conversation by another user.
+// TargetType is one of AdminTargetUser, AdminTargetMessage or AdminTargetConversation.
+type Report struct {
+	ID           int
+	ReportUUID   string
+	ReporterUUID string
+	TargetType   string
+	TargetUUID   string
+	// ConversationUUID is the reported conversation, or the conversation of the reported message
+	ConversationUUID string
+	Reason           string
+	Details          string
+	// Message is the reported message as it was when it was reported, it is only set for message reports
+	Message        *ReportedMessage
+	Status         string
+	ReviewerUUID   string
+	ResolutionNote string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// ReportedMessage is the copy of a reported message that is kept with the report.
+type ReportedMessage struct {
+	ConversationUUID string
+	SenderUUID       string
+	Content          string
+	CreatedAt        time.Time
+}
+
+// ReportStatusUpdate is an admin moving a report to another status of the moderation queue.
+// Note is optional, it is kept with the report and stored in the audit log.
+type ReportStatusUpdate struct {
+	AdminUUID  string
+	ReportUUID string
+	Status     string
+	Note       string
+}
+
+// ReportQuery -.
+// Reports are listed from the oldest, Cursor is the id of the last report of the previous page, zero for the first page.
+// Status and TargetType only list the matching reports if set.
+type ReportQuery struct {
+	Status     string
+	TargetType string
+	Cursor     int
+	Limit      int
+}
+
+// ReportPage -.
+// NextCursor is zero on the last page.
+type ReportPage struct {
+	Reports    []Report
+	NextCursor int
+	Limit      int
+}
@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+type Session struct {
+	SessionUUID           string
+	UserUUID              string
+	AccessTokenID         string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+type RefreshTokenDTO struct {
+	TokenHash            string
+	SessionUUID          string
+	UserUUID             string
+	AccessTokenID        string
+	AccessTokenExpiresAt time.Time
+	ExpiresAt            time.Time
+	RotatedAt            *time.Time
+	RevokedAt            *time.Time
+}
+
+// SessionDevice describes the client a session was started from
+type SessionDevice struct {
+	DeviceName string
+	UserAgent  string
+	IP         string
+}
+
+type SessionInfo struct {
+	SessionUUID string
+	DeviceName  string
+	UserAgent   string
+	IP          string
+	CreatedAt   time.Time
+	LastSeenAt  time.Time
+	// Current is true for the session of the access token used for the request
+	Current bool
+}
+
+type SessionDTO struct {
+	SessionUUID string
+	UserUUID    string
+	DeviceName  string
+	UserAgent   string
+	IP          string
+	CreatedAt   time.Time
+	LastSeenAt  time.Time
+	ExpiresAt   time.Time
+	RevokedAt   *time.Time
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// SSOLogin is a started single sign-on login. The user is sent to AuthURL, and the provider
+// sends them back to the callback with State and an authorization code.
+type SSOLogin struct {
+	AuthURL string
+	State   string
+}
+
+type SSOLoginStateDTO struct {
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
+// UserIdentityDTO links an account of the identity provider, identified by its issuer and subject, to a user
+type UserIdentityDTO struct {
+	Issuer   string
+	Subject  string
+	UserUUID string
+	Email    string
+}
@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 type UserCredentials struct {
 	Username string
 	Password string
@@ -23,9 +25,14 @@ type UserRegistrationDTO struct {
 }
 
 type UserCredentialsDTO struct {
-	ID       int
-	Username string
-	Password string
-	Email    string
-	UserUuid string
+	ID         int
+	Username   string
+	Password   string
+	Email      string
+	UserUuid   string
+	VerifiedAt *time.Time
+	// DeleteAfter is set while the account is scheduled for deletion
+	DeleteAfter *time.Time
+	// SuspendedAt is set while the account is suspended by an admin
+	SuspendedAt *time.Time
 }
@@ -0,0 +1,34 @@
+package entity
+
+// UserSearchQuery -.
+// Cursor is the last result of the previous page, nil for the first page.
+type UserSearchQuery struct {
+	UserUUID string
+	Query    string
+	Cursor   *UserSearchCursor
+	Limit    int
+}
+
+// UserSearchCursor -.
+// Results are ordered by score, then by user UUID for results with the same score.
+type UserSearchCursor struct {
+	Score    float64 `json:"score"`
+	UserUUID string  `json:"user_uuid"`
+}
+
+// UserSearchPage -.
+// NextCursor is nil on the last page.
+type UserSearchPage struct {
+	Results    []UserSearchResult
+	NextCursor *UserSearchCursor
+	Limit      int
+}
+
+type UserSearchResult struct {
+	UserUUID  string
+	Username  string
+	FirstName string
+	LastName  string
+	Avatar    string
+	Score     float64
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// Purposes of single-use tokens sent to the user
+const (
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposeEmailChange       = "email_change"
+)
+
+// PasswordResetRequest asks for a reset email, IP is the client IP the request came from
+type PasswordResetRequest struct {
+	Email string
+	IP    string
+}
+
+type PasswordReset struct {
+	Token       string
+	NewPassword string
+}
+
+type UserTokenDTO struct {
+	TokenHash string
+	UserUUID  string
+	Purpose   string
+	Payload   string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
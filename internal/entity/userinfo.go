@@ -1,10 +1,14 @@
 package entity
 
+import "time"
+
 type UserProfileDTO struct {
-	UserUUID  string `json:"user_uuid,omitempty"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Avatar    string `json:"avatar"`
+	UserUUID  string      `json:"user_uuid,omitempty"`
+	FirstName string      `json:"first_name"`
+	LastName  string      `json:"last_name"`
+	Avatar    string      `json:"avatar"`
+	IsBot     bool        `json:"is_bot"`
+	Status    *UserStatus `json:"status,omitempty"`
 }
 
 type UserProfile struct {
@@ -12,6 +16,29 @@ type UserProfile struct {
 	FirstName string
 	LastName  string
 	Avatar    string
+	IsBot     bool
+	// Status is nil if the user has no custom status
+	Status *UserStatus
+}
+
+// UserStatus is a custom status such as "in a meeting until 3pm".
+// It is cleared once ExpiresAt has passed, statuses without ExpiresAt are kept until the user clears them.
+type UserStatus struct {
+	Emoji     string     `json:"emoji"`
+	Text      string     `json:"text"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Expired -.
+func (s *UserStatus) Expired(now time.Time) bool {
+	return s != nil && s.ExpiresAt != nil && !s.ExpiresAt.After(now)
+}
+
+// StatusChange is a status that has to be pushed to the contacts of the user, Status is nil if it was cleared.
+type StatusChange struct {
+	UserUUID     string
+	Status       *UserStatus
+	ContactUUIDs []string
 }
 
 func (dto *UserProfileDTO) ToUserInfo() UserProfile {
@@ -23,5 +50,7 @@ func (dto *UserProfileDTO) ToUserInfo() UserProfile {
 		FirstName: dto.FirstName,
 		LastName:  dto.LastName,
 		Avatar:    dto.Avatar,
+		IsBot:     dto.IsBot,
+		Status:    dto.Status,
 	}
 }
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+type AccountDeletionUseCase struct {
+	repo        AccountDeletionRepo
+	userRepo    UserRepo
+	sessionRepo SessionRepo
+	hasher      PasswordHasher
+	gracePeriod time.Duration
+}
+
+// NewAccountDeletion -.
+// With a grace period of zero accounts are erased as soon as the deletion is requested.
+func NewAccountDeletion(r AccountDeletionRepo, u UserRepo, s SessionRepo, h PasswordHasher, gracePeriod time.Duration) *AccountDeletionUseCase {
+	return &AccountDeletionUseCase{
+		repo:        r,
+		userRepo:    u,
+		sessionRepo: s,
+		hasher:      h,
+		gracePeriod: gracePeriod,
+	}
+}
+
+func (uc *AccountDeletionUseCase) DeleteAccount(ctx context.Context, userUUID string, password string) (entity.AccountDeletion, error) {
+	// Get user credentials from user data repository by querying 'user_credentials' table
+	userInfo, err := uc.userRepo.GetUserCredentialsByUUID(ctx, userUUID)
+	if err != nil {
+		return entity.AccountDeletion{}, fmt.Errorf("AccountDeletionUseCase - DeleteAccount - uc.userRepo.GetUserCredentialsByUUID: %w", err)
+	}
+
+	// Return error if user is not found. Will be handled by controller
+	if userInfo == nil {
+		return entity.AccountDeletion{}, entity.ErrUserNotFound
+	}
+
+	// The password has to be confirmed again, a stolen access token alone must not be able to delete the account
+	if !verifyPassword(uc.hasher, password, userInfo.Password) {
+		return entity.AccountDeletion{}, entity.ErrIncorrectPassword
+	}
+
+	// Log the user out everywhere, whether the account is erased now or after the grace period
+	err = uc.sessionRepo.RevokeUserSessions(ctx, userUUID)
+	if err != nil {
+		return entity.AccountDeletion{}, fmt.Errorf("AccountDeletionUseCase - DeleteAccount - uc.sessionRepo.RevokeUserSessions: %w", err)
+	}
+
+	if uc.gracePeriod <= 0 {
+		// Erase the user's data from account deletion data repository
+		err = uc.repo.EraseUser(ctx, userUUID)
+		if err != nil {
+			return entity.AccountDeletion{}, fmt.Errorf("AccountDeletionUseCase - DeleteAccount - uc.repo.EraseUser: %w", err)
+		}
+		return entity.AccountDeletion{Deleted: true}, nil
+	}
+
+	// Logging in again before the grace period ends cancels the deletion
+	deleteAfter := time.Now().Add(uc.gracePeriod)
+	err = uc.repo.ScheduleDeletion(ctx, userUUID, deleteAfter)
+	if err != nil {
+		return entity.AccountDeletion{}, fmt.Errorf("AccountDeletionUseCase - DeleteAccount - uc.repo.ScheduleDeletion: %w", err)
+	}
+	return entity.AccountDeletion{DeleteAfter: &deleteAfter}, nil
+}
+
+// PurgeDueAccounts erases every account whose grace period has ended and returns how many were erased.
+func (uc *AccountDeletionUseCase) PurgeDueAccounts(ctx context.Context) (int, error) {
+	// Get the accounts that are due from account deletion data repository
+	userUUIDs, err := uc.repo.GetDueDeletions(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("AccountDeletionUseCase - PurgeDueAccounts - uc.repo.GetDueDeletions: %w", err)
+	}
+
+	for i, userUUID := range userUUIDs {
+		err = uc.repo.EraseUser(ctx, userUUID)
+		if err != nil {
+			return i, fmt.Errorf("AccountDeletionUseCase - PurgeDueAccounts - uc.repo.EraseUser: %w", err)
+		}
+	}
+	return len(userUUIDs), nil
+}
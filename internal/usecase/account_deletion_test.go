@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/password"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAccountDeletionUseCase_DeleteAccount(t *testing.T) {
+	// Example hashed password for testing
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	userCredentials := &entity.UserCredentialsDTO{UserUuid: testUserUUID, Password: string(hashedPassword)}
+
+	// Define the structure of each test case
+	type testCase struct {
+		name        string                                                                                                                  // Name of the test case
+		password    string                                                                                                                  // Password the user confirmed
+		gracePeriod time.Duration                                                                                                           // Grace period before the account is erased
+		setupMocks  func(mockRepo *mocks.MockAccountDeletionRepo, mockUserRepo *mocks.MockUserRepo, mockSessionRepo *mocks.MockSessionRepo) // Function to set up mock behavior
+		wantDeleted bool                                                                                                                    // Whether the account is expected to be erased right away
+		wantErr     error                                                                                                                   // Expected sentinel error, if any
+		wantAnyErr  bool                                                                                                                    // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:     "success - erased without grace period",
+			password: "password123",
+			setupMocks: func(mockRepo *mocks.MockAccountDeletionRepo, mockUserRepo *mocks.MockUserRepo, mockSessionRepo *mocks.MockSessionRepo) {
+				mockUserRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+				mockSessionRepo.EXPECT().RevokeUserSessions(gomock.Any(), testUserUUID).Return(nil)
+				mockRepo.EXPECT().EraseUser(gomock.Any(), testUserUUID).Return(nil)
+			},
+			wantDeleted: true,
+		},
+		{
+			name:        "success - scheduled with grace period",
+			password:    "password123",
+			gracePeriod: 24 * time.Hour,
+			setupMocks: func(mockRepo *mocks.MockAccountDeletionRepo, mockUserRepo *mocks.MockUserRepo, mockSessionRepo *mocks.MockSessionRepo) {
+				mockUserRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+				mockSessionRepo.EXPECT().RevokeUserSessions(gomock.Any(), testUserUUID).Return(nil)
+				mockRepo.EXPECT().
+					ScheduleDeletion(gomock.Any(), testUserUUID, gomock.Any()).
+					Do(func(_ context.Context, _ string, deleteAfter time.Time) {
+						if deleteAfter.Before(time.Now().Add(23 * time.Hour)) {
+							t.Errorf("AccountDeletionUseCase.DeleteAccount() scheduled deletion at %v", deleteAfter)
+						}
+					}).
+					Return(nil)
+			},
+			wantDeleted: false,
+		},
+		{
+			name:     "error - incorrect password",
+			password: "wrongpassword",
+			setupMocks: func(mockRepo *mocks.MockAccountDeletionRepo, mockUserRepo *mocks.MockUserRepo, mockSessionRepo *mocks.MockSessionRepo) {
+				mockUserRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+			},
+			wantErr:    entity.ErrIncorrectPassword,
+			wantAnyErr: true,
+		},
+		{
+			name:     "error - user not found",
+			password: "password123",
+			setupMocks: func(mockRepo *mocks.MockAccountDeletionRepo, mockUserRepo *mocks.MockUserRepo, mockSessionRepo *mocks.MockSessionRepo) {
+				mockUserRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(nil, nil)
+			},
+			wantErr:    entity.ErrUserNotFound,
+			wantAnyErr: true,
+		},
+		{
+			name:     "error erasing user",
+			password: "password123",
+			setupMocks: func(mockRepo *mocks.MockAccountDeletionRepo, mockUserRepo *mocks.MockUserRepo, mockSessionRepo *mocks.MockSessionRepo) {
+				mockUserRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+				mockSessionRepo.EXPECT().RevokeUserSessions(gomock.Any(), testUserUUID).Return(nil)
+				mockRepo.EXPECT().EraseUser(gomock.Any(), testUserUUID).Return(fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories
+			mockRepo := mocks.NewMockAccountDeletionRepo(ctrl)
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			mockSessionRepo := mocks.NewMockSessionRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo, mockUserRepo, mockSessionRepo)
+			}
+
+			uc := NewAccountDeletion(mockRepo, mockUserRepo, mockSessionRepo, password.NewBcrypt(bcrypt.MinCost), tt.gracePeriod)
+
+			// Call the method under test
+			got, err := uc.DeleteAccount(context.Background(), testUserUUID, tt.password)
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("AccountDeletionUseCase.DeleteAccount() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("AccountDeletionUseCase.DeleteAccount() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Deleted != tt.wantDeleted || (got.DeleteAfter == nil) != tt.wantDeleted {
+				t.Errorf("AccountDeletionUseCase.DeleteAccount() = %+v, wantDeleted %v", got, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestAccountDeletionUseCase_PurgeDueAccounts(t *testing.T) {
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                        // Name of the test case
+		setupMocks func(mockRepo *mocks.MockAccountDeletionRepo) // Function to set up mock behavior
+		want       int                                           // Expected number of erased accounts
+		wantErr    bool                                          // Whether an error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - due accounts erased",
+			setupMocks: func(mockRepo *mocks.MockAccountDeletionRepo) {
+				mockRepo.EXPECT().GetDueDeletions(gomock.Any(), gomock.Any()).Return([]string{"user_1", "user_2"}, nil)
+				mockRepo.EXPECT().EraseUser(gomock.Any(), "user_1").Return(nil)
+				mockRepo.EXPECT().EraseUser(gomock.Any(), "user_2").Return(nil)
+			},
+			want: 2,
+		},
+		{
+			name: "error erasing stops the purge",
+			setupMocks: func(mockRepo *mocks.MockAccountDeletionRepo) {
+				mockRepo.EXPECT().GetDueDeletions(gomock.Any(), gomock.Any()).Return([]string{"user_1", "user_2"}, nil)
+				mockRepo.EXPECT().EraseUser(gomock.Any(), "user_1").Return(fmt.Errorf("some error"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instance of the repository
+			mockRepo := mocks.NewMockAccountDeletionRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := NewAccountDeletion(mockRepo, nil, nil, nil, time.Hour)
+
+			// Call the method under test
+			got, err := uc.PurgeDueAccounts(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AccountDeletionUseCase.PurgeDueAccounts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("AccountDeletionUseCase.PurgeDueAccounts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
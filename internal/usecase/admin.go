@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+const (
+	_adminDefaultLimit = 50
+	_adminMaxLimit     = 200
+)
+
+// AdminUseCase lets admins moderate users and conversations.
+// Every action is stored in the admin audit log, in the same transaction as the change it makes where there is one.
+type AdminUseCase struct {
+	repo        AdminRepo
+	sessionRepo SessionRepo
+}
+
+// NewAdmin -.
+func NewAdmin(r AdminRepo, s SessionRepo) *AdminUseCase {
+	return &AdminUseCase{
+		repo:        r,
+		sessionRepo: s,
+	}
+}
+
+// GetUserAccess returns the role of the user and whether they are suspended.
+func (uc *AdminUseCase) GetUserAccess(ctx context.Context, userUUID string) (entity.UserAccess, error) {
+	// Get the access of the user from admin data repository by querying 'user_credentials' table
+	access, err := uc.repo.GetUserAccess(ctx, userUUID)
+	if err != nil {
+		return entity.UserAccess{}, fmt.Errorf("AdminUseCase - GetUserAccess - uc.repo.GetUserAccess: %w", err)
+	}
+
+	// Return error if user is not found. Will be handled by controller
+	if access == nil {
+		return entity.UserAccess{}, entity.ErrUserNotFound
+	}
+	return *access, nil
+}
+
+// ListUsers returns a page of the users matching the query, including suspended and hidden users.
+func (uc *AdminUseCase) ListUsers(ctx context.Context, query entity.AdminUserQuery) (entity.AdminUserPage, error) {
+	// Matching is case insensitive
+	query.Query = strings.ToLower(strings.TrimSpace(query.Query))
+	query.Limit = adminLimit(query.Limit)
+	limit := query.Limit
+
+	// One more user than requested tells whether there is a next page
+	query.Limit++
+
+	// Get the users from admin data repository by querying 'user_credentials' and 'user_info' tables
+	users, err := uc.repo.ListUsers(ctx, query)
+	if err != nil {
+		return entity.AdminUserPage{}, fmt.Errorf("AdminUseCase - ListUsers - uc.repo.ListUsers: %w", err)
+	}
+
+	page := entity.AdminUserPage{Users: users, Limit: limit}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		page.NextCursor = page.Users[limit-1].ID
+	}
+	return page, nil
+}
+
+// SuspendUser suspends the user and logs them out of every session.
+// Suspended users can not log in, and their remaining access tokens are rejected.
+func (uc *AdminUseCase) SuspendUser(ctx context.Context, action entity.AdminAction) error {
+	// Return error if the admin targets themselves. Will be handled by controller
+	if action.TargetUUID == action.AdminUUID {
+		return entity.ErrAdminSelfAction
+	}
+
+	// Suspend the user in 'user_credentials' table and store the audit entry using admin data repository
+	found, err := uc.repo.SuspendUser(ctx, newAuditEntry(action, entity.AdminActionSuspendUser, entity.AdminTargetUser))
+	if err != nil {
+		return fmt.Errorf("AdminUseCase - SuspendUser - uc.repo.SuspendUser: %w", err)
+	}
+
+	// Return error if user is not found. Will be handled by controller
+	if !found {
+		return entity.ErrUserNotFound
+	}
+
+	// Revoke every refresh token and access token of the user, so that they can not get new access tokens either
+	err = uc.sessionRepo.RevokeUserSessions(ctx, action.TargetUUID)
+	if err != nil {
+		return fmt.Errorf("AdminUseCase - SuspendUser - uc.sessionRepo.RevokeUserSessions: %w", err)
+	}
+	return nil
+}
+
+// UnsuspendUser lifts the suspension of the user, they have to log in again.
+func (uc *AdminUseCase) UnsuspendUser(ctx context.Context, action entity.AdminAction) error {
+	// Lift the suspension in 'user_credentials' table and store the audit entry using admin data repository
+	found, err := uc.repo.UnsuspendUser(ctx, newAuditEntry(action, entity.AdminActionUnsuspendUser, entity.AdminTargetUser))
+	if err != nil {
+		return fmt.Errorf("AdminUseCase - UnsuspendUser - uc.repo.UnsuspendUser: %w", err)
+	}
+
+	// Return error if user is not found. Will be handled by controller
+	if !found {
+		return entity.ErrUserNotFound
+	}
+	return nil
+}
+
+// ForceLogout logs the user out of every session.
+func (uc *AdminUseCase) ForceLogout(ctx context.Context, action entity.AdminAction) error {
+	// Return error if the admin targets themselves. Will be handled by controller
+	if action.TargetUUID == action.AdminUUID {
+		return entity.ErrAdminSelfAction
+	}
+
+	// Return error if user is not found. Will be handled by controller
+	if _, err := uc.GetUserAccess(ctx, action.TargetUUID); err != nil {
+		return err
+	}
+
+	// Revoke every refresh token and access token of the user
+	err := uc.sessionRepo.RevokeUserSessions(ctx, action.TargetUUID)
+	if err != nil {
+		return fmt.Errorf("AdminUseCase - ForceLogout - uc.sessionRepo.RevokeUserSessions: %w", err)
+	}
+
+	// Store the audit entry into 'admin_audit_log' table using admin data repository
+	err = uc.repo.StoreAuditEntry(ctx, newAuditEntry(action, entity.AdminActionForceLogout, entity.AdminTargetUser))
+	if err != nil {
+		return fmt.Errorf("AdminUseCase - ForceLogout - uc.repo.StoreAuditEntry: %w", err)
+	}
+	return nil
+}
+
+// DeleteMessage deletes any message, whoever sent it. It returns the conversation of the message.
+func (uc *AdminUseCase) DeleteMessage(ctx context.Context, action entity.AdminAction) (string, error) {
+	// Delete the message from 'messages' table and store the audit entry using admin data repository
+	conversationUUID, err := uc.repo.DeleteMessage(ctx, newAuditEntry(action, entity.AdminActionDeleteMessage, entity.AdminTargetMessage))
+	if err != nil {
+		return "", fmt.Errorf("AdminUseCase - DeleteMessage - uc.repo.DeleteMessage: %w", err)
+	}
+
+	// Return error if message is not found. Will be handled by controller
+	if conversationUUID == "" {
+		return "", entity.ErrMessageNotFound
+	}
+	return conversationUUID, nil
+}
+
+// DeleteConversation deletes a direct or group conversation with every message in it.
+func (uc *AdminUseCase) DeleteConversation(ctx context.Context, action entity.AdminAction) error {
+	// Delete the conversation from 'conversations' table and store the audit entry using admin data repository
+	found, err := uc.repo.DeleteConversation(ctx, newAuditEntry(action, entity.AdminActionDeleteConversation, entity.AdminTargetConversation))
+	if err != nil {
+		return fmt.Errorf("AdminUseCase - DeleteConversation - uc.repo.DeleteConversation: %w", err)
+	}
+
+	// Return error if conversation is not found. Will be handled by controller
+	if !found {
+		return entity.ErrConversationNotFound
+	}
+	return nil
+}
+
+// GetAuditLog returns a page of the audit log from the newest entry.
+func (uc *AdminUseCase) GetAuditLog(ctx context.Context, query entity.AdminAuditQuery) (entity.AdminAuditPage, error) {
+	query.Limit = adminLimit(query.Limit)
+	limit := query.Limit
+
+	// One more entry than requested tells whether there is a next page
+	query.Limit++
+
+	// Get the entries from admin data repository by querying 'admin_audit_log' table
+	entries, err := uc.repo.GetAuditLog(ctx, query)
+	if err != nil {
+		return entity.AdminAuditPage{}, fmt.Errorf("AdminUseCase - GetAuditLog - uc.repo.GetAuditLog: %w", err)
+	}
+
+	page := entity.AdminAuditPage{Entries: entries, Limit: limit}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.NextCursor = page.Entries[limit-1].ID
+	}
+	return page, nil
+}
+
+func newAuditEntry(action entity.AdminAction, name string, targetType string) entity.AdminAuditDTO {
+	return entity.AdminAuditDTO{
+		AuditUUID:  uuid.New().String(),
+		AdminUUID:  action.AdminUUID,
+		Action:     name,
+		TargetType: targetType,
+		TargetUUID: action.TargetUUID,
+		Reason:     strings.TrimSpace(action.Reason),
+	}
+}
+
+func adminLimit(limit int) int {
+	if limit <= 0 {
+		return _adminDefaultLimit
+	}
+	if limit > _adminMaxLimit {
+		return _adminMaxLimit
+	}
+	return limit
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+)
+
+const testAdminUUID = "admin-uuid"
+
+func TestAdminUseCase_SuspendUser(t *testing.T) {
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                                                      // Name of the test case
+		action     entity.AdminAction                                                          // Action of the admin
+		setupMocks func(mockRepo *mocks.MockAdminRepo, mockSessionRepo *mocks.MockSessionRepo) // Function to set up mock behavior
+		wantErr    error                                                                       // Expected error, if any
+		wantAnyErr bool                                                                        // Whether any error is expected
+	}
+
+	action := entity.AdminAction{AdminUUID: testAdminUUID, TargetUUID: testUserUUID, Reason: " spam "}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:   "success - audited and logged out",
+			action: action,
+			setupMocks: func(mockRepo *mocks.MockAdminRepo, mockSessionRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().SuspendUser(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, audit entity.AdminAuditDTO) (bool, error) {
+					if audit.Action != entity.AdminActionSuspendUser || audit.TargetType != entity.AdminTargetUser ||
+						audit.AdminUUID != testAdminUUID || audit.TargetUUID != testUserUUID || audit.Reason != "spam" || audit.AuditUUID == "" {
+						t.Errorf("AdminRepo.SuspendUser() audit = %+v", audit)
+					}
+					return true, nil
+				})
+				mockSessionRepo.EXPECT().RevokeUserSessions(gomock.Any(), testUserUUID).Return(nil)
+			},
+		},
+		{
+			name:    "error - admins can not suspend themselves",
+			action:  entity.AdminAction{AdminUUID: testAdminUUID, TargetUUID: testAdminUUID},
+			wantErr: entity.ErrAdminSelfAction,
+		},
+		{
+			name:   "error - user not found",
+			action: action,
+			setupMocks: func(mockRepo *mocks.MockAdminRepo, mockSessionRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().SuspendUser(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr: entity.ErrUserNotFound,
+		},
+		{
+			name:   "error - revoking sessions",
+			action: action,
+			setupMocks: func(mockRepo *mocks.MockAdminRepo, mockSessionRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().SuspendUser(gomock.Any(), gomock.Any()).Return(true, nil)
+				mockSessionRepo.EXPECT().RevokeUserSessions(gomock.Any(), testUserUUID).Return(fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories
+			mockRepo := mocks.NewMockAdminRepo(ctrl)
+			mockSessionRepo := mocks.NewMockSessionRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo, mockSessionRepo)
+			}
+
+			uc := NewAdmin(mockRepo, mockSessionRepo)
+
+			// Call the method under test
+			err := uc.SuspendUser(context.Background(), tt.action)
+			if tt.wantErr != nil || tt.wantAnyErr {
+				if err == nil || (tt.wantErr != nil && err != tt.wantErr) {
+					t.Errorf("AdminUseCase.SuspendUser() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("AdminUseCase.SuspendUser() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestAdminUseCase_ForceLogout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAdminRepo(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepo(ctrl)
+	uc := NewAdmin(mockRepo, mockSessionRepo)
+	action := entity.AdminAction{AdminUUID: testAdminUUID, TargetUUID: testUserUUID}
+
+	// The sessions are revoked before the action is audited
+	gomock.InOrder(
+		mockRepo.EXPECT().GetUserAccess(gomock.Any(), testUserUUID).Return(&entity.UserAccess{Role: entity.RoleUser}, nil),
+		mockSessionRepo.EXPECT().RevokeUserSessions(gomock.Any(), testUserUUID).Return(nil),
+		mockRepo.EXPECT().StoreAuditEntry(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, audit entity.AdminAuditDTO) error {
+			if audit.Action != entity.AdminActionForceLogout || audit.TargetUUID != testUserUUID {
+				t.Errorf("AdminRepo.StoreAuditEntry() audit = %+v", audit)
+			}
+			return nil
+		}),
+	)
+	if err := uc.ForceLogout(context.Background(), action); err != nil {
+		t.Fatalf("AdminUseCase.ForceLogout() error = %v", err)
+	}
+
+	// Unknown users are not audited
+	mockRepo.EXPECT().GetUserAccess(gomock.Any(), "unknown-uuid").Return(nil, nil)
+	err := uc.ForceLogout(context.Background(), entity.AdminAction{AdminUUID: testAdminUUID, TargetUUID: "unknown-uuid"})
+	if err != entity.ErrUserNotFound {
+		t.Errorf("AdminUseCase.ForceLogout() unknown user error = %v, want %v", err, entity.ErrUserNotFound)
+	}
+}
+
+func TestAdminUseCase_DeleteMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAdminRepo(ctrl)
+	uc := NewAdmin(mockRepo, mocks.NewMockSessionRepo(ctrl))
+
+	mockRepo.EXPECT().DeleteMessage(gomock.Any(), gomock.Any()).Return("conv-uuid", nil)
+	conversationUUID, err := uc.DeleteMessage(context.Background(), entity.AdminAction{AdminUUID: testAdminUUID, TargetUUID: "msg-uuid"})
+	if err != nil || conversationUUID != "conv-uuid" {
+		t.Errorf("AdminUseCase.DeleteMessage() = %q, %v, want conv-uuid", conversationUUID, err)
+	}
+
+	mockRepo.EXPECT().DeleteMessage(gomock.Any(), gomock.Any()).Return("", nil)
+	_, err = uc.DeleteMessage(context.Background(), entity.AdminAction{AdminUUID: testAdminUUID, TargetUUID: "unknown-uuid"})
+	if err != entity.ErrMessageNotFound {
+		t.Errorf("AdminUseCase.DeleteMessage() unknown message error = %v, want %v", err, entity.ErrMessageNotFound)
+	}
+}
+
+func TestAdminUseCase_ListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAdminRepo(ctrl)
+	uc := NewAdmin(mockRepo, mocks.NewMockSessionRepo(ctrl))
+
+	// One more user than the limit is requested to know whether there is a next page
+	mockRepo.EXPECT().ListUsers(gomock.Any(), entity.AdminUserQuery{Query: "alice", Limit: 3}).
+		Return([]entity.AdminUser{{ID: 1}, {ID: 4}, {ID: 7}}, nil)
+
+	page, err := uc.ListUsers(context.Background(), entity.AdminUserQuery{Query: "  Alice ", Limit: 2})
+	if err != nil {
+		t.Fatalf("AdminUseCase.ListUsers() error = %v", err)
+	}
+	if len(page.Users) != 2 || page.NextCursor != 4 || page.Limit != 2 {
+		t.Errorf("AdminUseCase.ListUsers() = %+v, want 2 users and cursor 4", page)
+	}
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// Last use of an API key is only recorded once per interval, not on every request
+const _apiKeyTouchInterval = time.Minute
+
+type BotUseCase struct {
+	repo          BotRepo
+	groupChatRepo GroupChatRepo
+}
+
+// NewBot -.
+func NewBot(r BotRepo, g GroupChatRepo) *BotUseCase {
+	return &BotUseCase{
+		repo:          r,
+		groupChatRepo: g,
+	}
+}
+
+func (uc *BotUseCase) CreateBot(ctx context.Context, ownerUUID string, name string) (entity.Bot, error) {
+	bot := entity.BotDTO{
+		BotUUID:   uuid.New().String(),
+		OwnerUUID: ownerUUID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+
+	// Store the bot and its profile into 'bots' and 'user_info' tables using bot data repository
+	err := uc.repo.CreateBot(ctx, bot)
+	if err != nil {
+		return entity.Bot{}, fmt.Errorf("BotUseCase - CreateBot - uc.repo.CreateBot: %w", err)
+	}
+	return toBot(bot), nil
+}
+
+func (uc *BotUseCase) GetBots(ctx context.Context, ownerUUID string) ([]entity.Bot, error) {
+	// Get the owner's bots from bot data repository by querying 'bots' table
+	botDTOs, err := uc.repo.GetOwnerBots(ctx, ownerUUID)
+	if err != nil {
+		return nil, fmt.Errorf("BotUseCase - GetBots - uc.repo.GetOwnerBots: %w", err)
+	}
+
+	bots := []entity.Bot{}
+	for _, bot := range botDTOs {
+		bots = append(bots, toBot(bot))
+	}
+	return bots, nil
+}
+
+// DeleteBot revokes every API key of the bot and removes it from its conversations.
+func (uc *BotUseCase) DeleteBot(ctx context.Context, ownerUUID string, botUUID string) error {
+	_, err := uc.getOwnedBot(ctx, ownerUUID, botUUID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.repo.DeleteBot(ctx, botUUID)
+	if err != nil {
+		return fmt.Errorf("BotUseCase - DeleteBot - uc.repo.DeleteBot: %w", err)
+	}
+	return nil
+}
+
+// CreateAPIKey issues a new API key for the bot. The key is only returned here, only its hash is stored.
+func (uc *BotUseCase) CreateAPIKey(ctx context.Context, ownerUUID string, botUUID string, request entity.APIKeyRequest) (entity.APIKey, error) {
+	_, err := uc.getOwnedBot(ctx, ownerUUID, botUUID)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	// Return error if a scope is unknown or the key would not be limited to any conversation. Will be handled by controller
+	if len(request.Scopes) == 0 || len(request.ConversationUUIDs) == 0 {
+		return entity.APIKey{}, entity.ErrInvalidAPIKeyScope
+	}
+	for _, scope := range request.Scopes {
+		if !isAPIKeyScope(scope) {
+			return entity.APIKey{}, entity.ErrInvalidAPIKeyScope
+		}
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return entity.APIKey{}, fmt.Errorf("BotUseCase - CreateAPIKey - generateToken: %w", err)
+	}
+	key := entity.APIKeyPrefix + token
+
+	keyDTO := entity.APIKeyDTO{
+		KeyUUID:           uuid.New().String(),
+		BotUUID:           botUUID,
+		Name:              request.Name,
+		KeyHash:           hashToken(key),
+		Scopes:            request.Scopes,
+		ConversationUUIDs: request.ConversationUUIDs,
+		CreatedAt:         time.Now(),
+		ExpiresAt:         request.ExpiresAt,
+	}
+
+	// Store the key hash into 'api_keys' table using bot data repository
+	err = uc.repo.StoreAPIKey(ctx, keyDTO)
+	if err != nil {
+		return entity.APIKey{}, fmt.Errorf("BotUseCase - CreateAPIKey - uc.repo.StoreAPIKey: %w", err)
+	}
+
+	apiKey := toAPIKey(keyDTO)
+	apiKey.Key = key
+	return apiKey, nil
+}
+
+func (uc *BotUseCase) GetAPIKeys(ctx context.Context, ownerUUID string, botUUID string) ([]entity.APIKey, error) {
+	_, err := uc.getOwnedBot(ctx, ownerUUID, botUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the bot's API keys from bot data repository by querying 'api_keys' table
+	keyDTOs, err := uc.repo.GetBotAPIKeys(ctx, botUUID)
+	if err != nil {
+		return nil, fmt.Errorf("BotUseCase - GetAPIKeys - uc.repo.GetBotAPIKeys: %w", err)
+	}
+
+	keys := []entity.APIKey{}
+	for _, key := range keyDTOs {
+		keys = append(keys, toAPIKey(key))
+	}
+	return keys, nil
+}
+
+func (uc *BotUseCase) RevokeAPIKey(ctx context.Context, ownerUUID string, botUUID string, keyUUID string) error {
+	_, err := uc.getOwnedBot(ctx, ownerUUID, botUUID)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := uc.repo.RevokeAPIKey(ctx, botUUID, keyUUID)
+	if err != nil {
+		return fmt.Errorf("BotUseCase - RevokeAPIKey - uc.repo.RevokeAPIKey: %w", err)
+	}
+
+	// Return error if the key does not belong to the bot or was already revoked. Will be handled by controller
+	if !revoked {
+		return entity.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// AuthenticateAPIKey returns the bot the API key belongs to, together with what the key allows.
+func (uc *BotUseCase) AuthenticateAPIKey(ctx context.Context, key string) (entity.APIKeyPrincipal, error) {
+	// Get the key from bot data repository by its hash
+	keyDTO, err := uc.repo.GetAPIKeyByHash(ctx, hashToken(key))
+	if err != nil {
+		return entity.APIKeyPrincipal{}, fmt.Errorf("BotUseCase - AuthenticateAPIKey - uc.repo.GetAPIKeyByHash: %w", err)
+	}
+
+	// Return error if the key is unknown, revoked or expired. Will be handled by controller
+	now := time.Now()
+	if keyDTO == nil || keyDTO.RevokedAt != nil || (keyDTO.ExpiresAt != nil && !keyDTO.ExpiresAt.After(now)) {
+		return entity.APIKeyPrincipal{}, entity.ErrInvalidAPIKey
+	}
+
+	// Return error if the bot was deleted, or if its owner was suspended or asked for their account to be deleted.
+	// Will be handled by controller
+	if keyDTO.BotDeletedAt != nil || keyDTO.OwnerSuspendedAt != nil || keyDTO.OwnerDeleteAfter != nil {
+		return entity.APIKeyPrincipal{}, entity.ErrInvalidAPIKey
+	}
+
+	if keyDTO.LastUsedAt == nil || now.Sub(*keyDTO.LastUsedAt) >= _apiKeyTouchInterval {
+		err = uc.repo.TouchAPIKey(ctx, keyDTO.KeyUUID, now)
+		if err != nil {
+			return entity.APIKeyPrincipal{}, fmt.Errorf("BotUseCase - AuthenticateAPIKey - uc.repo.TouchAPIKey: %w", err)
+		}
+	}
+
+	return entity.APIKeyPrincipal{
+		KeyUUID:           keyDTO.KeyUUID,
+		BotUUID:           keyDTO.BotUUID,
+		Scopes:            keyDTO.Scopes,
+		ConversationUUIDs: keyDTO.ConversationUUIDs,
+	}, nil
+}
+
+// AuthorizeConversation returns an error unless the API key was granted the scope on the conversation
+// and the bot is still a participant of it.
+func (uc *BotUseCase) AuthorizeConversation(ctx context.Context, principal entity.APIKeyPrincipal, conversationUUID string, scope string) error {
+	// Return error if the key was not granted the scope on this conversation. Will be handled by controller
+	if !principal.HasScope(scope) || !principal.AllowsConversation(conversationUUID) {
+		return entity.ErrAPIKeyForbidden
+	}
+
+	// Check if bot is in group chat by querying 'participants' table from group chat data repository
+	exist, err := uc.groupChatRepo.ValidateUserInGroupChat(ctx, conversationUUID, principal.BotUUID)
+	if err != nil {
+		return fmt.Errorf("BotUseCase - AuthorizeConversation - uc.groupChatRepo.ValidateUserInGroupChat: %w", err)
+	}
+
+	// Return error if the bot was removed from the conversation. Will be handled by controller
+	if !exist {
+		return entity.ErrAPIKeyForbidden
+	}
+	return nil
+}
+
+// Returns the bot if it exists, was not deleted and belongs to the owner
+func (uc *BotUseCase) getOwnedBot(ctx context.Context, ownerUUID string, botUUID string) (*entity.BotDTO, error) {
+	// Get the bot from bot data repository by querying 'bots' table
+	bot, err := uc.repo.GetBot(ctx, botUUID)
+	if err != nil {
+		return nil, fmt.Errorf("BotUseCase - getOwnedBot - uc.repo.GetBot: %w", err)
+	}
+
+	// Other users' bots are reported as not found. Will be handled by controller
+	if bot == nil || bot.DeletedAt != nil || bot.OwnerUUID != ownerUUID {
+		return nil, entity.ErrBotNotFound
+	}
+	return bot, nil
+}
+
+func isAPIKeyScope(scope string) bool {
+	for _, s := range entity.APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func toBot(bot entity.BotDTO) entity.Bot {
+	return entity.Bot{
+		BotUUID:   bot.BotUUID,
+		OwnerUUID: bot.OwnerUUID,
+		Name:      bot.Name,
+		CreatedAt: bot.CreatedAt,
+	}
+}
+
+func toAPIKey(key entity.APIKeyDTO) entity.APIKey {
+	return entity.APIKey{
+		KeyUUID:           key.KeyUUID,
+		BotUUID:           key.BotUUID,
+		Name:              key.Name,
+		Scopes:            key.Scopes,
+		ConversationUUIDs: key.ConversationUUIDs,
+		CreatedAt:         key.CreatedAt,
+		LastUsedAt:        key.LastUsedAt,
+		ExpiresAt:         key.ExpiresAt,
+	}
+}
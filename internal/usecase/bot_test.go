@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+)
+
+func TestBotUseCase_CreateAPIKey(t *testing.T) {
+	// Captures the key as it was handed to the repository
+	var storedKey entity.APIKeyDTO
+
+	ownedBot := &entity.BotDTO{BotUUID: "bot_uuid", OwnerUUID: testUserUUID, Name: "Bot"}
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                            // Name of the test case
+		request    entity.APIKeyRequest              // Input key request
+		setupMocks func(mockRepo *mocks.MockBotRepo) // Function to set up mock behavior
+		wantErr    error                             // Expected error, if any
+		wantAnyErr bool                              // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success",
+			request: entity.APIKeyRequest{
+				Name:              "ci",
+				Scopes:            []string{entity.ScopeMessagesWrite},
+				ConversationUUIDs: []string{"conv_uuid"},
+			},
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetBot(gomock.Any(), "bot_uuid").Return(ownedBot, nil)
+				mockRepo.EXPECT().StoreAPIKey(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, key entity.APIKeyDTO) { storedKey = key }).
+					Return(nil)
+			},
+		},
+		{
+			name: "error - bot of another user",
+			request: entity.APIKeyRequest{
+				Scopes:            []string{entity.ScopeMessagesWrite},
+				ConversationUUIDs: []string{"conv_uuid"},
+			},
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetBot(gomock.Any(), "bot_uuid").
+					Return(&entity.BotDTO{BotUUID: "bot_uuid", OwnerUUID: "other_user"}, nil)
+			},
+			wantErr: entity.ErrBotNotFound,
+		},
+		{
+			name: "error - deleted bot",
+			request: entity.APIKeyRequest{
+				Scopes:            []string{entity.ScopeMessagesWrite},
+				ConversationUUIDs: []string{"conv_uuid"},
+			},
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				deletedAt := time.Now()
+				mockRepo.EXPECT().GetBot(gomock.Any(), "bot_uuid").
+					Return(&entity.BotDTO{BotUUID: "bot_uuid", OwnerUUID: testUserUUID, DeletedAt: &deletedAt}, nil)
+			},
+			wantErr: entity.ErrBotNotFound,
+		},
+		{
+			name: "error - unknown scope",
+			request: entity.APIKeyRequest{
+				Scopes:            []string{"admin"},
+				ConversationUUIDs: []string{"conv_uuid"},
+			},
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetBot(gomock.Any(), "bot_uuid").Return(ownedBot, nil)
+			},
+			wantErr: entity.ErrInvalidAPIKeyScope,
+		},
+		{
+			name: "error - no conversation",
+			request: entity.APIKeyRequest{
+				Scopes: []string{entity.ScopeMessagesWrite},
+			},
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetBot(gomock.Any(), "bot_uuid").Return(ownedBot, nil)
+			},
+			wantErr: entity.ErrInvalidAPIKeyScope,
+		},
+		{
+			name: "error storing key",
+			request: entity.APIKeyRequest{
+				Scopes:            []string{entity.ScopeMessagesWrite},
+				ConversationUUIDs: []string{"conv_uuid"},
+			},
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetBot(gomock.Any(), "bot_uuid").Return(ownedBot, nil)
+				mockRepo.EXPECT().StoreAPIKey(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storedKey = entity.APIKeyDTO{}
+
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories
+			mockRepo := mocks.NewMockBotRepo(ctrl)
+			mockGroupChatRepo := mocks.NewMockGroupChatRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := NewBot(mockRepo, mockGroupChatRepo)
+
+			// Call the method under test
+			got, err := uc.CreateAPIKey(context.Background(), testUserUUID, "bot_uuid", tt.request)
+			if tt.wantErr != nil || tt.wantAnyErr {
+				if err == nil || (tt.wantErr != nil && err != tt.wantErr) {
+					t.Errorf("BotUseCase.CreateAPIKey() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BotUseCase.CreateAPIKey() unexpected error = %v", err)
+			}
+
+			// The key is returned once and only its hash is stored
+			if !strings.HasPrefix(got.Key, entity.APIKeyPrefix) {
+				t.Errorf("BotUseCase.CreateAPIKey() key = %q, want prefix %q", got.Key, entity.APIKeyPrefix)
+			}
+			if storedKey.KeyHash != hashToken(got.Key) || strings.Contains(storedKey.KeyHash, got.Key) {
+				t.Errorf("BotUseCase.CreateAPIKey() stored hash %q does not match the returned key", storedKey.KeyHash)
+			}
+			if storedKey.BotUUID != "bot_uuid" || got.KeyUUID != storedKey.KeyUUID {
+				t.Errorf("BotUseCase.CreateAPIKey() stored %+v, returned %+v", storedKey, got)
+			}
+		})
+	}
+}
+
+func TestBotUseCase_AuthenticateAPIKey(t *testing.T) {
+	const key = entity.APIKeyPrefix + "some-key"
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	justUsed := time.Now()
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                            // Name of the test case
+		setupMocks func(mockRepo *mocks.MockBotRepo) // Function to set up mock behavior
+		wantBot    string                            // Expected bot UUID of the principal
+		wantErr    error                             // Expected error, if any
+		wantAnyErr bool                              // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - last use recorded",
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashToken(key)).
+					Return(&entity.APIKeyDTO{KeyUUID: "key_uuid", BotUUID: "bot_uuid", ExpiresAt: &future}, nil)
+				mockRepo.EXPECT().TouchAPIKey(gomock.Any(), "key_uuid", gomock.Any()).Return(nil)
+			},
+			wantBot: "bot_uuid",
+		},
+		{
+			name: "success - recently used key is not touched again",
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashToken(key)).
+					Return(&entity.APIKeyDTO{KeyUUID: "key_uuid", BotUUID: "bot_uuid", LastUsedAt: &justUsed}, nil)
+			},
+			wantBot: "bot_uuid",
+		},
+		{
+			name: "error - unknown key",
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashToken(key)).Return(nil, nil)
+			},
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name: "error - revoked key",
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashToken(key)).
+					Return(&entity.APIKeyDTO{KeyUUID: "key_uuid", BotUUID: "bot_uuid", RevokedAt: &past}, nil)
+			},
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name: "error - expired key",
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashToken(key)).
+					Return(&entity.APIKeyDTO{KeyUUID: "key_uuid", BotUUID: "bot_uuid", ExpiresAt: &past}, nil)
+			},
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name: "error - bot deleted",
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashToken(key)).
+					Return(&entity.APIKeyDTO{KeyUUID: "key_uuid", BotUUID: "bot_uuid", BotDeletedAt: &past}, nil)
+			},
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name: "error - owner suspended",
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashToken(key)).
+					Return(&entity.APIKeyDTO{KeyUUID: "key_uuid", BotUUID: "bot_uuid", OwnerSuspendedAt: &past}, nil)
+			},
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name: "error - owner account pending deletion",
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashToken(key)).
+					Return(&entity.APIKeyDTO{KeyUUID: "key_uuid", BotUUID: "bot_uuid", OwnerDeleteAfter: &future}, nil)
+			},
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name: "error getting key",
+			setupMocks: func(mockRepo *mocks.MockBotRepo) {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashToken(key)).Return(nil, fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories
+			mockRepo := mocks.NewMockBotRepo(ctrl)
+			mockGroupChatRepo := mocks.NewMockGroupChatRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := NewBot(mockRepo, mockGroupChatRepo)
+
+			// Call the method under test
+			got, err := uc.AuthenticateAPIKey(context.Background(), key)
+			if tt.wantErr != nil || tt.wantAnyErr {
+				if err == nil || (tt.wantErr != nil && err != tt.wantErr) {
+					t.Errorf("BotUseCase.AuthenticateAPIKey() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BotUseCase.AuthenticateAPIKey() unexpected error = %v", err)
+			}
+			if got.BotUUID != tt.wantBot {
+				t.Errorf("BotUseCase.AuthenticateAPIKey() bot = %v, want %v", got.BotUUID, tt.wantBot)
+			}
+		})
+	}
+}
+
+func TestBotUseCase_AuthorizeConversation(t *testing.T) {
+	principal := entity.APIKeyPrincipal{
+		KeyUUID:           "key_uuid",
+		BotUUID:           "bot_uuid",
+		Scopes:            []string{entity.ScopeMessagesWrite},
+		ConversationUUIDs: []string{"conv_uuid"},
+	}
+
+	// Define the structure of each test case
+	type testCase struct {
+		name             string                                           // Name of the test case
+		conversationUUID string                                           // Conversation the bot wants to post to
+		setupMocks       func(mockGroupChatRepo *mocks.MockGroupChatRepo) // Function to set up mock behavior
+		wantErr          error                                            // Expected error, if any
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:             "success",
+			conversationUUID: "conv_uuid",
+			setupMocks: func(mockGroupChatRepo *mocks.MockGroupChatRepo) {
+				mockGroupChatRepo.EXPECT().ValidateUserInGroupChat(gomock.Any(), "conv_uuid", "bot_uuid").Return(true, nil)
+			},
+		},
+		{
+			name:             "error - conversation not granted to the key",
+			conversationUUID: "other_conv",
+			wantErr:          entity.ErrAPIKeyForbidden,
+		},
+		{
+			name:             "error - bot removed from the conversation",
+			conversationUUID: "conv_uuid",
+			setupMocks: func(mockGroupChatRepo *mocks.MockGroupChatRepo) {
+				mockGroupChatRepo.EXPECT().ValidateUserInGroupChat(gomock.Any(), "conv_uuid", "bot_uuid").Return(false, nil)
+			},
+			wantErr: entity.ErrAPIKeyForbidden,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories
+			mockRepo := mocks.NewMockBotRepo(ctrl)
+			mockGroupChatRepo := mocks.NewMockGroupChatRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockGroupChatRepo)
+			}
+
+			uc := NewBot(mockRepo, mockGroupChatRepo)
+
+			// Call the method under test
+			err := uc.AuthorizeConversation(context.Background(), principal, tt.conversationUUID, entity.ScopeMessagesWrite)
+			if err != tt.wantErr {
+				t.Errorf("BotUseCase.AuthorizeConversation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
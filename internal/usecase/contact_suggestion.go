@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+const (
+	// Only the best ranked users are cached, so that reading the suggestions stays cheap
+	_suggestionCacheSize    = 100
+	_suggestionDefaultLimit = 20
+	_suggestionMaxLimit     = 50
+)
+
+// ContactSuggestionUseCase suggests users the user may know, ranked by their mutual contacts and shared group chats.
+// The suggestions of each user are cached, and only computed again once a contact or group change around the user
+// invalidated them, or once they are older than ttl.
+type ContactSuggestionUseCase struct {
+	repo ContactSuggestionRepo
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// NewContactSuggestion -.
+func NewContactSuggestion(r ContactSuggestionRepo, ttl time.Duration) *ContactSuggestionUseCase {
+	return &ContactSuggestionUseCase{
+		repo: r,
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+// GetSuggestions returns the best ranked users the user may know.
+// Users who are blocked, removed, already requested or who do not accept contacts from the user are left out.
+func (uc *ContactSuggestionUseCase) GetSuggestions(ctx context.Context, userUUID string, limit int) ([]entity.ContactSuggestion, error) {
+	if limit <= 0 {
+		limit = _suggestionDefaultLimit
+	}
+	if limit > _suggestionMaxLimit {
+		limit = _suggestionMaxLimit
+	}
+
+	// Get when the suggestions were computed from contact suggestion data repository by querying 'contact_suggestion_state' table
+	state, err := uc.repo.GetSuggestionState(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("ContactSuggestionUseCase - GetSuggestions - uc.repo.GetSuggestionState: %w", err)
+	}
+
+	// Compute the suggestions of the user only, from the 'contacts' and 'participants' tables
+	if uc.stale(state) {
+		err = uc.repo.RefreshSuggestions(ctx, userUUID, _suggestionCacheSize)
+		if err != nil {
+			return nil, fmt.Errorf("ContactSuggestionUseCase - GetSuggestions - uc.repo.RefreshSuggestions: %w", err)
+		}
+	}
+
+	// Get the cached suggestions from 'contact_suggestions' table using contact suggestion data repository
+	suggestions, err := uc.repo.GetSuggestions(ctx, userUUID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ContactSuggestionUseCase - GetSuggestions - uc.repo.GetSuggestions: %w", err)
+	}
+	return suggestions, nil
+}
+
+// stale tells whether the suggestions have to be computed again, they are stale if they were never computed
+func (uc *ContactSuggestionUseCase) stale(state *entity.ContactSuggestionState) bool {
+	if state == nil {
+		return true
+	}
+	if state.InvalidatedAt != nil && state.InvalidatedAt.After(state.ComputedAt) {
+		return true
+	}
+	return uc.now().Sub(state.ComputedAt) >= uc.ttl
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+)
+
+func TestContactSuggestionUseCase_GetSuggestions(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	computedAt := now.Add(-time.Hour)
+	invalidatedBefore := computedAt.Add(-time.Minute)
+	invalidatedAfter := computedAt.Add(time.Minute)
+	suggestions := []entity.ContactSuggestion{{User: entity.UserProfile{UserUUID: testContactUserUUID}, Username: testContactUserName, MutualContacts: 2, SharedGroups: 1}}
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                          // Name of the test case
+		limit      int                                             // Requested number of suggestions
+		setupMocks func(mockRepo *mocks.MockContactSuggestionRepo) // Function to set up mock behavior
+		want       []entity.ContactSuggestion                      // Expected suggestions
+		wantErr    bool                                            // Whether an error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:  "success - cached suggestions are fresh",
+			limit: 5,
+			setupMocks: func(mockRepo *mocks.MockContactSuggestionRepo) {
+				mockRepo.EXPECT().GetSuggestionState(gomock.Any(), testUserUUID).
+					Return(&entity.ContactSuggestionState{ComputedAt: computedAt, InvalidatedAt: &invalidatedBefore}, nil)
+				mockRepo.EXPECT().GetSuggestions(gomock.Any(), testUserUUID, 5).Return(suggestions, nil)
+			},
+			want: suggestions,
+		},
+		{
+			name: "success - never computed",
+			setupMocks: func(mockRepo *mocks.MockContactSuggestionRepo) {
+				mockRepo.EXPECT().GetSuggestionState(gomock.Any(), testUserUUID).Return(nil, nil)
+				mockRepo.EXPECT().RefreshSuggestions(gomock.Any(), testUserUUID, _suggestionCacheSize).Return(nil)
+				mockRepo.EXPECT().GetSuggestions(gomock.Any(), testUserUUID, _suggestionDefaultLimit).Return(suggestions, nil)
+			},
+			want: suggestions,
+		},
+		{
+			name:  "success - invalidated since computed",
+			limit: 1000,
+			setupMocks: func(mockRepo *mocks.MockContactSuggestionRepo) {
+				mockRepo.EXPECT().GetSuggestionState(gomock.Any(), testUserUUID).
+					Return(&entity.ContactSuggestionState{ComputedAt: computedAt, InvalidatedAt: &invalidatedAfter}, nil)
+				mockRepo.EXPECT().RefreshSuggestions(gomock.Any(), testUserUUID, _suggestionCacheSize).Return(nil)
+				mockRepo.EXPECT().GetSuggestions(gomock.Any(), testUserUUID, _suggestionMaxLimit).Return(nil, nil)
+			},
+		},
+		{
+			name: "success - older than the ttl",
+			setupMocks: func(mockRepo *mocks.MockContactSuggestionRepo) {
+				mockRepo.EXPECT().GetSuggestionState(gomock.Any(), testUserUUID).
+					Return(&entity.ContactSuggestionState{ComputedAt: now.Add(-25 * time.Hour)}, nil)
+				mockRepo.EXPECT().RefreshSuggestions(gomock.Any(), testUserUUID, _suggestionCacheSize).Return(nil)
+				mockRepo.EXPECT().GetSuggestions(gomock.Any(), testUserUUID, _suggestionDefaultLimit).Return(suggestions, nil)
+			},
+			want: suggestions,
+		},
+		{
+			name: "error refreshing suggestions",
+			setupMocks: func(mockRepo *mocks.MockContactSuggestionRepo) {
+				mockRepo.EXPECT().GetSuggestionState(gomock.Any(), testUserUUID).Return(nil, nil)
+				mockRepo.EXPECT().RefreshSuggestions(gomock.Any(), testUserUUID, _suggestionCacheSize).Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create a mock instance of the repository
+			mockRepo := mocks.NewMockContactSuggestionRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := NewContactSuggestion(mockRepo, 24*time.Hour)
+			uc.now = func() time.Time { return now }
+
+			// Call the method under test
+			got, err := uc.GetSuggestions(context.Background(), testUserUUID, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ContactSuggestionUseCase.GetSuggestions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ContactSuggestionUseCase.GetSuggestions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,79 +2,494 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/maxyong7/chat-messaging-app/internal/entity"
 )
 
+const (
+	// Shorter salts would let the same hashes be matched against other uploads
+	_contactImportMinSaltLength = 16
+	_contactImportMaxSaltLength = 128
+	_contactImportMaxHashes     = 1000
+
+	_contactsDefaultLimit     = 50
+	_contactsMaxLimit         = 200
+	_contactNicknameMaxLength = 64
+	_contactLabelMaxLength    = 32
+	_contactMaxLabels         = 20
+)
+
 type ContactsUseCase struct {
 	repo         ContactsRepo
 	userInfoRepo UserRepo
+	privacyRepo  PrivacyRepo
+	// importLimiter limits how often each user can match their address book, so that it can not be used to probe email addresses
+	importLimiter *rateLimiter
 }
 
-func NewContacts(r ContactsRepo, userInfoRepo UserRepo) *ContactsUseCase {
+// NewContacts -.
+// Each user can import contacts importRateLimit times per importRateWindow, an importRateLimit of zero disables the limit.
+func NewContacts(r ContactsRepo, userInfoRepo UserRepo, privacyRepo PrivacyRepo, importRateLimit int, importRateWindow time.Duration) *ContactsUseCase {
 	return &ContactsUseCase{
-		repo:         r,
-		userInfoRepo: userInfoRepo,
+		repo:          r,
+		userInfoRepo:  userInfoRepo,
+		privacyRepo:   privacyRepo,
+		importLimiter: newRateLimiter(importRateLimit, importRateWindow),
 	}
 }
 
-func (uc *ContactsUseCase) GetContacts(ctx context.Context, userUuid string) ([]entity.Contacts, error) {
+// GetContacts returns a page of the contacts of the user, optionally only the favourites or the contacts with a label.
+func (uc *ContactsUseCase) GetContacts(ctx context.Context, query entity.ContactsQuery) (entity.ContactsPage, error) {
+	// Return error if the sort is unknown. Will be handled by controller
+	switch query.Sort {
+	case "":
+		query.Sort = entity.ContactsSortName
+	case entity.ContactsSortName, entity.ContactsSortFavourite:
+	default:
+		return entity.ContactsPage{}, entity.ErrInvalidContactsQuery
+	}
+
+	// Labels are stored in lower case, so the filter is too
+	if query.Label != "" {
+		label, ok := normalizeContactLabel(query.Label)
+		if !ok {
+			return entity.ContactsPage{}, entity.ErrInvalidContactsQuery
+		}
+		query.Label = label
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = _contactsDefaultLimit
+	}
+	if query.Limit > _contactsMaxLimit {
+		query.Limit = _contactsMaxLimit
+	}
+	limit := query.Limit
+
+	// One more contact than requested tells whether there is a next page
+	query.Limit++
+
 	// Query 'contacts' table from contacts data repository
 	// Then join 'user_info' table on 'user_uuid' to get user's firstname, lastname and avatar
-	contacts, err := uc.repo.GetContactsByUserUUID(ctx, userUuid)
+	contacts, err := uc.repo.GetContactsByUserUUID(ctx, query)
+	if err != nil {
+		return entity.ContactsPage{}, fmt.Errorf("ContactsUseCase - GetContacts - GetContactsByUserUUID: %w", err)
+	}
+
+	// The background job may not have cleared the expired statuses yet
+	now := time.Now()
+	for i := range contacts {
+		if contacts[i].Status.Expired(now) {
+			contacts[i].Status = nil
+		}
+	}
+
+	page := entity.ContactsPage{Contacts: contacts, Limit: limit}
+	if len(contacts) > limit {
+		page.Contacts = contacts[:limit]
+		page.NextCursor = page.Contacts[limit-1].UserUUID
+	}
+	return page, nil
+}
+
+// UpdateContactDetails sets the nickname, favourite and labels the user gave to the contact.
+func (uc *ContactsUseCase) UpdateContactDetails(ctx context.Context, contactUserName string, userUuid string, details entity.ContactDetails) error {
+	// Return error if the nickname or the labels are not valid. Will be handled by controller
+	details, ok := normalizeContactDetails(details)
+	if !ok {
+		return entity.ErrInvalidContactDetails
+	}
+
+	// Check if user exists by querying 'user_credentials' table in user data repository
+	contactUserUUID, err := uc.userInfoRepo.GetUserUUIDByUsername(ctx, contactUserName)
+	if err != nil {
+		return fmt.Errorf("ContactsUseCase - UpdateContactDetails - GetUserUUIDByUsername: %w", err)
+	}
+
+	// Return error if username does not exist. Will be handled by controller
+	if contactUserUUID == nil {
+		return entity.ErrUserNameNotFound
+	}
+
+	// Update the details in 'contacts' table from contacts data repository
+	found, err := uc.repo.UpdateContactDetails(ctx, userUuid, *contactUserUUID, details)
 	if err != nil {
-		return nil, fmt.Errorf("ContactsUseCase - GetContacts - GetContactsByUserUUID: %w", err)
+		return fmt.Errorf("ContactsUseCase - UpdateContactDetails - uc.repo.UpdateContactDetails: %w", err)
+	}
+
+	// Return error if contact does not exist. Will be handled by controller
+	if !found {
+		return entity.ErrContactDoesNotExists
 	}
-	return contacts, nil
+	return nil
 }
 
-func (uc *ContactsUseCase) AddContact(ctx context.Context, contactUserName string, userUuid string) error {
+// AddContact sends a contact request to the user with the username.
+// If they already sent one to the user, their request is accepted instead and both users are added to the contacts of each other.
+func (uc *ContactsUseCase) AddContact(ctx context.Context, contactUserName string, userUuid string) (entity.ContactRequest, error) {
 	// Check username exists in user repository by querying 'user_credentials' table
 	contactUserUUID, err := uc.userInfoRepo.GetUserUUIDByUsername(ctx, contactUserName)
 	if err != nil {
-		return fmt.Errorf("ContactsUseCase - AddContacts - GetUserUUIDByUsername: %w", err)
+		return entity.ContactRequest{}, fmt.Errorf("ContactsUseCase - AddContacts - GetUserUUIDByUsername: %w", err)
 	}
 
 	// Return error if contact is not found. Will be handled by controller
 	if contactUserUUID == nil {
-		return entity.ErrUserNameNotFound
+		return entity.ContactRequest{}, entity.ErrUserNameNotFound
+	}
+
+	// Return error if the user adds themselves. Will be handled by controller
+	if *contactUserUUID == userUuid {
+		return entity.ContactRequest{}, entity.ErrInvalidContactRequest
 	}
 
 	// Check contact exist from contacts data repository by querying 'contacts' table
-	exist, err := uc.repo.CheckContactExist(ctx, userUuid, *contactUserUUID)
+	contact, err := uc.repo.GetContact(ctx, userUuid, *contactUserUUID)
 	if err != nil {
-		return err
+		return entity.ContactRequest{}, fmt.Errorf("ContactsUseCase - AddContacts - uc.repo.GetContact: %w", err)
+	}
+
+	// Return error if the user already has the contact. Removed contacts have to be requested again
+	if contact != nil && !contact.Removed {
+		return entity.ContactRequest{}, entity.ErrContactAlreadyExists
+	}
+
+	// Accept the request of the contact if they already sent one to the user
+	incoming, err := uc.repo.GetPendingContactRequest(ctx, *contactUserUUID, userUuid)
+	if err != nil {
+		return entity.ContactRequest{}, fmt.Errorf("ContactsUseCase - AddContacts - uc.repo.GetPendingContactRequest: %w", err)
+	}
+	if incoming != nil {
+		return uc.acceptContactRequest(ctx, *incoming)
+	}
+
+	// Check the privacy settings of the contact allow the user to add them
+	allowed, err := uc.canAddContact(ctx, userUuid, *contactUserUUID)
+	if err != nil {
+		return entity.ContactRequest{}, fmt.Errorf("ContactsUseCase - AddContacts - uc.canAddContact: %w", err)
+	}
+
+	// Return error if the contact does not accept contacts from the user. Will be handled by controller
+	if !allowed {
+		return entity.ContactRequest{}, entity.ErrContactNotAllowed
+	}
+
+	// Return error if the user already sent a request to the contact. Will be handled by controller
+	outgoing, err := uc.repo.GetPendingContactRequest(ctx, userUuid, *contactUserUUID)
+	if err != nil {
+		return entity.ContactRequest{}, fmt.Errorf("ContactsUseCase - AddContacts - uc.repo.GetPendingContactRequest: %w", err)
+	}
+	if outgoing != nil {
+		return entity.ContactRequest{}, entity.ErrContactRequestExists
+	}
+
+	// Convert arguments into contactRequestDTO
+	request := entity.ContactRequestDTO{
+		RequestUUID:  uuid.New().String(),
+		FromUserUUID: userUuid,
+		ToUserUUID:   *contactUserUUID,
+		Status:       entity.ContactRequestPending,
+		CreatedAt:    time.Now(),
 	}
 
-	if exist {
-		// If contact exist, then update 'remove' column on 'contacts' table in contacts data respository
-		err := uc.repo.UpdateRemovedStatus(ctx, entity.ContactsDTO{
-			UserUUID:        userUuid,
-			ContactUserUUID: *contactUserUUID,
-			Removed:         false,
+	// Store the request into 'contact_requests' table in contacts data repository
+	err = uc.repo.StoreContactRequest(ctx, request)
+	if err != nil {
+		return entity.ContactRequest{}, fmt.Errorf("ContactsUseCase - AddContacts - uc.repo.StoreContactRequest: %w", err)
+	}
+	return toContactRequest(request), nil
+}
+
+// GetContactRequests returns the pending contact requests sent to the user or sent by the user.
+func (uc *ContactsUseCase) GetContactRequests(ctx context.Context, userUUID string, direction string) ([]entity.ContactRequest, error) {
+	// Query 'contact_requests' table from contacts data repository
+	// Then join 'user_info' table to get the firstname, lastname and avatar of the other user
+	requests, err := uc.repo.GetContactRequests(ctx, userUUID, direction)
+	if err != nil {
+		return nil, fmt.Errorf("ContactsUseCase - GetContactRequests - uc.repo.GetContactRequests: %w", err)
+	}
+	return requests, nil
+}
+
+// AcceptContactRequest accepts a request sent to the user, both users are added to the contacts of each other.
+func (uc *ContactsUseCase) AcceptContactRequest(ctx context.Context, requestUUID string, userUUID string) (entity.ContactRequest, error) {
+	request, err := uc.getPendingRequest(ctx, requestUUID, func(request entity.ContactRequestDTO) bool {
+		return request.ToUserUUID == userUUID
+	})
+	if err != nil {
+		return entity.ContactRequest{}, err
+	}
+	return uc.acceptContactRequest(ctx, request)
+}
+
+// DeclineContactRequest declines a request sent to the user.
+func (uc *ContactsUseCase) DeclineContactRequest(ctx context.Context, requestUUID string, userUUID string) (entity.ContactRequest, error) {
+	request, err := uc.getPendingRequest(ctx, requestUUID, func(request entity.ContactRequestDTO) bool {
+		return request.ToUserUUID == userUUID
+	})
+	if err != nil {
+		return entity.ContactRequest{}, err
+	}
+	return uc.closeContactRequest(ctx, request, entity.ContactRequestDeclined)
+}
+
+// CancelContactRequest cancels a request sent by the user.
+func (uc *ContactsUseCase) CancelContactRequest(ctx context.Context, requestUUID string, userUUID string) (entity.ContactRequest, error) {
+	request, err := uc.getPendingRequest(ctx, requestUUID, func(request entity.ContactRequestDTO) bool {
+		return request.FromUserUUID == userUUID
+	})
+	if err != nil {
+		return entity.ContactRequest{}, err
+	}
+	return uc.closeContactRequest(ctx, request, entity.ContactRequestCancelled)
+}
+
+// ImportContacts returns the registered users whose email address matches one of the uploaded hashes.
+// If the import adds them, a contact request is sent to every match that is not a contact yet and accepts contacts from the user,
+// all of them in one transaction.
+func (uc *ContactsUseCase) ImportContacts(ctx context.Context, contactImport entity.ContactImport) ([]entity.ContactMatch, error) {
+	// Return error if the user imported too often. Will be handled by controller
+	if !uc.importLimiter.allow(contactImport.UserUUID, time.Now()) {
+		return nil, entity.ErrTooManyRequests
+	}
+
+	// Return error if the salt or the hashes are not valid. Will be handled by controller
+	hashes, ok := normalizeEmailHashes(contactImport.Hashes)
+	saltLength := utf8.RuneCountInString(contactImport.Salt)
+	if !ok || saltLength < _contactImportMinSaltLength || saltLength > _contactImportMaxSaltLength {
+		return nil, entity.ErrInvalidContactImport
+	}
+
+	// Match the hashes against 'user_credentials' table from contacts data repository
+	matches, err := uc.repo.MatchEmailHashes(ctx, contactImport.UserUUID, contactImport.Salt, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("ContactsUseCase - ImportContacts - uc.repo.MatchEmailHashes: %w", err)
+	}
+	if !contactImport.Add || len(matches) == 0 {
+		return matches, nil
+	}
+
+	// Only request the matches who accept contacts from the user, as set in their privacy settings
+	requests, err := uc.newImportRequests(ctx, contactImport.UserUUID, matches)
+	if err != nil {
+		return nil, fmt.Errorf("ContactsUseCase - ImportContacts - uc.newImportRequests: %w", err)
+	}
+	if len(requests) == 0 {
+		return matches, nil
+	}
+
+	// Store the requests into 'contact_requests' table in contacts data repository
+	stored, err := uc.repo.StoreContactRequests(ctx, requests)
+	if err != nil {
+		return nil, fmt.Errorf("ContactsUseCase - ImportContacts - uc.repo.StoreContactRequests: %w", err)
+	}
+
+	// Requests that were already pending are not stored again
+	storedByUser := make(map[string]entity.ContactRequest, len(stored))
+	for _, request := range stored {
+		storedByUser[request.ToUserUUID] = toContactRequest(request)
+	}
+	for i := range matches {
+		if request, ok := storedByUser[matches[i].User.UserUUID]; ok {
+			matches[i].Request = &request
+		}
+	}
+	return matches, nil
+}
+
+// newImportRequests builds the contact requests to the matches that are not contacts of the user yet and accept contacts from them.
+func (uc *ContactsUseCase) newImportRequests(ctx context.Context, userUUID string, matches []entity.ContactMatch) ([]entity.ContactRequestDTO, error) {
+	userUUIDs := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if !match.Contact {
+			userUUIDs = append(userUUIDs, match.User.UserUUID)
+		}
+	}
+	if len(userUUIDs) == 0 {
+		return nil, nil
+	}
+
+	// Get the privacy settings of every match at once, users without settings accept contacts from everyone
+	settingsDTOs, err := uc.privacyRepo.GetPrivacySettingsByUserUUIDs(ctx, userUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	settings := make(map[string]entity.PrivacySettings, len(settingsDTOs))
+	for _, settingsDTO := range settingsDTOs {
+		settings[settingsDTO.UserUUID] = toPrivacySettings(settingsDTO)
+	}
+
+	now := time.Now()
+	var requests []entity.ContactRequestDTO
+	for _, contactUserUUID := range userUUIDs {
+		userSettings, ok := settings[contactUserUUID]
+		if !ok {
+			userSettings = entity.DefaultPrivacySettings()
+		}
+
+		switch userSettings.WhoCanAddContact {
+		case entity.PrivacyNobody:
+			continue
+		case entity.PrivacyContactsOfContacts:
+			// Check the user is known to the match by querying 'contacts' table
+			known, err := uc.repo.CheckContactOfContact(ctx, contactUserUUID, userUUID)
+			if err != nil {
+				return nil, err
+			}
+			if !known {
+				continue
+			}
+		}
+
+		requests = append(requests, entity.ContactRequestDTO{
+			RequestUUID:  uuid.New().String(),
+			FromUserUUID: userUUID,
+			ToUserUUID:   contactUserUUID,
+			Status:       entity.ContactRequestPending,
+			CreatedAt:    now,
 		})
+	}
+	return requests, nil
+}
+
+// normalizeEmailHashes lower cases the hashes and removes duplicates.
+// It returns false if there are none, too many, or one of them is not a hex encoded SHA-256 hash.
+func normalizeEmailHashes(hashes []string) ([]string, bool) {
+	if len(hashes) == 0 || len(hashes) > _contactImportMaxHashes {
+		return nil, false
+	}
+
+	seen := make(map[string]bool, len(hashes))
+	normalized := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		hash = strings.ToLower(strings.TrimSpace(hash))
+		decoded, err := hex.DecodeString(hash)
+		if err != nil || len(decoded) != sha256.Size {
+			return nil, false
+		}
+		if !seen[hash] {
+			seen[hash] = true
+			normalized = append(normalized, hash)
+		}
+	}
+	return normalized, true
+}
 
-		if err != nil {
-			return fmt.Errorf("ContactsUseCase - AddContacts - uc.repo.UpdateRemoved: %w", err)
+// normalizeContactDetails trims the nickname, lower cases the labels and drops duplicated labels.
+func normalizeContactDetails(details entity.ContactDetails) (entity.ContactDetails, bool) {
+	if details.Nickname != nil {
+		nickname := strings.TrimSpace(*details.Nickname)
+		if utf8.RuneCountInString(nickname) > _contactNicknameMaxLength {
+			return entity.ContactDetails{}, false
 		}
-		return nil
+		details.Nickname = &nickname
 	}
 
-	// Convert arguments into contactsDTO
-	contactsDTO := entity.ContactsDTO{
-		UserUUID:         userUuid,
-		ContactUserUUID:  *contactUserUUID,
-		ConversationUUID: uuid.New().String(),
+	if details.Labels != nil {
+		if len(*details.Labels) > _contactMaxLabels {
+			return entity.ContactDetails{}, false
+		}
+		seen := make(map[string]bool, len(*details.Labels))
+		labels := make([]string, 0, len(*details.Labels))
+		for _, label := range *details.Labels {
+			label, ok := normalizeContactLabel(label)
+			if !ok {
+				return entity.ContactDetails{}, false
+			}
+			if !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+		details.Labels = &labels
 	}
+	return details, true
+}
+
+func normalizeContactLabel(label string) (string, bool) {
+	label = strings.ToLower(strings.TrimSpace(label))
+	length := utf8.RuneCountInString(label)
+	return label, length > 0 && length <= _contactLabelMaxLength
+}
 
-	// Store contact into 'contacts' table in contacts data repository
-	err = uc.repo.StoreContacts(ctx, contactsDTO)
+// getPendingRequest returns the pending request if the user is allowed to act on it.
+// Requests of other users are not found, so that their existence is not leaked.
+func (uc *ContactsUseCase) getPendingRequest(ctx context.Context, requestUUID string, allowed func(entity.ContactRequestDTO) bool) (entity.ContactRequestDTO, error) {
+	// Get the request from contacts data repository by querying 'contact_requests' table
+	request, err := uc.repo.GetContactRequest(ctx, requestUUID)
 	if err != nil {
-		return fmt.Errorf("ContactsUseCase - AddContacts - uc.repo.StoreContacts: %w", err)
+		return entity.ContactRequestDTO{}, fmt.Errorf("ContactsUseCase - getPendingRequest - uc.repo.GetContactRequest: %w", err)
+	}
+
+	// Return error if request is not found. Will be handled by controller
+	if request == nil || !allowed(*request) {
+		return entity.ContactRequestDTO{}, entity.ErrContactRequestNotFound
+	}
+
+	// Return error if the request was already answered or cancelled. Will be handled by controller
+	if request.Status != entity.ContactRequestPending {
+		return entity.ContactRequestDTO{}, entity.ErrContactRequestNotPending
+	}
+	return *request, nil
+}
+
+func (uc *ContactsUseCase) acceptContactRequest(ctx context.Context, request entity.ContactRequestDTO) (entity.ContactRequest, error) {
+	// The direct conversation is only created once the request is accepted
+	request.ConversationUUID = uuid.New().String()
+
+	// Accept the request and store both contacts into 'contacts' table in contacts data repository
+	conversationUUID, err := uc.repo.AcceptContactRequest(ctx, request)
+	if err != nil {
+		return entity.ContactRequest{}, fmt.Errorf("ContactsUseCase - acceptContactRequest - uc.repo.AcceptContactRequest: %w", err)
+	}
+
+	// Return error if the request was answered or cancelled in the meantime. Will be handled by controller
+	if conversationUUID == "" {
+		return entity.ContactRequest{}, entity.ErrContactRequestNotPending
+	}
+
+	now := time.Now()
+	request.Status = entity.ContactRequestAccepted
+	request.ConversationUUID = conversationUUID
+	request.RespondedAt = &now
+	return toContactRequest(request), nil
+}
+
+func (uc *ContactsUseCase) closeContactRequest(ctx context.Context, request entity.ContactRequestDTO, status string) (entity.ContactRequest, error) {
+	// Update 'status' column in 'contact_requests' table from contacts data repository
+	updated, err := uc.repo.UpdateContactRequestStatus(ctx, request.RequestUUID, status)
+	if err != nil {
+		return entity.ContactRequest{}, fmt.Errorf("ContactsUseCase - closeContactRequest - uc.repo.UpdateContactRequestStatus: %w", err)
+	}
+
+	// Return error if the request was answered or cancelled in the meantime. Will be handled by controller
+	if !updated {
+		return entity.ContactRequest{}, entity.ErrContactRequestNotPending
+	}
+
+	now := time.Now()
+	request.Status = status
+	request.RespondedAt = &now
+	return toContactRequest(request), nil
+}
+
+func toContactRequest(request entity.ContactRequestDTO) entity.ContactRequest {
+	return entity.ContactRequest{
+		RequestUUID:      request.RequestUUID,
+		FromUserUUID:     request.FromUserUUID,
+		ToUserUUID:       request.ToUserUUID,
+		Status:           request.Status,
+		ConversationUUID: request.ConversationUUID,
+		CreatedAt:        request.CreatedAt,
+		RespondedAt:      request.RespondedAt,
 	}
-	return nil
 }
 
 func (uc *ContactsUseCase) RemoveContact(ctx context.Context, contactUserName string, userUuid string) error {
@@ -151,3 +566,21 @@ func (uc *ContactsUseCase) UpdateBlockContact(ctx context.Context, contactUserNa
 	}
 	return nil
 }
+
+// canAddContact checks who the contact accepts contacts from, as set in their privacy settings.
+func (uc *ContactsUseCase) canAddContact(ctx context.Context, userUuid string, contactUserUUID string) (bool, error) {
+	settings, err := getPrivacySettings(ctx, uc.privacyRepo, contactUserUUID)
+	if err != nil {
+		return false, err
+	}
+
+	switch settings.WhoCanAddContact {
+	case entity.PrivacyNobody:
+		return false, nil
+	case entity.PrivacyContactsOfContacts:
+		// Check the user is known to the contact by querying 'contacts' table
+		return uc.repo.CheckContactOfContact(ctx, contactUserUUID, userUuid)
+	default:
+		return true, nil
+	}
+}
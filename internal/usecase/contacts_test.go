@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/maxyong7/chat-messaging-app/internal/entity"
@@ -20,18 +22,14 @@ var (
 
 // Test function to validate the GetContacts method of ContactsUseCase.
 func TestContactsUseCase_GetContacts(t *testing.T) {
-	// Structure to hold the arguments passed to GetContacts
-	type args struct {
-		ctx      context.Context // Context passed to the method
-		userUuid string          // User UUID for which contacts are fetched
-	}
 	// Structure to hold test case data
 	type testCase struct {
-		name       string                                                                   // Test case name
-		args       args                                                                     // Arguments passed to the method
-		setupMocks func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo) // Function to set up mocks
-		want       []entity.Contacts                                                        // Expected result
-		wantErr    bool                                                                     // Indicates if error is expected
+		name       string                                 // Test case name
+		query      entity.ContactsQuery                   // Query passed to the method
+		setupMocks func(mockRepo *mocks.MockContactsRepo) // Function to set up mocks
+		want       entity.ContactsPage                    // Expected result
+		wantErr    error                                  // Expected error, if any
+		wantAnyErr bool                                   // Indicates if any error is expected
 	}
 
 	// Defining an expected successful contact to be returned
@@ -44,39 +42,56 @@ func TestContactsUseCase_GetContacts(t *testing.T) {
 		},
 		ConversationUUID: "conversation_1234", // Example conversation UUID
 		Blocked:          false,               // Block status of the contact
+		Nickname:         "test_nickname",     // Nickname given by the user
+		Labels:           []string{"family"},  // Labels given by the user
 	}
+	otherContact := entity.Contacts{UserProfile: entity.UserProfile{UserUUID: "other_contact_uuid"}}
 
 	// Defining test cases for GetContacts method
 	tests := []testCase{
 		{
-			name: "success", // Successful retrieval of contacts
-			args: args{
-				ctx:      context.Background(), // Context to be passed
-				userUuid: testUserUUID,         // Test user UUID
-			},
+			name:  "success - defaults are applied", // Successful retrieval of contacts
+			query: entity.ContactsQuery{UserUUID: testUserUUID},
 			// Mocking the GetContactsByUserUUID method for a successful case
-			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo) {
+			setupMocks: func(mockRepo *mocks.MockContactsRepo) {
 				mockRepo.EXPECT().
-					GetContactsByUserUUID(gomock.Any(), testUserUUID).
+					GetContactsByUserUUID(gomock.Any(), entity.ContactsQuery{UserUUID: testUserUUID, Sort: entity.ContactsSortName, Limit: 51}).
 					Return([]entity.Contacts{successfulGetContacts}, nil) // Returning success response
 			},
-			want:    []entity.Contacts{successfulGetContacts}, // Expected contact to be returned
-			wantErr: false,                                    // No error expected
+			want: entity.ContactsPage{Contacts: []entity.Contacts{successfulGetContacts}, Limit: 50}, // Expected contact to be returned
 		},
 		{
-			name: "error fetching contacts", // Error case when fetching contacts
-			args: args{
-				ctx:      context.Background(),
-				userUuid: testUserUUID,
+			name:  "success - next page", // One more contact than the limit is returned by the repository
+			query: entity.ContactsQuery{UserUUID: testUserUUID, Label: " Family ", Favourite: true, Sort: entity.ContactsSortFavourite, Cursor: "cursor_uuid", Limit: 1},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo) {
+				mockRepo.EXPECT().
+					GetContactsByUserUUID(gomock.Any(), entity.ContactsQuery{
+						UserUUID:  testUserUUID,
+						Label:     "family",
+						Favourite: true,
+						Sort:      entity.ContactsSortFavourite,
+						Cursor:    "cursor_uuid",
+						Limit:     2,
+					}).
+					Return([]entity.Contacts{successfulGetContacts, otherContact}, nil)
 			},
+			want: entity.ContactsPage{Contacts: []entity.Contacts{successfulGetContacts}, NextCursor: testContactUserUUID, Limit: 1},
+		},
+		{
+			name:    "error - unknown sort",
+			query:   entity.ContactsQuery{UserUUID: testUserUUID, Sort: "age"},
+			wantErr: entity.ErrInvalidContactsQuery,
+		},
+		{
+			name:  "error fetching contacts", // Error case when fetching contacts
+			query: entity.ContactsQuery{UserUUID: testUserUUID},
 			// Mocking the GetContactsByUserUUID method to return an error
-			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo) {
+			setupMocks: func(mockRepo *mocks.MockContactsRepo) {
 				mockRepo.EXPECT().
-					GetContactsByUserUUID(gomock.Any(), testUserUUID).
+					GetContactsByUserUUID(gomock.Any(), gomock.Any()).
 					Return(nil, fmt.Errorf("some error")) // Returning error response
 			},
-			want:    nil,  // No contacts expected due to error
-			wantErr: true, // Error expected
+			wantAnyErr: true, // Error expected
 		},
 	}
 
@@ -86,29 +101,32 @@ func TestContactsUseCase_GetContacts(t *testing.T) {
 			ctrl := gomock.NewController(t) // Creating a mock controller
 			defer ctrl.Finish()             // Ensuring mock controller is cleaned up
 
-			// Create mock instances for ContactsRepo and UserRepo
+			// Create mock instance for ContactsRepo
 			mockRepo := mocks.NewMockContactsRepo(ctrl)
-			mockUserRepo := mocks.NewMockUserRepo(ctrl)
 
 			// Setup mocks for the specific test case
 			if tt.setupMocks != nil {
-				tt.setupMocks(mockRepo, mockUserRepo)
+				tt.setupMocks(mockRepo)
 			}
 
 			// Create instance of ContactsUseCase with mocked dependencies
 			uc := &ContactsUseCase{
-				repo:         mockRepo,
-				userInfoRepo: mockUserRepo,
+				repo: mockRepo,
 			}
 
 			// Call the method being tested and capture the result
-			got, err := uc.GetContacts(tt.args.ctx, tt.args.userUuid)
+			got, err := uc.GetContacts(context.Background(), tt.query)
 			// Verify if the returned error matches the expected error
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ContactsUseCase.GetContacts() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantErr != nil || tt.wantAnyErr {
+				if err == nil || (tt.wantErr != nil && err != tt.wantErr) {
+					t.Errorf("ContactsUseCase.GetContacts() error = %v, wantErr %v", err, tt.wantErr)
+				}
 				return
 			}
-			// Verify if the returned contacts match the expected contacts
+			if err != nil {
+				t.Fatalf("ContactsUseCase.GetContacts() unexpected error = %v", err)
+			}
+			// Verify if the returned page matches the expected page
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("ContactsUseCase.GetContacts() = %v, want %v", got, tt.want)
 			}
@@ -116,7 +134,99 @@ func TestContactsUseCase_GetContacts(t *testing.T) {
 	}
 }
 
-// Test function to validate the AddContact method of ContactsUseCase
+func TestContactsUseCase_UpdateContactDetails(t *testing.T) {
+	nickname := "  Mum "
+	favourite := true
+	labels := []string{"Family", "family ", "oncall"}
+
+	// Structure to hold test case data
+	type testCase struct {
+		name       string                                                                   // Test case name
+		details    entity.ContactDetails                                                    // Details passed to the method
+		setupMocks func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo) // Function to set up mocks
+		wantErr    error                                                                    // Expected error, if any
+	}
+
+	// Defining test cases for UpdateContactDetails method
+	tests := []testCase{
+		{
+			name:    "success - details are normalized",
+			details: entity.ContactDetails{Nickname: &nickname, Favourite: &favourite, Labels: &labels},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo) {
+				mockUserRepo.EXPECT().GetUserUUIDByUsername(gomock.Any(), testContactUserName).Return(&testContactUserUUID, nil)
+				mockRepo.EXPECT().UpdateContactDetails(gomock.Any(), testUserUUID, testContactUserUUID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userUUID string, contactUserUUID string, details entity.ContactDetails) (bool, error) {
+						if *details.Nickname != "Mum" || !*details.Favourite || !reflect.DeepEqual(*details.Labels, []string{"family", "oncall"}) {
+							t.Errorf("ContactsRepo.UpdateContactDetails() details = %+v", details)
+						}
+						return true, nil
+					})
+			},
+		},
+		{
+			name:    "success - omitted details are left unchanged",
+			details: entity.ContactDetails{Favourite: &favourite},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo) {
+				mockUserRepo.EXPECT().GetUserUUIDByUsername(gomock.Any(), testContactUserName).Return(&testContactUserUUID, nil)
+				mockRepo.EXPECT().UpdateContactDetails(gomock.Any(), testUserUUID, testContactUserUUID, entity.ContactDetails{Favourite: &favourite}).Return(true, nil)
+			},
+		},
+		{
+			name:    "error - label is too long",
+			details: entity.ContactDetails{Labels: &[]string{strings.Repeat("a", 33)}},
+			wantErr: entity.ErrInvalidContactDetails,
+		},
+		{
+			name:    "error - empty label",
+			details: entity.ContactDetails{Labels: &[]string{" "}},
+			wantErr: entity.ErrInvalidContactDetails,
+		},
+		{
+			name:    "error - not a contact",
+			details: entity.ContactDetails{Favourite: &favourite},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo) {
+				mockUserRepo.EXPECT().GetUserUUIDByUsername(gomock.Any(), testContactUserName).Return(&testContactUserUUID, nil)
+				mockRepo.EXPECT().UpdateContactDetails(gomock.Any(), testUserUUID, testContactUserUUID, gomock.Any()).Return(false, nil)
+			},
+			wantErr: entity.ErrContactDoesNotExists,
+		},
+		{
+			name:    "error - username not found",
+			details: entity.ContactDetails{Favourite: &favourite},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo) {
+				mockUserRepo.EXPECT().GetUserUUIDByUsername(gomock.Any(), testContactUserName).Return(nil, nil)
+			},
+			wantErr: entity.ErrUserNameNotFound,
+		},
+	}
+
+	// Loop through each test case and run the test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances for ContactsRepo and UserRepo
+			mockRepo := mocks.NewMockContactsRepo(ctrl)
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo, mockUserRepo)
+			}
+
+			uc := &ContactsUseCase{
+				repo:         mockRepo,
+				userInfoRepo: mockUserRepo,
+			}
+
+			// Call the method being tested
+			err := uc.UpdateContactDetails(context.Background(), testContactUserName, testUserUUID, tt.details)
+			if err != tt.wantErr {
+				t.Errorf("ContactsUseCase.UpdateContactDetails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestContactsUseCase_AddContact(t *testing.T) {
 	// Structure to hold the arguments passed to AddContact
 	type args struct {
@@ -126,74 +236,196 @@ func TestContactsUseCase_AddContact(t *testing.T) {
 	}
 	// Structure to hold test case data
 	type testCase struct {
-		name       string                                                                   // Test case name
-		args       args                                                                     // Arguments passed to the method
-		setupMocks func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo) // Function to set up mocks
-		wantErr    bool                                                                     // Indicates if error is expected
+		name       string                                                                                                           // Test case name
+		args       args                                                                                                             // Arguments passed to the method
+		setupMocks func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) // Function to set up mocks
+		wantStatus string                                                                                                           // Expected status of the request
+		wantErr    error                                                                                                            // Expected error, nil if none
+	}
+
+	defaultArgs := args{
+		ctx:             context.Background(),
+		contactUserName: testContactUserName, // Test contact username
+		userUuid:        testUserUUID,        // Test user UUID
+	}
+
+	// Pending request sent by the contact to the user
+	incomingRequest := entity.ContactRequestDTO{
+		RequestUUID:  "request_1234",
+		FromUserUUID: testContactUserUUID,
+		ToUserUUID:   testUserUUID,
+		Status:       entity.ContactRequestPending,
 	}
 
 	// Defining test cases for AddContact method
 	tests := []testCase{
 		{
-			name: "success", // Successful addition of contact
-			args: args{
-				ctx:             context.Background(),
-				contactUserName: testContactUserName, // Test contact username
-				userUuid:        testUserUUID,        // Test user UUID
-			},
-			// Mocking the GetUserUUIDByUsername and other methods for success case
-			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo) {
+			name: "success - request sent", // Successful contact request
+			args: defaultArgs,
+			// Mocking the GetUserUUIDByUsername and StoreContactRequest methods for success case
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
 				mockUserRepo.EXPECT().
 					GetUserUUIDByUsername(gomock.Any(), testContactUserName).
 					Return(&testContactUserUUID, nil) // Returning success response for fetching UUID
 
 				mockRepo.EXPECT().
-					CheckContactExist(gomock.Any(), testUserUUID, testContactUserUUID).
-					Return(false, nil) // Contact does not exist
-
-				mockRepo.EXPECT().
-					StoreContacts(gomock.Any(), gomock.Any()).
-					Return(nil) // Contact successfully stored
+					StoreContactRequest(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, request entity.ContactRequestDTO) error {
+						if request.FromUserUUID != testUserUUID || request.ToUserUUID != testContactUserUUID ||
+							request.Status != entity.ContactRequestPending || request.RequestUUID == "" {
+							t.Errorf("ContactsRepo.StoreContactRequest() request = %+v", request)
+						}
+						return nil
+					}) // Request successfully stored
 			},
-			wantErr: false, // No error expected
+			wantStatus: entity.ContactRequestPending,
 		},
 		{
 			name: "contacts username not found", // Case when the username does not exist
-			args: args{
-				ctx:             context.Background(),
-				contactUserName: testContactUserName,
-				userUuid:        testUserUUID,
-			},
+			args: defaultArgs,
 			// Mocking the GetUserUUIDByUsername method to return no UUID
-			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo) {
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
 				mockUserRepo.EXPECT().
 					GetUserUUIDByUsername(gomock.Any(), testContactUserName).
 					Return(nil, nil) // Username not found
 			},
-			wantErr: true, // Error expected
+			wantErr: entity.ErrUserNameNotFound, // Error expected
+		},
+		{
+			name: "user adds themselves", // Case when the username is the one of the user
+			args: defaultArgs,
+			// Mocking the GetUserUUIDByUsername method to return the user UUID
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockUserRepo.EXPECT().
+					GetUserUUIDByUsername(gomock.Any(), testContactUserName).
+					Return(&testUserUUID, nil) // Returning user UUID
+			},
+			wantErr: entity.ErrInvalidContactRequest, // Error expected
 		},
 		{
 			name: "contact already exists", // Case when the contact already exists
-			args: args{
-				ctx:             context.Background(),
-				contactUserName: testContactUserName,
-				userUuid:        testUserUUID,
+			args: defaultArgs,
+			// Mocking the GetUserUUIDByUsername and GetContact methods
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockUserRepo.EXPECT().
+					GetUserUUIDByUsername(gomock.Any(), testContactUserName).
+					Return(&testContactUserUUID, nil) // Returning contact UUID
+
+				mockRepo.EXPECT().
+					GetContact(gomock.Any(), testUserUUID, testContactUserUUID).
+					Return(&entity.ContactsDTO{UserUUID: testUserUUID, ContactUserUUID: testContactUserUUID}, nil) // Contact already exists
 			},
-			// Mocking the GetUserUUIDByUsername and CheckContactExist methods
-			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo) {
+			wantErr: entity.ErrContactAlreadyExists, // Error expected
+		},
+		{
+			name: "removed contact is requested again", // Case when the user removed the contact before
+			args: defaultArgs,
+			// Mocking the GetContact method to return a removed contact
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
 				mockUserRepo.EXPECT().
 					GetUserUUIDByUsername(gomock.Any(), testContactUserName).
 					Return(&testContactUserUUID, nil) // Returning contact UUID
 
 				mockRepo.EXPECT().
-					CheckContactExist(gomock.Any(), testUserUUID, testContactUserUUID).
-					Return(true, nil) // Contact already exists
+					GetContact(gomock.Any(), testUserUUID, testContactUserUUID).
+					Return(&entity.ContactsDTO{UserUUID: testUserUUID, ContactUserUUID: testContactUserUUID, Removed: true}, nil) // Contact was removed
 
 				mockRepo.EXPECT().
-					UpdateRemovedStatus(gomock.Any(), gomock.Any()).
-					Return(nil) // Successfully updated the removed status
+					StoreContactRequest(gomock.Any(), gomock.Any()).
+					Return(nil) // Request successfully stored
 			},
-			wantErr: false, // No error expected
+			wantStatus: entity.ContactRequestPending,
+		},
+		{
+			name: "request of the contact is accepted", // Case when the contact already sent a request to the user
+			args: defaultArgs,
+			// Mocking the GetPendingContactRequest method to return the request of the contact
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockUserRepo.EXPECT().
+					GetUserUUIDByUsername(gomock.Any(), testContactUserName).
+					Return(&testContactUserUUID, nil) // Returning contact UUID
+
+				mockRepo.EXPECT().
+					GetPendingContactRequest(gomock.Any(), testContactUserUUID, testUserUUID).
+					Return(&incomingRequest, nil) // Contact already sent a request
+
+				mockRepo.EXPECT().
+					AcceptContactRequest(gomock.Any(), gomock.Any()).
+					Return("conversation_1234", nil) // Request successfully accepted
+			},
+			wantStatus: entity.ContactRequestAccepted,
+		},
+		{
+			name: "request already pending", // Case when the user already sent a request to the contact
+			args: defaultArgs,
+			// Mocking the GetPendingContactRequest method to return the request of the user
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockUserRepo.EXPECT().
+					GetUserUUIDByUsername(gomock.Any(), testContactUserName).
+					Return(&testContactUserUUID, nil) // Returning contact UUID
+
+				mockRepo.EXPECT().
+					GetPendingContactRequest(gomock.Any(), testUserUUID, testContactUserUUID).
+					Return(&entity.ContactRequestDTO{RequestUUID: "request_1234", Status: entity.ContactRequestPending}, nil) // Request already sent
+			},
+			wantErr: entity.ErrContactRequestExists, // Error expected
+		},
+		{
+			name: "contact does not accept contacts", // Case when the privacy settings of the contact do not allow anyone
+			args: defaultArgs,
+			// Mocking the privacy settings of the contact
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockUserRepo.EXPECT().
+					GetUserUUIDByUsername(gomock.Any(), testContactUserName).
+					Return(&testContactUserUUID, nil) // Returning contact UUID
+
+				mockPrivacyRepo.EXPECT().
+					GetPrivacySettings(gomock.Any(), testContactUserUUID).
+					Return(&entity.PrivacySettingsDTO{UserUUID: testContactUserUUID, WhoCanAddContact: entity.PrivacyNobody}, nil)
+			},
+			wantErr: entity.ErrContactNotAllowed, // Error expected
+		},
+		{
+			name: "user is not a contact of a contact", // Case when the contact only accepts contacts of their contacts
+			args: defaultArgs,
+			// Mocking the privacy settings of the contact and their contacts
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockUserRepo.EXPECT().
+					GetUserUUIDByUsername(gomock.Any(), testContactUserName).
+					Return(&testContactUserUUID, nil) // Returning contact UUID
+
+				mockPrivacyRepo.EXPECT().
+					GetPrivacySettings(gomock.Any(), testContactUserUUID).
+					Return(&entity.PrivacySettingsDTO{UserUUID: testContactUserUUID, WhoCanAddContact: entity.PrivacyContactsOfContacts}, nil)
+
+				mockRepo.EXPECT().
+					CheckContactOfContact(gomock.Any(), testContactUserUUID, testUserUUID).
+					Return(false, nil) // User is unknown to the contact
+			},
+			wantErr: entity.ErrContactNotAllowed, // Error expected
+		},
+		{
+			name: "user is a contact of a contact", // Case when the contact accepts contacts of their contacts
+			args: defaultArgs,
+			// Mocking the privacy settings of the contact and their contacts
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockUserRepo *mocks.MockUserRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockUserRepo.EXPECT().
+					GetUserUUIDByUsername(gomock.Any(), testContactUserName).
+					Return(&testContactUserUUID, nil) // Returning contact UUID
+
+				mockPrivacyRepo.EXPECT().
+					GetPrivacySettings(gomock.Any(), testContactUserUUID).
+					Return(&entity.PrivacySettingsDTO{UserUUID: testContactUserUUID, WhoCanAddContact: entity.PrivacyContactsOfContacts}, nil)
+
+				mockRepo.EXPECT().
+					CheckContactOfContact(gomock.Any(), testContactUserUUID, testUserUUID).
+					Return(true, nil) // User is known to the contact
+
+				mockRepo.EXPECT().
+					StoreContactRequest(gomock.Any(), gomock.Any()).
+					Return(nil) // Request successfully stored
+			},
+			wantStatus: entity.ContactRequestPending,
 		},
 	}
 
@@ -203,26 +435,178 @@ func TestContactsUseCase_AddContact(t *testing.T) {
 			ctrl := gomock.NewController(t) // Creating a mock controller
 			defer ctrl.Finish()             // Ensuring mock controller is cleaned up
 
-			// Create mock instances for ContactsRepo and UserRepo
+			// Create mock instances for ContactsRepo, UserRepo and PrivacyRepo
 			mockRepo := mocks.NewMockContactsRepo(ctrl)
 			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			mockPrivacyRepo := mocks.NewMockPrivacyRepo(ctrl)
 
 			// Setup mocks for the specific test case
 			if tt.setupMocks != nil {
-				tt.setupMocks(mockRepo, mockUserRepo)
+				tt.setupMocks(mockRepo, mockUserRepo, mockPrivacyRepo)
 			}
+			// Users who never changed their privacy settings accept contacts from everyone
+			mockPrivacyRepo.EXPECT().GetPrivacySettings(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			// Unless a test case says otherwise, the users are not contacts and there is no pending request
+			mockRepo.EXPECT().GetContact(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			mockRepo.EXPECT().GetPendingContactRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 
 			// Create instance of ContactsUseCase with mocked dependencies
 			uc := &ContactsUseCase{
 				repo:         mockRepo,
 				userInfoRepo: mockUserRepo,
+				privacyRepo:  mockPrivacyRepo,
 			}
 
 			// Call the method being tested and capture the result
-			err := uc.AddContact(tt.args.ctx, tt.args.contactUserName, tt.args.userUuid)
+			got, err := uc.AddContact(tt.args.ctx, tt.args.contactUserName, tt.args.userUuid)
 			// Verify if the returned error matches the expected error
-			if (err != nil) != tt.wantErr {
+			if err != tt.wantErr {
 				t.Errorf("ContactsUseCase.AddContact() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			// Verify if the request has the expected status
+			if got.Status != tt.wantStatus {
+				t.Errorf("ContactsUseCase.AddContact() status = %v, want %v", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// Test function to validate answering and cancelling contact requests
+func TestContactsUseCase_RespondToContactRequest(t *testing.T) {
+	// Pending request sent by the contact to the user
+	pendingRequest := entity.ContactRequestDTO{
+		RequestUUID:  "request_1234",
+		FromUserUUID: testContactUserUUID,
+		ToUserUUID:   testUserUUID,
+		Status:       entity.ContactRequestPending,
+	}
+	declinedRequest := pendingRequest
+	declinedRequest.Status = entity.ContactRequestDeclined
+
+	// Structure to hold test case data
+	type testCase struct {
+		name       string                                                   // Test case name
+		respond    func(uc *ContactsUseCase) (entity.ContactRequest, error) // Method being tested
+		setupMocks func(mockRepo *mocks.MockContactsRepo)                   // Function to set up mocks
+		wantStatus string                                                   // Expected status of the request
+		wantErr    error                                                    // Expected error, nil if none
+	}
+
+	// Defining test cases for AcceptContactRequest, DeclineContactRequest and CancelContactRequest methods
+	tests := []testCase{
+		{
+			name: "accept - conversation is created",
+			respond: func(uc *ContactsUseCase) (entity.ContactRequest, error) {
+				return uc.AcceptContactRequest(context.Background(), "request_1234", testUserUUID)
+			},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo) {
+				mockRepo.EXPECT().GetContactRequest(gomock.Any(), "request_1234").Return(&pendingRequest, nil)
+				mockRepo.EXPECT().AcceptContactRequest(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, request entity.ContactRequestDTO) (string, error) {
+						if request.RequestUUID != "request_1234" || request.ConversationUUID == "" {
+							t.Errorf("ContactsRepo.AcceptContactRequest() request = %+v", request)
+						}
+						return request.ConversationUUID, nil
+					})
+			},
+			wantStatus: entity.ContactRequestAccepted,
+		},
+		{
+			name: "accept - only the recipient can accept",
+			respond: func(uc *ContactsUseCase) (entity.ContactRequest, error) {
+				return uc.AcceptContactRequest(context.Background(), "request_1234", testContactUserUUID)
+			},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo) {
+				mockRepo.EXPECT().GetContactRequest(gomock.Any(), "request_1234").Return(&pendingRequest, nil)
+			},
+			wantErr: entity.ErrContactRequestNotFound,
+		},
+		{
+			name: "accept - cancelled in the meantime",
+			respond: func(uc *ContactsUseCase) (entity.ContactRequest, error) {
+				return uc.AcceptContactRequest(context.Background(), "request_1234", testUserUUID)
+			},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo) {
+				mockRepo.EXPECT().GetContactRequest(gomock.Any(), "request_1234").Return(&pendingRequest, nil)
+				mockRepo.EXPECT().AcceptContactRequest(gomock.Any(), gomock.Any()).Return("", nil)
+			},
+			wantErr: entity.ErrContactRequestNotPending,
+		},
+		{
+			name: "decline - success",
+			respond: func(uc *ContactsUseCase) (entity.ContactRequest, error) {
+				return uc.DeclineContactRequest(context.Background(), "request_1234", testUserUUID)
+			},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo) {
+				mockRepo.EXPECT().GetContactRequest(gomock.Any(), "request_1234").Return(&pendingRequest, nil)
+				mockRepo.EXPECT().UpdateContactRequestStatus(gomock.Any(), "request_1234", entity.ContactRequestDeclined).Return(true, nil)
+			},
+			wantStatus: entity.ContactRequestDeclined,
+		},
+		{
+			name: "decline - already declined",
+			respond: func(uc *ContactsUseCase) (entity.ContactRequest, error) {
+				return uc.DeclineContactRequest(context.Background(), "request_1234", testUserUUID)
+			},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo) {
+				mockRepo.EXPECT().GetContactRequest(gomock.Any(), "request_1234").Return(&declinedRequest, nil)
+			},
+			wantErr: entity.ErrContactRequestNotPending,
+		},
+		{
+			name: "cancel - success",
+			respond: func(uc *ContactsUseCase) (entity.ContactRequest, error) {
+				return uc.CancelContactRequest(context.Background(), "request_1234", testContactUserUUID)
+			},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo) {
+				mockRepo.EXPECT().GetContactRequest(gomock.Any(), "request_1234").Return(&pendingRequest, nil)
+				mockRepo.EXPECT().UpdateContactRequestStatus(gomock.Any(), "request_1234", entity.ContactRequestCancelled).Return(true, nil)
+			},
+			wantStatus: entity.ContactRequestCancelled,
+		},
+		{
+			name: "cancel - only the sender can cancel",
+			respond: func(uc *ContactsUseCase) (entity.ContactRequest, error) {
+				return uc.CancelContactRequest(context.Background(), "request_1234", testUserUUID)
+			},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo) {
+				mockRepo.EXPECT().GetContactRequest(gomock.Any(), "request_1234").Return(&pendingRequest, nil)
+			},
+			wantErr: entity.ErrContactRequestNotFound,
+		},
+		{
+			name: "cancel - request not found",
+			respond: func(uc *ContactsUseCase) (entity.ContactRequest, error) {
+				return uc.CancelContactRequest(context.Background(), "unknown_request", testContactUserUUID)
+			},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo) {
+				mockRepo.EXPECT().GetContactRequest(gomock.Any(), "unknown_request").Return(nil, nil)
+			},
+			wantErr: entity.ErrContactRequestNotFound,
+		},
+	}
+
+	// Loop through each test case and run the test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t) // Creating a mock controller
+			defer ctrl.Finish()             // Ensuring mock controller is cleaned up
+
+			// Create mock instance for ContactsRepo
+			mockRepo := mocks.NewMockContactsRepo(ctrl)
+			tt.setupMocks(mockRepo)
+
+			// Call the method being tested and capture the result
+			got, err := tt.respond(&ContactsUseCase{repo: mockRepo})
+			// Verify if the returned error matches the expected error
+			if err != tt.wantErr {
+				t.Errorf("ContactsUseCase.%s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			// Verify if the request has the expected status
+			if got.Status != tt.wantStatus {
+				t.Errorf("ContactsUseCase.%s status = %v, want %v", tt.name, got.Status, tt.wantStatus)
 			}
 		})
 	}
@@ -471,3 +855,155 @@ func TestContactsUseCase_UpdateBlockContact(t *testing.T) {
 		})
 	}
 }
+
+func TestContactsUseCase_ImportContacts(t *testing.T) {
+	salt := "0123456789abcdef"
+	hash := "9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08"
+	otherHash := "60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752"
+
+	// Match for the contact user, who is not yet a contact of the user
+	match := entity.ContactMatch{
+		Hash:     strings.ToLower(hash),
+		User:     entity.UserProfile{UserUUID: testContactUserUUID},
+		Username: testContactUserName,
+	}
+	// Match for a user who is already a contact of the user
+	contactMatch := entity.ContactMatch{
+		Hash:    otherHash,
+		User:    entity.UserProfile{UserUUID: "existing_contact_uuid"},
+		Contact: true,
+	}
+
+	// Structure to hold test case data
+	type testCase struct {
+		name        string                                                                     // Test case name
+		input       entity.ContactImport                                                       // Input to the method
+		setupMocks  func(mockRepo *mocks.MockContactsRepo, mockPrivacy *mocks.MockPrivacyRepo) // Function to set up mocks
+		wantMatches int                                                                        // Expected number of matches
+		wantRequest bool                                                                       // Whether a request is expected for the contact user
+		wantErr     error                                                                      // Expected error, nil if none
+		wantAnyErr  bool                                                                       // Whether any error is expected
+	}
+
+	// Defining test cases for ImportContacts method
+	tests := []testCase{
+		{
+			name:  "match only - hashes are normalized and deduplicated",
+			input: entity.ContactImport{UserUUID: testUserUUID, Salt: salt, Hashes: []string{hash, " " + strings.ToLower(hash), otherHash}},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockPrivacy *mocks.MockPrivacyRepo) {
+				mockRepo.EXPECT().MatchEmailHashes(gomock.Any(), testUserUUID, salt, []string{strings.ToLower(hash), otherHash}).
+					Return([]entity.ContactMatch{match, contactMatch}, nil)
+			},
+			wantMatches: 2,
+		},
+		{
+			name:  "add - requests are sent to matches who are not contacts",
+			input: entity.ContactImport{UserUUID: testUserUUID, Salt: salt, Hashes: []string{hash, otherHash}, Add: true},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockPrivacy *mocks.MockPrivacyRepo) {
+				mockRepo.EXPECT().MatchEmailHashes(gomock.Any(), testUserUUID, salt, gomock.Any()).Return([]entity.ContactMatch{match, contactMatch}, nil)
+				mockPrivacy.EXPECT().GetPrivacySettingsByUserUUIDs(gomock.Any(), []string{testContactUserUUID}).Return(nil, nil)
+				mockRepo.EXPECT().StoreContactRequests(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, requests []entity.ContactRequestDTO) ([]entity.ContactRequestDTO, error) {
+						if len(requests) != 1 || requests[0].FromUserUUID != testUserUUID || requests[0].ToUserUUID != testContactUserUUID ||
+							requests[0].Status != entity.ContactRequestPending || requests[0].RequestUUID == "" {
+							t.Errorf("ContactsRepo.StoreContactRequests() requests = %+v", requests)
+						}
+						return requests, nil
+					})
+			},
+			wantMatches: 2,
+			wantRequest: true,
+		},
+		{
+			name:  "add - privacy settings are respected",
+			input: entity.ContactImport{UserUUID: testUserUUID, Salt: salt, Hashes: []string{hash}, Add: true},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockPrivacy *mocks.MockPrivacyRepo) {
+				mockRepo.EXPECT().MatchEmailHashes(gomock.Any(), testUserUUID, salt, gomock.Any()).Return([]entity.ContactMatch{match}, nil)
+				mockPrivacy.EXPECT().GetPrivacySettingsByUserUUIDs(gomock.Any(), gomock.Any()).Return([]entity.PrivacySettingsDTO{{
+					UserUUID:         testContactUserUUID,
+					WhoCanAddContact: entity.PrivacyContactsOfContacts,
+				}}, nil)
+				mockRepo.EXPECT().CheckContactOfContact(gomock.Any(), testContactUserUUID, testUserUUID).Return(false, nil)
+			},
+			wantMatches: 1,
+		},
+		{
+			name:    "error - hash is not a SHA-256 hash",
+			input:   entity.ContactImport{UserUUID: testUserUUID, Salt: salt, Hashes: []string{"someone@example.com"}},
+			wantErr: entity.ErrInvalidContactImport,
+		},
+		{
+			name:    "error - salt is too short",
+			input:   entity.ContactImport{UserUUID: testUserUUID, Salt: "salt", Hashes: []string{hash}},
+			wantErr: entity.ErrInvalidContactImport,
+		},
+		{
+			name:    "error - no hashes",
+			input:   entity.ContactImport{UserUUID: testUserUUID, Salt: salt},
+			wantErr: entity.ErrInvalidContactImport,
+		},
+		{
+			name:  "error - storing the requests",
+			input: entity.ContactImport{UserUUID: testUserUUID, Salt: salt, Hashes: []string{hash}, Add: true},
+			setupMocks: func(mockRepo *mocks.MockContactsRepo, mockPrivacy *mocks.MockPrivacyRepo) {
+				mockRepo.EXPECT().MatchEmailHashes(gomock.Any(), testUserUUID, salt, gomock.Any()).Return([]entity.ContactMatch{match}, nil)
+				mockPrivacy.EXPECT().GetPrivacySettingsByUserUUIDs(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().StoreContactRequests(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Running each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories
+			mockRepo := mocks.NewMockContactsRepo(ctrl)
+			mockPrivacy := mocks.NewMockPrivacyRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo, mockPrivacy)
+			}
+
+			uc := NewContacts(mockRepo, mocks.NewMockUserRepo(ctrl), mockPrivacy, 0, time.Hour)
+
+			// Call the method under test
+			got, err := uc.ImportContacts(context.Background(), tt.input)
+			if tt.wantErr != nil || tt.wantAnyErr {
+				if err == nil || (tt.wantErr != nil && err != tt.wantErr) {
+					t.Errorf("ContactsUseCase.ImportContacts() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ContactsUseCase.ImportContacts() unexpected error = %v", err)
+			}
+			if len(got) != tt.wantMatches {
+				t.Fatalf("ContactsUseCase.ImportContacts() = %d matches, want %d", len(got), tt.wantMatches)
+			}
+			if hasRequest := got[0].Request != nil; hasRequest != tt.wantRequest {
+				t.Errorf("ContactsUseCase.ImportContacts() request = %+v, want request %v", got[0].Request, tt.wantRequest)
+			}
+		})
+	}
+}
+
+func TestContactsUseCase_ImportContactsRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockContactsRepo(ctrl)
+	uc := NewContacts(mockRepo, mocks.NewMockUserRepo(ctrl), mocks.NewMockPrivacyRepo(ctrl), 1, time.Hour)
+	input := entity.ContactImport{UserUUID: testUserUUID, Salt: "0123456789abcdef", Hashes: []string{"not a hash"}}
+
+	// Invalid imports count against the limit as well
+	if _, err := uc.ImportContacts(context.Background(), input); err != entity.ErrInvalidContactImport {
+		t.Fatalf("ContactsUseCase.ImportContacts() error = %v, want %v", err, entity.ErrInvalidContactImport)
+	}
+	if _, err := uc.ImportContacts(context.Background(), input); err != entity.ErrTooManyRequests {
+		t.Errorf("ContactsUseCase.ImportContacts() error = %v, want %v", err, entity.ErrTooManyRequests)
+	}
+}
@@ -8,16 +8,18 @@ import (
 )
 
 type ConversationUseCase struct {
-	repo ConversationRepo
+	repo         ConversationRepo
+	contactsRepo ContactsRepo
 }
 
 type ReactionData struct {
 	ReactionType string `json:"reaction_type"`
 }
 
-func NewConversation(r ConversationRepo) *ConversationUseCase {
+func NewConversation(r ConversationRepo, contactsRepo ContactsRepo) *ConversationUseCase {
 	return &ConversationUseCase{
-		repo: r,
+		repo:         r,
+		contactsRepo: contactsRepo,
 	}
 }
 
@@ -40,6 +42,17 @@ func (uc *ConversationUseCase) GetConversationList(ctx context.Context, reqParam
 }
 
 func (uc *ConversationUseCase) StoreConversationAndMessage(ctx context.Context, conv entity.Conversation) error {
+	// Check neither user of a direct conversation blocked the other by querying 'contacts' table
+	blocked, err := uc.contactsRepo.CheckConversationBlocked(ctx, conv.ConversationUUID, conv.SenderUUID)
+	if err != nil {
+		return fmt.Errorf("ConversationUseCase - StoreConversation - uc.contactsRepo.CheckConversationBlocked: %w", err)
+	}
+
+	// Return error if the conversation is blocked. Will be handled by controller
+	if blocked {
+		return entity.ErrConversationBlocked
+	}
+
 	// Convert conversation entity object into convDTO
 	convDTO := entity.ConversationDTO{
 		SenderUUID:       conv.SenderUUID,
@@ -52,7 +65,7 @@ func (uc *ConversationUseCase) StoreConversationAndMessage(ctx context.Context,
 	// Insert message into 'messages' table to store the entire conversation history
 	// and Upsert 'conversations' table with the most recent message
 	// using conversation data repository
-	err := uc.repo.InsertConversationAndMessage(ctx, convDTO)
+	err = uc.repo.InsertConversationAndMessage(ctx, convDTO)
 	if err != nil {
 		return fmt.Errorf("ConversationUseCase - StoreConversation - uc.repo.InsertConversationAndMessage: %w", err)
 	}
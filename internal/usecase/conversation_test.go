@@ -128,10 +128,10 @@ func TestConversationUseCase_StoreConversationAndMessage(t *testing.T) {
 
 	// Define the structure of each test case
 	type testCase struct {
-		name       string                                     // Name of the test case
-		args       args                                       // Input arguments for the test case
-		setupMocks func(mockRepo *mocks.MockConversationRepo) // Function to set up mock behavior
-		wantErr    bool                                       // Whether an error is expected
+		name       string                                                                               // Name of the test case
+		args       args                                                                                 // Input arguments for the test case
+		setupMocks func(mockRepo *mocks.MockConversationRepo, mockContactsRepo *mocks.MockContactsRepo) // Function to set up mock behavior
+		wantErr    bool                                                                                 // Whether an error is expected
 	}
 
 	// Define a sample time for testing
@@ -152,7 +152,7 @@ func TestConversationUseCase_StoreConversationAndMessage(t *testing.T) {
 					CreatedAt:        testTime,
 				},
 			},
-			setupMocks: func(mockRepo *mocks.MockConversationRepo) {
+			setupMocks: func(mockRepo *mocks.MockConversationRepo, mockContactsRepo *mocks.MockContactsRepo) {
 				// Define the expected behavior of the mock
 				convDTO := entity.ConversationDTO{
 					SenderUUID:       "sender_uuid_1234",
@@ -180,7 +180,7 @@ func TestConversationUseCase_StoreConversationAndMessage(t *testing.T) {
 					CreatedAt:        testTime,
 				},
 			},
-			setupMocks: func(mockRepo *mocks.MockConversationRepo) {
+			setupMocks: func(mockRepo *mocks.MockConversationRepo, mockContactsRepo *mocks.MockContactsRepo) {
 				// Define the expected behavior of the mock
 				convDTO := entity.ConversationDTO{
 					SenderUUID:       "sender_uuid_1234",
@@ -195,6 +195,27 @@ func TestConversationUseCase_StoreConversationAndMessage(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			// Test case where one of the users of the direct conversation blocked the other
+			name: "blocked conversation",
+			args: args{
+				ctx: context.Background(),
+				conv: entity.Conversation{
+					SenderUUID:       "sender_uuid_1234",
+					ConversationUUID: "conv_uuid_1234",
+					MessageUUID:      "msg_uuid_1234",
+					Content:          "Hello!",
+					CreatedAt:        testTime,
+				},
+			},
+			setupMocks: func(mockRepo *mocks.MockConversationRepo, mockContactsRepo *mocks.MockContactsRepo) {
+				// The message is not stored
+				mockContactsRepo.EXPECT().
+					CheckConversationBlocked(gomock.Any(), "conv_uuid_1234", "sender_uuid_1234").
+					Return(true, nil)
+			},
+			wantErr: true,
+		},
 	}
 
 	// Iterate over each test case
@@ -204,17 +225,21 @@ func TestConversationUseCase_StoreConversationAndMessage(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish() // Ensure that the controller checks the expectations and cleans up after the test
 
-			// Create a mock instance of the ConversationRepo interface
+			// Create mock instances of the ConversationRepo and ContactsRepo interfaces
 			mockRepo := mocks.NewMockConversationRepo(ctrl)
+			mockContactsRepo := mocks.NewMockContactsRepo(ctrl)
 
 			// Set up the mock expectations using the provided setupMocks function
 			if tt.setupMocks != nil {
-				tt.setupMocks(mockRepo)
+				tt.setupMocks(mockRepo, mockContactsRepo)
 			}
+			// Unless a test case says otherwise, the conversation is not blocked
+			mockContactsRepo.EXPECT().CheckConversationBlocked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
 
-			// Create an instance of the ConversationUseCase using the mock repository
+			// Create an instance of the ConversationUseCase using the mock repositories
 			uc := &ConversationUseCase{
-				repo: mockRepo,
+				repo:         mockRepo,
+				contactsRepo: mockContactsRepo,
 			}
 
 			// Call the method under test with the provided arguments
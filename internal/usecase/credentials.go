@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+type CredentialsUseCase struct {
+	userRepo           UserRepo
+	tokenRepo          UserTokenRepo
+	sessionRepo        SessionRepo
+	mailer             Mailer
+	hasher             PasswordHasher
+	publicURL          string
+	tokenTTL           time.Duration
+	usernameHoldPeriod time.Duration
+}
+
+// NewCredentials -.
+// A username that was given up stays reserved for its previous owner during usernameHoldPeriod.
+func NewCredentials(u UserRepo, t UserTokenRepo, s SessionRepo, m Mailer, h PasswordHasher, publicURL string, tokenTTL time.Duration, usernameHoldPeriod time.Duration) *CredentialsUseCase {
+	return &CredentialsUseCase{
+		userRepo:           u,
+		tokenRepo:          t,
+		sessionRepo:        s,
+		mailer:             m,
+		hasher:             h,
+		publicURL:          publicURL,
+		tokenTTL:           tokenTTL,
+		usernameHoldPeriod: usernameHoldPeriod,
+	}
+}
+
+// ChangePassword sets a new password and logs out every other session. It returns the sessions that were logged out.
+func (uc *CredentialsUseCase) ChangePassword(ctx context.Context, change entity.PasswordChange) ([]string, error) {
+	_, err := uc.confirmPassword(ctx, change.UserUUID, change.CurrentPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hash password before storing into database
+	hashedPassword, err := uc.hasher.Hash(change.NewPassword)
+	if err != nil {
+		return nil, fmt.Errorf("CredentialsUseCase - ChangePassword - uc.hasher.Hash: %w", err)
+	}
+
+	// Update password in 'user_credentials' table using user data repository
+	err = uc.userRepo.UpdatePassword(ctx, change.UserUUID, hashedPassword)
+	if err != nil {
+		return nil, fmt.Errorf("CredentialsUseCase - ChangePassword - uc.userRepo.UpdatePassword: %w", err)
+	}
+
+	// Get sessions that are neither revoked nor expired from session data repository by querying 'sessions' table
+	sessions, err := uc.sessionRepo.GetActiveSessions(ctx, change.UserUUID)
+	if err != nil {
+		return nil, fmt.Errorf("CredentialsUseCase - ChangePassword - uc.sessionRepo.GetActiveSessions: %w", err)
+	}
+
+	// Whoever knew the old password must not stay logged in, only the session making the request is kept
+	var revoked []string
+	for _, session := range sessions {
+		if session.SessionUUID == change.SessionUUID {
+			continue
+		}
+
+		err = uc.sessionRepo.RevokeSession(ctx, session.SessionUUID)
+		if err != nil {
+			return revoked, fmt.Errorf("CredentialsUseCase - ChangePassword - uc.sessionRepo.RevokeSession: %w", err)
+		}
+		revoked = append(revoked, session.SessionUUID)
+	}
+	return revoked, nil
+}
+
+// RequestEmailChange mails a confirmation link to the new address, the email is only changed once it is confirmed.
+func (uc *CredentialsUseCase) RequestEmailChange(ctx context.Context, change entity.EmailChange) error {
+	userInfo, err := uc.confirmPassword(ctx, change.UserUUID, change.CurrentPassword)
+	if err != nil {
+		return err
+	}
+
+	// Nothing to confirm if the email stays the same
+	if strings.EqualFold(change.NewEmail, userInfo.Email) {
+		return nil
+	}
+
+	err = uc.checkEmailAvailable(ctx, change.NewEmail)
+	if err != nil {
+		return err
+	}
+
+	// Issue a confirmation token bound to the new email, only its hash is stored
+	token, err := issueUserToken(ctx, uc.tokenRepo, change.UserUUID, entity.TokenPurposeEmailChange, change.NewEmail, uc.tokenTTL)
+	if err != nil {
+		return fmt.Errorf("CredentialsUseCase - RequestEmailChange - issueUserToken: %w", err)
+	}
+
+	// Send the confirmation link to the new address, proving the user can receive mail there
+	err = uc.mailer.Send(ctx, entity.Mail{
+		To:      change.NewEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to confirm your new email address. It expires in %s.\n\n%s/confirm-email-change?token=%s\n\n"+
+			"If you did not ask to change your email address, you can ignore this email.\n",
+			userInfo.Username, uc.tokenTTL, uc.publicURL, url.QueryEscape(token)),
+	})
+	if err != nil {
+		return fmt.Errorf("CredentialsUseCase - RequestEmailChange - uc.mailer.Send: %w", err)
+	}
+	return nil
+}
+
+// ConfirmEmailChange changes the email to the address the token was sent to.
+func (uc *CredentialsUseCase) ConfirmEmailChange(ctx context.Context, token string) error {
+	// Mark confirmation token as used in 'user_tokens' table, a token can only be consumed once
+	userToken, err := uc.tokenRepo.ConsumeUserToken(ctx, hashToken(token), entity.TokenPurposeEmailChange)
+	if err != nil {
+		return fmt.Errorf("CredentialsUseCase - ConfirmEmailChange - uc.tokenRepo.ConsumeUserToken: %w", err)
+	}
+
+	// Return error if token is unknown, already used or expired. Will be handled by controller
+	if userToken == nil {
+		return entity.ErrInvalidVerificationToken
+	}
+
+	// Get user credentials from user data repository by querying 'user_credentials' table
+	userInfo, err := uc.userRepo.GetUserCredentialsByUUID(ctx, userToken.UserUUID)
+	if err != nil {
+		return fmt.Errorf("CredentialsUseCase - ConfirmEmailChange - uc.userRepo.GetUserCredentialsByUUID: %w", err)
+	}
+
+	// Return error if the account was deleted since the token was sent. Will be handled by controller
+	if userInfo == nil {
+		return entity.ErrInvalidVerificationToken
+	}
+
+	// Someone else may have taken the address since the token was sent
+	err = uc.checkEmailAvailable(ctx, userToken.Payload)
+	if err != nil {
+		return err
+	}
+
+	// Update email in 'user_credentials' and 'user_info' tables using user data repository
+	err = uc.userRepo.UpdateEmail(ctx, userToken.UserUUID, userToken.Payload)
+	if err != nil {
+		return fmt.Errorf("CredentialsUseCase - ConfirmEmailChange - uc.userRepo.UpdateEmail: %w", err)
+	}
+
+	// Let the old address know, in case the change was not made by the user
+	if userInfo.Email == "" {
+		return nil
+	}
+	err = uc.mailer.Send(ctx, entity.Mail{
+		To:      userInfo.Email,
+		Subject: "Your email address was changed",
+		Body: fmt.Sprintf("Hi %s,\n\nThe email address of your account was changed to %s.\n\n"+
+			"If you did not make this change, reset your password and contact support.\n",
+			userInfo.Username, userToken.Payload),
+	})
+	if err != nil {
+		return fmt.Errorf("CredentialsUseCase - ConfirmEmailChange - uc.mailer.Send: %w", err)
+	}
+	return nil
+}
+
+// ChangeUsername sets a new username. The old one stays reserved for the user during the hold period.
+func (uc *CredentialsUseCase) ChangeUsername(ctx context.Context, change entity.UsernameChange) error {
+	userInfo, err := uc.confirmPassword(ctx, change.UserUUID, change.CurrentPassword)
+	if err != nil {
+		return err
+	}
+
+	// Nothing to change if the username stays the same
+	if change.NewUsername == userInfo.Username {
+		return nil
+	}
+
+	// Check if username is used or reserved by another user from user data repository
+	available, err := uc.userRepo.IsUsernameAvailable(ctx, change.NewUsername, change.UserUUID)
+	if err != nil {
+		return fmt.Errorf("CredentialsUseCase - ChangeUsername - uc.userRepo.IsUsernameAvailable: %w", err)
+	}
+
+	// Return error if username is not available. Will be handled by controller
+	if !available {
+		return entity.ErrUsernameUnavailable
+	}
+
+	// Update username in 'user_credentials' table and record the old one in 'username_history' table
+	err = uc.userRepo.UpdateUsername(ctx, change.UserUUID, userInfo.Username, change.NewUsername, time.Now().Add(uc.usernameHoldPeriod))
+	if err != nil {
+		return fmt.Errorf("CredentialsUseCase - ChangeUsername - uc.userRepo.UpdateUsername: %w", err)
+	}
+	return nil
+}
+
+// The current password has to be confirmed again, a stolen access token alone must not be able to take over the account
+func (uc *CredentialsUseCase) confirmPassword(ctx context.Context, userUUID string, password string) (*entity.UserCredentialsDTO, error) {
+	// Get user credentials from user data repository by querying 'user_credentials' table
+	userInfo, err := uc.userRepo.GetUserCredentialsByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("CredentialsUseCase - confirmPassword - uc.userRepo.GetUserCredentialsByUUID: %w", err)
+	}
+
+	// Return error if user is not found. Will be handled by controller
+	if userInfo == nil {
+		return nil, entity.ErrUserNotFound
+	}
+
+	// Return error if password does not match. Will be handled by controller
+	if !verifyPassword(uc.hasher, password, userInfo.Password) {
+		return nil, entity.ErrIncorrectPassword
+	}
+	return userInfo, nil
+}
+
+// Returns an error if the email belongs to another account
+func (uc *CredentialsUseCase) checkEmailAvailable(ctx context.Context, email string) error {
+	// Check if email is registered from user data repository by querying 'user_credentials' table
+	exist, err := uc.userRepo.CheckUserExist(ctx, entity.UserRegistrationDTO{Email: email})
+	if err != nil {
+		return fmt.Errorf("CredentialsUseCase - checkEmailAvailable - uc.userRepo.CheckUserExist: %w", err)
+	}
+
+	// Return error if email is already used. Will be handled by controller
+	if exist {
+		return entity.ErrEmailUnavailable
+	}
+	return nil
+}
@@ -0,0 +1,417 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase/mailer"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/password"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// credentialsMocks groups the repositories used by CredentialsUseCase
+type credentialsMocks struct {
+	userRepo    *mocks.MockUserRepo
+	tokenRepo   *mocks.MockUserTokenRepo
+	sessionRepo *mocks.MockSessionRepo
+}
+
+func newTestCredentialsUseCase(ctrl *gomock.Controller) (*CredentialsUseCase, credentialsMocks, *mailer.Memory) {
+	m := credentialsMocks{
+		userRepo:    mocks.NewMockUserRepo(ctrl),
+		tokenRepo:   mocks.NewMockUserTokenRepo(ctrl),
+		sessionRepo: mocks.NewMockSessionRepo(ctrl),
+	}
+	memoryMailer := mailer.NewMemory()
+	uc := NewCredentials(m.userRepo, m.tokenRepo, m.sessionRepo, memoryMailer, password.NewBcrypt(bcrypt.MinCost), "http://localhost", time.Hour, 30*24*time.Hour)
+	return uc, m, memoryMailer
+}
+
+func TestCredentialsUseCase_ChangePassword(t *testing.T) {
+	// Example hashed password for testing
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	userCredentials := &entity.UserCredentialsDTO{UserUuid: testUserUUID, Password: string(hashedPassword)}
+
+	// Define the structure of each test case
+	type testCase struct {
+		name        string                   // Name of the test case
+		password    string                   // Current password the user confirmed
+		setupMocks  func(m credentialsMocks) // Function to set up mock behavior
+		wantRevoked []string                 // Expected sessions that were logged out
+		wantErr     error                    // Expected sentinel error, if any
+		wantAnyErr  bool                     // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:     "success - other sessions revoked",
+			password: "password123",
+			setupMocks: func(m credentialsMocks) {
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+				m.userRepo.EXPECT().UpdatePassword(gomock.Any(), testUserUUID, gomock.Any()).
+					Do(func(_ context.Context, _ string, hash string) {
+						if !verifyPassword(password.NewBcrypt(bcrypt.MinCost), "newpassword", hash) {
+							t.Errorf("CredentialsUseCase.ChangePassword() stored a hash that does not match the new password")
+						}
+					}).
+					Return(nil)
+				m.sessionRepo.EXPECT().GetActiveSessions(gomock.Any(), testUserUUID).Return([]entity.SessionDTO{
+					{SessionUUID: "current-session"}, {SessionUUID: "other-session"},
+				}, nil)
+				m.sessionRepo.EXPECT().RevokeSession(gomock.Any(), "other-session").Return(nil)
+			},
+			wantRevoked: []string{"other-session"},
+		},
+		{
+			name:     "error - incorrect password",
+			password: "wrongpassword",
+			setupMocks: func(m credentialsMocks) {
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+			},
+			wantErr:    entity.ErrIncorrectPassword,
+			wantAnyErr: true,
+		},
+		{
+			name:     "error - user not found",
+			password: "password123",
+			setupMocks: func(m credentialsMocks) {
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(nil, nil)
+			},
+			wantErr:    entity.ErrUserNotFound,
+			wantAnyErr: true,
+		},
+		{
+			name:     "error updating password",
+			password: "password123",
+			setupMocks: func(m credentialsMocks) {
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+				m.userRepo.EXPECT().UpdatePassword(gomock.Any(), testUserUUID, gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m, _ := newTestCredentialsUseCase(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(m)
+			}
+
+			// Call the method under test
+			got, err := uc.ChangePassword(context.Background(), entity.PasswordChange{
+				UserUUID:        testUserUUID,
+				SessionUUID:     "current-session",
+				CurrentPassword: tt.password,
+				NewPassword:     "newpassword",
+			})
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("CredentialsUseCase.ChangePassword() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("CredentialsUseCase.ChangePassword() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.wantRevoked) {
+				t.Errorf("CredentialsUseCase.ChangePassword() = %v, want %v", got, tt.wantRevoked)
+			}
+		})
+	}
+}
+
+func TestCredentialsUseCase_RequestEmailChange(t *testing.T) {
+	// Example hashed password for testing
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	userCredentials := &entity.UserCredentialsDTO{UserUuid: testUserUUID, Username: "testuser", Email: "old@example.com", Password: string(hashedPassword)}
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                   // Name of the test case
+		password   string                   // Current password the user confirmed
+		newEmail   string                   // Email the user wants to change to
+		setupMocks func(m credentialsMocks) // Function to set up mock behavior
+		wantMail   bool                     // Whether a confirmation email is expected
+		wantErr    error                    // Expected sentinel error, if any
+		wantAnyErr bool                     // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:     "success - confirmation sent to new address",
+			password: "password123",
+			newEmail: "new@example.com",
+			setupMocks: func(m credentialsMocks) {
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+				m.userRepo.EXPECT().CheckUserExist(gomock.Any(), entity.UserRegistrationDTO{Email: "new@example.com"}).Return(false, nil)
+				m.tokenRepo.EXPECT().StoreUserToken(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, userToken entity.UserTokenDTO) {
+						if userToken.Purpose != entity.TokenPurposeEmailChange || userToken.Payload != "new@example.com" {
+							t.Errorf("CredentialsUseCase.RequestEmailChange() stored token %+v", userToken)
+						}
+					}).
+					Return(nil)
+			},
+			wantMail: true,
+		},
+		{
+			name:     "success - same email",
+			password: "password123",
+			newEmail: "Old@example.com",
+			setupMocks: func(m credentialsMocks) {
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+			},
+		},
+		{
+			name:     "error - email used by another account",
+			password: "password123",
+			newEmail: "taken@example.com",
+			setupMocks: func(m credentialsMocks) {
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+				m.userRepo.EXPECT().CheckUserExist(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantErr:    entity.ErrEmailUnavailable,
+			wantAnyErr: true,
+		},
+		{
+			name:     "error - incorrect password",
+			password: "wrongpassword",
+			newEmail: "new@example.com",
+			setupMocks: func(m credentialsMocks) {
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+			},
+			wantErr:    entity.ErrIncorrectPassword,
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m, memoryMailer := newTestCredentialsUseCase(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(m)
+			}
+
+			// Call the method under test
+			err := uc.RequestEmailChange(context.Background(), entity.EmailChange{
+				UserUUID:        testUserUUID,
+				CurrentPassword: tt.password,
+				NewEmail:        tt.newEmail,
+			})
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("CredentialsUseCase.RequestEmailChange() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("CredentialsUseCase.RequestEmailChange() error = %v, want %v", err, tt.wantErr)
+			}
+
+			sent := memoryMailer.Sent()
+			if (len(sent) == 1) != tt.wantMail {
+				t.Fatalf("CredentialsUseCase.RequestEmailChange() sent %d mails, wantMail %v", len(sent), tt.wantMail)
+			}
+			if tt.wantMail && (sent[0].To != tt.newEmail || !strings.Contains(sent[0].Body, "/confirm-email-change?token=")) {
+				t.Errorf("CredentialsUseCase.RequestEmailChange() sent %+v", sent[0])
+			}
+		})
+	}
+}
+
+func TestCredentialsUseCase_ConfirmEmailChange(t *testing.T) {
+	userCredentials := &entity.UserCredentialsDTO{UserUuid: testUserUUID, Username: "testuser", Email: "old@example.com"}
+	userToken := &entity.UserTokenDTO{UserUUID: testUserUUID, Purpose: entity.TokenPurposeEmailChange, Payload: "new@example.com"}
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                   // Name of the test case
+		setupMocks func(m credentialsMocks) // Function to set up mock behavior
+		wantNotice bool                     // Whether the old address is expected to be notified
+		wantErr    error                    // Expected sentinel error, if any
+		wantAnyErr bool                     // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - email changed and old address notified",
+			setupMocks: func(m credentialsMocks) {
+				m.tokenRepo.EXPECT().ConsumeUserToken(gomock.Any(), hashToken("some-token"), entity.TokenPurposeEmailChange).Return(userToken, nil)
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+				m.userRepo.EXPECT().CheckUserExist(gomock.Any(), entity.UserRegistrationDTO{Email: "new@example.com"}).Return(false, nil)
+				m.userRepo.EXPECT().UpdateEmail(gomock.Any(), testUserUUID, "new@example.com").Return(nil)
+			},
+			wantNotice: true,
+		},
+		{
+			name: "error - invalid token",
+			setupMocks: func(m credentialsMocks) {
+				m.tokenRepo.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Any(), entity.TokenPurposeEmailChange).Return(nil, nil)
+			},
+			wantErr:    entity.ErrInvalidVerificationToken,
+			wantAnyErr: true,
+		},
+		{
+			name: "error - email taken since the token was sent",
+			setupMocks: func(m credentialsMocks) {
+				m.tokenRepo.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Any(), entity.TokenPurposeEmailChange).Return(userToken, nil)
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+				m.userRepo.EXPECT().CheckUserExist(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantErr:    entity.ErrEmailUnavailable,
+			wantAnyErr: true,
+		},
+		{
+			name: "error updating email",
+			setupMocks: func(m credentialsMocks) {
+				m.tokenRepo.EXPECT().ConsumeUserToken(gomock.Any(), gomock.Any(), entity.TokenPurposeEmailChange).Return(userToken, nil)
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+				m.userRepo.EXPECT().CheckUserExist(gomock.Any(), gomock.Any()).Return(false, nil)
+				m.userRepo.EXPECT().UpdateEmail(gomock.Any(), testUserUUID, "new@example.com").Return(fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m, memoryMailer := newTestCredentialsUseCase(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(m)
+			}
+
+			// Call the method under test
+			err := uc.ConfirmEmailChange(context.Background(), "some-token")
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("CredentialsUseCase.ConfirmEmailChange() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("CredentialsUseCase.ConfirmEmailChange() error = %v, want %v", err, tt.wantErr)
+			}
+
+			sent := memoryMailer.Sent()
+			if (len(sent) == 1) != tt.wantNotice {
+				t.Fatalf("CredentialsUseCase.ConfirmEmailChange() sent %d mails, wantNotice %v", len(sent), tt.wantNotice)
+			}
+			if tt.wantNotice && sent[0].To != "old@example.com" {
+				t.Errorf("CredentialsUseCase.ConfirmEmailChange() notified %q", sent[0].To)
+			}
+		})
+	}
+}
+
+func TestCredentialsUseCase_ChangeUsername(t *testing.T) {
+	// Example hashed password for testing
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	userCredentials := &entity.UserCredentialsDTO{UserUuid: testUserUUID, Username: "olduser", Password: string(hashedPassword)}
+
+	// Define the structure of each test case
+	type testCase struct {
+		name        string                   // Name of the test case
+		password    string                   // Current password the user confirmed
+		newUsername string                   // Username the user wants to change to
+		setupMocks  func(m credentialsMocks) // Function to set up mock behavior
+		wantErr     error                    // Expected sentinel error, if any
+		wantAnyErr  bool                     // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:        "success - old username reserved",
+			password:    "password123",
+			newUsername: "newuser",
+			setupMocks: func(m credentialsMocks) {
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+				m.userRepo.EXPECT().IsUsernameAvailable(gomock.Any(), "newuser", testUserUUID).Return(true, nil)
+				m.userRepo.EXPECT().UpdateUsername(gomock.Any(), testUserUUID, "olduser", "newuser", gomock.Any()).
+					Do(func(_ context.Context, _, _, _ string, reservedUntil time.Time) {
+						if reservedUntil.Before(time.Now().Add(29 * 24 * time.Hour)) {
+							t.Errorf("CredentialsUseCase.ChangeUsername() reserved old username until %v", reservedUntil)
+						}
+					}).
+					Return(nil)
+			},
+		},
+		{
+			name:        "success - same username",
+			password:    "password123",
+			newUsername: "olduser",
+			setupMocks: func(m credentialsMocks) {
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+			},
+		},
+		{
+			name:        "error - username taken or reserved",
+			password:    "password123",
+			newUsername: "takenuser",
+			setupMocks: func(m credentialsMocks) {
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+				m.userRepo.EXPECT().IsUsernameAvailable(gomock.Any(), "takenuser", testUserUUID).Return(false, nil)
+			},
+			wantErr:    entity.ErrUsernameUnavailable,
+			wantAnyErr: true,
+		},
+		{
+			name:        "error - incorrect password",
+			password:    "wrongpassword",
+			newUsername: "newuser",
+			setupMocks: func(m credentialsMocks) {
+				m.userRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(userCredentials, nil)
+			},
+			wantErr:    entity.ErrIncorrectPassword,
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m, _ := newTestCredentialsUseCase(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(m)
+			}
+
+			// Call the method under test
+			err := uc.ChangeUsername(context.Background(), entity.UsernameChange{
+				UserUUID:        testUserUUID,
+				CurrentPassword: tt.password,
+				NewUsername:     tt.newUsername,
+			})
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("CredentialsUseCase.ChangeUsername() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("CredentialsUseCase.ChangeUsername() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+const (
+	// Messages are read in batches so that long histories never have to be held in memory at once
+	_exportMessageBatchSize = 500
+	// A pending export older than this was interrupted, e.g. by a restart, and is replaced by a new one
+	_staleExportAfter = time.Hour
+)
+
+type DataExportUseCase struct {
+	repo     DataExportRepo
+	userRepo UserRepo
+	store    BlobStore
+	ttl      time.Duration
+	// run starts building an export in the background, it is replaced in tests to build synchronously
+	run func(func())
+}
+
+// NewDataExport -.
+// Finished archives can be downloaded for ttl, after which they are deleted.
+func NewDataExport(r DataExportRepo, u UserRepo, store BlobStore, ttl time.Duration) *DataExportUseCase {
+	return &DataExportUseCase{
+		repo:     r,
+		userRepo: u,
+		store:    store,
+		ttl:      ttl,
+		run:      func(f func()) { go f() },
+	}
+}
+
+func (uc *DataExportUseCase) RequestExport(ctx context.Context, userUUID string) (entity.DataExport, error) {
+	// Get the user's previous exports from data export data repository
+	exports, err := uc.repo.GetUserExports(ctx, userUUID)
+	if err != nil {
+		return entity.DataExport{}, fmt.Errorf("DataExportUseCase - RequestExport - uc.repo.GetUserExports: %w", err)
+	}
+
+	for _, export := range exports {
+		// An export that is still being built is returned instead of starting another one
+		if export.Status == entity.DataExportPending && time.Since(export.CreatedAt) < _staleExportAfter {
+			return toDataExport(export), nil
+		}
+
+		// Only the latest export is kept
+		err = uc.deleteExport(ctx, export)
+		if err != nil {
+			return entity.DataExport{}, fmt.Errorf("DataExportUseCase - RequestExport - uc.deleteExport: %w", err)
+		}
+	}
+
+	export := entity.DataExportDTO{
+		ExportUUID: uuid.New().String(),
+		UserUUID:   userUUID,
+		Status:     entity.DataExportPending,
+		CreatedAt:  time.Now(),
+	}
+
+	// Store export into 'data_exports' table using data export data repository
+	err = uc.repo.StoreExport(ctx, export)
+	if err != nil {
+		return entity.DataExport{}, fmt.Errorf("DataExportUseCase - RequestExport - uc.repo.StoreExport: %w", err)
+	}
+
+	// The archive is built after the request has returned, so it must not use the request context
+	uc.run(func() { uc.build(context.Background(), export) })
+
+	return toDataExport(export), nil
+}
+
+func (uc *DataExportUseCase) GetExport(ctx context.Context, userUUID string, exportUUID string) (entity.DataExport, error) {
+	export, err := uc.getUserExport(ctx, userUUID, exportUUID)
+	if err != nil {
+		return entity.DataExport{}, err
+	}
+	return toDataExport(*export), nil
+}
+
+func (uc *DataExportUseCase) OpenExport(ctx context.Context, userUUID string, exportUUID string) (io.ReadCloser, error) {
+	export, err := uc.getUserExport(ctx, userUUID, exportUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return error if the archive is not built yet or already expired. Will be handled by controller
+	if toDataExport(*export).Status != entity.DataExportReady {
+		return nil, entity.ErrDataExportNotReady
+	}
+
+	archive, err := uc.store.Open(ctx, export.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportUseCase - OpenExport - uc.store.Open: %w", err)
+	}
+	return archive, nil
+}
+
+// PurgeExpiredExports deletes the archives that can no longer be downloaded and returns how many were deleted.
+func (uc *DataExportUseCase) PurgeExpiredExports(ctx context.Context) (int, error) {
+	// Get the expired exports from data export data repository
+	exports, err := uc.repo.GetExpiredExports(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("DataExportUseCase - PurgeExpiredExports - uc.repo.GetExpiredExports: %w", err)
+	}
+
+	for i, export := range exports {
+		err = uc.deleteExport(ctx, export)
+		if err != nil {
+			return i, fmt.Errorf("DataExportUseCase - PurgeExpiredExports - uc.deleteExport: %w", err)
+		}
+	}
+	return len(exports), nil
+}
+
+// getUserExport returns the export if it belongs to the user
+func (uc *DataExportUseCase) getUserExport(ctx context.Context, userUUID string, exportUUID string) (*entity.DataExportDTO, error) {
+	// Get export from data export data repository by querying 'data_exports' table
+	export, err := uc.repo.GetExport(ctx, exportUUID)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportUseCase - getUserExport - uc.repo.GetExport: %w", err)
+	}
+
+	// Exports of other users are reported as not found, so that their ids can't be probed
+	if export == nil || export.UserUUID != userUUID {
+		return nil, entity.ErrDataExportNotFound
+	}
+	return export, nil
+}
+
+func (uc *DataExportUseCase) deleteExport(ctx context.Context, export entity.DataExportDTO) error {
+	if export.StorageKey != "" {
+		err := uc.store.Delete(ctx, export.StorageKey)
+		if err != nil {
+			return err
+		}
+	}
+	return uc.repo.DeleteExport(ctx, export.ExportUUID)
+}
+
+// build writes the archive and records whether it succeeded
+func (uc *DataExportUseCase) build(ctx context.Context, export entity.DataExportDTO) {
+	storageKey := fmt.Sprintf("exports/%s/%s.zip", export.UserUUID, export.ExportUUID)
+	err := uc.writeArchive(ctx, export.UserUUID, storageKey)
+
+	now := time.Now()
+	export.CompletedAt = &now
+	if err != nil {
+		export.Status = entity.DataExportFailed
+		export.Error = err.Error()
+	} else {
+		expiresAt := now.Add(uc.ttl)
+		export.Status = entity.DataExportReady
+		export.StorageKey = storageKey
+		export.ExpiresAt = &expiresAt
+	}
+
+	// Nobody is waiting for the result, it can only be reported through the export status
+	_ = uc.repo.UpdateExport(ctx, export)
+}
+
+// writeArchive builds the zip in a temporary file and puts it into the blob store
+func (uc *DataExportUseCase) writeArchive(ctx context.Context, userUUID string, storageKey string) error {
+	tmp, err := os.CreateTemp("", "data-export-*.zip")
+	if err != nil {
+		return fmt.Errorf("DataExportUseCase - writeArchive - os.CreateTemp: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	zw := zip.NewWriter(tmp)
+	err = uc.writeArchiveFiles(ctx, zw, userUUID)
+	if err != nil {
+		return err
+	}
+	err = zw.Close()
+	if err != nil {
+		return fmt.Errorf("DataExportUseCase - writeArchive - zw.Close: %w", err)
+	}
+
+	_, err = tmp.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("DataExportUseCase - writeArchive - tmp.Seek: %w", err)
+	}
+	err = uc.store.Put(ctx, storageKey, tmp)
+	if err != nil {
+		return fmt.Errorf("DataExportUseCase - writeArchive - uc.store.Put: %w", err)
+	}
+	return nil
+}
+
+func (uc *DataExportUseCase) writeArchiveFiles(ctx context.Context, zw *zip.Writer, userUUID string) error {
+	// Get user profile from user data repository by querying 'user_info' table
+	profile, err := uc.userRepo.GetUserProfile(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("DataExportUseCase - writeArchiveFiles - uc.userRepo.GetUserProfile: %w", err)
+	}
+	if profile == nil {
+		return entity.ErrUserNotFound
+	}
+	err = writeJSONFile(zw, "profile.json", profile)
+	if err != nil {
+		return err
+	}
+
+	contacts, err := uc.repo.GetExportContacts(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("DataExportUseCase - writeArchiveFiles - uc.repo.GetExportContacts: %w", err)
+	}
+	err = writeJSONFile(zw, "contacts.json", contacts)
+	if err != nil {
+		return err
+	}
+
+	groups, err := uc.repo.GetExportGroupMemberships(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("DataExportUseCase - writeArchiveFiles - uc.repo.GetExportGroupMemberships: %w", err)
+	}
+	err = writeJSONFile(zw, "group_memberships.json", groups)
+	if err != nil {
+		return err
+	}
+
+	err = uc.writeMessages(ctx, zw, userUUID)
+	if err != nil {
+		return err
+	}
+
+	reactions, err := uc.repo.GetExportReactions(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("DataExportUseCase - writeArchiveFiles - uc.repo.GetExportReactions: %w", err)
+	}
+	err = writeJSONFile(zw, "reactions.json", reactions)
+	if err != nil {
+		return err
+	}
+
+	seenReceipts, err := uc.repo.GetExportSeenReceipts(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("DataExportUseCase - writeArchiveFiles - uc.repo.GetExportSeenReceipts: %w", err)
+	}
+	return writeJSONFile(zw, "seen_receipts.json", seenReceipts)
+}
+
+// writeMessages streams the sent messages into messages.json one batch at a time
+func (uc *DataExportUseCase) writeMessages(ctx context.Context, zw *zip.Writer, userUUID string) error {
+	w, err := zw.Create("messages.json")
+	if err != nil {
+		return fmt.Errorf("DataExportUseCase - writeMessages - zw.Create: %w", err)
+	}
+
+	_, err = io.WriteString(w, "[")
+	if err != nil {
+		return err
+	}
+
+	var after *entity.ExportMessage
+	first := true
+	for {
+		messages, err := uc.repo.GetSentMessages(ctx, userUUID, after, _exportMessageBatchSize)
+		if err != nil {
+			return fmt.Errorf("DataExportUseCase - writeMessages - uc.repo.GetSentMessages: %w", err)
+		}
+
+		for _, message := range messages {
+			b, err := json.Marshal(message)
+			if err != nil {
+				return err
+			}
+			if !first {
+				b = append([]byte(","), b...)
+			}
+			first = false
+			_, err = w.Write(b)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(messages) < _exportMessageBatchSize {
+			break
+		}
+		after = &messages[len(messages)-1]
+	}
+
+	_, err = io.WriteString(w, "]")
+	return err
+}
+
+func writeJSONFile(zw *zip.Writer, name string, v interface{}) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("writeJSONFile - zw.Create: %w", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func toDataExport(export entity.DataExportDTO) entity.DataExport {
+	status := export.Status
+	if status == entity.DataExportReady && export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt) {
+		status = entity.DataExportExpired
+	}
+
+	return entity.DataExport{
+		ExportUUID:  export.ExportUUID,
+		Status:      status,
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+	}
+}
@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase/storage"
+)
+
+// readTestArchive returns the content of every file in the zip stored under key
+func readTestArchive(t *testing.T, store *storage.Memory, key string) map[string]string {
+	archive, err := store.Open(context.Background(), key)
+	if err != nil {
+		t.Fatalf("store.Open() error = %v", err)
+	}
+	defer archive.Close()
+
+	data, _ := io.ReadAll(archive)
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func TestDataExportUseCase_RequestExport(t *testing.T) {
+	// Captures the export once the build finished
+	var updatedExport entity.DataExportDTO
+
+	sentAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                                                                            // Name of the test case
+		setupMocks func(mockRepo *mocks.MockDataExportRepo, mockUserRepo *mocks.MockUserRepo, store *storage.Memory) // Function to set up mock behavior
+		wantStatus string                                                                                            // Expected status returned to the caller
+		wantBuilt  string                                                                                            // Expected status once the build finished, empty if no build is expected
+		wantErr    bool                                                                                              // Whether an error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - archive built and previous export replaced",
+			setupMocks: func(mockRepo *mocks.MockDataExportRepo, mockUserRepo *mocks.MockUserRepo, store *storage.Memory) {
+				store.Put(context.Background(), "exports/old.zip", strings.NewReader("old"))
+				mockRepo.EXPECT().GetUserExports(gomock.Any(), testUserUUID).Return([]entity.DataExportDTO{
+					{ExportUUID: "old_export", UserUUID: testUserUUID, Status: entity.DataExportReady, StorageKey: "exports/old.zip"},
+				}, nil)
+				mockRepo.EXPECT().DeleteExport(gomock.Any(), "old_export").Return(nil)
+				mockRepo.EXPECT().StoreExport(gomock.Any(), gomock.Any()).Return(nil)
+
+				mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), testUserUUID).
+					Return(&entity.UserProfileDTO{UserUUID: testUserUUID, FirstName: "Test", LastName: "User"}, nil)
+				mockRepo.EXPECT().GetExportContacts(gomock.Any(), testUserUUID).
+					Return([]entity.ExportContact{{ContactUserUUID: "contact_1", Blocked: true, Removed: true}}, nil)
+				mockRepo.EXPECT().GetExportGroupMemberships(gomock.Any(), testUserUUID).
+					Return([]entity.ExportGroupMembership{{ConversationUUID: "group_1", Title: "Group", JoinDate: sentAt}}, nil)
+				mockRepo.EXPECT().GetSentMessages(gomock.Any(), testUserUUID, nil, _exportMessageBatchSize).
+					Return([]entity.ExportMessage{{MessageUUID: "message_1", Content: "hello", CreatedAt: sentAt}}, nil)
+				mockRepo.EXPECT().GetExportReactions(gomock.Any(), testUserUUID).
+					Return([]entity.ExportReaction{{MessageUUID: "message_2", ReactionType: "like"}}, nil)
+				mockRepo.EXPECT().GetExportSeenReceipts(gomock.Any(), testUserUUID).
+					Return([]entity.ExportSeenReceipt{{MessageUUID: "message_2", SeenTimestamp: sentAt}}, nil)
+				mockRepo.EXPECT().UpdateExport(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, export entity.DataExportDTO) { updatedExport = export }).
+					Return(nil)
+			},
+			wantStatus: entity.DataExportPending,
+			wantBuilt:  entity.DataExportReady,
+		},
+		{
+			name: "success - export in progress is returned",
+			setupMocks: func(mockRepo *mocks.MockDataExportRepo, mockUserRepo *mocks.MockUserRepo, store *storage.Memory) {
+				mockRepo.EXPECT().GetUserExports(gomock.Any(), testUserUUID).Return([]entity.DataExportDTO{
+					{ExportUUID: "pending_export", UserUUID: testUserUUID, Status: entity.DataExportPending, CreatedAt: time.Now()},
+				}, nil)
+			},
+			wantStatus: entity.DataExportPending,
+		},
+		{
+			name: "success - failed build is recorded",
+			setupMocks: func(mockRepo *mocks.MockDataExportRepo, mockUserRepo *mocks.MockUserRepo, store *storage.Memory) {
+				mockRepo.EXPECT().GetUserExports(gomock.Any(), testUserUUID).Return(nil, nil)
+				mockRepo.EXPECT().StoreExport(gomock.Any(), gomock.Any()).Return(nil)
+				mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), testUserUUID).Return(nil, fmt.Errorf("some error"))
+				mockRepo.EXPECT().UpdateExport(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, export entity.DataExportDTO) { updatedExport = export }).
+					Return(nil)
+			},
+			wantStatus: entity.DataExportPending,
+			wantBuilt:  entity.DataExportFailed,
+		},
+		{
+			name: "error storing export",
+			setupMocks: func(mockRepo *mocks.MockDataExportRepo, mockUserRepo *mocks.MockUserRepo, store *storage.Memory) {
+				mockRepo.EXPECT().GetUserExports(gomock.Any(), testUserUUID).Return(nil, nil)
+				mockRepo.EXPECT().StoreExport(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updatedExport = entity.DataExportDTO{}
+
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories and an in-memory blob store
+			mockRepo := mocks.NewMockDataExportRepo(ctrl)
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			store := storage.NewMemory()
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo, mockUserRepo, store)
+			}
+
+			uc := NewDataExport(mockRepo, mockUserRepo, store, time.Hour)
+			// Build synchronously so the result can be checked
+			uc.run = func(f func()) { f() }
+
+			// Call the method under test
+			got, err := uc.RequestExport(context.Background(), testUserUUID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DataExportUseCase.RequestExport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("DataExportUseCase.RequestExport() status = %v, want %v", got.Status, tt.wantStatus)
+			}
+			if updatedExport.Status != tt.wantBuilt {
+				t.Fatalf("DataExportUseCase.RequestExport() built export %+v, want status %q", updatedExport, tt.wantBuilt)
+			}
+			if tt.wantBuilt != entity.DataExportReady {
+				return
+			}
+
+			// The previous archive is deleted and the new one contains every kind of data
+			if keys := store.Keys(); len(keys) != 1 || keys[0] != updatedExport.StorageKey {
+				t.Errorf("DataExportUseCase.RequestExport() stored %v, want only %q", keys, updatedExport.StorageKey)
+			}
+			files := readTestArchive(t, store, updatedExport.StorageKey)
+			for _, name := range []string{"profile.json", "contacts.json", "group_memberships.json", "messages.json", "reactions.json", "seen_receipts.json"} {
+				if _, ok := files[name]; !ok {
+					t.Errorf("DataExportUseCase.RequestExport() archive is missing %s", name)
+				}
+			}
+			var messages []entity.ExportMessage
+			if err := json.Unmarshal([]byte(files["messages.json"]), &messages); err != nil || len(messages) != 1 || messages[0].Content != "hello" {
+				t.Errorf("DataExportUseCase.RequestExport() messages.json = %s", files["messages.json"])
+			}
+			if !strings.Contains(files["contacts.json"], `"removed": true`) {
+				t.Errorf("DataExportUseCase.RequestExport() contacts.json = %s", files["contacts.json"])
+			}
+		})
+	}
+}
+
+func TestDataExportUseCase_writeMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// A full batch means there may be more messages, the next batch starts after the last message
+	firstBatch := make([]entity.ExportMessage, _exportMessageBatchSize)
+	for i := range firstBatch {
+		firstBatch[i] = entity.ExportMessage{MessageUUID: fmt.Sprintf("message_%d", i)}
+	}
+	mockRepo := mocks.NewMockDataExportRepo(ctrl)
+	mockRepo.EXPECT().GetSentMessages(gomock.Any(), testUserUUID, nil, _exportMessageBatchSize).Return(firstBatch, nil)
+	mockRepo.EXPECT().GetSentMessages(gomock.Any(), testUserUUID, &firstBatch[_exportMessageBatchSize-1], _exportMessageBatchSize).
+		Return([]entity.ExportMessage{{MessageUUID: "last_message"}}, nil)
+
+	uc := &DataExportUseCase{repo: mockRepo}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := uc.writeMessages(context.Background(), zw, testUserUUID); err != nil {
+		t.Fatalf("DataExportUseCase.writeMessages() error = %v", err)
+	}
+	zw.Close()
+
+	zr, _ := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	rc, _ := zr.File[0].Open()
+	var messages []entity.ExportMessage
+	if err := json.NewDecoder(rc).Decode(&messages); err != nil {
+		t.Fatalf("DataExportUseCase.writeMessages() wrote invalid JSON: %v", err)
+	}
+	if len(messages) != _exportMessageBatchSize+1 || messages[len(messages)-1].MessageUUID != "last_message" {
+		t.Errorf("DataExportUseCase.writeMessages() wrote %d messages", len(messages))
+	}
+}
+
+func TestDataExportUseCase_OpenExport(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	// Define the structure of each test case
+	type testCase struct {
+		name    string                // Name of the test case
+		export  *entity.DataExportDTO // Export returned by the repository
+		wantErr error                 // Expected sentinel error, if any
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:   "success - ready archive",
+			export: &entity.DataExportDTO{ExportUUID: "export_1", UserUUID: testUserUUID, Status: entity.DataExportReady, StorageKey: "exports/export_1.zip", ExpiresAt: &future},
+		},
+		{
+			name:    "error - still pending",
+			export:  &entity.DataExportDTO{ExportUUID: "export_1", UserUUID: testUserUUID, Status: entity.DataExportPending},
+			wantErr: entity.ErrDataExportNotReady,
+		},
+		{
+			name:    "error - expired",
+			export:  &entity.DataExportDTO{ExportUUID: "export_1", UserUUID: testUserUUID, Status: entity.DataExportReady, StorageKey: "exports/export_1.zip", ExpiresAt: &past},
+			wantErr: entity.ErrDataExportNotReady,
+		},
+		{
+			name:    "error - export of another user",
+			export:  &entity.DataExportDTO{ExportUUID: "export_1", UserUUID: "another_user", Status: entity.DataExportReady, StorageKey: "exports/export_1.zip", ExpiresAt: &future},
+			wantErr: entity.ErrDataExportNotFound,
+		},
+		{
+			name:    "error - unknown export",
+			wantErr: entity.ErrDataExportNotFound,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockDataExportRepo(ctrl)
+			mockRepo.EXPECT().GetExport(gomock.Any(), "export_1").Return(tt.export, nil)
+			store := storage.NewMemory()
+			store.Put(context.Background(), "exports/export_1.zip", strings.NewReader("zip"))
+
+			uc := NewDataExport(mockRepo, nil, store, time.Hour)
+
+			// Call the method under test
+			archive, err := uc.OpenExport(context.Background(), testUserUUID, "export_1")
+			if err != tt.wantErr {
+				t.Fatalf("DataExportUseCase.OpenExport() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer archive.Close()
+			if content, _ := io.ReadAll(archive); string(content) != "zip" {
+				t.Errorf("DataExportUseCase.OpenExport() = %q", content)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+type EmailVerificationUseCase struct {
+	userRepo       UserRepo
+	tokenRepo      UserTokenRepo
+	mailer         Mailer
+	publicURL      string
+	tokenTTL       time.Duration
+	resendCooldown time.Duration
+}
+
+func NewEmailVerification(u UserRepo, t UserTokenRepo, m Mailer, publicURL string, tokenTTL time.Duration, resendCooldown time.Duration) *EmailVerificationUseCase {
+	return &EmailVerificationUseCase{
+		userRepo:       u,
+		tokenRepo:      t,
+		mailer:         m,
+		publicURL:      publicURL,
+		tokenTTL:       tokenTTL,
+		resendCooldown: resendCooldown,
+	}
+}
+
+func (uc *EmailVerificationUseCase) SendVerificationEmail(ctx context.Context, email string) error {
+	// Get user credentials from user data repository by querying 'user_credentials' table
+	userInfo, err := uc.userRepo.GetUserCredentials(ctx, entity.UserCredentialsDTO{Email: email})
+	if err != nil {
+		return fmt.Errorf("EmailVerificationUseCase - SendVerificationEmail - uc.userRepo.GetUserCredentials: %w", err)
+	}
+
+	// Nothing to send for unknown or already verified accounts
+	if userInfo == nil || userInfo.VerifiedAt != nil {
+		return nil
+	}
+
+	err = uc.sendVerificationEmail(ctx, *userInfo)
+	if err != nil {
+		return fmt.Errorf("EmailVerificationUseCase - SendVerificationEmail - uc.sendVerificationEmail: %w", err)
+	}
+	return nil
+}
+
+func (uc *EmailVerificationUseCase) ResendVerificationEmail(ctx context.Context, email string) error {
+	// Get user credentials from user data repository by querying 'user_credentials' table
+	userInfo, err := uc.userRepo.GetUserCredentials(ctx, entity.UserCredentialsDTO{Email: email})
+	if err != nil {
+		return fmt.Errorf("EmailVerificationUseCase - ResendVerificationEmail - uc.userRepo.GetUserCredentials: %w", err)
+	}
+
+	// Unknown and already verified emails are silently ignored so the endpoint cannot be used to find out who has an account
+	if userInfo == nil || userInfo.VerifiedAt != nil {
+		return nil
+	}
+
+	// Only one verification email can be sent per cooldown period
+	count, err := uc.tokenRepo.CountUserTokensSince(ctx, userInfo.UserUuid, entity.TokenPurposeEmailVerification, time.Now().Add(-uc.resendCooldown))
+	if err != nil {
+		return fmt.Errorf("EmailVerificationUseCase - ResendVerificationEmail - uc.tokenRepo.CountUserTokensSince: %w", err)
+	}
+
+	// Return error if a verification email was sent recently. Will be handled by controller
+	if count > 0 {
+		return entity.ErrTooManyRequests
+	}
+
+	err = uc.sendVerificationEmail(ctx, *userInfo)
+	if err != nil {
+		return fmt.Errorf("EmailVerificationUseCase - ResendVerificationEmail - uc.sendVerificationEmail: %w", err)
+	}
+	return nil
+}
+
+func (uc *EmailVerificationUseCase) ConfirmEmail(ctx context.Context, token string) error {
+	// Mark verification token as used in 'user_tokens' table, a token can only be consumed once
+	userToken, err := uc.tokenRepo.ConsumeUserToken(ctx, hashToken(token), entity.TokenPurposeEmailVerification)
+	if err != nil {
+		return fmt.Errorf("EmailVerificationUseCase - ConfirmEmail - uc.tokenRepo.ConsumeUserToken: %w", err)
+	}
+
+	// Return error if token is unknown, already used or expired. Will be handled by controller
+	if userToken == nil {
+		return entity.ErrInvalidVerificationToken
+	}
+
+	// Set 'verified_at' in 'user_credentials' table, only if the email has not changed since the token was sent
+	verified, err := uc.userRepo.MarkEmailVerified(ctx, userToken.UserUUID, userToken.Payload)
+	if err != nil {
+		return fmt.Errorf("EmailVerificationUseCase - ConfirmEmail - uc.userRepo.MarkEmailVerified: %w", err)
+	}
+
+	// Return error if the email was changed or already verified. Will be handled by controller
+	if !verified {
+		return entity.ErrInvalidVerificationToken
+	}
+	return nil
+}
+
+// Issues a verification token bound to the user's current email and mails it
+func (uc *EmailVerificationUseCase) sendVerificationEmail(ctx context.Context, userInfo entity.UserCredentialsDTO) error {
+	token, err := issueUserToken(ctx, uc.tokenRepo, userInfo.UserUuid, entity.TokenPurposeEmailVerification, userInfo.Email, uc.tokenTTL)
+	if err != nil {
+		return fmt.Errorf("issueUserToken: %w", err)
+	}
+
+	err = uc.mailer.Send(ctx, entity.Mail{
+		To:      userInfo.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to verify your email address. It expires in %s.\n\n%s/verify-email?token=%s\n",
+			userInfo.Username, uc.tokenTTL, uc.publicURL, url.QueryEscape(token)),
+	})
+	if err != nil {
+		return fmt.Errorf("uc.mailer.Send: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase/mailer"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+)
+
+func TestEmailVerificationUseCase_ResendVerificationEmail(t *testing.T) {
+	verifiedAt := time.Now()
+	unverifiedUser := &entity.UserCredentialsDTO{UserUuid: testUserUUID, Username: "testuser", Email: "test@example.com"}
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                                                         // Name of the test case
+		setupMocks func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) // Function to set up mock behavior
+		wantMail   bool                                                                           // Whether a verification mail is expected to be sent
+		wantErr    error                                                                          // Expected sentinel error, if any
+		wantAnyErr bool                                                                           // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - verification mail sent",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) {
+				mockUserRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Email: "test@example.com"}).
+					Return(unverifiedUser, nil)
+				mockTokenRepo.EXPECT().
+					CountUserTokensSince(gomock.Any(), testUserUUID, entity.TokenPurposeEmailVerification, gomock.Any()).
+					Return(0, nil)
+				mockTokenRepo.EXPECT().
+					StoreUserToken(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			wantMail: true,
+		},
+		{
+			name: "success - already verified is ignored",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) {
+				mockUserRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Email: "test@example.com"}).
+					Return(&entity.UserCredentialsDTO{UserUuid: testUserUUID, VerifiedAt: &verifiedAt}, nil)
+			},
+			wantMail: false,
+		},
+		{
+			name: "error - sent too recently",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) {
+				mockUserRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Email: "test@example.com"}).
+					Return(unverifiedUser, nil)
+				mockTokenRepo.EXPECT().
+					CountUserTokensSince(gomock.Any(), testUserUUID, entity.TokenPurposeEmailVerification, gomock.Any()).
+					Return(1, nil)
+			},
+			wantMail:   false,
+			wantErr:    entity.ErrTooManyRequests,
+			wantAnyErr: true,
+		},
+		{
+			name: "error storing verification token",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) {
+				mockUserRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Email: "test@example.com"}).
+					Return(unverifiedUser, nil)
+				mockTokenRepo.EXPECT().
+					CountUserTokensSince(gomock.Any(), testUserUUID, entity.TokenPurposeEmailVerification, gomock.Any()).
+					Return(0, nil)
+				mockTokenRepo.EXPECT().
+					StoreUserToken(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("some error"))
+			},
+			wantMail:   false,
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories and an in-memory mailer
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			mockTokenRepo := mocks.NewMockUserTokenRepo(ctrl)
+			memoryMailer := mailer.NewMemory()
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockUserRepo, mockTokenRepo)
+			}
+
+			uc := NewEmailVerification(mockUserRepo, mockTokenRepo, memoryMailer, "http://localhost", 24*time.Hour, time.Minute)
+
+			// Call the method under test
+			err := uc.ResendVerificationEmail(context.Background(), "test@example.com")
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("EmailVerificationUseCase.ResendVerificationEmail() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("EmailVerificationUseCase.ResendVerificationEmail() error = %v, want %v", err, tt.wantErr)
+			}
+			if sent := memoryMailer.Sent(); (len(sent) == 1) != tt.wantMail {
+				t.Errorf("EmailVerificationUseCase.ResendVerificationEmail() sent %d mails, wantMail %v", len(sent), tt.wantMail)
+			}
+		})
+	}
+}
+
+func TestEmailVerificationUseCase_ConfirmEmail(t *testing.T) {
+	verificationToken := &entity.UserTokenDTO{UserUUID: testUserUUID, Payload: "test@example.com"}
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                                                         // Name of the test case
+		setupMocks func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) // Function to set up mock behavior
+		wantErr    error                                                                          // Expected sentinel error, if any
+		wantAnyErr bool                                                                           // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - email verified",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) {
+				mockTokenRepo.EXPECT().
+					ConsumeUserToken(gomock.Any(), hashToken("verification_token"), entity.TokenPurposeEmailVerification).
+					Return(verificationToken, nil)
+				mockUserRepo.EXPECT().
+					MarkEmailVerified(gomock.Any(), testUserUUID, "test@example.com").
+					Return(true, nil)
+			},
+		},
+		{
+			name: "error - used or expired token",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) {
+				mockTokenRepo.EXPECT().
+					ConsumeUserToken(gomock.Any(), hashToken("verification_token"), entity.TokenPurposeEmailVerification).
+					Return(nil, nil)
+			},
+			wantErr:    entity.ErrInvalidVerificationToken,
+			wantAnyErr: true,
+		},
+		{
+			name: "error - email changed since the token was sent",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) {
+				mockTokenRepo.EXPECT().
+					ConsumeUserToken(gomock.Any(), hashToken("verification_token"), entity.TokenPurposeEmailVerification).
+					Return(verificationToken, nil)
+				mockUserRepo.EXPECT().
+					MarkEmailVerified(gomock.Any(), testUserUUID, "test@example.com").
+					Return(false, nil)
+			},
+			wantErr:    entity.ErrInvalidVerificationToken,
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			mockTokenRepo := mocks.NewMockUserTokenRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockUserRepo, mockTokenRepo)
+			}
+
+			uc := &EmailVerificationUseCase{userRepo: mockUserRepo, tokenRepo: mockTokenRepo}
+
+			// Call the method under test
+			err := uc.ConfirmEmail(context.Background(), "verification_token")
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("EmailVerificationUseCase.ConfirmEmail() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("EmailVerificationUseCase.ConfirmEmail() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -10,16 +10,31 @@ import (
 type GroupChatUseCase struct {
 	repo         GroupChatRepo
 	userInfoRepo UserRepo
+	contactsRepo ContactsRepo
+	privacyRepo  PrivacyRepo
 }
 
-func NewGroupChat(r GroupChatRepo, userInfoRepo UserRepo) *GroupChatUseCase {
+func NewGroupChat(r GroupChatRepo, userInfoRepo UserRepo, contactsRepo ContactsRepo, privacyRepo PrivacyRepo) *GroupChatUseCase {
 	return &GroupChatUseCase{
 		repo:         r,
 		userInfoRepo: userInfoRepo,
+		contactsRepo: contactsRepo,
+		privacyRepo:  privacyRepo,
 	}
 }
 
 func (uc *GroupChatUseCase) CreateGroupChat(ctx context.Context, groupChat entity.GroupChat) error {
+	for _, participant := range groupChat.Participants {
+		// Check the participant can be added to the group by the user
+		allowed, err := uc.canAddToGroup(ctx, groupChat.UserUUID, participant.ParticipantUUID)
+		if err != nil {
+			return fmt.Errorf("GroupChatUseCase - CreateGroupChat - uc.canAddToGroup: %w", err)
+		}
+		if !allowed {
+			return entity.ErrGroupAddNotAllowed
+		}
+	}
+
 	err := uc.repo.CreateGroupChat(ctx, toGroupChatDTO(groupChat))
 	if err != nil {
 		return fmt.Errorf("GroupChatUseCase - CreateGroupChat - CreateGroupChat: %w", err)
@@ -51,6 +66,15 @@ func (uc *GroupChatUseCase) AddParticipant(ctx context.Context, groupChat entity
 		if exist {
 			return entity.ErrParticipantAlrdInGroupChat
 		}
+
+		// Check the privacy settings of the participant allow the user to add them
+		allowed, err := uc.canAddToGroup(ctx, groupChatDTO.UserUUID, groupChatDTO.Participants[i].ParticipantUUID)
+		if err != nil {
+			return fmt.Errorf("GroupChatUseCase - AddParticipant - uc.canAddToGroup: %w", err)
+		}
+		if !allowed {
+			return entity.ErrGroupAddNotAllowed
+		}
 	}
 
 	// Add participants into 'participants' table using group chat data repository
@@ -117,6 +141,43 @@ func (uc *GroupChatUseCase) UpdateGroupTitle(ctx context.Context, groupChat enti
 	return nil
 }
 
+// canAddToGroup checks who the participant can be added to group chats by, as set in their privacy settings.
+// Users who blocked each other can never add each other.
+func (uc *GroupChatUseCase) canAddToGroup(ctx context.Context, userUUID string, participantUUID string) (bool, error) {
+	// Check neither user blocked the other by querying 'contacts' table
+	blocked, err := uc.contactsRepo.CheckBlocked(ctx, userUUID, participantUUID)
+	if err != nil {
+		return false, err
+	}
+	if blocked {
+		return false, nil
+	}
+
+	settings, err := getPrivacySettings(ctx, uc.privacyRepo, participantUUID)
+	if err != nil {
+		return false, err
+	}
+
+	switch settings.WhoCanAddToGroups {
+	case entity.PrivacyNobody:
+		return false, nil
+	case entity.PrivacyContacts:
+		// Get the contacts of the participant by querying 'contacts' table
+		contactUUIDs, err := uc.contactsRepo.GetContactUUIDs(ctx, participantUUID)
+		if err != nil {
+			return false, err
+		}
+		for _, contactUUID := range contactUUIDs {
+			if contactUUID == userUUID {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
 // Convert group chat entity object to group chat DTO
 func toGroupChatDTO(gc entity.GroupChat) entity.GroupChatDTO {
 	participantsDTO := []entity.ParticipantDTO{}
@@ -19,10 +19,10 @@ func TestGroupChatUseCase_CreateGroupChat(t *testing.T) {
 
 	// Define the structure of each test case
 	type testCase struct {
-		name       string                                  // Name of the test case, used to identify the test in the output
-		args       args                                    // The input arguments for the test case
-		setupMocks func(mockRepo *mocks.MockGroupChatRepo) // Function to set up mock behavior for the test
-		wantErr    bool                                    // Whether the test expects an error to occur
+		name       string                                                                            // Name of the test case, used to identify the test in the output
+		args       args                                                                              // The input arguments for the test case
+		setupMocks func(mockRepo *mocks.MockGroupChatRepo, mockContactsRepo *mocks.MockContactsRepo) // Function to set up mock behavior for the test
+		wantErr    bool                                                                              // Whether the test expects an error to occur
 	}
 
 	// Define the test cases
@@ -38,7 +38,7 @@ func TestGroupChatUseCase_CreateGroupChat(t *testing.T) {
 				},
 			},
 			// This function sets up the expected behavior of the mock repository for this test case
-			setupMocks: func(mockRepo *mocks.MockGroupChatRepo) {
+			setupMocks: func(mockRepo *mocks.MockGroupChatRepo, mockContactsRepo *mocks.MockContactsRepo) {
 				groupChatDTO := toGroupChatDTO(entity.GroupChat{
 					UserUUID:         "user_uuid_1234",
 					Title:            "Group Title",
@@ -62,7 +62,7 @@ func TestGroupChatUseCase_CreateGroupChat(t *testing.T) {
 				},
 			},
 			// This function sets up the mock to simulate an error when creating the group chat
-			setupMocks: func(mockRepo *mocks.MockGroupChatRepo) {
+			setupMocks: func(mockRepo *mocks.MockGroupChatRepo, mockContactsRepo *mocks.MockContactsRepo) {
 				groupChatDTO := toGroupChatDTO(entity.GroupChat{
 					UserUUID:         "user_uuid_1234",
 					Title:            "Group Title",
@@ -74,6 +74,26 @@ func TestGroupChatUseCase_CreateGroupChat(t *testing.T) {
 			},
 			wantErr: true, // The test expects an error to occur
 		},
+		{
+			name: "participant blocked the user", // Test case for when one of the participants and the user blocked the other
+			args: args{
+				ctx: context.Background(),
+				groupChat: entity.GroupChat{
+					UserUUID: "user_uuid_1234",
+					Title:    "Group Title",
+					Participants: []entity.Participant{
+						{ParticipantUUID: "participant_uuid_1234"},
+					},
+				},
+			},
+			// This function sets up the mock so that the group chat is not created
+			setupMocks: func(mockRepo *mocks.MockGroupChatRepo, mockContactsRepo *mocks.MockContactsRepo) {
+				mockContactsRepo.EXPECT().
+					CheckBlocked(gomock.Any(), "user_uuid_1234", "participant_uuid_1234").
+					Return(true, nil)
+			},
+			wantErr: true, // The test expects an error to occur
+		},
 	}
 
 	// Iterate over each test case and run it
@@ -83,17 +103,19 @@ func TestGroupChatUseCase_CreateGroupChat(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish() // Ensure that the mock expectations are checked and cleaned up after the test
 
-			// Create a mock instance of the GroupChatRepo interface
+			// Create mock instances of the GroupChatRepo and ContactsRepo interfaces
 			mockRepo := mocks.NewMockGroupChatRepo(ctrl)
+			mockContactsRepo := mocks.NewMockContactsRepo(ctrl)
 
 			// Set up the mock expectations using the setupMocks function provided in the test case
 			if tt.setupMocks != nil {
-				tt.setupMocks(mockRepo)
+				tt.setupMocks(mockRepo, mockContactsRepo)
 			}
 
-			// Create an instance of GroupChatUseCase using the mock repository
+			// Create an instance of GroupChatUseCase using the mock repositories
 			uc := &GroupChatUseCase{
-				repo: mockRepo,
+				repo:         mockRepo,
+				contactsRepo: mockContactsRepo,
 			}
 
 			// Call the method under test with the provided arguments
@@ -237,10 +259,10 @@ func TestGroupChatUseCase_AddParticipant(t *testing.T) {
 
 	// Define the structure of each test case
 	type testCase struct {
-		name       string                                  // Name of the test case, used to identify the test in the output
-		args       args                                    // The input arguments for the test case
-		setupMocks func(mockRepo *mocks.MockGroupChatRepo) // Function to set up mock behavior for the test
-		wantErr    bool                                    // Whether the test expects an error to occur
+		name       string                                                                                                                    // Name of the test case, used to identify the test in the output
+		args       args                                                                                                                      // The input arguments for the test case
+		setupMocks func(mockRepo *mocks.MockGroupChatRepo, mockContactsRepo *mocks.MockContactsRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) // Function to set up mock behavior for the test
+		wantErr    bool                                                                                                                      // Whether the test expects an error to occur
 	}
 
 	// Define the test cases
@@ -258,7 +280,7 @@ func TestGroupChatUseCase_AddParticipant(t *testing.T) {
 				},
 			},
 			// This function sets up the expected behavior of the mock repository for this test case
-			setupMocks: func(mockRepo *mocks.MockGroupChatRepo) {
+			setupMocks: func(mockRepo *mocks.MockGroupChatRepo, mockContactsRepo *mocks.MockContactsRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
 				groupChatDTO := toGroupChatDTO(entity.GroupChat{
 					UserUUID:         "user_uuid_1234",
 					ConversationUUID: "conv_uuid_1234",
@@ -292,7 +314,7 @@ func TestGroupChatUseCase_AddParticipant(t *testing.T) {
 				},
 			},
 			// This function sets up the mock to simulate that the user is not in the group chat
-			setupMocks: func(mockRepo *mocks.MockGroupChatRepo) {
+			setupMocks: func(mockRepo *mocks.MockGroupChatRepo, mockContactsRepo *mocks.MockContactsRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
 				mockRepo.EXPECT().
 					ValidateUserInGroupChat(gomock.Any(), "conv_uuid_1234", "user_uuid_1234").
 					Return(false, nil) // Simulate that the user is not in the group chat
@@ -312,7 +334,7 @@ func TestGroupChatUseCase_AddParticipant(t *testing.T) {
 				},
 			},
 			// This function sets up the mock to simulate that the participant is already in the group chat
-			setupMocks: func(mockRepo *mocks.MockGroupChatRepo) {
+			setupMocks: func(mockRepo *mocks.MockGroupChatRepo, mockContactsRepo *mocks.MockContactsRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
 				mockRepo.EXPECT().
 					ValidateUserInGroupChat(gomock.Any(), "conv_uuid_1234", "user_uuid_1234").
 					Return(true, nil) // Simulate that the user is in the group chat
@@ -322,6 +344,87 @@ func TestGroupChatUseCase_AddParticipant(t *testing.T) {
 			},
 			wantErr: true, // The test expects an error to occur
 		},
+		{
+			name: "participant does not allow group adds", // Test case for when the privacy settings of the participant do not allow anyone
+			args: args{
+				ctx: context.Background(),
+				groupChat: entity.GroupChat{
+					UserUUID:         "user_uuid_1234",
+					ConversationUUID: "conv_uuid_1234",
+					Participants: []entity.Participant{
+						{ParticipantUUID: "participant_uuid_1234"},
+					},
+				},
+			},
+			// This function sets up the mock to simulate that the participant can not be added by anyone
+			setupMocks: func(mockRepo *mocks.MockGroupChatRepo, mockContactsRepo *mocks.MockContactsRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockRepo.EXPECT().
+					ValidateUserInGroupChat(gomock.Any(), "conv_uuid_1234", "user_uuid_1234").
+					Return(true, nil)
+				mockRepo.EXPECT().
+					ValidateUserInGroupChat(gomock.Any(), "conv_uuid_1234", "participant_uuid_1234").
+					Return(false, nil)
+				mockPrivacyRepo.EXPECT().
+					GetPrivacySettings(gomock.Any(), "participant_uuid_1234").
+					Return(&entity.PrivacySettingsDTO{UserUUID: "participant_uuid_1234", WhoCanAddToGroups: entity.PrivacyNobody}, nil)
+			},
+			wantErr: true, // The test expects an error to occur
+		},
+		{
+			name: "participant only allows contacts", // Test case for when the user is not a contact of the participant
+			args: args{
+				ctx: context.Background(),
+				groupChat: entity.GroupChat{
+					UserUUID:         "user_uuid_1234",
+					ConversationUUID: "conv_uuid_1234",
+					Participants: []entity.Participant{
+						{ParticipantUUID: "participant_uuid_1234"},
+					},
+				},
+			},
+			// This function sets up the mock to simulate that the user is not in the contacts of the participant
+			setupMocks: func(mockRepo *mocks.MockGroupChatRepo, mockContactsRepo *mocks.MockContactsRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockRepo.EXPECT().
+					ValidateUserInGroupChat(gomock.Any(), "conv_uuid_1234", "user_uuid_1234").
+					Return(true, nil)
+				mockRepo.EXPECT().
+					ValidateUserInGroupChat(gomock.Any(), "conv_uuid_1234", "participant_uuid_1234").
+					Return(false, nil)
+				mockPrivacyRepo.EXPECT().
+					GetPrivacySettings(gomock.Any(), "participant_uuid_1234").
+					Return(&entity.PrivacySettingsDTO{UserUUID: "participant_uuid_1234", WhoCanAddToGroups: entity.PrivacyContacts}, nil)
+				mockContactsRepo.EXPECT().
+					GetContactUUIDs(gomock.Any(), "participant_uuid_1234").
+					Return([]string{"other_uuid_1234"}, nil)
+			},
+			wantErr: true, // The test expects an error to occur
+		},
+		{
+			name: "participant blocked the user", // Test case for when one of the users blocked the other
+			args: args{
+				ctx: context.Background(),
+				groupChat: entity.GroupChat{
+					UserUUID:         "user_uuid_1234",
+					ConversationUUID: "conv_uuid_1234",
+					Participants: []entity.Participant{
+						{ParticipantUUID: "participant_uuid_1234"},
+					},
+				},
+			},
+			// This function sets up the mock to simulate that the participant blocked the user
+			setupMocks: func(mockRepo *mocks.MockGroupChatRepo, mockContactsRepo *mocks.MockContactsRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockRepo.EXPECT().
+					ValidateUserInGroupChat(gomock.Any(), "conv_uuid_1234", "user_uuid_1234").
+					Return(true, nil)
+				mockRepo.EXPECT().
+					ValidateUserInGroupChat(gomock.Any(), "conv_uuid_1234", "participant_uuid_1234").
+					Return(false, nil)
+				mockContactsRepo.EXPECT().
+					CheckBlocked(gomock.Any(), "user_uuid_1234", "participant_uuid_1234").
+					Return(true, nil)
+			},
+			wantErr: true, // The test expects an error to occur
+		},
 	}
 
 	// Iterate over each test case and run it
@@ -331,17 +434,25 @@ func TestGroupChatUseCase_AddParticipant(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish() // Ensure that the mock expectations are checked and cleaned up after the test
 
-			// Create a mock instance of the GroupChatRepo interface
+			// Create mock instances of the GroupChatRepo, ContactsRepo and PrivacyRepo interfaces
 			mockRepo := mocks.NewMockGroupChatRepo(ctrl)
+			mockContactsRepo := mocks.NewMockContactsRepo(ctrl)
+			mockPrivacyRepo := mocks.NewMockPrivacyRepo(ctrl)
 
 			// Set up the mock expectations using the setupMocks function provided in the test case
 			if tt.setupMocks != nil {
-				tt.setupMocks(mockRepo)
+				tt.setupMocks(mockRepo, mockContactsRepo, mockPrivacyRepo)
 			}
+			// Users who never changed their privacy settings can be added by everyone
+			mockPrivacyRepo.EXPECT().GetPrivacySettings(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			// Unless a test case says otherwise, the users did not block each other
+			mockContactsRepo.EXPECT().CheckBlocked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
 
-			// Create an instance of GroupChatUseCase using the mock repository
+			// Create an instance of GroupChatUseCase using the mock repositories
 			uc := &GroupChatUseCase{
-				repo: mockRepo,
+				repo:         mockRepo,
+				contactsRepo: mockContactsRepo,
+				privacyRepo:  mockPrivacyRepo,
 			}
 
 			// Call the method under test with the provided arguments
@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"net/http"
+
+	// Register the decoders of the accepted image types
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/google/uuid"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/pkg/imaging"
+)
+
+const _thumbnailQuality = 85
+
+// _imageTypes are the content types that can be uploaded, sniffed from the content rather than trusted from the client
+var _imageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// ImageUseCase stores the avatars of users and the pictures of group chats.
+// Uploads are cropped to a square and scaled into one JPEG thumbnail per size, the original is not kept.
+type ImageUseCase struct {
+	store         BlobStore
+	userRepo      UserRepo
+	groupChatRepo GroupChatRepo
+	maxUploadSize int64
+	maxPixels     int
+	sizes         []int
+}
+
+// NewImage -.
+// Uploads are limited to maxUploadSize bytes and maxPixels pixels, the last of sizes is served by default.
+func NewImage(store BlobStore, userRepo UserRepo, groupChatRepo GroupChatRepo, maxUploadSize int64, maxPixels int, sizes []int) *ImageUseCase {
+	return &ImageUseCase{
+		store:         store,
+		userRepo:      userRepo,
+		groupChatRepo: groupChatRepo,
+		maxUploadSize: maxUploadSize,
+		maxPixels:     maxPixels,
+		sizes:         sizes,
+	}
+}
+
+// UploadAvatar stores the image and makes it the avatar of the user, the previous uploaded avatar is deleted.
+func (uc *ImageUseCase) UploadAvatar(ctx context.Context, userUUID string, r io.Reader) (entity.Image, error) {
+	// Get user profile from user data repository by querying 'user_info' table
+	userProfileDTO, err := uc.userRepo.GetUserProfile(ctx, userUUID)
+	if err != nil {
+		return entity.Image{}, fmt.Errorf("ImageUseCase - UploadAvatar - uc.userRepo.GetUserProfile: %w", err)
+	}
+
+	// Return error if user is not found. Will be handled by controller
+	if userProfileDTO == nil {
+		return entity.Image{}, entity.ErrUserNotFound
+	}
+
+	img, err := uc.storeImage(ctx, r)
+	if err != nil {
+		return entity.Image{}, err
+	}
+
+	// Update the avatar in 'user_info' table using user data repository
+	err = uc.userRepo.UpdateAvatar(ctx, userUUID, img.URL)
+	if err != nil {
+		uc.deleteImage(ctx, img.URL)
+		return entity.Image{}, fmt.Errorf("ImageUseCase - UploadAvatar - uc.userRepo.UpdateAvatar: %w", err)
+	}
+
+	uc.deleteImage(ctx, userProfileDTO.Avatar)
+	return img, nil
+}
+
+// UploadGroupPicture stores the image and makes it the picture of the group chat, the previous uploaded picture is deleted.
+// Only participants of the group chat can change its picture.
+func (uc *ImageUseCase) UploadGroupPicture(ctx context.Context, userUUID string, conversationUUID string, r io.Reader) (entity.Image, error) {
+	// Check if user is in groupchat by querying 'participants' table from group chat data repository
+	exist, err := uc.groupChatRepo.ValidateUserInGroupChat(ctx, conversationUUID, userUUID)
+	if err != nil {
+		return entity.Image{}, fmt.Errorf("ImageUseCase - UploadGroupPicture - uc.groupChatRepo.ValidateUserInGroupChat: %w", err)
+	}
+
+	// If user not in group chat, dont allow user to change the picture. Returned error will be handled by controller
+	if !exist {
+		return entity.Image{}, entity.ErrUserNotInGroupChat
+	}
+
+	// Get the current picture from group chat data repository by querying 'conversations' table
+	previous, err := uc.groupChatRepo.GetGroupPicture(ctx, conversationUUID)
+	if err != nil {
+		return entity.Image{}, fmt.Errorf("ImageUseCase - UploadGroupPicture - uc.groupChatRepo.GetGroupPicture: %w", err)
+	}
+
+	img, err := uc.storeImage(ctx, r)
+	if err != nil {
+		return entity.Image{}, err
+	}
+
+	// Update the picture in 'conversations' table using group chat data repository
+	err = uc.groupChatRepo.UpdateGroupPicture(ctx, conversationUUID, img.URL)
+	if err != nil {
+		uc.deleteImage(ctx, img.URL)
+		return entity.Image{}, fmt.Errorf("ImageUseCase - UploadGroupPicture - uc.groupChatRepo.UpdateGroupPicture: %w", err)
+	}
+
+	uc.deleteImage(ctx, previous)
+	return img, nil
+}
+
+// OpenImage returns a thumbnail of the image, a size of zero returns the largest one.
+func (uc *ImageUseCase) OpenImage(ctx context.Context, imageUUID string, size int) (io.ReadCloser, error) {
+	if size == 0 && len(uc.sizes) > 0 {
+		size = uc.sizes[len(uc.sizes)-1]
+	}
+
+	// Return error if the image or the size can not exist. Will be handled by controller
+	if _, err := uuid.Parse(imageUUID); err != nil || !uc.hasSize(size) {
+		return nil, entity.ErrImageNotFound
+	}
+
+	// Open the thumbnail from the blob store
+	thumbnail, err := uc.store.Open(ctx, entity.ImageKey(imageUUID, size))
+	if err != nil {
+		if errors.Is(err, entity.ErrBlobNotFound) {
+			return nil, entity.ErrImageNotFound
+		}
+		return nil, fmt.Errorf("ImageUseCase - OpenImage - uc.store.Open: %w", err)
+	}
+	return thumbnail, nil
+}
+
+// storeImage checks, decodes and stores the thumbnails of an uploaded image.
+func (uc *ImageUseCase) storeImage(ctx context.Context, r io.Reader) (entity.Image, error) {
+	// Read one byte more than allowed to know whether the upload is too large
+	data, err := io.ReadAll(io.LimitReader(r, uc.maxUploadSize+1))
+	if err != nil {
+		return entity.Image{}, fmt.Errorf("ImageUseCase - storeImage - io.ReadAll: %w", err)
+	}
+
+	// Return error if the upload is too large or not an accepted image. Will be handled by controller
+	if int64(len(data)) > uc.maxUploadSize {
+		return entity.Image{}, entity.ErrImageTooLarge
+	}
+	if !_imageTypes[http.DetectContentType(data)] {
+		return entity.Image{}, entity.ErrUnsupportedImageType
+	}
+
+	// Check the dimensions before decoding, so that a small file can not expand into a huge image
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return entity.Image{}, entity.ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > uc.maxPixels {
+		return entity.Image{}, entity.ErrImageTooLarge
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return entity.Image{}, entity.ErrInvalidImage
+	}
+	// JPEG has no transparency, transparent pixels become white
+	flattened := imaging.Flatten(decoded, color.White)
+
+	img := entity.Image{
+		ImageUUID: uuid.New().String(),
+		Sizes:     uc.sizes,
+	}
+	img.URL = entity.ImageURL(img.ImageUUID)
+	for _, size := range uc.sizes {
+		var buf bytes.Buffer
+		err = jpeg.Encode(&buf, imaging.Thumbnail(flattened, size), &jpeg.Options{Quality: _thumbnailQuality})
+		if err != nil {
+			return entity.Image{}, fmt.Errorf("ImageUseCase - storeImage - jpeg.Encode: %w", err)
+		}
+
+		// Store the thumbnail in the blob store
+		err = uc.store.Put(ctx, entity.ImageKey(img.ImageUUID, size), &buf)
+		if err != nil {
+			uc.deleteImage(ctx, img.URL)
+			return entity.Image{}, fmt.Errorf("ImageUseCase - storeImage - uc.store.Put: %w", err)
+		}
+	}
+	return img, nil
+}
+
+// deleteImage deletes the thumbnails of an uploaded image, it does nothing for images hosted elsewhere.
+// Failures only leave unused blobs behind, so they are ignored.
+func (uc *ImageUseCase) deleteImage(ctx context.Context, url string) {
+	imageUUID, ok := entity.ImageUUIDFromURL(url)
+	if !ok {
+		return
+	}
+	for _, size := range uc.sizes {
+		_ = uc.store.Delete(ctx, entity.ImageKey(imageUUID, size))
+	}
+}
+
+func (uc *ImageUseCase) hasSize(size int) bool {
+	for _, s := range uc.sizes {
+		if s == size {
+			return true
+		}
+	}
+	return false
+}
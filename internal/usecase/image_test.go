@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase/storage"
+)
+
+// testPNG returns a PNG of the given dimensions
+func testPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestImageUseCase_UploadAvatar(t *testing.T) {
+	sizes := []int{16, 32}
+	oldImageUUID := "6f1c2a4e-1111-4a3b-9c2d-000000000001"
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                                        // Name of the test case
+		upload     []byte                                                        // Uploaded content
+		setupMocks func(mockUserRepo *mocks.MockUserRepo, store *storage.Memory) // Function to set up mock behavior
+		wantErr    error                                                         // Expected error, nil if none
+		wantAnyErr bool                                                          // Whether an unspecified error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:   "success - thumbnails stored and previous avatar deleted",
+			upload: testPNG(t, 60, 40),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, store *storage.Memory) {
+				for _, size := range sizes {
+					store.Put(context.Background(), entity.ImageKey(oldImageUUID, size), strings.NewReader("old"))
+				}
+				mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), testUserUUID).
+					Return(&entity.UserProfileDTO{UserUUID: testUserUUID, Avatar: entity.ImageURL(oldImageUUID)}, nil)
+				mockUserRepo.EXPECT().UpdateAvatar(gomock.Any(), testUserUUID, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:   "user not found",
+			upload: testPNG(t, 10, 10),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, store *storage.Memory) {
+				mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), testUserUUID).Return(nil, nil)
+			},
+			wantErr: entity.ErrUserNotFound,
+		},
+		{
+			name:   "too large",
+			upload: make([]byte, 2048),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, store *storage.Memory) {
+				mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), testUserUUID).Return(&entity.UserProfileDTO{UserUUID: testUserUUID}, nil)
+			},
+			wantErr: entity.ErrImageTooLarge,
+		},
+		{
+			name:   "too many pixels",
+			upload: testPNG(t, 200, 200),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, store *storage.Memory) {
+				mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), testUserUUID).Return(&entity.UserProfileDTO{UserUUID: testUserUUID}, nil)
+			},
+			wantErr: entity.ErrImageTooLarge,
+		},
+		{
+			name:   "unsupported type",
+			upload: []byte("<html><body>not an image</body></html>"),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, store *storage.Memory) {
+				mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), testUserUUID).Return(&entity.UserProfileDTO{UserUUID: testUserUUID}, nil)
+			},
+			wantErr: entity.ErrUnsupportedImageType,
+		},
+		{
+			name:   "error updating avatar",
+			upload: testPNG(t, 10, 10),
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, store *storage.Memory) {
+				mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), testUserUUID).Return(&entity.UserProfileDTO{UserUUID: testUserUUID}, nil)
+				mockUserRepo.EXPECT().UpdateAvatar(gomock.Any(), testUserUUID, gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories and an in memory blob store
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			mockGroupChatRepo := mocks.NewMockGroupChatRepo(ctrl)
+			store := storage.NewMemory()
+			tt.setupMocks(mockUserRepo, store)
+
+			uc := NewImage(store, mockUserRepo, mockGroupChatRepo, 1024, 10000, sizes)
+
+			// Call the method under test
+			img, err := uc.UploadAvatar(context.Background(), testUserUUID, bytes.NewReader(tt.upload))
+			if tt.wantErr != nil || tt.wantAnyErr {
+				if err == nil || (tt.wantErr != nil && err != tt.wantErr) {
+					t.Fatalf("ImageUseCase.UploadAvatar() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				// Nothing is left behind by a failed upload
+				if img.ImageUUID != "" {
+					t.Errorf("ImageUseCase.UploadAvatar() = %+v, want empty image", img)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImageUseCase.UploadAvatar() error = %v", err)
+			}
+
+			// Every size is stored as a square JPEG
+			for _, size := range sizes {
+				thumbnail, err := uc.OpenImage(context.Background(), img.ImageUUID, size)
+				if err != nil {
+					t.Fatalf("ImageUseCase.OpenImage(%d) error = %v", size, err)
+				}
+				config, err := jpeg.DecodeConfig(thumbnail)
+				thumbnail.Close()
+				if err != nil || config.Width != size || config.Height != size {
+					t.Errorf("thumbnail %d = %dx%d, %v, want %dx%d jpeg", size, config.Width, config.Height, err, size, size)
+				}
+			}
+
+			// The previous avatar is gone
+			if _, err := uc.OpenImage(context.Background(), oldImageUUID, sizes[0]); err != entity.ErrImageNotFound {
+				t.Errorf("ImageUseCase.OpenImage() previous avatar error = %v, want %v", err, entity.ErrImageNotFound)
+			}
+		})
+	}
+}
+
+func TestImageUseCase_UploadGroupPicture(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepo(ctrl)
+	mockGroupChatRepo := mocks.NewMockGroupChatRepo(ctrl)
+	uc := NewImage(storage.NewMemory(), mockUserRepo, mockGroupChatRepo, 1024*1024, 10000, []int{16})
+
+	t.Run("success", func(t *testing.T) {
+		mockGroupChatRepo.EXPECT().ValidateUserInGroupChat(gomock.Any(), "group_1", testUserUUID).Return(true, nil)
+		mockGroupChatRepo.EXPECT().GetGroupPicture(gomock.Any(), "group_1").Return("", nil)
+		mockGroupChatRepo.EXPECT().UpdateGroupPicture(gomock.Any(), "group_1", gomock.Any()).Return(nil)
+
+		img, err := uc.UploadGroupPicture(context.Background(), testUserUUID, "group_1", bytes.NewReader(testPNG(t, 20, 20)))
+		if err != nil || img.URL != entity.ImageURL(img.ImageUUID) {
+			t.Fatalf("ImageUseCase.UploadGroupPicture() = %+v, %v, want stored image", img, err)
+		}
+	})
+
+	t.Run("user not in group chat", func(t *testing.T) {
+		mockGroupChatRepo.EXPECT().ValidateUserInGroupChat(gomock.Any(), "group_1", testUserUUID).Return(false, nil)
+
+		_, err := uc.UploadGroupPicture(context.Background(), testUserUUID, "group_1", bytes.NewReader(testPNG(t, 20, 20)))
+		if err != entity.ErrUserNotInGroupChat {
+			t.Fatalf("ImageUseCase.UploadGroupPicture() error = %v, want %v", err, entity.ErrUserNotInGroupChat)
+		}
+	})
+}
+
+func TestImageUseCase_AvatarOfAnotherUserIsKept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sizes := []int{16}
+	otherImageUUID := "6f1c2a4e-1111-4a3b-9c2d-000000000002"
+
+	mockUserRepo := mocks.NewMockUserRepo(ctrl)
+	mockGroupChatRepo := mocks.NewMockGroupChatRepo(ctrl)
+	store := storage.NewMemory()
+	store.Put(context.Background(), entity.ImageKey(otherImageUUID, sizes[0]), strings.NewReader("other"))
+
+	uc := NewImage(store, mockUserRepo, mockGroupChatRepo, 1024*1024, 10000, sizes)
+	profileUC := NewUserProfile(mockUserRepo, nil)
+
+	// The avatar of another user can't be taken over through a profile update
+	mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), testUserUUID).Return(&entity.UserProfileDTO{UserUUID: testUserUUID}, nil)
+	err := profileUC.UpdateUserProfile(context.Background(), entity.UserProfile{
+		UserUUID: testUserUUID, FirstName: "Test", LastName: "User", Avatar: entity.ImageURL(otherImageUUID),
+	})
+	if err != entity.ErrInvalidAvatar {
+		t.Fatalf("UserProfileUseCase.UpdateUserProfile() error = %v, want %v", err, entity.ErrInvalidAvatar)
+	}
+
+	// So the next upload has no previous avatar to delete
+	mockUserRepo.EXPECT().GetUserProfile(gomock.Any(), testUserUUID).Return(&entity.UserProfileDTO{UserUUID: testUserUUID}, nil)
+	mockUserRepo.EXPECT().UpdateAvatar(gomock.Any(), testUserUUID, gomock.Any()).Return(nil)
+	_, err = uc.UploadAvatar(context.Background(), testUserUUID, bytes.NewReader(testPNG(t, 20, 20)))
+	if err != nil {
+		t.Fatalf("ImageUseCase.UploadAvatar() error = %v", err)
+	}
+
+	thumbnail, err := uc.OpenImage(context.Background(), otherImageUUID, sizes[0])
+	if err != nil {
+		t.Fatalf("ImageUseCase.OpenImage() avatar of another user error = %v, want kept", err)
+	}
+	thumbnail.Close()
+}
@@ -3,8 +3,11 @@ package usecase
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/pkg/oidc"
 )
 
 //go:generate mockgen -source=interfaces.go -destination=./mocks_test.go -package=usecase_test
@@ -24,6 +27,204 @@ type (
 		GetUserProfile(context.Context, string) (*entity.UserProfileDTO, error)
 		GetUserUUIDByUsername(context.Context, string) (*string, error)
 		UpdateUserProfile(ctx context.Context, userInfo entity.UserProfileDTO) error
+		UpdatePassword(ctx context.Context, userUUID string, password string) error
+		ReplacePasswordHash(ctx context.Context, userUUID string, oldHash string, newHash string) error
+		MarkEmailVerified(ctx context.Context, userUUID string, email string) (bool, error)
+		GetUserCredentialsByUUID(ctx context.Context, userUUID string) (*entity.UserCredentialsDTO, error)
+		CancelAccountDeletion(ctx context.Context, userUUID string) error
+		UpdateEmail(ctx context.Context, userUUID string, email string) error
+		IsUsernameAvailable(ctx context.Context, username string, userUUID string) (bool, error)
+		UpdateUsername(ctx context.Context, userUUID string, oldUsername string, newUsername string, reservedUntil time.Time) error
+		UpdateUserStatus(ctx context.Context, userUUID string, status *entity.UserStatus) error
+		UpdateAvatar(ctx context.Context, userUUID string, avatar string) error
+		ClearExpiredStatuses(ctx context.Context, now time.Time) ([]string, error)
+	}
+
+	// PasswordReset -.
+	PasswordReset interface {
+		RequestPasswordReset(ctx context.Context, request entity.PasswordResetRequest) error
+		ResetPassword(ctx context.Context, passwordReset entity.PasswordReset) error
+	}
+
+	// Credentials -.
+	Credentials interface {
+		ChangePassword(ctx context.Context, change entity.PasswordChange) ([]string, error)
+		RequestEmailChange(ctx context.Context, change entity.EmailChange) error
+		ConfirmEmailChange(ctx context.Context, token string) error
+		ChangeUsername(ctx context.Context, change entity.UsernameChange) error
+	}
+
+	// UserTokenRepo -.
+	UserTokenRepo interface {
+		StoreUserToken(ctx context.Context, userToken entity.UserTokenDTO) error
+		ConsumeUserToken(ctx context.Context, tokenHash string, purpose string) (*entity.UserTokenDTO, error)
+		ResetPassword(ctx context.Context, tokenHash string, password string) (*entity.UserTokenDTO, error)
+		CountUserTokensSince(ctx context.Context, userUUID string, purpose string, since time.Time) (int, error)
+	}
+
+	// EmailVerification -.
+	EmailVerification interface {
+		SendVerificationEmail(ctx context.Context, email string) error
+		ResendVerificationEmail(ctx context.Context, email string) error
+		ConfirmEmail(ctx context.Context, token string) error
+	}
+
+	// MFA -.
+	MFA interface {
+		Enroll(ctx context.Context, userUUID string) (entity.MFAEnrollment, error)
+		ConfirmEnrollment(ctx context.Context, userUUID string, code string) ([]string, error)
+		IsEnabled(ctx context.Context, userUUID string) (bool, error)
+		Verify(ctx context.Context, userUUID string, code string) error
+	}
+
+	// MFARepo -.
+	MFARepo interface {
+		StorePendingMFA(ctx context.Context, userUUID string, secret string) error
+		GetMFA(ctx context.Context, userUUID string) (*entity.MFADTO, error)
+		ConfirmMFA(ctx context.Context, userUUID string, step int64, recoveryCodeHashes []string) error
+		UpdateLastUsedStep(ctx context.Context, userUUID string, step int64) (bool, error)
+		ConsumeRecoveryCode(ctx context.Context, userUUID string, codeHash string) (bool, error)
+	}
+
+	// LoginThrottle -.
+	LoginThrottle interface {
+		CheckLogin(ctx context.Context, attempt entity.LoginAttempt) (time.Duration, error)
+		RecordFailure(ctx context.Context, attempt entity.LoginAttempt) error
+		RecordSuccess(ctx context.Context, attempt entity.LoginAttempt) error
+	}
+
+	// LoginAttemptRepo -.
+	LoginAttemptRepo interface {
+		GetLoginAttempt(ctx context.Context, key string) (*entity.LoginAttemptDTO, error)
+		IncrementFailedLogin(ctx context.Context, key string, resetBefore time.Time) (int, error)
+		LockLogin(ctx context.Context, key string, lockedUntil time.Time) error
+		ResetLoginAttempts(ctx context.Context, key string) error
+		StoreLockoutAudit(ctx context.Context, lockout entity.LoginLockoutDTO) error
+	}
+
+	// SSO -.
+	SSO interface {
+		BeginLogin(ctx context.Context) (entity.SSOLogin, error)
+		CompleteLogin(ctx context.Context, state string, code string) (string, error)
+	}
+
+	// IdentityProvider -.
+	IdentityProvider interface {
+		AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+		Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*oidc.Claims, error)
+	}
+
+	// SSORepo -.
+	SSORepo interface {
+		StoreLoginState(ctx context.Context, loginState entity.SSOLoginStateDTO) error
+		ConsumeLoginState(ctx context.Context, stateHash string) (*entity.SSOLoginStateDTO, error)
+		GetIdentityUserUUID(ctx context.Context, issuer string, subject string) (*string, error)
+		LinkIdentity(ctx context.Context, identity entity.UserIdentityDTO) error
+		CreateUserWithIdentity(ctx context.Context, userRegis entity.UserRegistrationDTO, identity entity.UserIdentityDTO, emailVerified bool) (string, error)
+	}
+
+	// AccountDeletion -.
+	AccountDeletion interface {
+		DeleteAccount(ctx context.Context, userUUID string, password string) (entity.AccountDeletion, error)
+		PurgeDueAccounts(ctx context.Context) (int, error)
+	}
+
+	// AccountDeletionRepo -.
+	AccountDeletionRepo interface {
+		ScheduleDeletion(ctx context.Context, userUUID string, deleteAfter time.Time) error
+		GetDueDeletions(ctx context.Context, now time.Time) ([]string, error)
+		EraseUser(ctx context.Context, userUUID string) error
+	}
+
+	// DataExport -.
+	DataExport interface {
+		RequestExport(ctx context.Context, userUUID string) (entity.DataExport, error)
+		GetExport(ctx context.Context, userUUID string, exportUUID string) (entity.DataExport, error)
+		OpenExport(ctx context.Context, userUUID string, exportUUID string) (io.ReadCloser, error)
+		PurgeExpiredExports(ctx context.Context) (int, error)
+	}
+
+	// DataExportRepo -.
+	DataExportRepo interface {
+		StoreExport(ctx context.Context, export entity.DataExportDTO) error
+		GetExport(ctx context.Context, exportUUID string) (*entity.DataExportDTO, error)
+		GetUserExports(ctx context.Context, userUUID string) ([]entity.DataExportDTO, error)
+		GetExpiredExports(ctx context.Context, now time.Time) ([]entity.DataExportDTO, error)
+		UpdateExport(ctx context.Context, export entity.DataExportDTO) error
+		DeleteExport(ctx context.Context, exportUUID string) error
+		GetExportContacts(ctx context.Context, userUUID string) ([]entity.ExportContact, error)
+		GetExportGroupMemberships(ctx context.Context, userUUID string) ([]entity.ExportGroupMembership, error)
+		GetSentMessages(ctx context.Context, userUUID string, after *entity.ExportMessage, limit int) ([]entity.ExportMessage, error)
+		GetExportReactions(ctx context.Context, userUUID string) ([]entity.ExportReaction, error)
+		GetExportSeenReceipts(ctx context.Context, userUUID string) ([]entity.ExportSeenReceipt, error)
+	}
+
+	// BlobStore -.
+	BlobStore interface {
+		Put(ctx context.Context, key string, r io.Reader) error
+		Open(ctx context.Context, key string) (io.ReadCloser, error)
+		Delete(ctx context.Context, key string) error
+	}
+
+	// Bot -.
+	Bot interface {
+		CreateBot(ctx context.Context, ownerUUID string, name string) (entity.Bot, error)
+		GetBots(ctx context.Context, ownerUUID string) ([]entity.Bot, error)
+		DeleteBot(ctx context.Context, ownerUUID string, botUUID string) error
+		CreateAPIKey(ctx context.Context, ownerUUID string, botUUID string, request entity.APIKeyRequest) (entity.APIKey, error)
+		GetAPIKeys(ctx context.Context, ownerUUID string, botUUID string) ([]entity.APIKey, error)
+		RevokeAPIKey(ctx context.Context, ownerUUID string, botUUID string, keyUUID string) error
+		AuthenticateAPIKey(ctx context.Context, key string) (entity.APIKeyPrincipal, error)
+		AuthorizeConversation(ctx context.Context, principal entity.APIKeyPrincipal, conversationUUID string, scope string) error
+	}
+
+	// BotRepo -.
+	BotRepo interface {
+		CreateBot(ctx context.Context, bot entity.BotDTO) error
+		GetBot(ctx context.Context, botUUID string) (*entity.BotDTO, error)
+		GetOwnerBots(ctx context.Context, ownerUUID string) ([]entity.BotDTO, error)
+		DeleteBot(ctx context.Context, botUUID string) error
+		StoreAPIKey(ctx context.Context, key entity.APIKeyDTO) error
+		GetAPIKeyByHash(ctx context.Context, keyHash string) (*entity.APIKeyDTO, error)
+		GetBotAPIKeys(ctx context.Context, botUUID string) ([]entity.APIKeyDTO, error)
+		RevokeAPIKey(ctx context.Context, botUUID string, keyUUID string) (bool, error)
+		TouchAPIKey(ctx context.Context, keyUUID string, usedAt time.Time) error
+	}
+
+	// Mailer -.
+	Mailer interface {
+		Send(ctx context.Context, mail entity.Mail) error
+	}
+
+	// PasswordHasher -.
+	PasswordHasher interface {
+		Hash(password string) (string, error)
+		Verify(password string, encoded string) (bool, error)
+		NeedsRehash(encoded string) bool
+	}
+
+	// Session -.
+	Session interface {
+		CreateSession(ctx context.Context, userUUID string, device entity.SessionDevice) (entity.Session, error)
+		RefreshSession(ctx context.Context, refreshToken string, device entity.SessionDevice) (entity.Session, error)
+		RevokeSession(ctx context.Context, refreshToken string) (string, error)
+		IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+		ListSessions(ctx context.Context, userUUID string, currentSessionUUID string) ([]entity.SessionInfo, error)
+		RevokeUserSession(ctx context.Context, userUUID string, sessionUUID string) error
+		RevokeOtherSessions(ctx context.Context, userUUID string, currentSessionUUID string) ([]string, error)
+	}
+
+	// SessionRepo -.
+	SessionRepo interface {
+		StoreSession(ctx context.Context, session entity.SessionDTO, refreshToken entity.RefreshTokenDTO) error
+		GetRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshTokenDTO, error)
+		RotateRefreshToken(ctx context.Context, oldTokenHash string, refreshToken entity.RefreshTokenDTO) (bool, error)
+		RevokeSession(ctx context.Context, sessionUUID string) error
+		RevokeUserSessions(ctx context.Context, userUUID string) error
+		CheckTokenRevoked(ctx context.Context, jti string) (bool, error)
+		TouchSession(ctx context.Context, sessionUUID string, device entity.SessionDevice, expiresAt time.Time) error
+		GetSession(ctx context.Context, sessionUUID string) (*entity.SessionDTO, error)
+		GetActiveSessions(ctx context.Context, userUUID string) ([]entity.SessionDTO, error)
 	}
 
 	Conversation interface {
@@ -37,18 +238,38 @@ type (
 	}
 
 	Contact interface {
-		GetContacts(ctx context.Context, userUuid string) ([]entity.Contacts, error)
-		AddContact(ctx context.Context, contactUserName string, userUuid string) error
+		GetContacts(ctx context.Context, query entity.ContactsQuery) (entity.ContactsPage, error)
+		AddContact(ctx context.Context, contactUserName string, userUuid string) (entity.ContactRequest, error)
 		RemoveContact(ctx context.Context, contactUserName string, userUuid string) error
 		UpdateBlockContact(ctx context.Context, contactUserName string, userUuid string, block bool) error
+		UpdateContactDetails(ctx context.Context, contactUserName string, userUuid string, details entity.ContactDetails) error
+		GetContactRequests(ctx context.Context, userUUID string, direction string) ([]entity.ContactRequest, error)
+		AcceptContactRequest(ctx context.Context, requestUUID string, userUUID string) (entity.ContactRequest, error)
+		DeclineContactRequest(ctx context.Context, requestUUID string, userUUID string) (entity.ContactRequest, error)
+		CancelContactRequest(ctx context.Context, requestUUID string, userUUID string) (entity.ContactRequest, error)
+		ImportContacts(ctx context.Context, contactImport entity.ContactImport) ([]entity.ContactMatch, error)
 	}
 
 	ContactsRepo interface {
-		GetContactsByUserUUID(ctx context.Context, userUuid string) ([]entity.Contacts, error)
+		GetContactsByUserUUID(ctx context.Context, query entity.ContactsQuery) ([]entity.Contacts, error)
 		CheckContactExist(ctx context.Context, userUuid string, contactUserUuid string) (bool, error)
-		StoreContacts(context.Context, entity.ContactsDTO) error
+		GetContact(ctx context.Context, userUUID string, contactUserUUID string) (*entity.ContactsDTO, error)
 		UpdateRemovedStatus(context.Context, entity.ContactsDTO) error
 		UpdateBlockedStatus(context.Context, entity.ContactsDTO) error
+		UpdateContactDetails(ctx context.Context, userUUID string, contactUserUUID string, details entity.ContactDetails) (bool, error)
+		GetContactUUIDs(ctx context.Context, userUUID string) ([]string, error)
+		CheckContactOfContact(ctx context.Context, userUUID string, otherUserUUID string) (bool, error)
+		GetContactRequest(ctx context.Context, requestUUID string) (*entity.ContactRequestDTO, error)
+		GetPendingContactRequest(ctx context.Context, fromUserUUID string, toUserUUID string) (*entity.ContactRequestDTO, error)
+		StoreContactRequest(ctx context.Context, request entity.ContactRequestDTO) error
+		GetContactRequests(ctx context.Context, userUUID string, direction string) ([]entity.ContactRequest, error)
+		UpdateContactRequestStatus(ctx context.Context, requestUUID string, status string) (bool, error)
+		AcceptContactRequest(ctx context.Context, request entity.ContactRequestDTO) (string, error)
+		CheckBlocked(ctx context.Context, userUUID string, otherUserUUID string) (bool, error)
+		CheckConversationBlocked(ctx context.Context, conversationUUID string, userUUID string) (bool, error)
+		CheckMessageBlocked(ctx context.Context, messageUUID string, userUUID string) (bool, error)
+		MatchEmailHashes(ctx context.Context, userUUID string, salt string, hashes []string) ([]entity.ContactMatch, error)
+		StoreContactRequests(ctx context.Context, requests []entity.ContactRequestDTO) ([]entity.ContactRequestDTO, error)
 	}
 
 	MessageRepo interface {
@@ -64,7 +285,7 @@ type (
 		GetMessagesFromConversation(ctx context.Context, reqParam entity.RequestParams, conversationUUID string) ([]entity.GetMessageDTO, error)
 		DeleteMessage(ctx context.Context, msg entity.Message) (bool, error)
 		UpdateSeenStatus(ctx context.Context, seenStatus entity.SeenStatus) error
-		GetSeenStatus(ctx context.Context, messageUUID string) ([]entity.GetSeenStatusDTO, error)
+		GetSeenStatus(ctx context.Context, userUUID string, messageUUID string) ([]entity.GetSeenStatusDTO, error)
 		SearchMessage(ctx context.Context, keyword string, conversationUUID string) ([]entity.SearchMessageDTO, error)
 	}
 
@@ -85,6 +306,8 @@ type (
 		RemoveParticipants(ctx context.Context, groupChat entity.GroupChatDTO) error
 		UpdateGroupTitle(ctx context.Context, groupChat entity.GroupChatDTO) error
 		ValidateUserInGroupChat(ctx context.Context, conversationUUID string, userUUID string) (bool, error)
+		GetGroupPicture(ctx context.Context, conversationUUID string) (string, error)
+		UpdateGroupPicture(ctx context.Context, conversationUUID string, picture string) error
 	}
 
 	GroupChat interface {
@@ -97,5 +320,108 @@ type (
 	UserProfile interface {
 		GetUserProfile(ctx context.Context, userUUID string) (entity.UserProfile, error)
 		UpdateUserProfile(ctx context.Context, userInfo entity.UserProfile) error
+		SetStatus(ctx context.Context, userUUID string, status entity.UserStatus) (entity.StatusChange, error)
+		ClearStatus(ctx context.Context, userUUID string) (entity.StatusChange, error)
+		ClearExpiredStatuses(ctx context.Context) ([]entity.StatusChange, error)
+	}
+
+	// UserSearch -.
+	UserSearch interface {
+		SearchUsers(ctx context.Context, query entity.UserSearchQuery) (entity.UserSearchPage, error)
+	}
+
+	// UserSearchRepo -.
+	UserSearchRepo interface {
+		SearchUsers(ctx context.Context, query entity.UserSearchQuery) ([]entity.UserSearchResult, error)
+	}
+
+	// ContactSuggestion -.
+	ContactSuggestion interface {
+		GetSuggestions(ctx context.Context, userUUID string, limit int) ([]entity.ContactSuggestion, error)
+	}
+
+	// ContactSuggestionRepo -.
+	ContactSuggestionRepo interface {
+		GetSuggestionState(ctx context.Context, userUUID string) (*entity.ContactSuggestionState, error)
+		RefreshSuggestions(ctx context.Context, userUUID string, limit int) error
+		GetSuggestions(ctx context.Context, userUUID string, limit int) ([]entity.ContactSuggestion, error)
+	}
+
+	// Privacy -.
+	Privacy interface {
+		GetPrivacySettings(ctx context.Context, userUUID string) (entity.PrivacySettings, error)
+		UpdatePrivacySettings(ctx context.Context, userUUID string, update entity.PrivacySettingsUpdate) (entity.PrivacySettings, error)
+	}
+
+	// PrivacyRepo -.
+	PrivacyRepo interface {
+		GetPrivacySettings(ctx context.Context, userUUID string) (*entity.PrivacySettingsDTO, error)
+		GetPrivacySettingsByUserUUIDs(ctx context.Context, userUUIDs []string) ([]entity.PrivacySettingsDTO, error)
+		StorePrivacySettings(ctx context.Context, settings entity.PrivacySettingsDTO) error
+	}
+
+	// Presence -.
+	Presence interface {
+		Connect(ctx context.Context, userUUID string) (*entity.PresenceChange, error)
+		Disconnect(ctx context.Context, userUUID string) error
+		Touch(ctx context.Context, userUUID string) (*entity.PresenceChange, error)
+		Sweep(ctx context.Context) ([]entity.PresenceChange, error)
+		GetContactsPresence(ctx context.Context, userUUID string) ([]entity.Presence, error)
+	}
+
+	// PresenceRepo -.
+	PresenceRepo interface {
+		StoreLastSeen(ctx context.Context, userUUID string, lastSeenAt time.Time) error
+		GetLastSeen(ctx context.Context, userUUIDs []string) ([]entity.LastSeenDTO, error)
+	}
+
+	// Image -.
+	Image interface {
+		UploadAvatar(ctx context.Context, userUUID string, r io.Reader) (entity.Image, error)
+		UploadGroupPicture(ctx context.Context, userUUID string, conversationUUID string, r io.Reader) (entity.Image, error)
+		OpenImage(ctx context.Context, imageUUID string, size int) (io.ReadCloser, error)
+	}
+
+	// Admin -.
+	Admin interface {
+		GetUserAccess(ctx context.Context, userUUID string) (entity.UserAccess, error)
+		ListUsers(ctx context.Context, query entity.AdminUserQuery) (entity.AdminUserPage, error)
+		SuspendUser(ctx context.Context, action entity.AdminAction) error
+		UnsuspendUser(ctx context.Context, action entity.AdminAction) error
+		ForceLogout(ctx context.Context, action entity.AdminAction) error
+		DeleteMessage(ctx context.Context, action entity.AdminAction) (string, error)
+		DeleteConversation(ctx context.Context, action entity.AdminAction) error
+		GetAuditLog(ctx context.Context, query entity.AdminAuditQuery) (entity.AdminAuditPage, error)
+	}
+
+	// AdminRepo -.
+	AdminRepo interface {
+		GetUserAccess(ctx context.Context, userUUID string) (*entity.UserAccess, error)
+		ListUsers(ctx context.Context, query entity.AdminUserQuery) ([]entity.AdminUser, error)
+		SuspendUser(ctx context.Context, audit entity.AdminAuditDTO) (bool, error)
+		UnsuspendUser(ctx context.Context, audit entity.AdminAuditDTO) (bool, error)
+		DeleteMessage(ctx context.Context, audit entity.AdminAuditDTO) (string, error)
+		DeleteConversation(ctx context.Context, audit entity.AdminAuditDTO) (bool, error)
+		StoreAuditEntry(ctx context.Context, audit entity.AdminAuditDTO) error
+		GetAuditLog(ctx context.Context, query entity.AdminAuditQuery) ([]entity.AdminAuditDTO, error)
+	}
+
+	// Report -.
+	Report interface {
+		ReportAbuse(ctx context.Context, report entity.Report) (entity.Report, error)
+		ListReports(ctx context.Context, query entity.ReportQuery) (entity.ReportPage, error)
+		GetReport(ctx context.Context, reportUUID string) (entity.Report, error)
+		UpdateReportStatus(ctx context.Context, update entity.ReportStatusUpdate) (entity.Report, error)
+	}
+
+	// ReportRepo -.
+	ReportRepo interface {
+		CheckUserExists(ctx context.Context, userUUID string) (bool, error)
+		CheckParticipant(ctx context.Context, conversationUUID string, userUUID string) (bool, error)
+		GetReportedMessage(ctx context.Context, messageUUID string) (*entity.ReportedMessage, error)
+		StoreReport(ctx context.Context, report entity.Report) (bool, error)
+		GetReport(ctx context.Context, reportUUID string) (*entity.Report, error)
+		ListReports(ctx context.Context, query entity.ReportQuery) ([]entity.Report, error)
+		UpdateReportStatus(ctx context.Context, report entity.Report, fromStatus string, audit entity.AdminAuditDTO) (bool, error)
 	}
 )
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// LockoutPolicy -.
+// Once a username or an IP reaches its maximum number of failures within FailureWindow, it is locked for
+// BaseLockout, doubling with every further failure up to MaxLockout.
+type LockoutPolicy struct {
+	MaxFailures      int
+	MaxFailuresPerIP int
+	FailureWindow    time.Duration
+	BaseLockout      time.Duration
+	MaxLockout       time.Duration
+}
+
+type LoginThrottleUseCase struct {
+	repo   LoginAttemptRepo
+	policy LockoutPolicy
+}
+
+func NewLoginThrottle(r LoginAttemptRepo, policy LockoutPolicy) *LoginThrottleUseCase {
+	return &LoginThrottleUseCase{
+		repo:   r,
+		policy: policy,
+	}
+}
+
+func (uc *LoginThrottleUseCase) CheckLogin(ctx context.Context, attempt entity.LoginAttempt) (time.Duration, error) {
+	var retryAfter time.Duration
+	for _, key := range attemptKeys(attempt) {
+		// Get failed attempts from login attempt data repository
+		loginAttempt, err := uc.repo.GetLoginAttempt(ctx, key)
+		if err != nil {
+			return 0, fmt.Errorf("LoginThrottleUseCase - CheckLogin - uc.repo.GetLoginAttempt: %w", err)
+		}
+
+		if loginAttempt != nil && loginAttempt.LockedUntil != nil {
+			if wait := time.Until(*loginAttempt.LockedUntil); wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+	}
+
+	// Return error if either the username or the IP is locked. Will be handled by controller
+	if retryAfter > 0 {
+		return retryAfter, entity.ErrAccountLocked
+	}
+	return 0, nil
+}
+
+func (uc *LoginThrottleUseCase) RecordFailure(ctx context.Context, attempt entity.LoginAttempt) error {
+	// Failures older than the window no longer count
+	resetBefore := time.Now().Add(-uc.policy.FailureWindow)
+
+	for _, key := range attemptKeys(attempt) {
+		// Increase failed attempts in login attempt data repository
+		failures, err := uc.repo.IncrementFailedLogin(ctx, key, resetBefore)
+		if err != nil {
+			return fmt.Errorf("LoginThrottleUseCase - RecordFailure - uc.repo.IncrementFailedLogin: %w", err)
+		}
+
+		maxFailures := uc.policy.MaxFailures
+		if strings.HasPrefix(key, _ipKeyPrefix) {
+			// Many users can share an IP behind a NAT, so IPs get a higher limit
+			maxFailures = uc.policy.MaxFailuresPerIP
+		}
+		if failures < maxFailures {
+			continue
+		}
+
+		lockedUntil := time.Now().Add(uc.lockoutDuration(failures - maxFailures))
+		err = uc.repo.LockLogin(ctx, key, lockedUntil)
+		if err != nil {
+			return fmt.Errorf("LoginThrottleUseCase - RecordFailure - uc.repo.LockLogin: %w", err)
+		}
+
+		// Keep a record of every lockout for the security team
+		err = uc.repo.StoreLockoutAudit(ctx, entity.LoginLockoutDTO{
+			Key:         key,
+			Identifier:  attempt.Identifier,
+			IP:          attempt.IP,
+			Failures:    failures,
+			LockedUntil: lockedUntil,
+		})
+		if err != nil {
+			return fmt.Errorf("LoginThrottleUseCase - RecordFailure - uc.repo.StoreLockoutAudit: %w", err)
+		}
+	}
+	return nil
+}
+
+func (uc *LoginThrottleUseCase) RecordSuccess(ctx context.Context, attempt entity.LoginAttempt) error {
+	// Only the account is cleared. Clearing the IP would let an attacker with one valid account
+	// reset the IP counter while guessing passwords of other accounts.
+	err := uc.repo.ResetLoginAttempts(ctx, identifierKey(attempt.Identifier))
+	if err != nil {
+		return fmt.Errorf("LoginThrottleUseCase - RecordSuccess - uc.repo.ResetLoginAttempts: %w", err)
+	}
+	return nil
+}
+
+// Lockout doubles with every failure past the maximum, capped at MaxLockout
+func (uc *LoginThrottleUseCase) lockoutDuration(extraFailures int) time.Duration {
+	duration := uc.policy.BaseLockout
+	for i := 0; i < extraFailures; i++ {
+		duration *= 2
+		if duration >= uc.policy.MaxLockout {
+			return uc.policy.MaxLockout
+		}
+	}
+	return duration
+}
+
+const (
+	_identifierKeyPrefix = "user:"
+	_ipKeyPrefix         = "ip:"
+)
+
+// Failures are counted both per username and per client IP
+func attemptKeys(attempt entity.LoginAttempt) []string {
+	keys := []string{identifierKey(attempt.Identifier)}
+	if attempt.IP != "" {
+		keys = append(keys, _ipKeyPrefix+attempt.IP)
+	}
+	return keys
+}
+
+// Usernames are compared case-insensitively so that 'Alice' and 'alice' share one counter
+func identifierKey(identifier string) string {
+	return _identifierKeyPrefix + strings.ToLower(identifier)
+}
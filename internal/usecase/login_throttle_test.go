@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+)
+
+var testLockoutPolicy = LockoutPolicy{
+	MaxFailures:      5,
+	MaxFailuresPerIP: 50,
+	FailureWindow:    15 * time.Minute,
+	BaseLockout:      time.Minute,
+	MaxLockout:       time.Hour,
+}
+
+func TestLoginThrottleUseCase_CheckLogin(t *testing.T) {
+	lockedUntil := time.Now().Add(10 * time.Minute)
+	expiredLock := time.Now().Add(-time.Minute)
+
+	// Define the structure of each test case
+	type testCase struct {
+		name           string                                                 // Name of the test case
+		setupMocks     func(mockLoginAttemptRepo *mocks.MockLoginAttemptRepo) // Function to set up mock behavior
+		wantRetryAfter bool                                                   // Whether a retry delay is expected
+		wantErr        error                                                  // Expected sentinel error, if any
+		wantAnyErr     bool                                                   // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - no failed attempts",
+			setupMocks: func(mockLoginAttemptRepo *mocks.MockLoginAttemptRepo) {
+				mockLoginAttemptRepo.EXPECT().GetLoginAttempt(gomock.Any(), "user:testuser").Return(nil, nil)
+				mockLoginAttemptRepo.EXPECT().GetLoginAttempt(gomock.Any(), "ip:192.0.2.1").Return(nil, nil)
+			},
+		},
+		{
+			name: "success - lock expired",
+			setupMocks: func(mockLoginAttemptRepo *mocks.MockLoginAttemptRepo) {
+				mockLoginAttemptRepo.EXPECT().GetLoginAttempt(gomock.Any(), "user:testuser").
+					Return(&entity.LoginAttemptDTO{Key: "user:testuser", Failures: 5, LockedUntil: &expiredLock}, nil)
+				mockLoginAttemptRepo.EXPECT().GetLoginAttempt(gomock.Any(), "ip:192.0.2.1").Return(nil, nil)
+			},
+		},
+		{
+			name: "error - username locked",
+			setupMocks: func(mockLoginAttemptRepo *mocks.MockLoginAttemptRepo) {
+				mockLoginAttemptRepo.EXPECT().GetLoginAttempt(gomock.Any(), "user:testuser").
+					Return(&entity.LoginAttemptDTO{Key: "user:testuser", Failures: 5, LockedUntil: &lockedUntil}, nil)
+				mockLoginAttemptRepo.EXPECT().GetLoginAttempt(gomock.Any(), "ip:192.0.2.1").Return(nil, nil)
+			},
+			wantRetryAfter: true,
+			wantErr:        entity.ErrAccountLocked,
+			wantAnyErr:     true,
+		},
+		{
+			name: "error - ip locked",
+			setupMocks: func(mockLoginAttemptRepo *mocks.MockLoginAttemptRepo) {
+				mockLoginAttemptRepo.EXPECT().GetLoginAttempt(gomock.Any(), "user:testuser").Return(nil, nil)
+				mockLoginAttemptRepo.EXPECT().GetLoginAttempt(gomock.Any(), "ip:192.0.2.1").
+					Return(&entity.LoginAttemptDTO{Key: "ip:192.0.2.1", Failures: 50, LockedUntil: &lockedUntil}, nil)
+			},
+			wantRetryAfter: true,
+			wantErr:        entity.ErrAccountLocked,
+			wantAnyErr:     true,
+		},
+		{
+			name: "error getting login attempt",
+			setupMocks: func(mockLoginAttemptRepo *mocks.MockLoginAttemptRepo) {
+				mockLoginAttemptRepo.EXPECT().GetLoginAttempt(gomock.Any(), "user:testuser").Return(nil, fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create a mock instance of the login attempt repository
+			mockLoginAttemptRepo := mocks.NewMockLoginAttemptRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockLoginAttemptRepo)
+			}
+
+			uc := NewLoginThrottle(mockLoginAttemptRepo, testLockoutPolicy)
+
+			// Call the method under test, usernames are not case sensitive
+			retryAfter, err := uc.CheckLogin(context.Background(), entity.LoginAttempt{Identifier: "TestUser", IP: "192.0.2.1"})
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("LoginThrottleUseCase.CheckLogin() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("LoginThrottleUseCase.CheckLogin() error = %v, want %v", err, tt.wantErr)
+			}
+			if (retryAfter > 0) != tt.wantRetryAfter {
+				t.Errorf("LoginThrottleUseCase.CheckLogin() retryAfter = %v, wantRetryAfter %v", retryAfter, tt.wantRetryAfter)
+			}
+		})
+	}
+}
+
+func TestLoginThrottleUseCase_RecordFailure(t *testing.T) {
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                                 // Name of the test case
+		setupMocks func(mockLoginAttemptRepo *mocks.MockLoginAttemptRepo) // Function to set up mock behavior
+		wantErr    bool                                                   // Whether an error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - below the limit",
+			setupMocks: func(mockLoginAttemptRepo *mocks.MockLoginAttemptRepo) {
+				mockLoginAttemptRepo.EXPECT().IncrementFailedLogin(gomock.Any(), "user:testuser", gomock.Any()).Return(4, nil)
+				mockLoginAttemptRepo.EXPECT().IncrementFailedLogin(gomock.Any(), "ip:192.0.2.1", gomock.Any()).Return(4, nil)
+			},
+		},
+		{
+			name: "success - username locked and audited",
+			setupMocks: func(mockLoginAttemptRepo *mocks.MockLoginAttemptRepo) {
+				mockLoginAttemptRepo.EXPECT().IncrementFailedLogin(gomock.Any(), "user:testuser", gomock.Any()).Return(5, nil)
+				mockLoginAttemptRepo.EXPECT().LockLogin(gomock.Any(), "user:testuser", gomock.Any()).
+					Do(func(_ context.Context, _ string, lockedUntil time.Time) {
+						// The first lock lasts for the base lockout
+						if wait := time.Until(lockedUntil); wait <= 0 || wait > time.Minute {
+							t.Errorf("LoginThrottleUseCase.RecordFailure() locked for %v, want %v", wait, time.Minute)
+						}
+					}).
+					Return(nil)
+				mockLoginAttemptRepo.EXPECT().StoreLockoutAudit(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, lockout entity.LoginLockoutDTO) {
+						if lockout.Key != "user:testuser" || lockout.Identifier != "TestUser" || lockout.Failures != 5 {
+							t.Errorf("LoginThrottleUseCase.RecordFailure() audited %+v", lockout)
+						}
+					}).
+					Return(nil)
+				// Many users can share an IP, so the IP is still below its limit
+				mockLoginAttemptRepo.EXPECT().IncrementFailedLogin(gomock.Any(), "ip:192.0.2.1", gomock.Any()).Return(5, nil)
+			},
+		},
+		{
+			name: "error incrementing failed login",
+			setupMocks: func(mockLoginAttemptRepo *mocks.MockLoginAttemptRepo) {
+				mockLoginAttemptRepo.EXPECT().IncrementFailedLogin(gomock.Any(), "user:testuser", gomock.Any()).Return(0, fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error storing lockout audit",
+			setupMocks: func(mockLoginAttemptRepo *mocks.MockLoginAttemptRepo) {
+				mockLoginAttemptRepo.EXPECT().IncrementFailedLogin(gomock.Any(), "user:testuser", gomock.Any()).Return(6, nil)
+				mockLoginAttemptRepo.EXPECT().LockLogin(gomock.Any(), "user:testuser", gomock.Any()).Return(nil)
+				mockLoginAttemptRepo.EXPECT().StoreLockoutAudit(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create a mock instance of the login attempt repository
+			mockLoginAttemptRepo := mocks.NewMockLoginAttemptRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockLoginAttemptRepo)
+			}
+
+			uc := NewLoginThrottle(mockLoginAttemptRepo, testLockoutPolicy)
+
+			// Call the method under test
+			err := uc.RecordFailure(context.Background(), entity.LoginAttempt{Identifier: "TestUser", IP: "192.0.2.1"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoginThrottleUseCase.RecordFailure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoginThrottleUseCase_lockoutDuration(t *testing.T) {
+	uc := NewLoginThrottle(nil, testLockoutPolicy)
+
+	tests := []struct {
+		extraFailures int
+		want          time.Duration
+	}{
+		{0, time.Minute},
+		{1, 2 * time.Minute},
+		{3, 8 * time.Minute},
+		{6, time.Hour},
+		{100, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := uc.lockoutDuration(tt.extraFailures); got != tt.want {
+			t.Errorf("LoginThrottleUseCase.lockoutDuration(%d) = %v, want %v", tt.extraFailures, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// File writes every mail as an .eml file into a directory, for local runs without an SMTP server.
+type File struct {
+	dir  string
+	from string
+}
+
+// NewFile -.
+func NewFile(dir string, from string) *File {
+	return &File{
+		dir:  dir,
+		from: from,
+	}
+}
+
+// Send -.
+func (m *File) Send(ctx context.Context, mail entity.Mail) error {
+	err := os.MkdirAll(m.dir, 0o750)
+	if err != nil {
+		return fmt.Errorf("File - Send - os.MkdirAll: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405"), uuid.New().String())
+	err = os.WriteFile(filepath.Join(m.dir, name), buildMessage(m.from, mail), 0o600)
+	if err != nil {
+		return fmt.Errorf("File - Send - os.WriteFile: %w", err)
+	}
+	return nil
+}
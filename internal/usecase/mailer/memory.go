@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// Memory keeps sent mails in memory, for local runs and tests.
+type Memory struct {
+	mu   sync.Mutex
+	sent []entity.Mail
+}
+
+// NewMemory -.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Send -.
+func (m *Memory) Send(ctx context.Context, mail entity.Mail) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = append(m.sent, mail)
+	return nil
+}
+
+// Sent returns a copy of every mail sent so far.
+func (m *Memory) Sent() []entity.Mail {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sent := make([]entity.Mail, len(m.sent))
+	copy(sent, m.sent)
+	return sent
+}
@@ -0,0 +1,58 @@
+// Package mailer implements the Mailer used to send emails to users.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// SMTP -.
+type SMTP struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTP -.
+func NewSMTP(host string, port string, username string, password string, from string) *SMTP {
+	m := &SMTP{
+		addr: net.JoinHostPort(host, port),
+		from: from,
+	}
+
+	// Local relays usually accept mail without authentication
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return m
+}
+
+// Send -.
+func (m *SMTP) Send(ctx context.Context, mail entity.Mail) error {
+	err := smtp.SendMail(m.addr, m.auth, m.from, []string{mail.To}, buildMessage(m.from, mail))
+	if err != nil {
+		return fmt.Errorf("SMTP - Send - smtp.SendMail: %w", err)
+	}
+	return nil
+}
+
+// buildMessage formats the mail as a plain text RFC 5322 message
+func buildMessage(from string, mail entity.Mail) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", mail.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mail.Subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(mail.Body)
+	return msg.Bytes()
+}
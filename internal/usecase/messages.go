@@ -13,12 +13,14 @@ import (
 type MessageUseCase struct {
 	msgRepo      MessageRepo
 	reactionRepo ReactionRepo
+	privacyRepo  PrivacyRepo
 }
 
-func NewMessage(m MessageRepo, r ReactionRepo) *MessageUseCase {
+func NewMessage(m MessageRepo, r ReactionRepo, privacyRepo PrivacyRepo) *MessageUseCase {
 	return &MessageUseCase{
 		msgRepo:      m,
 		reactionRepo: r,
+		privacyRepo:  privacyRepo,
 	}
 }
 
@@ -49,7 +51,16 @@ func (uc *MessageUseCase) GetMessagesFromConversation(ctx context.Context,
 	return messages, nil
 }
 
+// UpdateSeenStatus does not store anything for users who do not share read receipts.
 func (uc *MessageUseCase) UpdateSeenStatus(ctx context.Context, seenStatus entity.SeenStatus) error {
+	settings, err := getPrivacySettings(ctx, uc.privacyRepo, seenStatus.UserUUID)
+	if err != nil {
+		return fmt.Errorf("MessageUseCase - UpdateSeenStatus - getPrivacySettings: %w", err)
+	}
+	if !settings.ReadReceipts {
+		return nil
+	}
+
 	// Convert seen status entity object into seenStatusDTO
 	seenStatusDTO := entity.SeenStatusDTO{
 		UserUUID:         seenStatus.UserUUID,
@@ -57,14 +68,25 @@ func (uc *MessageUseCase) UpdateSeenStatus(ctx context.Context, seenStatus entit
 	}
 
 	// Update 'seen_status' table in message data repository
-	err := uc.msgRepo.UpdateSeenStatus(ctx, seenStatusDTO)
+	err = uc.msgRepo.UpdateSeenStatus(ctx, seenStatusDTO)
 	if err != nil {
 		return fmt.Errorf("MessageUseCase - UpdateSeenStatus - uc.msgRepo.UpdateSeenStatus: %w", err)
 	}
 	return nil
 }
 
-func (uc *MessageUseCase) GetSeenStatus(ctx context.Context, messageUUID string) ([]entity.GetSeenStatusDTO, error) {
+// GetSeenStatus only shows read receipts to users who share their own.
+func (uc *MessageUseCase) GetSeenStatus(ctx context.Context, userUUID string, messageUUID string) ([]entity.GetSeenStatusDTO, error) {
+	settings, err := getPrivacySettings(ctx, uc.privacyRepo, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("MessageUseCase - GetSeenStatus - getPrivacySettings: %w", err)
+	}
+
+	// Return error if the user does not share read receipts. Will be handled by controller
+	if !settings.ReadReceipts {
+		return nil, entity.ErrReadReceiptsDisabled
+	}
+
 	// Get seen status by querting 'seen_status' table from message data repository
 	seenStatus, err := uc.msgRepo.GetSeenStatus(ctx, messageUUID)
 	if err != nil {
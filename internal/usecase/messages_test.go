@@ -134,7 +134,7 @@ func TestMessageUseCase_UpdateSeenStatus(t *testing.T) {
 	type testCase struct {
 		name       string
 		args       args
-		setupMocks func(mockMsgRepo *mocks.MockMessageRepo)
+		setupMocks func(mockMsgRepo *mocks.MockMessageRepo, mockPrivacyRepo *mocks.MockPrivacyRepo)
 		wantErr    bool
 	}
 
@@ -148,7 +148,8 @@ func TestMessageUseCase_UpdateSeenStatus(t *testing.T) {
 					ConversationUUID: "conv_uuid_1234",
 				},
 			},
-			setupMocks: func(mockMsgRepo *mocks.MockMessageRepo) {
+			setupMocks: func(mockMsgRepo *mocks.MockMessageRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockPrivacyRepo.EXPECT().GetPrivacySettings(gomock.Any(), "user_uuid_1234").Return(nil, nil)
 				seenStatusDTO := entity.SeenStatusDTO{
 					UserUUID:         "user_uuid_1234",
 					ConversationUUID: "conv_uuid_1234",
@@ -168,7 +169,8 @@ func TestMessageUseCase_UpdateSeenStatus(t *testing.T) {
 					ConversationUUID: "conv_uuid_1234",
 				},
 			},
-			setupMocks: func(mockMsgRepo *mocks.MockMessageRepo) {
+			setupMocks: func(mockMsgRepo *mocks.MockMessageRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockPrivacyRepo.EXPECT().GetPrivacySettings(gomock.Any(), "user_uuid_1234").Return(nil, nil)
 				seenStatusDTO := entity.SeenStatusDTO{
 					UserUUID:         "user_uuid_1234",
 					ConversationUUID: "conv_uuid_1234",
@@ -179,6 +181,22 @@ func TestMessageUseCase_UpdateSeenStatus(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "read receipts not shared",
+			args: args{
+				ctx: context.Background(),
+				seenStatus: entity.SeenStatus{
+					UserUUID:         "user_uuid_1234",
+					ConversationUUID: "conv_uuid_1234",
+				},
+			},
+			setupMocks: func(mockMsgRepo *mocks.MockMessageRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				// Nothing is stored for users who do not share read receipts
+				mockPrivacyRepo.EXPECT().GetPrivacySettings(gomock.Any(), "user_uuid_1234").
+					Return(&entity.PrivacySettingsDTO{UserUUID: "user_uuid_1234", ReadReceipts: false}, nil)
+			},
+			wantErr: false,
+		},
 	}
 
 	// Iterate over each test case
@@ -188,17 +206,19 @@ func TestMessageUseCase_UpdateSeenStatus(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish() // Ensure that the mock expectations are checked and cleaned up after the test
 
-			// Create a mock instance of the MessageRepo interface
+			// Create mock instances of the MessageRepo and PrivacyRepo interfaces
 			mockMsgRepo := mocks.NewMockMessageRepo(ctrl)
+			mockPrivacyRepo := mocks.NewMockPrivacyRepo(ctrl)
 
 			// Set up the mock expectations using the setupMocks function provided in the test case
 			if tt.setupMocks != nil {
-				tt.setupMocks(mockMsgRepo)
+				tt.setupMocks(mockMsgRepo, mockPrivacyRepo)
 			}
 
-			// Create an instance of MessageUseCase using the mock repository
+			// Create an instance of MessageUseCase using the mock repositories
 			uc := &MessageUseCase{
-				msgRepo: mockMsgRepo,
+				msgRepo:     mockMsgRepo,
+				privacyRepo: mockPrivacyRepo,
 			}
 
 			// Call the method under test with the provided arguments
@@ -215,12 +235,13 @@ func TestMessageUseCase_UpdateSeenStatus(t *testing.T) {
 func TestMessageUseCase_GetSeenStatus(t *testing.T) {
 	type args struct {
 		ctx         context.Context
+		userUUID    string
 		messageUUID string
 	}
 	type testCase struct {
 		name       string
 		args       args
-		setupMocks func(mockMsgRepo *mocks.MockMessageRepo)
+		setupMocks func(mockMsgRepo *mocks.MockMessageRepo, mockPrivacyRepo *mocks.MockPrivacyRepo)
 		want       []entity.GetSeenStatusDTO
 		wantErr    bool
 	}
@@ -230,9 +251,11 @@ func TestMessageUseCase_GetSeenStatus(t *testing.T) {
 			name: "success",
 			args: args{
 				ctx:         context.Background(),
+				userUUID:    "user_uuid_1234",
 				messageUUID: "msg_uuid_1234",
 			},
-			setupMocks: func(mockMsgRepo *mocks.MockMessageRepo) {
+			setupMocks: func(mockMsgRepo *mocks.MockMessageRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockPrivacyRepo.EXPECT().GetPrivacySettings(gomock.Any(), "user_uuid_1234").Return(nil, nil)
 				mockMsgRepo.EXPECT().
 					GetSeenStatus(gomock.Any(), "msg_uuid_1234").
 					Return([]entity.GetSeenStatusDTO{{SeenTimestamp: "2024-01-01T00:00:00Z"}}, nil)
@@ -244,9 +267,11 @@ func TestMessageUseCase_GetSeenStatus(t *testing.T) {
 			name: "error getting seen status",
 			args: args{
 				ctx:         context.Background(),
+				userUUID:    "user_uuid_1234",
 				messageUUID: "msg_uuid_1234",
 			},
-			setupMocks: func(mockMsgRepo *mocks.MockMessageRepo) {
+			setupMocks: func(mockMsgRepo *mocks.MockMessageRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockPrivacyRepo.EXPECT().GetPrivacySettings(gomock.Any(), "user_uuid_1234").Return(nil, nil)
 				mockMsgRepo.EXPECT().
 					GetSeenStatus(gomock.Any(), "msg_uuid_1234").
 					Return(nil, fmt.Errorf("some error"))
@@ -254,6 +279,21 @@ func TestMessageUseCase_GetSeenStatus(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "read receipts not shared",
+			args: args{
+				ctx:         context.Background(),
+				userUUID:    "user_uuid_1234",
+				messageUUID: "msg_uuid_1234",
+			},
+			setupMocks: func(mockMsgRepo *mocks.MockMessageRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				// Users who do not share read receipts can not see those of others
+				mockPrivacyRepo.EXPECT().GetPrivacySettings(gomock.Any(), "user_uuid_1234").
+					Return(&entity.PrivacySettingsDTO{UserUUID: "user_uuid_1234", ReadReceipts: false}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 
 	// Iterate over each test case
@@ -263,21 +303,23 @@ func TestMessageUseCase_GetSeenStatus(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish() // Ensure that the mock expectations are checked and cleaned up after the test
 
-			// Create a mock instance of the MessageRepo interface
+			// Create mock instances of the MessageRepo and PrivacyRepo interfaces
 			mockMsgRepo := mocks.NewMockMessageRepo(ctrl)
+			mockPrivacyRepo := mocks.NewMockPrivacyRepo(ctrl)
 
 			// Set up the mock expectations using the setupMocks function provided in the test case
 			if tt.setupMocks != nil {
-				tt.setupMocks(mockMsgRepo)
+				tt.setupMocks(mockMsgRepo, mockPrivacyRepo)
 			}
 
-			// Create an instance of MessageUseCase using the mock repository
+			// Create an instance of MessageUseCase using the mock repositories
 			uc := &MessageUseCase{
-				msgRepo: mockMsgRepo,
+				msgRepo:     mockMsgRepo,
+				privacyRepo: mockPrivacyRepo,
 			}
 
 			// Call the method under test with the provided arguments
-			got, err := uc.GetSeenStatus(tt.args.ctx, tt.args.messageUUID)
+			got, err := uc.GetSeenStatus(tt.args.ctx, tt.args.userUUID, tt.args.messageUUID)
 
 			// Check if the error status matches the expected value
 			if (err != nil) != tt.wantErr {
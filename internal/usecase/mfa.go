@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/pkg/totp"
+)
+
+const (
+	_recoveryCodeCount = 10
+	_recoveryCodeBytes = 5
+	// Accept the codes of one time step before and after the current one, to allow for clock drift
+	_totpSkew = 1
+)
+
+type MFAUseCase struct {
+	repo     MFARepo
+	userRepo UserRepo
+	issuer   string
+}
+
+func NewMFA(r MFARepo, u UserRepo, issuer string) *MFAUseCase {
+	return &MFAUseCase{
+		repo:     r,
+		userRepo: u,
+		issuer:   issuer,
+	}
+}
+
+func (uc *MFAUseCase) Enroll(ctx context.Context, userUUID string) (entity.MFAEnrollment, error) {
+	// Get current two-factor settings from mfa data repository by querying 'user_mfa' table
+	mfa, err := uc.repo.GetMFA(ctx, userUUID)
+	if err != nil {
+		return entity.MFAEnrollment{}, fmt.Errorf("MFAUseCase - Enroll - uc.repo.GetMFA: %w", err)
+	}
+
+	// Return error if two-factor authentication is already enabled. Will be handled by controller
+	if mfa != nil && mfa.ConfirmedAt != nil {
+		return entity.MFAEnrollment{}, entity.ErrMFAAlreadyEnabled
+	}
+
+	// Get user credentials to label the account in the authenticator app
+	userInfo, err := uc.userRepo.GetUserCredentialsByUUID(ctx, userUUID)
+	if err != nil {
+		return entity.MFAEnrollment{}, fmt.Errorf("MFAUseCase - Enroll - uc.userRepo.GetUserCredentialsByUUID: %w", err)
+	}
+	if userInfo == nil {
+		return entity.MFAEnrollment{}, entity.ErrUserNotFound
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return entity.MFAEnrollment{}, fmt.Errorf("MFAUseCase - Enroll - totp.GenerateSecret: %w", err)
+	}
+
+	// Store the secret as pending, it is only enabled once the user confirms it with a first code
+	err = uc.repo.StorePendingMFA(ctx, userUUID, secret)
+	if err != nil {
+		return entity.MFAEnrollment{}, fmt.Errorf("MFAUseCase - Enroll - uc.repo.StorePendingMFA: %w", err)
+	}
+
+	return entity.MFAEnrollment{
+		Secret: secret,
+		URI:    totp.URI(uc.issuer, userInfo.Username, secret),
+	}, nil
+}
+
+func (uc *MFAUseCase) ConfirmEnrollment(ctx context.Context, userUUID string, code string) ([]string, error) {
+	// Get pending two-factor settings from mfa data repository by querying 'user_mfa' table
+	mfa, err := uc.repo.GetMFA(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("MFAUseCase - ConfirmEnrollment - uc.repo.GetMFA: %w", err)
+	}
+
+	// Return error if enrollment was not started or is already confirmed. Will be handled by controller
+	if mfa == nil {
+		return nil, entity.ErrMFANotEnrolled
+	}
+	if mfa.ConfirmedAt != nil {
+		return nil, entity.ErrMFAAlreadyEnabled
+	}
+
+	// Return error if the first code does not match the secret. Will be handled by controller
+	step, ok := totp.Validate(mfa.Secret, normalizeMFACode(code), time.Now(), _totpSkew)
+	if !ok {
+		return nil, entity.ErrInvalidMFACode
+	}
+
+	recoveryCodes, recoveryCodeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("MFAUseCase - ConfirmEnrollment - generateRecoveryCodes: %w", err)
+	}
+
+	// Enable two-factor authentication and store hashed recovery codes into 'mfa_recovery_codes' table
+	err = uc.repo.ConfirmMFA(ctx, userUUID, step, recoveryCodeHashes)
+	if err != nil {
+		return nil, fmt.Errorf("MFAUseCase - ConfirmEnrollment - uc.repo.ConfirmMFA: %w", err)
+	}
+
+	// Recovery codes are only shown to the user this one time
+	return recoveryCodes, nil
+}
+
+func (uc *MFAUseCase) IsEnabled(ctx context.Context, userUUID string) (bool, error) {
+	// Get two-factor settings from mfa data repository by querying 'user_mfa' table
+	mfa, err := uc.repo.GetMFA(ctx, userUUID)
+	if err != nil {
+		return false, fmt.Errorf("MFAUseCase - IsEnabled - uc.repo.GetMFA: %w", err)
+	}
+	return mfa != nil && mfa.ConfirmedAt != nil, nil
+}
+
+func (uc *MFAUseCase) Verify(ctx context.Context, userUUID string, code string) error {
+	// Get two-factor settings from mfa data repository by querying 'user_mfa' table
+	mfa, err := uc.repo.GetMFA(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("MFAUseCase - Verify - uc.repo.GetMFA: %w", err)
+	}
+
+	// Return error if two-factor authentication is not enabled. Will be handled by controller
+	if mfa == nil || mfa.ConfirmedAt == nil {
+		return entity.ErrMFANotEnrolled
+	}
+
+	code = normalizeMFACode(code)
+
+	// Authenticator codes are all digits, anything else is treated as a recovery code
+	if len(code) == totp.Digits && strings.Trim(code, "0123456789") == "" {
+		step, ok := totp.Validate(mfa.Secret, code, time.Now(), _totpSkew)
+		if !ok {
+			return entity.ErrInvalidMFACode
+		}
+
+		// Reject a code that was already used, so an intercepted code cannot be replayed
+		updated, err := uc.repo.UpdateLastUsedStep(ctx, userUUID, step)
+		if err != nil {
+			return fmt.Errorf("MFAUseCase - Verify - uc.repo.UpdateLastUsedStep: %w", err)
+		}
+		if !updated {
+			return entity.ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	// Mark recovery code as used in 'mfa_recovery_codes' table, a recovery code can only be used once
+	consumed, err := uc.repo.ConsumeRecoveryCode(ctx, userUUID, hashToken(code))
+	if err != nil {
+		return fmt.Errorf("MFAUseCase - Verify - uc.repo.ConsumeRecoveryCode: %w", err)
+	}
+	if !consumed {
+		return entity.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// Codes are compared without spaces or dashes and in lower case, so users can type them the way they were shown
+func normalizeMFACode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, " ", "")
+	return strings.ReplaceAll(code, "-", "")
+}
+
+// Generates the recovery codes shown to the user, formatted as 'xxxx-xxxx', and their hashes to be stored
+func generateRecoveryCodes() ([]string, []string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	codes := make([]string, 0, _recoveryCodeCount)
+	hashes := make([]string, 0, _recoveryCodeCount)
+	for i := 0; i < _recoveryCodeCount; i++ {
+		b := make([]byte, _recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		code := strings.ToLower(encoding.EncodeToString(b))
+		codes = append(codes, code[:4]+"-"+code[4:])
+		hashes = append(hashes, hashToken(code))
+	}
+	return codes, hashes, nil
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/totp"
+)
+
+func TestMFAUseCase_ConfirmEnrollment(t *testing.T) {
+	secret, _ := totp.GenerateSecret()
+	validCode, _ := totp.Code(secret, totp.Step(time.Now()))
+	confirmedAt := time.Now()
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                            // Name of the test case
+		code       string                            // Code typed by the user
+		setupMocks func(mockRepo *mocks.MockMFARepo) // Function to set up mock behavior
+		wantErr    error                             // Expected sentinel error, if any
+		wantAnyErr bool                              // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - recovery codes issued",
+			code: validCode,
+			setupMocks: func(mockRepo *mocks.MockMFARepo) {
+				mockRepo.EXPECT().
+					GetMFA(gomock.Any(), testUserUUID).
+					Return(&entity.MFADTO{UserUUID: testUserUUID, Secret: secret}, nil)
+				mockRepo.EXPECT().
+					ConfirmMFA(gomock.Any(), testUserUUID, gomock.Any(), gomock.Len(_recoveryCodeCount)).
+					Return(nil)
+			},
+		},
+		{
+			name: "error - not enrolled",
+			code: validCode,
+			setupMocks: func(mockRepo *mocks.MockMFARepo) {
+				mockRepo.EXPECT().
+					GetMFA(gomock.Any(), testUserUUID).
+					Return(nil, nil)
+			},
+			wantErr:    entity.ErrMFANotEnrolled,
+			wantAnyErr: true,
+		},
+		{
+			name: "error - already enabled",
+			code: validCode,
+			setupMocks: func(mockRepo *mocks.MockMFARepo) {
+				mockRepo.EXPECT().
+					GetMFA(gomock.Any(), testUserUUID).
+					Return(&entity.MFADTO{UserUUID: testUserUUID, Secret: secret, ConfirmedAt: &confirmedAt}, nil)
+			},
+			wantErr:    entity.ErrMFAAlreadyEnabled,
+			wantAnyErr: true,
+		},
+		{
+			name: "error - wrong code",
+			code: "abcdef",
+			setupMocks: func(mockRepo *mocks.MockMFARepo) {
+				mockRepo.EXPECT().
+					GetMFA(gomock.Any(), testUserUUID).
+					Return(&entity.MFADTO{UserUUID: testUserUUID, Secret: secret}, nil)
+			},
+			wantErr:    entity.ErrInvalidMFACode,
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instance of the MFARepo interface
+			mockRepo := mocks.NewMockMFARepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := &MFAUseCase{repo: mockRepo}
+
+			// Call the method under test
+			got, err := uc.ConfirmEnrollment(context.Background(), testUserUUID, tt.code)
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("MFAUseCase.ConfirmEnrollment() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("MFAUseCase.ConfirmEnrollment() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && len(got) != _recoveryCodeCount {
+				t.Errorf("MFAUseCase.ConfirmEnrollment() returned %d recovery codes, want %d", len(got), _recoveryCodeCount)
+			}
+		})
+	}
+}
+
+func TestMFAUseCase_Verify(t *testing.T) {
+	secret, _ := totp.GenerateSecret()
+	validCode, _ := totp.Code(secret, totp.Step(time.Now()))
+	confirmedAt := time.Now()
+	enabledMFA := &entity.MFADTO{UserUUID: testUserUUID, Secret: secret, ConfirmedAt: &confirmedAt}
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                            // Name of the test case
+		code       string                            // Code typed by the user
+		setupMocks func(mockRepo *mocks.MockMFARepo) // Function to set up mock behavior
+		wantErr    error                             // Expected sentinel error, if any
+		wantAnyErr bool                              // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - authenticator code",
+			code: validCode,
+			setupMocks: func(mockRepo *mocks.MockMFARepo) {
+				mockRepo.EXPECT().GetMFA(gomock.Any(), testUserUUID).Return(enabledMFA, nil)
+				mockRepo.EXPECT().
+					UpdateLastUsedStep(gomock.Any(), testUserUUID, gomock.Any()).
+					Return(true, nil)
+			},
+		},
+		{
+			name: "error - authenticator code replayed",
+			code: validCode,
+			setupMocks: func(mockRepo *mocks.MockMFARepo) {
+				mockRepo.EXPECT().GetMFA(gomock.Any(), testUserUUID).Return(enabledMFA, nil)
+				mockRepo.EXPECT().
+					UpdateLastUsedStep(gomock.Any(), testUserUUID, gomock.Any()).
+					Return(false, nil)
+			},
+			wantErr:    entity.ErrInvalidMFACode,
+			wantAnyErr: true,
+		},
+		{
+			name: "success - recovery code typed in upper case",
+			code: "ABCD-EFGH",
+			setupMocks: func(mockRepo *mocks.MockMFARepo) {
+				mockRepo.EXPECT().GetMFA(gomock.Any(), testUserUUID).Return(enabledMFA, nil)
+				mockRepo.EXPECT().
+					ConsumeRecoveryCode(gomock.Any(), testUserUUID, hashToken("abcdefgh")).
+					Return(true, nil)
+			},
+		},
+		{
+			name: "error - recovery code already used",
+			code: "abcd-efgh",
+			setupMocks: func(mockRepo *mocks.MockMFARepo) {
+				mockRepo.EXPECT().GetMFA(gomock.Any(), testUserUUID).Return(enabledMFA, nil)
+				mockRepo.EXPECT().
+					ConsumeRecoveryCode(gomock.Any(), testUserUUID, hashToken("abcdefgh")).
+					Return(false, nil)
+			},
+			wantErr:    entity.ErrInvalidMFACode,
+			wantAnyErr: true,
+		},
+		{
+			name: "error - not enabled",
+			code: validCode,
+			setupMocks: func(mockRepo *mocks.MockMFARepo) {
+				mockRepo.EXPECT().GetMFA(gomock.Any(), testUserUUID).Return(nil, nil)
+			},
+			wantErr:    entity.ErrMFANotEnrolled,
+			wantAnyErr: true,
+		},
+		{
+			name: "error getting mfa settings",
+			code: validCode,
+			setupMocks: func(mockRepo *mocks.MockMFARepo) {
+				mockRepo.EXPECT().GetMFA(gomock.Any(), testUserUUID).Return(nil, fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instance of the MFARepo interface
+			mockRepo := mocks.NewMockMFARepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := &MFAUseCase{repo: mockRepo}
+
+			// Call the method under test
+			err := uc.Verify(context.Background(), testUserUUID, tt.code)
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("MFAUseCase.Verify() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("MFAUseCase.Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMFAUseCase_Enroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMFARepo(ctrl)
+	mockUserRepo := mocks.NewMockUserRepo(ctrl)
+
+	mockRepo.EXPECT().GetMFA(gomock.Any(), testUserUUID).Return(nil, nil)
+	mockUserRepo.EXPECT().
+		GetUserCredentialsByUUID(gomock.Any(), testUserUUID).
+		Return(&entity.UserCredentialsDTO{UserUuid: testUserUUID, Username: "testuser"}, nil)
+	mockRepo.EXPECT().StorePendingMFA(gomock.Any(), testUserUUID, gomock.Any()).Return(nil)
+
+	uc := NewMFA(mockRepo, mockUserRepo, "chat")
+
+	got, err := uc.Enroll(context.Background(), testUserUUID)
+	if err != nil {
+		t.Fatalf("MFAUseCase.Enroll() error = %v", err)
+	}
+	if got.Secret == "" || !strings.HasPrefix(got.URI, "otpauth://totp/chat:testuser?") || !strings.Contains(got.URI, got.Secret) {
+		t.Errorf("MFAUseCase.Enroll() = %+v, want otpauth URI for the new secret", got)
+	}
+}
@@ -6,10 +6,13 @@ package mock_usecase
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	entity "github.com/maxyong7/chat-messaging-app/internal/entity"
+	oidc "github.com/maxyong7/chat-messaging-app/pkg/oidc"
 )
 
 // MockUser is a mock of User interface.
@@ -88,6 +91,20 @@ func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
 	return m.recorder
 }
 
+// CancelAccountDeletion mocks base method.
+func (m *MockUserRepo) CancelAccountDeletion(ctx context.Context, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelAccountDeletion", ctx, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelAccountDeletion indicates an expected call of CancelAccountDeletion.
+func (mr *MockUserRepoMockRecorder) CancelAccountDeletion(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAccountDeletion", reflect.TypeOf((*MockUserRepo)(nil).CancelAccountDeletion), ctx, userUUID)
+}
+
 // CheckUserExist mocks base method.
 func (m *MockUserRepo) CheckUserExist(arg0 context.Context, arg1 entity.UserRegistrationDTO) (bool, error) {
 	m.ctrl.T.Helper()
@@ -103,6 +120,21 @@ func (mr *MockUserRepoMockRecorder) CheckUserExist(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserExist", reflect.TypeOf((*MockUserRepo)(nil).CheckUserExist), arg0, arg1)
 }
 
+// ClearExpiredStatuses mocks base method.
+func (m *MockUserRepo) ClearExpiredStatuses(ctx context.Context, now time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearExpiredStatuses", ctx, now)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearExpiredStatuses indicates an expected call of ClearExpiredStatuses.
+func (mr *MockUserRepoMockRecorder) ClearExpiredStatuses(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearExpiredStatuses", reflect.TypeOf((*MockUserRepo)(nil).ClearExpiredStatuses), ctx, now)
+}
+
 // GetUserCredentials mocks base method.
 func (m *MockUserRepo) GetUserCredentials(arg0 context.Context, arg1 entity.UserCredentialsDTO) (*entity.UserCredentialsDTO, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +150,21 @@ func (mr *MockUserRepoMockRecorder) GetUserCredentials(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCredentials", reflect.TypeOf((*MockUserRepo)(nil).GetUserCredentials), arg0, arg1)
 }
 
+// GetUserCredentialsByUUID mocks base method.
+func (m *MockUserRepo) GetUserCredentialsByUUID(ctx context.Context, userUUID string) (*entity.UserCredentialsDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCredentialsByUUID", ctx, userUUID)
+	ret0, _ := ret[0].(*entity.UserCredentialsDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCredentialsByUUID indicates an expected call of GetUserCredentialsByUUID.
+func (mr *MockUserRepoMockRecorder) GetUserCredentialsByUUID(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCredentialsByUUID", reflect.TypeOf((*MockUserRepo)(nil).GetUserCredentialsByUUID), ctx, userUUID)
+}
+
 // GetUserProfile mocks base method.
 func (m *MockUserRepo) GetUserProfile(arg0 context.Context, arg1 string) (*entity.UserProfileDTO, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +195,50 @@ func (mr *MockUserRepoMockRecorder) GetUserUUIDByUsername(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserUUIDByUsername", reflect.TypeOf((*MockUserRepo)(nil).GetUserUUIDByUsername), arg0, arg1)
 }
 
+// IsUsernameAvailable mocks base method.
+func (m *MockUserRepo) IsUsernameAvailable(ctx context.Context, username, userUUID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUsernameAvailable", ctx, username, userUUID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUsernameAvailable indicates an expected call of IsUsernameAvailable.
+func (mr *MockUserRepoMockRecorder) IsUsernameAvailable(ctx, username, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUsernameAvailable", reflect.TypeOf((*MockUserRepo)(nil).IsUsernameAvailable), ctx, username, userUUID)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockUserRepo) MarkEmailVerified(ctx context.Context, userUUID, email string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", ctx, userUUID, email)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockUserRepoMockRecorder) MarkEmailVerified(ctx, userUUID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockUserRepo)(nil).MarkEmailVerified), ctx, userUUID, email)
+}
+
+// ReplacePasswordHash mocks base method.
+func (m *MockUserRepo) ReplacePasswordHash(ctx context.Context, userUUID, oldHash, newHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplacePasswordHash", ctx, userUUID, oldHash, newHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplacePasswordHash indicates an expected call of ReplacePasswordHash.
+func (mr *MockUserRepoMockRecorder) ReplacePasswordHash(ctx, userUUID, oldHash, newHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacePasswordHash", reflect.TypeOf((*MockUserRepo)(nil).ReplacePasswordHash), ctx, userUUID, oldHash, newHash)
+}
+
 // StoreUserInfo mocks base method.
 func (m *MockUserRepo) StoreUserInfo(arg0 context.Context, arg1 entity.UserRegistrationDTO) error {
 	m.ctrl.T.Helper()
@@ -162,845 +253,4250 @@ func (mr *MockUserRepoMockRecorder) StoreUserInfo(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserInfo", reflect.TypeOf((*MockUserRepo)(nil).StoreUserInfo), arg0, arg1)
 }
 
-// UpdateUserProfile mocks base method.
-func (m *MockUserRepo) UpdateUserProfile(ctx context.Context, userInfo entity.UserProfileDTO) error {
+// UpdateAvatar mocks base method.
+func (m *MockUserRepo) UpdateAvatar(ctx context.Context, userUUID, avatar string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserProfile", ctx, userInfo)
+	ret := m.ctrl.Call(m, "UpdateAvatar", ctx, userUUID, avatar)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateUserProfile indicates an expected call of UpdateUserProfile.
-func (mr *MockUserRepoMockRecorder) UpdateUserProfile(ctx, userInfo interface{}) *gomock.Call {
+// UpdateAvatar indicates an expected call of UpdateAvatar.
+func (mr *MockUserRepoMockRecorder) UpdateAvatar(ctx, userUUID, avatar interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUserRepo)(nil).UpdateUserProfile), ctx, userInfo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvatar", reflect.TypeOf((*MockUserRepo)(nil).UpdateAvatar), ctx, userUUID, avatar)
 }
 
-// MockConversation is a mock of Conversation interface.
-type MockConversation struct {
-	ctrl     *gomock.Controller
-	recorder *MockConversationMockRecorder
+// UpdateEmail mocks base method.
+func (m *MockUserRepo) UpdateEmail(ctx context.Context, userUUID, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmail", ctx, userUUID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MockConversationMockRecorder is the mock recorder for MockConversation.
-type MockConversationMockRecorder struct {
-	mock *MockConversation
+// UpdateEmail indicates an expected call of UpdateEmail.
+func (mr *MockUserRepoMockRecorder) UpdateEmail(ctx, userUUID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockUserRepo)(nil).UpdateEmail), ctx, userUUID, email)
 }
 
-// NewMockConversation creates a new mock instance.
-func NewMockConversation(ctrl *gomock.Controller) *MockConversation {
-	mock := &MockConversation{ctrl: ctrl}
-	mock.recorder = &MockConversationMockRecorder{mock}
-	return mock
+// UpdatePassword mocks base method.
+func (m *MockUserRepo) UpdatePassword(ctx context.Context, userUUID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, userUUID, password)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockConversation) EXPECT() *MockConversationMockRecorder {
-	return m.recorder
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepoMockRecorder) UpdatePassword(ctx, userUUID, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepo)(nil).UpdatePassword), ctx, userUUID, password)
 }
 
-// GetConversationList mocks base method.
-func (m *MockConversation) GetConversationList(arg0 context.Context, arg1 entity.RequestParams) ([]entity.ConversationList, error) {
+// UpdateUserProfile mocks base method.
+func (m *MockUserRepo) UpdateUserProfile(ctx context.Context, userInfo entity.UserProfileDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetConversationList", arg0, arg1)
-	ret0, _ := ret[0].([]entity.ConversationList)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateUserProfile", ctx, userInfo)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetConversationList indicates an expected call of GetConversationList.
-func (mr *MockConversationMockRecorder) GetConversationList(arg0, arg1 interface{}) *gomock.Call {
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockUserRepoMockRecorder) UpdateUserProfile(ctx, userInfo interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationList", reflect.TypeOf((*MockConversation)(nil).GetConversationList), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUserRepo)(nil).UpdateUserProfile), ctx, userInfo)
 }
 
-// StoreConversationAndMessage mocks base method.
-func (m *MockConversation) StoreConversationAndMessage(ctx context.Context, conv entity.Conversation) error {
+// UpdateUserStatus mocks base method.
+func (m *MockUserRepo) UpdateUserStatus(ctx context.Context, userUUID string, status *entity.UserStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreConversationAndMessage", ctx, conv)
+	ret := m.ctrl.Call(m, "UpdateUserStatus", ctx, userUUID, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// StoreConversationAndMessage indicates an expected call of StoreConversationAndMessage.
-func (mr *MockConversationMockRecorder) StoreConversationAndMessage(ctx, conv interface{}) *gomock.Call {
+// UpdateUserStatus indicates an expected call of UpdateUserStatus.
+func (mr *MockUserRepoMockRecorder) UpdateUserStatus(ctx, userUUID, status interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreConversationAndMessage", reflect.TypeOf((*MockConversation)(nil).StoreConversationAndMessage), ctx, conv)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStatus", reflect.TypeOf((*MockUserRepo)(nil).UpdateUserStatus), ctx, userUUID, status)
 }
 
-// MockConversationRepo is a mock of ConversationRepo interface.
-type MockConversationRepo struct {
+// UpdateUsername mocks base method.
+func (m *MockUserRepo) UpdateUsername(ctx context.Context, userUUID, oldUsername, newUsername string, reservedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", ctx, userUUID, oldUsername, newUsername, reservedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockUserRepoMockRecorder) UpdateUsername(ctx, userUUID, oldUsername, newUsername, reservedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockUserRepo)(nil).UpdateUsername), ctx, userUUID, oldUsername, newUsername, reservedUntil)
+}
+
+// MockPasswordReset is a mock of PasswordReset interface.
+type MockPasswordReset struct {
 	ctrl     *gomock.Controller
-	recorder *MockConversationRepoMockRecorder
+	recorder *MockPasswordResetMockRecorder
 }
 
-// MockConversationRepoMockRecorder is the mock recorder for MockConversationRepo.
-type MockConversationRepoMockRecorder struct {
-	mock *MockConversationRepo
+// MockPasswordResetMockRecorder is the mock recorder for MockPasswordReset.
+type MockPasswordResetMockRecorder struct {
+	mock *MockPasswordReset
 }
 
-// NewMockConversationRepo creates a new mock instance.
-func NewMockConversationRepo(ctrl *gomock.Controller) *MockConversationRepo {
-	mock := &MockConversationRepo{ctrl: ctrl}
-	mock.recorder = &MockConversationRepoMockRecorder{mock}
+// NewMockPasswordReset creates a new mock instance.
+func NewMockPasswordReset(ctrl *gomock.Controller) *MockPasswordReset {
+	mock := &MockPasswordReset{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockConversationRepo) EXPECT() *MockConversationRepoMockRecorder {
+func (m *MockPasswordReset) EXPECT() *MockPasswordResetMockRecorder {
 	return m.recorder
 }
 
-// GetConversationList mocks base method.
-func (m *MockConversationRepo) GetConversationList(arg0 context.Context, arg1 entity.RequestParamsDTO) ([]entity.ConversationList, error) {
+// RequestPasswordReset mocks base method.
+func (m *MockPasswordReset) RequestPasswordReset(ctx context.Context, request entity.PasswordResetRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetConversationList", arg0, arg1)
-	ret0, _ := ret[0].([]entity.ConversationList)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetConversationList indicates an expected call of GetConversationList.
-func (mr *MockConversationRepoMockRecorder) GetConversationList(arg0, arg1 interface{}) *gomock.Call {
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockPasswordResetMockRecorder) RequestPasswordReset(ctx, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationList", reflect.TypeOf((*MockConversationRepo)(nil).GetConversationList), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockPasswordReset)(nil).RequestPasswordReset), ctx, request)
 }
 
-// InsertConversationAndMessage mocks base method.
-func (m *MockConversationRepo) InsertConversationAndMessage(ctx context.Context, convDTO entity.ConversationDTO) error {
+// ResetPassword mocks base method.
+func (m *MockPasswordReset) ResetPassword(ctx context.Context, passwordReset entity.PasswordReset) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertConversationAndMessage", ctx, convDTO)
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, passwordReset)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertConversationAndMessage indicates an expected call of InsertConversationAndMessage.
-func (mr *MockConversationRepoMockRecorder) InsertConversationAndMessage(ctx, convDTO interface{}) *gomock.Call {
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockPasswordResetMockRecorder) ResetPassword(ctx, passwordReset interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertConversationAndMessage", reflect.TypeOf((*MockConversationRepo)(nil).InsertConversationAndMessage), ctx, convDTO)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockPasswordReset)(nil).ResetPassword), ctx, passwordReset)
 }
 
-// MockContact is a mock of Contact interface.
-type MockContact struct {
+// MockCredentials is a mock of Credentials interface.
+type MockCredentials struct {
 	ctrl     *gomock.Controller
-	recorder *MockContactMockRecorder
+	recorder *MockCredentialsMockRecorder
 }
 
-// MockContactMockRecorder is the mock recorder for MockContact.
-type MockContactMockRecorder struct {
-	mock *MockContact
+// MockCredentialsMockRecorder is the mock recorder for MockCredentials.
+type MockCredentialsMockRecorder struct {
+	mock *MockCredentials
 }
 
-// NewMockContact creates a new mock instance.
-func NewMockContact(ctrl *gomock.Controller) *MockContact {
-	mock := &MockContact{ctrl: ctrl}
-	mock.recorder = &MockContactMockRecorder{mock}
+// NewMockCredentials creates a new mock instance.
+func NewMockCredentials(ctrl *gomock.Controller) *MockCredentials {
+	mock := &MockCredentials{ctrl: ctrl}
+	mock.recorder = &MockCredentialsMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockContact) EXPECT() *MockContactMockRecorder {
+func (m *MockCredentials) EXPECT() *MockCredentialsMockRecorder {
 	return m.recorder
 }
 
-// AddContact mocks base method.
-func (m *MockContact) AddContact(ctx context.Context, contactUserName, userUuid string) error {
+// ChangePassword mocks base method.
+func (m *MockCredentials) ChangePassword(ctx context.Context, change entity.PasswordChange) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddContact", ctx, contactUserName, userUuid)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, change)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AddContact indicates an expected call of AddContact.
-func (mr *MockContactMockRecorder) AddContact(ctx, contactUserName, userUuid interface{}) *gomock.Call {
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockCredentialsMockRecorder) ChangePassword(ctx, change interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContact", reflect.TypeOf((*MockContact)(nil).AddContact), ctx, contactUserName, userUuid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockCredentials)(nil).ChangePassword), ctx, change)
 }
 
-// GetContacts mocks base method.
-func (m *MockContact) GetContacts(ctx context.Context, userUuid string) ([]entity.Contacts, error) {
+// ChangeUsername mocks base method.
+func (m *MockCredentials) ChangeUsername(ctx context.Context, change entity.UsernameChange) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContacts", ctx, userUuid)
-	ret0, _ := ret[0].([]entity.Contacts)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ChangeUsername", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetContacts indicates an expected call of GetContacts.
-func (mr *MockContactMockRecorder) GetContacts(ctx, userUuid interface{}) *gomock.Call {
+// ChangeUsername indicates an expected call of ChangeUsername.
+func (mr *MockCredentialsMockRecorder) ChangeUsername(ctx, change interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockContact)(nil).GetContacts), ctx, userUuid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUsername", reflect.TypeOf((*MockCredentials)(nil).ChangeUsername), ctx, change)
 }
 
-// RemoveContact mocks base method.
-func (m *MockContact) RemoveContact(ctx context.Context, contactUserName, userUuid string) error {
+// ConfirmEmailChange mocks base method.
+func (m *MockCredentials) ConfirmEmailChange(ctx context.Context, token string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveContact", ctx, contactUserName, userUuid)
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", ctx, token)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RemoveContact indicates an expected call of RemoveContact.
-func (mr *MockContactMockRecorder) RemoveContact(ctx, contactUserName, userUuid interface{}) *gomock.Call {
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockCredentialsMockRecorder) ConfirmEmailChange(ctx, token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContact", reflect.TypeOf((*MockContact)(nil).RemoveContact), ctx, contactUserName, userUuid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockCredentials)(nil).ConfirmEmailChange), ctx, token)
 }
 
-// UpdateBlockContact mocks base method.
-func (m *MockContact) UpdateBlockContact(ctx context.Context, contactUserName, userUuid string, block bool) error {
+// RequestEmailChange mocks base method.
+func (m *MockCredentials) RequestEmailChange(ctx context.Context, change entity.EmailChange) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateBlockContact", ctx, contactUserName, userUuid, block)
+	ret := m.ctrl.Call(m, "RequestEmailChange", ctx, change)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateBlockContact indicates an expected call of UpdateBlockContact.
-func (mr *MockContactMockRecorder) UpdateBlockContact(ctx, contactUserName, userUuid, block interface{}) *gomock.Call {
+// RequestEmailChange indicates an expected call of RequestEmailChange.
+func (mr *MockCredentialsMockRecorder) RequestEmailChange(ctx, change interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBlockContact", reflect.TypeOf((*MockContact)(nil).UpdateBlockContact), ctx, contactUserName, userUuid, block)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailChange", reflect.TypeOf((*MockCredentials)(nil).RequestEmailChange), ctx, change)
 }
 
-// MockContactsRepo is a mock of ContactsRepo interface.
-type MockContactsRepo struct {
+// MockUserTokenRepo is a mock of UserTokenRepo interface.
+type MockUserTokenRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockContactsRepoMockRecorder
+	recorder *MockUserTokenRepoMockRecorder
 }
 
-// MockContactsRepoMockRecorder is the mock recorder for MockContactsRepo.
-type MockContactsRepoMockRecorder struct {
-	mock *MockContactsRepo
+// MockUserTokenRepoMockRecorder is the mock recorder for MockUserTokenRepo.
+type MockUserTokenRepoMockRecorder struct {
+	mock *MockUserTokenRepo
 }
 
-// NewMockContactsRepo creates a new mock instance.
-func NewMockContactsRepo(ctrl *gomock.Controller) *MockContactsRepo {
-	mock := &MockContactsRepo{ctrl: ctrl}
-	mock.recorder = &MockContactsRepoMockRecorder{mock}
+// NewMockUserTokenRepo creates a new mock instance.
+func NewMockUserTokenRepo(ctrl *gomock.Controller) *MockUserTokenRepo {
+	mock := &MockUserTokenRepo{ctrl: ctrl}
+	mock.recorder = &MockUserTokenRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockContactsRepo) EXPECT() *MockContactsRepoMockRecorder {
+func (m *MockUserTokenRepo) EXPECT() *MockUserTokenRepoMockRecorder {
 	return m.recorder
 }
 
-// CheckContactExist mocks base method.
-func (m *MockContactsRepo) CheckContactExist(ctx context.Context, userUuid, contactUserUuid string) (bool, error) {
+// ConsumeUserToken mocks base method.
+func (m *MockUserTokenRepo) ConsumeUserToken(ctx context.Context, tokenHash, purpose string) (*entity.UserTokenDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckContactExist", ctx, userUuid, contactUserUuid)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "ConsumeUserToken", ctx, tokenHash, purpose)
+	ret0, _ := ret[0].(*entity.UserTokenDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CheckContactExist indicates an expected call of CheckContactExist.
-func (mr *MockContactsRepoMockRecorder) CheckContactExist(ctx, userUuid, contactUserUuid interface{}) *gomock.Call {
+// ConsumeUserToken indicates an expected call of ConsumeUserToken.
+func (mr *MockUserTokenRepoMockRecorder) ConsumeUserToken(ctx, tokenHash, purpose interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckContactExist", reflect.TypeOf((*MockContactsRepo)(nil).CheckContactExist), ctx, userUuid, contactUserUuid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeUserToken", reflect.TypeOf((*MockUserTokenRepo)(nil).ConsumeUserToken), ctx, tokenHash, purpose)
 }
 
-// GetContactsByUserUUID mocks base method.
-func (m *MockContactsRepo) GetContactsByUserUUID(ctx context.Context, userUuid string) ([]entity.Contacts, error) {
+// CountUserTokensSince mocks base method.
+func (m *MockUserTokenRepo) CountUserTokensSince(ctx context.Context, userUUID, purpose string, since time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContactsByUserUUID", ctx, userUuid)
-	ret0, _ := ret[0].([]entity.Contacts)
+	ret := m.ctrl.Call(m, "CountUserTokensSince", ctx, userUUID, purpose, since)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetContactsByUserUUID indicates an expected call of GetContactsByUserUUID.
-func (mr *MockContactsRepoMockRecorder) GetContactsByUserUUID(ctx, userUuid interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsByUserUUID", reflect.TypeOf((*MockContactsRepo)(nil).GetContactsByUserUUID), ctx, userUuid)
-}
-
-// StoreContacts mocks base method.
-func (m *MockContactsRepo) StoreContacts(arg0 context.Context, arg1 entity.ContactsDTO) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreContacts", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// StoreContacts indicates an expected call of StoreContacts.
-func (mr *MockContactsRepoMockRecorder) StoreContacts(arg0, arg1 interface{}) *gomock.Call {
+// CountUserTokensSince indicates an expected call of CountUserTokensSince.
+func (mr *MockUserTokenRepoMockRecorder) CountUserTokensSince(ctx, userUUID, purpose, since interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreContacts", reflect.TypeOf((*MockContactsRepo)(nil).StoreContacts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserTokensSince", reflect.TypeOf((*MockUserTokenRepo)(nil).CountUserTokensSince), ctx, userUUID, purpose, since)
 }
 
-// UpdateBlockedStatus mocks base method.
-func (m *MockContactsRepo) UpdateBlockedStatus(arg0 context.Context, arg1 entity.ContactsDTO) error {
+// ResetPassword mocks base method.
+func (m *MockUserTokenRepo) ResetPassword(ctx context.Context, tokenHash, password string) (*entity.UserTokenDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateBlockedStatus", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, tokenHash, password)
+	ret0, _ := ret[0].(*entity.UserTokenDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateBlockedStatus indicates an expected call of UpdateBlockedStatus.
-func (mr *MockContactsRepoMockRecorder) UpdateBlockedStatus(arg0, arg1 interface{}) *gomock.Call {
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUserTokenRepoMockRecorder) ResetPassword(ctx, tokenHash, password interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBlockedStatus", reflect.TypeOf((*MockContactsRepo)(nil).UpdateBlockedStatus), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserTokenRepo)(nil).ResetPassword), ctx, tokenHash, password)
 }
 
-// UpdateRemovedStatus mocks base method.
-func (m *MockContactsRepo) UpdateRemovedStatus(arg0 context.Context, arg1 entity.ContactsDTO) error {
+// StoreUserToken mocks base method.
+func (m *MockUserTokenRepo) StoreUserToken(ctx context.Context, userToken entity.UserTokenDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRemovedStatus", arg0, arg1)
+	ret := m.ctrl.Call(m, "StoreUserToken", ctx, userToken)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateRemovedStatus indicates an expected call of UpdateRemovedStatus.
-func (mr *MockContactsRepoMockRecorder) UpdateRemovedStatus(arg0, arg1 interface{}) *gomock.Call {
+// StoreUserToken indicates an expected call of StoreUserToken.
+func (mr *MockUserTokenRepoMockRecorder) StoreUserToken(ctx, userToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRemovedStatus", reflect.TypeOf((*MockContactsRepo)(nil).UpdateRemovedStatus), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserToken", reflect.TypeOf((*MockUserTokenRepo)(nil).StoreUserToken), ctx, userToken)
 }
 
-// MockMessageRepo is a mock of MessageRepo interface.
-type MockMessageRepo struct {
+// MockEmailVerification is a mock of EmailVerification interface.
+type MockEmailVerification struct {
 	ctrl     *gomock.Controller
-	recorder *MockMessageRepoMockRecorder
+	recorder *MockEmailVerificationMockRecorder
 }
 
-// MockMessageRepoMockRecorder is the mock recorder for MockMessageRepo.
-type MockMessageRepoMockRecorder struct {
-	mock *MockMessageRepo
+// MockEmailVerificationMockRecorder is the mock recorder for MockEmailVerification.
+type MockEmailVerificationMockRecorder struct {
+	mock *MockEmailVerification
 }
 
-// NewMockMessageRepo creates a new mock instance.
-func NewMockMessageRepo(ctrl *gomock.Controller) *MockMessageRepo {
-	mock := &MockMessageRepo{ctrl: ctrl}
-	mock.recorder = &MockMessageRepoMockRecorder{mock}
+// NewMockEmailVerification creates a new mock instance.
+func NewMockEmailVerification(ctrl *gomock.Controller) *MockEmailVerification {
+	mock := &MockEmailVerification{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockMessageRepo) EXPECT() *MockMessageRepoMockRecorder {
+func (m *MockEmailVerification) EXPECT() *MockEmailVerificationMockRecorder {
 	return m.recorder
 }
 
-// DeleteMessage mocks base method.
-func (m *MockMessageRepo) DeleteMessage(ctx context.Context, msg entity.MessageDTO) error {
+// ConfirmEmail mocks base method.
+func (m *MockEmailVerification) ConfirmEmail(ctx context.Context, token string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMessage", ctx, msg)
+	ret := m.ctrl.Call(m, "ConfirmEmail", ctx, token)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteMessage indicates an expected call of DeleteMessage.
-func (mr *MockMessageRepoMockRecorder) DeleteMessage(ctx, msg interface{}) *gomock.Call {
+// ConfirmEmail indicates an expected call of ConfirmEmail.
+func (mr *MockEmailVerificationMockRecorder) ConfirmEmail(ctx, token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockMessageRepo)(nil).DeleteMessage), ctx, msg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmail", reflect.TypeOf((*MockEmailVerification)(nil).ConfirmEmail), ctx, token)
 }
 
-// GetMessages mocks base method.
-func (m *MockMessageRepo) GetMessages(ctx context.Context, reqParam entity.RequestParamsDTO, conversationUUID string) ([]entity.GetMessageDTO, error) {
+// ResendVerificationEmail mocks base method.
+func (m *MockEmailVerification) ResendVerificationEmail(ctx context.Context, email string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMessages", ctx, reqParam, conversationUUID)
-	ret0, _ := ret[0].([]entity.GetMessageDTO)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ResendVerificationEmail", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetMessages indicates an expected call of GetMessages.
-func (mr *MockMessageRepoMockRecorder) GetMessages(ctx, reqParam, conversationUUID interface{}) *gomock.Call {
+// ResendVerificationEmail indicates an expected call of ResendVerificationEmail.
+func (mr *MockEmailVerificationMockRecorder) ResendVerificationEmail(ctx, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockMessageRepo)(nil).GetMessages), ctx, reqParam, conversationUUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerificationEmail", reflect.TypeOf((*MockEmailVerification)(nil).ResendVerificationEmail), ctx, email)
 }
 
-// GetSeenStatus mocks base method.
-func (m *MockMessageRepo) GetSeenStatus(ctx context.Context, messageUUID string) ([]entity.GetSeenStatusDTO, error) {
+// SendVerificationEmail mocks base method.
+func (m *MockEmailVerification) SendVerificationEmail(ctx context.Context, email string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSeenStatus", ctx, messageUUID)
-	ret0, _ := ret[0].([]entity.GetSeenStatusDTO)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SendVerificationEmail", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetSeenStatus indicates an expected call of GetSeenStatus.
-func (mr *MockMessageRepoMockRecorder) GetSeenStatus(ctx, messageUUID interface{}) *gomock.Call {
+// SendVerificationEmail indicates an expected call of SendVerificationEmail.
+func (mr *MockEmailVerificationMockRecorder) SendVerificationEmail(ctx, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeenStatus", reflect.TypeOf((*MockMessageRepo)(nil).GetSeenStatus), ctx, messageUUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerificationEmail", reflect.TypeOf((*MockEmailVerification)(nil).SendVerificationEmail), ctx, email)
 }
 
-// SearchMessage mocks base method.
-func (m *MockMessageRepo) SearchMessage(ctx context.Context, keyword, conversationUUID string) ([]entity.SearchMessageDTO, error) {
+// MockMFA is a mock of MFA interface.
+type MockMFA struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAMockRecorder
+}
+
+// MockMFAMockRecorder is the mock recorder for MockMFA.
+type MockMFAMockRecorder struct {
+	mock *MockMFA
+}
+
+// NewMockMFA creates a new mock instance.
+func NewMockMFA(ctrl *gomock.Controller) *MockMFA {
+	mock := &MockMFA{ctrl: ctrl}
+	mock.recorder = &MockMFAMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFA) EXPECT() *MockMFAMockRecorder {
+	return m.recorder
+}
+
+// ConfirmEnrollment mocks base method.
+func (m *MockMFA) ConfirmEnrollment(ctx context.Context, userUUID, code string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchMessage", ctx, keyword, conversationUUID)
-	ret0, _ := ret[0].([]entity.SearchMessageDTO)
+	ret := m.ctrl.Call(m, "ConfirmEnrollment", ctx, userUUID, code)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchMessage indicates an expected call of SearchMessage.
-func (mr *MockMessageRepoMockRecorder) SearchMessage(ctx, keyword, conversationUUID interface{}) *gomock.Call {
+// ConfirmEnrollment indicates an expected call of ConfirmEnrollment.
+func (mr *MockMFAMockRecorder) ConfirmEnrollment(ctx, userUUID, code interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMessage", reflect.TypeOf((*MockMessageRepo)(nil).SearchMessage), ctx, keyword, conversationUUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEnrollment", reflect.TypeOf((*MockMFA)(nil).ConfirmEnrollment), ctx, userUUID, code)
 }
 
-// UpdateSeenStatus mocks base method.
-func (m *MockMessageRepo) UpdateSeenStatus(ctx context.Context, seenStatus entity.SeenStatusDTO) error {
+// Enroll mocks base method.
+func (m *MockMFA) Enroll(ctx context.Context, userUUID string) (entity.MFAEnrollment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSeenStatus", ctx, seenStatus)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Enroll", ctx, userUUID)
+	ret0, _ := ret[0].(entity.MFAEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateSeenStatus indicates an expected call of UpdateSeenStatus.
-func (mr *MockMessageRepoMockRecorder) UpdateSeenStatus(ctx, seenStatus interface{}) *gomock.Call {
+// Enroll indicates an expected call of Enroll.
+func (mr *MockMFAMockRecorder) Enroll(ctx, userUUID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeenStatus", reflect.TypeOf((*MockMessageRepo)(nil).UpdateSeenStatus), ctx, seenStatus)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockMFA)(nil).Enroll), ctx, userUUID)
 }
 
-// ValidateMessageSentByUser mocks base method.
-func (m *MockMessageRepo) ValidateMessageSentByUser(ctx context.Context, msg entity.MessageDTO) (bool, error) {
+// IsEnabled mocks base method.
+func (m *MockMFA) IsEnabled(ctx context.Context, userUUID string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateMessageSentByUser", ctx, msg)
+	ret := m.ctrl.Call(m, "IsEnabled", ctx, userUUID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ValidateMessageSentByUser indicates an expected call of ValidateMessageSentByUser.
-func (mr *MockMessageRepoMockRecorder) ValidateMessageSentByUser(ctx, msg interface{}) *gomock.Call {
+// IsEnabled indicates an expected call of IsEnabled.
+func (mr *MockMFAMockRecorder) IsEnabled(ctx, userUUID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateMessageSentByUser", reflect.TypeOf((*MockMessageRepo)(nil).ValidateMessageSentByUser), ctx, msg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEnabled", reflect.TypeOf((*MockMFA)(nil).IsEnabled), ctx, userUUID)
 }
 
-// MockMessage is a mock of Message interface.
-type MockMessage struct {
+// Verify mocks base method.
+func (m *MockMFA) Verify(ctx context.Context, userUUID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, userUUID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockMFAMockRecorder) Verify(ctx, userUUID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockMFA)(nil).Verify), ctx, userUUID, code)
+}
+
+// MockMFARepo is a mock of MFARepo interface.
+type MockMFARepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockMessageMockRecorder
+	recorder *MockMFARepoMockRecorder
 }
 
-// MockMessageMockRecorder is the mock recorder for MockMessage.
-type MockMessageMockRecorder struct {
-	mock *MockMessage
+// MockMFARepoMockRecorder is the mock recorder for MockMFARepo.
+type MockMFARepoMockRecorder struct {
+	mock *MockMFARepo
 }
 
-// NewMockMessage creates a new mock instance.
-func NewMockMessage(ctrl *gomock.Controller) *MockMessage {
-	mock := &MockMessage{ctrl: ctrl}
-	mock.recorder = &MockMessageMockRecorder{mock}
+// NewMockMFARepo creates a new mock instance.
+func NewMockMFARepo(ctrl *gomock.Controller) *MockMFARepo {
+	mock := &MockMFARepo{ctrl: ctrl}
+	mock.recorder = &MockMFARepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockMessage) EXPECT() *MockMessageMockRecorder {
+func (m *MockMFARepo) EXPECT() *MockMFARepoMockRecorder {
 	return m.recorder
 }
 
-// DeleteMessage mocks base method.
-func (m *MockMessage) DeleteMessage(ctx context.Context, msg entity.Message) (bool, error) {
+// ConfirmMFA mocks base method.
+func (m *MockMFARepo) ConfirmMFA(ctx context.Context, userUUID string, step int64, recoveryCodeHashes []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMessage", ctx, msg)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ConfirmMFA", ctx, userUUID, step, recoveryCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// DeleteMessage indicates an expected call of DeleteMessage.
-func (mr *MockMessageMockRecorder) DeleteMessage(ctx, msg interface{}) *gomock.Call {
+// ConfirmMFA indicates an expected call of ConfirmMFA.
+func (mr *MockMFARepoMockRecorder) ConfirmMFA(ctx, userUUID, step, recoveryCodeHashes interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockMessage)(nil).DeleteMessage), ctx, msg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMFA", reflect.TypeOf((*MockMFARepo)(nil).ConfirmMFA), ctx, userUUID, step, recoveryCodeHashes)
 }
 
-// GetMessagesFromConversation mocks base method.
-func (m *MockMessage) GetMessagesFromConversation(ctx context.Context, reqParam entity.RequestParams, conversationUUID string) ([]entity.GetMessageDTO, error) {
+// ConsumeRecoveryCode mocks base method.
+func (m *MockMFARepo) ConsumeRecoveryCode(ctx context.Context, userUUID, codeHash string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMessagesFromConversation", ctx, reqParam, conversationUUID)
-	ret0, _ := ret[0].([]entity.GetMessageDTO)
+	ret := m.ctrl.Call(m, "ConsumeRecoveryCode", ctx, userUUID, codeHash)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMessagesFromConversation indicates an expected call of GetMessagesFromConversation.
-func (mr *MockMessageMockRecorder) GetMessagesFromConversation(ctx, reqParam, conversationUUID interface{}) *gomock.Call {
+// ConsumeRecoveryCode indicates an expected call of ConsumeRecoveryCode.
+func (mr *MockMFARepoMockRecorder) ConsumeRecoveryCode(ctx, userUUID, codeHash interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesFromConversation", reflect.TypeOf((*MockMessage)(nil).GetMessagesFromConversation), ctx, reqParam, conversationUUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeRecoveryCode", reflect.TypeOf((*MockMFARepo)(nil).ConsumeRecoveryCode), ctx, userUUID, codeHash)
 }
 
-// GetSeenStatus mocks base method.
-func (m *MockMessage) GetSeenStatus(ctx context.Context, messageUUID string) ([]entity.GetSeenStatusDTO, error) {
+// GetMFA mocks base method.
+func (m *MockMFARepo) GetMFA(ctx context.Context, userUUID string) (*entity.MFADTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSeenStatus", ctx, messageUUID)
-	ret0, _ := ret[0].([]entity.GetSeenStatusDTO)
+	ret := m.ctrl.Call(m, "GetMFA", ctx, userUUID)
+	ret0, _ := ret[0].(*entity.MFADTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSeenStatus indicates an expected call of GetSeenStatus.
-func (mr *MockMessageMockRecorder) GetSeenStatus(ctx, messageUUID interface{}) *gomock.Call {
+// GetMFA indicates an expected call of GetMFA.
+func (mr *MockMFARepoMockRecorder) GetMFA(ctx, userUUID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeenStatus", reflect.TypeOf((*MockMessage)(nil).GetSeenStatus), ctx, messageUUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMFA", reflect.TypeOf((*MockMFARepo)(nil).GetMFA), ctx, userUUID)
 }
 
-// SearchMessage mocks base method.
-func (m *MockMessage) SearchMessage(ctx context.Context, keyword, conversationUUID string) ([]entity.SearchMessageDTO, error) {
+// StorePendingMFA mocks base method.
+func (m *MockMFARepo) StorePendingMFA(ctx context.Context, userUUID, secret string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchMessage", ctx, keyword, conversationUUID)
-	ret0, _ := ret[0].([]entity.SearchMessageDTO)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "StorePendingMFA", ctx, userUUID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// SearchMessage indicates an expected call of SearchMessage.
-func (mr *MockMessageMockRecorder) SearchMessage(ctx, keyword, conversationUUID interface{}) *gomock.Call {
+// StorePendingMFA indicates an expected call of StorePendingMFA.
+func (mr *MockMFARepoMockRecorder) StorePendingMFA(ctx, userUUID, secret interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMessage", reflect.TypeOf((*MockMessage)(nil).SearchMessage), ctx, keyword, conversationUUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePendingMFA", reflect.TypeOf((*MockMFARepo)(nil).StorePendingMFA), ctx, userUUID, secret)
 }
 
-// UpdateSeenStatus mocks base method.
-func (m *MockMessage) UpdateSeenStatus(ctx context.Context, seenStatus entity.SeenStatus) error {
+// UpdateLastUsedStep mocks base method.
+func (m *MockMFARepo) UpdateLastUsedStep(ctx context.Context, userUUID string, step int64) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSeenStatus", ctx, seenStatus)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateLastUsedStep", ctx, userUUID, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateSeenStatus indicates an expected call of UpdateSeenStatus.
-func (mr *MockMessageMockRecorder) UpdateSeenStatus(ctx, seenStatus interface{}) *gomock.Call {
+// UpdateLastUsedStep indicates an expected call of UpdateLastUsedStep.
+func (mr *MockMFARepoMockRecorder) UpdateLastUsedStep(ctx, userUUID, step interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeenStatus", reflect.TypeOf((*MockMessage)(nil).UpdateSeenStatus), ctx, seenStatus)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsedStep", reflect.TypeOf((*MockMFARepo)(nil).UpdateLastUsedStep), ctx, userUUID, step)
 }
 
-// MockReactionRepo is a mock of ReactionRepo interface.
-type MockReactionRepo struct {
+// MockLoginThrottle is a mock of LoginThrottle interface.
+type MockLoginThrottle struct {
 	ctrl     *gomock.Controller
-	recorder *MockReactionRepoMockRecorder
+	recorder *MockLoginThrottleMockRecorder
 }
 
-// MockReactionRepoMockRecorder is the mock recorder for MockReactionRepo.
-type MockReactionRepoMockRecorder struct {
-	mock *MockReactionRepo
+// MockLoginThrottleMockRecorder is the mock recorder for MockLoginThrottle.
+type MockLoginThrottleMockRecorder struct {
+	mock *MockLoginThrottle
 }
 
-// NewMockReactionRepo creates a new mock instance.
-func NewMockReactionRepo(ctrl *gomock.Controller) *MockReactionRepo {
-	mock := &MockReactionRepo{ctrl: ctrl}
-	mock.recorder = &MockReactionRepoMockRecorder{mock}
+// NewMockLoginThrottle creates a new mock instance.
+func NewMockLoginThrottle(ctrl *gomock.Controller) *MockLoginThrottle {
+	mock := &MockLoginThrottle{ctrl: ctrl}
+	mock.recorder = &MockLoginThrottleMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockReactionRepo) EXPECT() *MockReactionRepoMockRecorder {
+func (m *MockLoginThrottle) EXPECT() *MockLoginThrottleMockRecorder {
 	return m.recorder
 }
 
-// GetReactions mocks base method.
-func (m *MockReactionRepo) GetReactions(ctx context.Context, messageUUID string) ([]entity.GetReactionDTO, error) {
+// CheckLogin mocks base method.
+func (m *MockLoginThrottle) CheckLogin(ctx context.Context, attempt entity.LoginAttempt) (time.Duration, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReactions", ctx, messageUUID)
-	ret0, _ := ret[0].([]entity.GetReactionDTO)
+	ret := m.ctrl.Call(m, "CheckLogin", ctx, attempt)
+	ret0, _ := ret[0].(time.Duration)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetReactions indicates an expected call of GetReactions.
-func (mr *MockReactionRepoMockRecorder) GetReactions(ctx, messageUUID interface{}) *gomock.Call {
+// CheckLogin indicates an expected call of CheckLogin.
+func (mr *MockLoginThrottleMockRecorder) CheckLogin(ctx, attempt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactions", reflect.TypeOf((*MockReactionRepo)(nil).GetReactions), ctx, messageUUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLogin", reflect.TypeOf((*MockLoginThrottle)(nil).CheckLogin), ctx, attempt)
 }
 
-// RemoveReaction mocks base method.
-func (m *MockReactionRepo) RemoveReaction(ctx context.Context, rr entity.RemoveReactionDTO) error {
+// RecordFailure mocks base method.
+func (m *MockLoginThrottle) RecordFailure(ctx context.Context, attempt entity.LoginAttempt) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveReaction", ctx, rr)
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, attempt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RemoveReaction indicates an expected call of RemoveReaction.
-func (mr *MockReactionRepoMockRecorder) RemoveReaction(ctx, rr interface{}) *gomock.Call {
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockLoginThrottleMockRecorder) RecordFailure(ctx, attempt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockReactionRepo)(nil).RemoveReaction), ctx, rr)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockLoginThrottle)(nil).RecordFailure), ctx, attempt)
 }
 
-// StoreReaction mocks base method.
-func (m *MockReactionRepo) StoreReaction(ctx context.Context, srDTO entity.StoreReactionDTO) error {
+// RecordSuccess mocks base method.
+func (m *MockLoginThrottle) RecordSuccess(ctx context.Context, attempt entity.LoginAttempt) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreReaction", ctx, srDTO)
+	ret := m.ctrl.Call(m, "RecordSuccess", ctx, attempt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// StoreReaction indicates an expected call of StoreReaction.
-func (mr *MockReactionRepoMockRecorder) StoreReaction(ctx, srDTO interface{}) *gomock.Call {
+// RecordSuccess indicates an expected call of RecordSuccess.
+func (mr *MockLoginThrottleMockRecorder) RecordSuccess(ctx, attempt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreReaction", reflect.TypeOf((*MockReactionRepo)(nil).StoreReaction), ctx, srDTO)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSuccess", reflect.TypeOf((*MockLoginThrottle)(nil).RecordSuccess), ctx, attempt)
 }
 
-// MockReaction is a mock of Reaction interface.
-type MockReaction struct {
+// MockLoginAttemptRepo is a mock of LoginAttemptRepo interface.
+type MockLoginAttemptRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockReactionMockRecorder
+	recorder *MockLoginAttemptRepoMockRecorder
 }
 
-// MockReactionMockRecorder is the mock recorder for MockReaction.
-type MockReactionMockRecorder struct {
-	mock *MockReaction
+// MockLoginAttemptRepoMockRecorder is the mock recorder for MockLoginAttemptRepo.
+type MockLoginAttemptRepoMockRecorder struct {
+	mock *MockLoginAttemptRepo
 }
 
-// NewMockReaction creates a new mock instance.
-func NewMockReaction(ctrl *gomock.Controller) *MockReaction {
-	mock := &MockReaction{ctrl: ctrl}
-	mock.recorder = &MockReactionMockRecorder{mock}
+// NewMockLoginAttemptRepo creates a new mock instance.
+func NewMockLoginAttemptRepo(ctrl *gomock.Controller) *MockLoginAttemptRepo {
+	mock := &MockLoginAttemptRepo{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockReaction) EXPECT() *MockReactionMockRecorder {
+func (m *MockLoginAttemptRepo) EXPECT() *MockLoginAttemptRepoMockRecorder {
 	return m.recorder
 }
 
-// RemoveReaction mocks base method.
-func (m *MockReaction) RemoveReaction(ctx context.Context, reaction entity.Reaction) error {
+// GetLoginAttempt mocks base method.
+func (m *MockLoginAttemptRepo) GetLoginAttempt(ctx context.Context, key string) (*entity.LoginAttemptDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveReaction", ctx, reaction)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetLoginAttempt", ctx, key)
+	ret0, _ := ret[0].(*entity.LoginAttemptDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RemoveReaction indicates an expected call of RemoveReaction.
-func (mr *MockReactionMockRecorder) RemoveReaction(ctx, reaction interface{}) *gomock.Call {
+// GetLoginAttempt indicates an expected call of GetLoginAttempt.
+func (mr *MockLoginAttemptRepoMockRecorder) GetLoginAttempt(ctx, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockReaction)(nil).RemoveReaction), ctx, reaction)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginAttempt", reflect.TypeOf((*MockLoginAttemptRepo)(nil).GetLoginAttempt), ctx, key)
 }
 
-// StoreReaction mocks base method.
-func (m *MockReaction) StoreReaction(ctx context.Context, reaction entity.Reaction) error {
+// IncrementFailedLogin mocks base method.
+func (m *MockLoginAttemptRepo) IncrementFailedLogin(ctx context.Context, key string, resetBefore time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreReaction", ctx, reaction)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "IncrementFailedLogin", ctx, key, resetBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// StoreReaction indicates an expected call of StoreReaction.
-func (mr *MockReactionMockRecorder) StoreReaction(ctx, reaction interface{}) *gomock.Call {
+// IncrementFailedLogin indicates an expected call of IncrementFailedLogin.
+func (mr *MockLoginAttemptRepoMockRecorder) IncrementFailedLogin(ctx, key, resetBefore interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreReaction", reflect.TypeOf((*MockReaction)(nil).StoreReaction), ctx, reaction)
-}
-
-// MockGroupChatRepo is a mock of GroupChatRepo interface.
-type MockGroupChatRepo struct {
-	ctrl     *gomock.Controller
-	recorder *MockGroupChatRepoMockRecorder
-}
-
-// MockGroupChatRepoMockRecorder is the mock recorder for MockGroupChatRepo.
-type MockGroupChatRepoMockRecorder struct {
-	mock *MockGroupChatRepo
-}
-
-// NewMockGroupChatRepo creates a new mock instance.
-func NewMockGroupChatRepo(ctrl *gomock.Controller) *MockGroupChatRepo {
-	mock := &MockGroupChatRepo{ctrl: ctrl}
-	mock.recorder = &MockGroupChatRepoMockRecorder{mock}
-	return mock
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementFailedLogin", reflect.TypeOf((*MockLoginAttemptRepo)(nil).IncrementFailedLogin), ctx, key, resetBefore)
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockGroupChatRepo) EXPECT() *MockGroupChatRepoMockRecorder {
-	return m.recorder
-}
-
-// AddParticipants mocks base method.
-func (m *MockGroupChatRepo) AddParticipants(ctx context.Context, groupChat entity.GroupChatDTO) error {
+// LockLogin mocks base method.
+func (m *MockLoginAttemptRepo) LockLogin(ctx context.Context, key string, lockedUntil time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddParticipants", ctx, groupChat)
+	ret := m.ctrl.Call(m, "LockLogin", ctx, key, lockedUntil)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AddParticipants indicates an expected call of AddParticipants.
-func (mr *MockGroupChatRepoMockRecorder) AddParticipants(ctx, groupChat interface{}) *gomock.Call {
+// LockLogin indicates an expected call of LockLogin.
+func (mr *MockLoginAttemptRepoMockRecorder) LockLogin(ctx, key, lockedUntil interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddParticipants", reflect.TypeOf((*MockGroupChatRepo)(nil).AddParticipants), ctx, groupChat)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLogin", reflect.TypeOf((*MockLoginAttemptRepo)(nil).LockLogin), ctx, key, lockedUntil)
 }
 
-// CreateGroupChat mocks base method.
-func (m *MockGroupChatRepo) CreateGroupChat(ctx context.Context, groupChat entity.GroupChatDTO) error {
+// ResetLoginAttempts mocks base method.
+func (m *MockLoginAttemptRepo) ResetLoginAttempts(ctx context.Context, key string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateGroupChat", ctx, groupChat)
+	ret := m.ctrl.Call(m, "ResetLoginAttempts", ctx, key)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateGroupChat indicates an expected call of CreateGroupChat.
-func (mr *MockGroupChatRepoMockRecorder) CreateGroupChat(ctx, groupChat interface{}) *gomock.Call {
+// ResetLoginAttempts indicates an expected call of ResetLoginAttempts.
+func (mr *MockLoginAttemptRepoMockRecorder) ResetLoginAttempts(ctx, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupChat", reflect.TypeOf((*MockGroupChatRepo)(nil).CreateGroupChat), ctx, groupChat)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginAttempts", reflect.TypeOf((*MockLoginAttemptRepo)(nil).ResetLoginAttempts), ctx, key)
 }
 
-// RemoveParticipants mocks base method.
-func (m *MockGroupChatRepo) RemoveParticipants(ctx context.Context, groupChat entity.GroupChatDTO) error {
+// StoreLockoutAudit mocks base method.
+func (m *MockLoginAttemptRepo) StoreLockoutAudit(ctx context.Context, lockout entity.LoginLockoutDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveParticipants", ctx, groupChat)
+	ret := m.ctrl.Call(m, "StoreLockoutAudit", ctx, lockout)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RemoveParticipants indicates an expected call of RemoveParticipants.
-func (mr *MockGroupChatRepoMockRecorder) RemoveParticipants(ctx, groupChat interface{}) *gomock.Call {
+// StoreLockoutAudit indicates an expected call of StoreLockoutAudit.
+func (mr *MockLoginAttemptRepoMockRecorder) StoreLockoutAudit(ctx, lockout interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveParticipants", reflect.TypeOf((*MockGroupChatRepo)(nil).RemoveParticipants), ctx, groupChat)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreLockoutAudit", reflect.TypeOf((*MockLoginAttemptRepo)(nil).StoreLockoutAudit), ctx, lockout)
 }
 
-// UpdateGroupTitle mocks base method.
-func (m *MockGroupChatRepo) UpdateGroupTitle(ctx context.Context, groupChat entity.GroupChatDTO) error {
+// MockSSO is a mock of SSO interface.
+type MockSSO struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSOMockRecorder
+}
+
+// MockSSOMockRecorder is the mock recorder for MockSSO.
+type MockSSOMockRecorder struct {
+	mock *MockSSO
+}
+
+// NewMockSSO creates a new mock instance.
+func NewMockSSO(ctrl *gomock.Controller) *MockSSO {
+	mock := &MockSSO{ctrl: ctrl}
+	mock.recorder = &MockSSOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSO) EXPECT() *MockSSOMockRecorder {
+	return m.recorder
+}
+
+// BeginLogin mocks base method.
+func (m *MockSSO) BeginLogin(ctx context.Context) (entity.SSOLogin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGroupTitle", ctx, groupChat)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "BeginLogin", ctx)
+	ret0, _ := ret[0].(entity.SSOLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateGroupTitle indicates an expected call of UpdateGroupTitle.
-func (mr *MockGroupChatRepoMockRecorder) UpdateGroupTitle(ctx, groupChat interface{}) *gomock.Call {
+// BeginLogin indicates an expected call of BeginLogin.
+func (mr *MockSSOMockRecorder) BeginLogin(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupTitle", reflect.TypeOf((*MockGroupChatRepo)(nil).UpdateGroupTitle), ctx, groupChat)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginLogin", reflect.TypeOf((*MockSSO)(nil).BeginLogin), ctx)
 }
 
-// ValidateUserInGroupChat mocks base method.
-func (m *MockGroupChatRepo) ValidateUserInGroupChat(ctx context.Context, conversationUUID, userUUID string) (bool, error) {
+// CompleteLogin mocks base method.
+func (m *MockSSO) CompleteLogin(ctx context.Context, state, code string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateUserInGroupChat", ctx, conversationUUID, userUUID)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "CompleteLogin", ctx, state, code)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ValidateUserInGroupChat indicates an expected call of ValidateUserInGroupChat.
-func (mr *MockGroupChatRepoMockRecorder) ValidateUserInGroupChat(ctx, conversationUUID, userUUID interface{}) *gomock.Call {
+// CompleteLogin indicates an expected call of CompleteLogin.
+func (mr *MockSSOMockRecorder) CompleteLogin(ctx, state, code interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUserInGroupChat", reflect.TypeOf((*MockGroupChatRepo)(nil).ValidateUserInGroupChat), ctx, conversationUUID, userUUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteLogin", reflect.TypeOf((*MockSSO)(nil).CompleteLogin), ctx, state, code)
 }
 
-// MockGroupChat is a mock of GroupChat interface.
-type MockGroupChat struct {
+// MockIdentityProvider is a mock of IdentityProvider interface.
+type MockIdentityProvider struct {
 	ctrl     *gomock.Controller
-	recorder *MockGroupChatMockRecorder
+	recorder *MockIdentityProviderMockRecorder
 }
 
-// MockGroupChatMockRecorder is the mock recorder for MockGroupChat.
-type MockGroupChatMockRecorder struct {
-	mock *MockGroupChat
+// MockIdentityProviderMockRecorder is the mock recorder for MockIdentityProvider.
+type MockIdentityProviderMockRecorder struct {
+	mock *MockIdentityProvider
 }
 
-// NewMockGroupChat creates a new mock instance.
-func NewMockGroupChat(ctrl *gomock.Controller) *MockGroupChat {
-	mock := &MockGroupChat{ctrl: ctrl}
-	mock.recorder = &MockGroupChatMockRecorder{mock}
+// NewMockIdentityProvider creates a new mock instance.
+func NewMockIdentityProvider(ctrl *gomock.Controller) *MockIdentityProvider {
+	mock := &MockIdentityProvider{ctrl: ctrl}
+	mock.recorder = &MockIdentityProviderMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockGroupChat) EXPECT() *MockGroupChatMockRecorder {
+func (m *MockIdentityProvider) EXPECT() *MockIdentityProviderMockRecorder {
 	return m.recorder
 }
 
-// AddParticipant mocks base method.
-func (m *MockGroupChat) AddParticipant(ctx context.Context, groupChat entity.GroupChat) error {
+// AuthCodeURL mocks base method.
+func (m *MockIdentityProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddParticipant", ctx, groupChat)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "AuthCodeURL", ctx, state, nonce, codeChallenge)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL.
+func (mr *MockIdentityProviderMockRecorder) AuthCodeURL(ctx, state, nonce, codeChallenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockIdentityProvider)(nil).AuthCodeURL), ctx, state, nonce, codeChallenge)
+}
+
+// Exchange mocks base method.
+func (m *MockIdentityProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*oidc.Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, code, codeVerifier, nonce)
+	ret0, _ := ret[0].(*oidc.Claims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockIdentityProviderMockRecorder) Exchange(ctx, code, codeVerifier, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockIdentityProvider)(nil).Exchange), ctx, code, codeVerifier, nonce)
+}
+
+// MockSSORepo is a mock of SSORepo interface.
+type MockSSORepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSORepoMockRecorder
+}
+
+// MockSSORepoMockRecorder is the mock recorder for MockSSORepo.
+type MockSSORepoMockRecorder struct {
+	mock *MockSSORepo
+}
+
+// NewMockSSORepo creates a new mock instance.
+func NewMockSSORepo(ctrl *gomock.Controller) *MockSSORepo {
+	mock := &MockSSORepo{ctrl: ctrl}
+	mock.recorder = &MockSSORepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSORepo) EXPECT() *MockSSORepoMockRecorder {
+	return m.recorder
+}
+
+// ConsumeLoginState mocks base method.
+func (m *MockSSORepo) ConsumeLoginState(ctx context.Context, stateHash string) (*entity.SSOLoginStateDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeLoginState", ctx, stateHash)
+	ret0, _ := ret[0].(*entity.SSOLoginStateDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeLoginState indicates an expected call of ConsumeLoginState.
+func (mr *MockSSORepoMockRecorder) ConsumeLoginState(ctx, stateHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeLoginState", reflect.TypeOf((*MockSSORepo)(nil).ConsumeLoginState), ctx, stateHash)
+}
+
+// CreateUserWithIdentity mocks base method.
+func (m *MockSSORepo) CreateUserWithIdentity(ctx context.Context, userRegis entity.UserRegistrationDTO, identity entity.UserIdentityDTO, emailVerified bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserWithIdentity", ctx, userRegis, identity, emailVerified)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserWithIdentity indicates an expected call of CreateUserWithIdentity.
+func (mr *MockSSORepoMockRecorder) CreateUserWithIdentity(ctx, userRegis, identity, emailVerified interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserWithIdentity", reflect.TypeOf((*MockSSORepo)(nil).CreateUserWithIdentity), ctx, userRegis, identity, emailVerified)
+}
+
+// GetIdentityUserUUID mocks base method.
+func (m *MockSSORepo) GetIdentityUserUUID(ctx context.Context, issuer, subject string) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentityUserUUID", ctx, issuer, subject)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentityUserUUID indicates an expected call of GetIdentityUserUUID.
+func (mr *MockSSORepoMockRecorder) GetIdentityUserUUID(ctx, issuer, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentityUserUUID", reflect.TypeOf((*MockSSORepo)(nil).GetIdentityUserUUID), ctx, issuer, subject)
+}
+
+// LinkIdentity mocks base method.
+func (m *MockSSORepo) LinkIdentity(ctx context.Context, identity entity.UserIdentityDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkIdentity", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkIdentity indicates an expected call of LinkIdentity.
+func (mr *MockSSORepoMockRecorder) LinkIdentity(ctx, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkIdentity", reflect.TypeOf((*MockSSORepo)(nil).LinkIdentity), ctx, identity)
+}
+
+// StoreLoginState mocks base method.
+func (m *MockSSORepo) StoreLoginState(ctx context.Context, loginState entity.SSOLoginStateDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreLoginState", ctx, loginState)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreLoginState indicates an expected call of StoreLoginState.
+func (mr *MockSSORepoMockRecorder) StoreLoginState(ctx, loginState interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreLoginState", reflect.TypeOf((*MockSSORepo)(nil).StoreLoginState), ctx, loginState)
+}
+
+// MockAccountDeletion is a mock of AccountDeletion interface.
+type MockAccountDeletion struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountDeletionMockRecorder
+}
+
+// MockAccountDeletionMockRecorder is the mock recorder for MockAccountDeletion.
+type MockAccountDeletionMockRecorder struct {
+	mock *MockAccountDeletion
+}
+
+// NewMockAccountDeletion creates a new mock instance.
+func NewMockAccountDeletion(ctrl *gomock.Controller) *MockAccountDeletion {
+	mock := &MockAccountDeletion{ctrl: ctrl}
+	mock.recorder = &MockAccountDeletionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountDeletion) EXPECT() *MockAccountDeletionMockRecorder {
+	return m.recorder
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccountDeletion) DeleteAccount(ctx context.Context, userUUID, password string) (entity.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, userUUID, password)
+	ret0, _ := ret[0].(entity.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccountDeletionMockRecorder) DeleteAccount(ctx, userUUID, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccountDeletion)(nil).DeleteAccount), ctx, userUUID, password)
+}
+
+// PurgeDueAccounts mocks base method.
+func (m *MockAccountDeletion) PurgeDueAccounts(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDueAccounts", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDueAccounts indicates an expected call of PurgeDueAccounts.
+func (mr *MockAccountDeletionMockRecorder) PurgeDueAccounts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDueAccounts", reflect.TypeOf((*MockAccountDeletion)(nil).PurgeDueAccounts), ctx)
+}
+
+// MockAccountDeletionRepo is a mock of AccountDeletionRepo interface.
+type MockAccountDeletionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountDeletionRepoMockRecorder
+}
+
+// MockAccountDeletionRepoMockRecorder is the mock recorder for MockAccountDeletionRepo.
+type MockAccountDeletionRepoMockRecorder struct {
+	mock *MockAccountDeletionRepo
+}
+
+// NewMockAccountDeletionRepo creates a new mock instance.
+func NewMockAccountDeletionRepo(ctrl *gomock.Controller) *MockAccountDeletionRepo {
+	mock := &MockAccountDeletionRepo{ctrl: ctrl}
+	mock.recorder = &MockAccountDeletionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountDeletionRepo) EXPECT() *MockAccountDeletionRepoMockRecorder {
+	return m.recorder
+}
+
+// EraseUser mocks base method.
+func (m *MockAccountDeletionRepo) EraseUser(ctx context.Context, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", ctx, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockAccountDeletionRepoMockRecorder) EraseUser(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockAccountDeletionRepo)(nil).EraseUser), ctx, userUUID)
+}
+
+// GetDueDeletions mocks base method.
+func (m *MockAccountDeletionRepo) GetDueDeletions(ctx context.Context, now time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueDeletions", ctx, now)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueDeletions indicates an expected call of GetDueDeletions.
+func (mr *MockAccountDeletionRepoMockRecorder) GetDueDeletions(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueDeletions", reflect.TypeOf((*MockAccountDeletionRepo)(nil).GetDueDeletions), ctx, now)
+}
+
+// ScheduleDeletion mocks base method.
+func (m *MockAccountDeletionRepo) ScheduleDeletion(ctx context.Context, userUUID string, deleteAfter time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleDeletion", ctx, userUUID, deleteAfter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleDeletion indicates an expected call of ScheduleDeletion.
+func (mr *MockAccountDeletionRepoMockRecorder) ScheduleDeletion(ctx, userUUID, deleteAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleDeletion", reflect.TypeOf((*MockAccountDeletionRepo)(nil).ScheduleDeletion), ctx, userUUID, deleteAfter)
+}
+
+// MockDataExport is a mock of DataExport interface.
+type MockDataExport struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataExportMockRecorder
+}
+
+// MockDataExportMockRecorder is the mock recorder for MockDataExport.
+type MockDataExportMockRecorder struct {
+	mock *MockDataExport
+}
+
+// NewMockDataExport creates a new mock instance.
+func NewMockDataExport(ctrl *gomock.Controller) *MockDataExport {
+	mock := &MockDataExport{ctrl: ctrl}
+	mock.recorder = &MockDataExportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataExport) EXPECT() *MockDataExportMockRecorder {
+	return m.recorder
+}
+
+// GetExport mocks base method.
+func (m *MockDataExport) GetExport(ctx context.Context, userUUID, exportUUID string) (entity.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, userUUID, exportUUID)
+	ret0, _ := ret[0].(entity.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockDataExportMockRecorder) GetExport(ctx, userUUID, exportUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockDataExport)(nil).GetExport), ctx, userUUID, exportUUID)
+}
+
+// OpenExport mocks base method.
+func (m *MockDataExport) OpenExport(ctx context.Context, userUUID, exportUUID string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenExport", ctx, userUUID, exportUUID)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenExport indicates an expected call of OpenExport.
+func (mr *MockDataExportMockRecorder) OpenExport(ctx, userUUID, exportUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenExport", reflect.TypeOf((*MockDataExport)(nil).OpenExport), ctx, userUUID, exportUUID)
+}
+
+// PurgeExpiredExports mocks base method.
+func (m *MockDataExport) PurgeExpiredExports(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredExports", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredExports indicates an expected call of PurgeExpiredExports.
+func (mr *MockDataExportMockRecorder) PurgeExpiredExports(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredExports", reflect.TypeOf((*MockDataExport)(nil).PurgeExpiredExports), ctx)
+}
+
+// RequestExport mocks base method.
+func (m *MockDataExport) RequestExport(ctx context.Context, userUUID string) (entity.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestExport", ctx, userUUID)
+	ret0, _ := ret[0].(entity.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestExport indicates an expected call of RequestExport.
+func (mr *MockDataExportMockRecorder) RequestExport(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestExport", reflect.TypeOf((*MockDataExport)(nil).RequestExport), ctx, userUUID)
+}
+
+// MockDataExportRepo is a mock of DataExportRepo interface.
+type MockDataExportRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataExportRepoMockRecorder
+}
+
+// MockDataExportRepoMockRecorder is the mock recorder for MockDataExportRepo.
+type MockDataExportRepoMockRecorder struct {
+	mock *MockDataExportRepo
+}
+
+// NewMockDataExportRepo creates a new mock instance.
+func NewMockDataExportRepo(ctrl *gomock.Controller) *MockDataExportRepo {
+	mock := &MockDataExportRepo{ctrl: ctrl}
+	mock.recorder = &MockDataExportRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataExportRepo) EXPECT() *MockDataExportRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteExport mocks base method.
+func (m *MockDataExportRepo) DeleteExport(ctx context.Context, exportUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExport", ctx, exportUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExport indicates an expected call of DeleteExport.
+func (mr *MockDataExportRepoMockRecorder) DeleteExport(ctx, exportUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExport", reflect.TypeOf((*MockDataExportRepo)(nil).DeleteExport), ctx, exportUUID)
+}
+
+// GetExpiredExports mocks base method.
+func (m *MockDataExportRepo) GetExpiredExports(ctx context.Context, now time.Time) ([]entity.DataExportDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredExports", ctx, now)
+	ret0, _ := ret[0].([]entity.DataExportDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredExports indicates an expected call of GetExpiredExports.
+func (mr *MockDataExportRepoMockRecorder) GetExpiredExports(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredExports", reflect.TypeOf((*MockDataExportRepo)(nil).GetExpiredExports), ctx, now)
+}
+
+// GetExport mocks base method.
+func (m *MockDataExportRepo) GetExport(ctx context.Context, exportUUID string) (*entity.DataExportDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, exportUUID)
+	ret0, _ := ret[0].(*entity.DataExportDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockDataExportRepoMockRecorder) GetExport(ctx, exportUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockDataExportRepo)(nil).GetExport), ctx, exportUUID)
+}
+
+// GetExportContacts mocks base method.
+func (m *MockDataExportRepo) GetExportContacts(ctx context.Context, userUUID string) ([]entity.ExportContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExportContacts", ctx, userUUID)
+	ret0, _ := ret[0].([]entity.ExportContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExportContacts indicates an expected call of GetExportContacts.
+func (mr *MockDataExportRepoMockRecorder) GetExportContacts(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExportContacts", reflect.TypeOf((*MockDataExportRepo)(nil).GetExportContacts), ctx, userUUID)
+}
+
+// GetExportGroupMemberships mocks base method.
+func (m *MockDataExportRepo) GetExportGroupMemberships(ctx context.Context, userUUID string) ([]entity.ExportGroupMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExportGroupMemberships", ctx, userUUID)
+	ret0, _ := ret[0].([]entity.ExportGroupMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExportGroupMemberships indicates an expected call of GetExportGroupMemberships.
+func (mr *MockDataExportRepoMockRecorder) GetExportGroupMemberships(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExportGroupMemberships", reflect.TypeOf((*MockDataExportRepo)(nil).GetExportGroupMemberships), ctx, userUUID)
+}
+
+// GetExportReactions mocks base method.
+func (m *MockDataExportRepo) GetExportReactions(ctx context.Context, userUUID string) ([]entity.ExportReaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExportReactions", ctx, userUUID)
+	ret0, _ := ret[0].([]entity.ExportReaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExportReactions indicates an expected call of GetExportReactions.
+func (mr *MockDataExportRepoMockRecorder) GetExportReactions(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExportReactions", reflect.TypeOf((*MockDataExportRepo)(nil).GetExportReactions), ctx, userUUID)
+}
+
+// GetExportSeenReceipts mocks base method.
+func (m *MockDataExportRepo) GetExportSeenReceipts(ctx context.Context, userUUID string) ([]entity.ExportSeenReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExportSeenReceipts", ctx, userUUID)
+	ret0, _ := ret[0].([]entity.ExportSeenReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExportSeenReceipts indicates an expected call of GetExportSeenReceipts.
+func (mr *MockDataExportRepoMockRecorder) GetExportSeenReceipts(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExportSeenReceipts", reflect.TypeOf((*MockDataExportRepo)(nil).GetExportSeenReceipts), ctx, userUUID)
+}
+
+// GetSentMessages mocks base method.
+func (m *MockDataExportRepo) GetSentMessages(ctx context.Context, userUUID string, after *entity.ExportMessage, limit int) ([]entity.ExportMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentMessages", ctx, userUUID, after, limit)
+	ret0, _ := ret[0].([]entity.ExportMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentMessages indicates an expected call of GetSentMessages.
+func (mr *MockDataExportRepoMockRecorder) GetSentMessages(ctx, userUUID, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentMessages", reflect.TypeOf((*MockDataExportRepo)(nil).GetSentMessages), ctx, userUUID, after, limit)
+}
+
+// GetUserExports mocks base method.
+func (m *MockDataExportRepo) GetUserExports(ctx context.Context, userUUID string) ([]entity.DataExportDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserExports", ctx, userUUID)
+	ret0, _ := ret[0].([]entity.DataExportDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserExports indicates an expected call of GetUserExports.
+func (mr *MockDataExportRepoMockRecorder) GetUserExports(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserExports", reflect.TypeOf((*MockDataExportRepo)(nil).GetUserExports), ctx, userUUID)
+}
+
+// StoreExport mocks base method.
+func (m *MockDataExportRepo) StoreExport(ctx context.Context, export entity.DataExportDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreExport", ctx, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreExport indicates an expected call of StoreExport.
+func (mr *MockDataExportRepoMockRecorder) StoreExport(ctx, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreExport", reflect.TypeOf((*MockDataExportRepo)(nil).StoreExport), ctx, export)
+}
+
+// UpdateExport mocks base method.
+func (m *MockDataExportRepo) UpdateExport(ctx context.Context, export entity.DataExportDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExport", ctx, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExport indicates an expected call of UpdateExport.
+func (mr *MockDataExportRepoMockRecorder) UpdateExport(ctx, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExport", reflect.TypeOf((*MockDataExportRepo)(nil).UpdateExport), ctx, export)
+}
+
+// MockBlobStore is a mock of BlobStore interface.
+type MockBlobStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobStoreMockRecorder
+}
+
+// MockBlobStoreMockRecorder is the mock recorder for MockBlobStore.
+type MockBlobStoreMockRecorder struct {
+	mock *MockBlobStore
+}
+
+// NewMockBlobStore creates a new mock instance.
+func NewMockBlobStore(ctrl *gomock.Controller) *MockBlobStore {
+	mock := &MockBlobStore{ctrl: ctrl}
+	mock.recorder = &MockBlobStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobStore) EXPECT() *MockBlobStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlobStore) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlobStoreMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlobStore)(nil).Delete), ctx, key)
+}
+
+// Open mocks base method.
+func (m *MockBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockBlobStoreMockRecorder) Open(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockBlobStore)(nil).Open), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockBlobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockBlobStoreMockRecorder) Put(ctx, key, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockBlobStore)(nil).Put), ctx, key, r)
+}
+
+// MockBot is a mock of Bot interface.
+type MockBot struct {
+	ctrl     *gomock.Controller
+	recorder *MockBotMockRecorder
+}
+
+// MockBotMockRecorder is the mock recorder for MockBot.
+type MockBotMockRecorder struct {
+	mock *MockBot
+}
+
+// NewMockBot creates a new mock instance.
+func NewMockBot(ctrl *gomock.Controller) *MockBot {
+	mock := &MockBot{ctrl: ctrl}
+	mock.recorder = &MockBotMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBot) EXPECT() *MockBotMockRecorder {
+	return m.recorder
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockBot) AuthenticateAPIKey(ctx context.Context, key string) (entity.APIKeyPrincipal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(entity.APIKeyPrincipal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockBotMockRecorder) AuthenticateAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockBot)(nil).AuthenticateAPIKey), ctx, key)
+}
+
+// AuthorizeConversation mocks base method.
+func (m *MockBot) AuthorizeConversation(ctx context.Context, principal entity.APIKeyPrincipal, conversationUUID, scope string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeConversation", ctx, principal, conversationUUID, scope)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeConversation indicates an expected call of AuthorizeConversation.
+func (mr *MockBotMockRecorder) AuthorizeConversation(ctx, principal, conversationUUID, scope interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeConversation", reflect.TypeOf((*MockBot)(nil).AuthorizeConversation), ctx, principal, conversationUUID, scope)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockBot) CreateAPIKey(ctx context.Context, ownerUUID, botUUID string, request entity.APIKeyRequest) (entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, ownerUUID, botUUID, request)
+	ret0, _ := ret[0].(entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockBotMockRecorder) CreateAPIKey(ctx, ownerUUID, botUUID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockBot)(nil).CreateAPIKey), ctx, ownerUUID, botUUID, request)
+}
+
+// CreateBot mocks base method.
+func (m *MockBot) CreateBot(ctx context.Context, ownerUUID, name string) (entity.Bot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBot", ctx, ownerUUID, name)
+	ret0, _ := ret[0].(entity.Bot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBot indicates an expected call of CreateBot.
+func (mr *MockBotMockRecorder) CreateBot(ctx, ownerUUID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBot", reflect.TypeOf((*MockBot)(nil).CreateBot), ctx, ownerUUID, name)
+}
+
+// DeleteBot mocks base method.
+func (m *MockBot) DeleteBot(ctx context.Context, ownerUUID, botUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBot", ctx, ownerUUID, botUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBot indicates an expected call of DeleteBot.
+func (mr *MockBotMockRecorder) DeleteBot(ctx, ownerUUID, botUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBot", reflect.TypeOf((*MockBot)(nil).DeleteBot), ctx, ownerUUID, botUUID)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockBot) GetAPIKeys(ctx context.Context, ownerUUID, botUUID string) ([]entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", ctx, ownerUUID, botUUID)
+	ret0, _ := ret[0].([]entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockBotMockRecorder) GetAPIKeys(ctx, ownerUUID, botUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockBot)(nil).GetAPIKeys), ctx, ownerUUID, botUUID)
+}
+
+// GetBots mocks base method.
+func (m *MockBot) GetBots(ctx context.Context, ownerUUID string) ([]entity.Bot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBots", ctx, ownerUUID)
+	ret0, _ := ret[0].([]entity.Bot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBots indicates an expected call of GetBots.
+func (mr *MockBotMockRecorder) GetBots(ctx, ownerUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBots", reflect.TypeOf((*MockBot)(nil).GetBots), ctx, ownerUUID)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockBot) RevokeAPIKey(ctx context.Context, ownerUUID, botUUID, keyUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, ownerUUID, botUUID, keyUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockBotMockRecorder) RevokeAPIKey(ctx, ownerUUID, botUUID, keyUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockBot)(nil).RevokeAPIKey), ctx, ownerUUID, botUUID, keyUUID)
+}
+
+// MockBotRepo is a mock of BotRepo interface.
+type MockBotRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockBotRepoMockRecorder
+}
+
+// MockBotRepoMockRecorder is the mock recorder for MockBotRepo.
+type MockBotRepoMockRecorder struct {
+	mock *MockBotRepo
+}
+
+// NewMockBotRepo creates a new mock instance.
+func NewMockBotRepo(ctrl *gomock.Controller) *MockBotRepo {
+	mock := &MockBotRepo{ctrl: ctrl}
+	mock.recorder = &MockBotRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBotRepo) EXPECT() *MockBotRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateBot mocks base method.
+func (m *MockBotRepo) CreateBot(ctx context.Context, bot entity.BotDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBot", ctx, bot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBot indicates an expected call of CreateBot.
+func (mr *MockBotRepoMockRecorder) CreateBot(ctx, bot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBot", reflect.TypeOf((*MockBotRepo)(nil).CreateBot), ctx, bot)
+}
+
+// DeleteBot mocks base method.
+func (m *MockBotRepo) DeleteBot(ctx context.Context, botUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBot", ctx, botUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBot indicates an expected call of DeleteBot.
+func (mr *MockBotRepoMockRecorder) DeleteBot(ctx, botUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBot", reflect.TypeOf((*MockBotRepo)(nil).DeleteBot), ctx, botUUID)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockBotRepo) GetAPIKeyByHash(ctx context.Context, keyHash string) (*entity.APIKeyDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", ctx, keyHash)
+	ret0, _ := ret[0].(*entity.APIKeyDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockBotRepoMockRecorder) GetAPIKeyByHash(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockBotRepo)(nil).GetAPIKeyByHash), ctx, keyHash)
+}
+
+// GetBot mocks base method.
+func (m *MockBotRepo) GetBot(ctx context.Context, botUUID string) (*entity.BotDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBot", ctx, botUUID)
+	ret0, _ := ret[0].(*entity.BotDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBot indicates an expected call of GetBot.
+func (mr *MockBotRepoMockRecorder) GetBot(ctx, botUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBot", reflect.TypeOf((*MockBotRepo)(nil).GetBot), ctx, botUUID)
+}
+
+// GetBotAPIKeys mocks base method.
+func (m *MockBotRepo) GetBotAPIKeys(ctx context.Context, botUUID string) ([]entity.APIKeyDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBotAPIKeys", ctx, botUUID)
+	ret0, _ := ret[0].([]entity.APIKeyDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBotAPIKeys indicates an expected call of GetBotAPIKeys.
+func (mr *MockBotRepoMockRecorder) GetBotAPIKeys(ctx, botUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotAPIKeys", reflect.TypeOf((*MockBotRepo)(nil).GetBotAPIKeys), ctx, botUUID)
+}
+
+// GetOwnerBots mocks base method.
+func (m *MockBotRepo) GetOwnerBots(ctx context.Context, ownerUUID string) ([]entity.BotDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerBots", ctx, ownerUUID)
+	ret0, _ := ret[0].([]entity.BotDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerBots indicates an expected call of GetOwnerBots.
+func (mr *MockBotRepoMockRecorder) GetOwnerBots(ctx, ownerUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerBots", reflect.TypeOf((*MockBotRepo)(nil).GetOwnerBots), ctx, ownerUUID)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockBotRepo) RevokeAPIKey(ctx context.Context, botUUID, keyUUID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, botUUID, keyUUID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockBotRepoMockRecorder) RevokeAPIKey(ctx, botUUID, keyUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockBotRepo)(nil).RevokeAPIKey), ctx, botUUID, keyUUID)
+}
+
+// StoreAPIKey mocks base method.
+func (m *MockBotRepo) StoreAPIKey(ctx context.Context, key entity.APIKeyDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreAPIKey", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreAPIKey indicates an expected call of StoreAPIKey.
+func (mr *MockBotRepoMockRecorder) StoreAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreAPIKey", reflect.TypeOf((*MockBotRepo)(nil).StoreAPIKey), ctx, key)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockBotRepo) TouchAPIKey(ctx context.Context, keyUUID string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", ctx, keyUUID, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockBotRepoMockRecorder) TouchAPIKey(ctx, keyUUID, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockBotRepo)(nil).TouchAPIKey), ctx, keyUUID, usedAt)
+}
+
+// MockMailer is a mock of Mailer interface.
+type MockMailer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailerMockRecorder
+}
+
+// MockMailerMockRecorder is the mock recorder for MockMailer.
+type MockMailerMockRecorder struct {
+	mock *MockMailer
+}
+
+// NewMockMailer creates a new mock instance.
+func NewMockMailer(ctrl *gomock.Controller) *MockMailer {
+	mock := &MockMailer{ctrl: ctrl}
+	mock.recorder = &MockMailerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailer) EXPECT() *MockMailerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockMailer) Send(ctx context.Context, mail entity.Mail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, mail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMailerMockRecorder) Send(ctx, mail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailer)(nil).Send), ctx, mail)
+}
+
+// MockPasswordHasher is a mock of PasswordHasher interface.
+type MockPasswordHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordHasherMockRecorder
+}
+
+// MockPasswordHasherMockRecorder is the mock recorder for MockPasswordHasher.
+type MockPasswordHasherMockRecorder struct {
+	mock *MockPasswordHasher
+}
+
+// NewMockPasswordHasher creates a new mock instance.
+func NewMockPasswordHasher(ctrl *gomock.Controller) *MockPasswordHasher {
+	mock := &MockPasswordHasher{ctrl: ctrl}
+	mock.recorder = &MockPasswordHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordHasher) EXPECT() *MockPasswordHasherMockRecorder {
+	return m.recorder
+}
+
+// Hash mocks base method.
+func (m *MockPasswordHasher) Hash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockPasswordHasherMockRecorder) Hash(password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockPasswordHasher)(nil).Hash), password)
+}
+
+// NeedsRehash mocks base method.
+func (m *MockPasswordHasher) NeedsRehash(encoded string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", encoded)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockPasswordHasherMockRecorder) NeedsRehash(encoded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*MockPasswordHasher)(nil).NeedsRehash), encoded)
+}
+
+// Verify mocks base method.
+func (m *MockPasswordHasher) Verify(password, encoded string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", password, encoded)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockPasswordHasherMockRecorder) Verify(password, encoded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockPasswordHasher)(nil).Verify), password, encoded)
+}
+
+// MockSession is a mock of Session interface.
+type MockSession struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionMockRecorder
+}
+
+// MockSessionMockRecorder is the mock recorder for MockSession.
+type MockSessionMockRecorder struct {
+	mock *MockSession
+}
+
+// NewMockSession creates a new mock instance.
+func NewMockSession(ctrl *gomock.Controller) *MockSession {
+	mock := &MockSession{ctrl: ctrl}
+	mock.recorder = &MockSessionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSession) EXPECT() *MockSessionMockRecorder {
+	return m.recorder
+}
+
+// CreateSession mocks base method.
+func (m *MockSession) CreateSession(ctx context.Context, userUUID string, device entity.SessionDevice) (entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, userUUID, device)
+	ret0, _ := ret[0].(entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockSessionMockRecorder) CreateSession(ctx, userUUID, device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSession)(nil).CreateSession), ctx, userUUID, device)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockSession) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", ctx, jti)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockSessionMockRecorder) IsTokenRevoked(ctx, jti interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockSession)(nil).IsTokenRevoked), ctx, jti)
+}
+
+// ListSessions mocks base method.
+func (m *MockSession) ListSessions(ctx context.Context, userUUID, currentSessionUUID string) ([]entity.SessionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, userUUID, currentSessionUUID)
+	ret0, _ := ret[0].([]entity.SessionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockSessionMockRecorder) ListSessions(ctx, userUUID, currentSessionUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockSession)(nil).ListSessions), ctx, userUUID, currentSessionUUID)
+}
+
+// RefreshSession mocks base method.
+func (m *MockSession) RefreshSession(ctx context.Context, refreshToken string, device entity.SessionDevice) (entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession", ctx, refreshToken, device)
+	ret0, _ := ret[0].(entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockSessionMockRecorder) RefreshSession(ctx, refreshToken, device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockSession)(nil).RefreshSession), ctx, refreshToken, device)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockSession) RevokeOtherSessions(ctx context.Context, userUUID, currentSessionUUID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", ctx, userUUID, currentSessionUUID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockSessionMockRecorder) RevokeOtherSessions(ctx, userUUID, currentSessionUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockSession)(nil).RevokeOtherSessions), ctx, userUUID, currentSessionUUID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSession) RevokeSession(ctx context.Context, refreshToken string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, refreshToken)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionMockRecorder) RevokeSession(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSession)(nil).RevokeSession), ctx, refreshToken)
+}
+
+// RevokeUserSession mocks base method.
+func (m *MockSession) RevokeUserSession(ctx context.Context, userUUID, sessionUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSession", ctx, userUUID, sessionUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSession indicates an expected call of RevokeUserSession.
+func (mr *MockSessionMockRecorder) RevokeUserSession(ctx, userUUID, sessionUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSession", reflect.TypeOf((*MockSession)(nil).RevokeUserSession), ctx, userUUID, sessionUUID)
+}
+
+// MockSessionRepo is a mock of SessionRepo interface.
+type MockSessionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRepoMockRecorder
+}
+
+// MockSessionRepoMockRecorder is the mock recorder for MockSessionRepo.
+type MockSessionRepoMockRecorder struct {
+	mock *MockSessionRepo
+}
+
+// NewMockSessionRepo creates a new mock instance.
+func NewMockSessionRepo(ctrl *gomock.Controller) *MockSessionRepo {
+	mock := &MockSessionRepo{ctrl: ctrl}
+	mock.recorder = &MockSessionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRepo) EXPECT() *MockSessionRepoMockRecorder {
+	return m.recorder
+}
+
+// CheckTokenRevoked mocks base method.
+func (m *MockSessionRepo) CheckTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckTokenRevoked", ctx, jti)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckTokenRevoked indicates an expected call of CheckTokenRevoked.
+func (mr *MockSessionRepoMockRecorder) CheckTokenRevoked(ctx, jti interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTokenRevoked", reflect.TypeOf((*MockSessionRepo)(nil).CheckTokenRevoked), ctx, jti)
+}
+
+// GetActiveSessions mocks base method.
+func (m *MockSessionRepo) GetActiveSessions(ctx context.Context, userUUID string) ([]entity.SessionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSessions", ctx, userUUID)
+	ret0, _ := ret[0].([]entity.SessionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSessions indicates an expected call of GetActiveSessions.
+func (mr *MockSessionRepoMockRecorder) GetActiveSessions(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSessions", reflect.TypeOf((*MockSessionRepo)(nil).GetActiveSessions), ctx, userUUID)
+}
+
+// GetRefreshToken mocks base method.
+func (m *MockSessionRepo) GetRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshTokenDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.RefreshTokenDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshToken indicates an expected call of GetRefreshToken.
+func (mr *MockSessionRepoMockRecorder) GetRefreshToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshToken", reflect.TypeOf((*MockSessionRepo)(nil).GetRefreshToken), ctx, tokenHash)
+}
+
+// GetSession mocks base method.
+func (m *MockSessionRepo) GetSession(ctx context.Context, sessionUUID string) (*entity.SessionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, sessionUUID)
+	ret0, _ := ret[0].(*entity.SessionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockSessionRepoMockRecorder) GetSession(ctx, sessionUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockSessionRepo)(nil).GetSession), ctx, sessionUUID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionRepo) RevokeSession(ctx context.Context, sessionUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionRepoMockRecorder) RevokeSession(ctx, sessionUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionRepo)(nil).RevokeSession), ctx, sessionUUID)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockSessionRepo) RevokeUserSessions(ctx context.Context, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockSessionRepoMockRecorder) RevokeUserSessions(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockSessionRepo)(nil).RevokeUserSessions), ctx, userUUID)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockSessionRepo) RotateRefreshToken(ctx context.Context, oldTokenHash string, refreshToken entity.RefreshTokenDTO) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, oldTokenHash, refreshToken)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockSessionRepoMockRecorder) RotateRefreshToken(ctx, oldTokenHash, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockSessionRepo)(nil).RotateRefreshToken), ctx, oldTokenHash, refreshToken)
+}
+
+// StoreSession mocks base method.
+func (m *MockSessionRepo) StoreSession(ctx context.Context, session entity.SessionDTO, refreshToken entity.RefreshTokenDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSession", ctx, session, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreSession indicates an expected call of StoreSession.
+func (mr *MockSessionRepoMockRecorder) StoreSession(ctx, session, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSession", reflect.TypeOf((*MockSessionRepo)(nil).StoreSession), ctx, session, refreshToken)
+}
+
+// TouchSession mocks base method.
+func (m *MockSessionRepo) TouchSession(ctx context.Context, sessionUUID string, device entity.SessionDevice, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSession", ctx, sessionUUID, device, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSession indicates an expected call of TouchSession.
+func (mr *MockSessionRepoMockRecorder) TouchSession(ctx, sessionUUID, device, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockSessionRepo)(nil).TouchSession), ctx, sessionUUID, device, expiresAt)
+}
+
+// MockConversation is a mock of Conversation interface.
+type MockConversation struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationMockRecorder
+}
+
+// MockConversationMockRecorder is the mock recorder for MockConversation.
+type MockConversationMockRecorder struct {
+	mock *MockConversation
+}
+
+// NewMockConversation creates a new mock instance.
+func NewMockConversation(ctrl *gomock.Controller) *MockConversation {
+	mock := &MockConversation{ctrl: ctrl}
+	mock.recorder = &MockConversationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversation) EXPECT() *MockConversationMockRecorder {
+	return m.recorder
+}
+
+// GetConversationList mocks base method.
+func (m *MockConversation) GetConversationList(arg0 context.Context, arg1 entity.RequestParams) ([]entity.ConversationList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversationList", arg0, arg1)
+	ret0, _ := ret[0].([]entity.ConversationList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversationList indicates an expected call of GetConversationList.
+func (mr *MockConversationMockRecorder) GetConversationList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationList", reflect.TypeOf((*MockConversation)(nil).GetConversationList), arg0, arg1)
+}
+
+// StoreConversationAndMessage mocks base method.
+func (m *MockConversation) StoreConversationAndMessage(ctx context.Context, conv entity.Conversation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreConversationAndMessage", ctx, conv)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreConversationAndMessage indicates an expected call of StoreConversationAndMessage.
+func (mr *MockConversationMockRecorder) StoreConversationAndMessage(ctx, conv interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreConversationAndMessage", reflect.TypeOf((*MockConversation)(nil).StoreConversationAndMessage), ctx, conv)
+}
+
+// MockConversationRepo is a mock of ConversationRepo interface.
+type MockConversationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationRepoMockRecorder
+}
+
+// MockConversationRepoMockRecorder is the mock recorder for MockConversationRepo.
+type MockConversationRepoMockRecorder struct {
+	mock *MockConversationRepo
+}
+
+// NewMockConversationRepo creates a new mock instance.
+func NewMockConversationRepo(ctrl *gomock.Controller) *MockConversationRepo {
+	mock := &MockConversationRepo{ctrl: ctrl}
+	mock.recorder = &MockConversationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationRepo) EXPECT() *MockConversationRepoMockRecorder {
+	return m.recorder
+}
+
+// GetConversationList mocks base method.
+func (m *MockConversationRepo) GetConversationList(arg0 context.Context, arg1 entity.RequestParamsDTO) ([]entity.ConversationList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversationList", arg0, arg1)
+	ret0, _ := ret[0].([]entity.ConversationList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversationList indicates an expected call of GetConversationList.
+func (mr *MockConversationRepoMockRecorder) GetConversationList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationList", reflect.TypeOf((*MockConversationRepo)(nil).GetConversationList), arg0, arg1)
+}
+
+// InsertConversationAndMessage mocks base method.
+func (m *MockConversationRepo) InsertConversationAndMessage(ctx context.Context, convDTO entity.ConversationDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertConversationAndMessage", ctx, convDTO)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertConversationAndMessage indicates an expected call of InsertConversationAndMessage.
+func (mr *MockConversationRepoMockRecorder) InsertConversationAndMessage(ctx, convDTO interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertConversationAndMessage", reflect.TypeOf((*MockConversationRepo)(nil).InsertConversationAndMessage), ctx, convDTO)
+}
+
+// MockContact is a mock of Contact interface.
+type MockContact struct {
+	ctrl     *gomock.Controller
+	recorder *MockContactMockRecorder
+}
+
+// MockContactMockRecorder is the mock recorder for MockContact.
+type MockContactMockRecorder struct {
+	mock *MockContact
+}
+
+// NewMockContact creates a new mock instance.
+func NewMockContact(ctrl *gomock.Controller) *MockContact {
+	mock := &MockContact{ctrl: ctrl}
+	mock.recorder = &MockContactMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContact) EXPECT() *MockContactMockRecorder {
+	return m.recorder
+}
+
+// AcceptContactRequest mocks base method.
+func (m *MockContact) AcceptContactRequest(ctx context.Context, requestUUID, userUUID string) (entity.ContactRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptContactRequest", ctx, requestUUID, userUUID)
+	ret0, _ := ret[0].(entity.ContactRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptContactRequest indicates an expected call of AcceptContactRequest.
+func (mr *MockContactMockRecorder) AcceptContactRequest(ctx, requestUUID, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptContactRequest", reflect.TypeOf((*MockContact)(nil).AcceptContactRequest), ctx, requestUUID, userUUID)
+}
+
+// AddContact mocks base method.
+func (m *MockContact) AddContact(ctx context.Context, contactUserName, userUuid string) (entity.ContactRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddContact", ctx, contactUserName, userUuid)
+	ret0, _ := ret[0].(entity.ContactRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddContact indicates an expected call of AddContact.
+func (mr *MockContactMockRecorder) AddContact(ctx, contactUserName, userUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContact", reflect.TypeOf((*MockContact)(nil).AddContact), ctx, contactUserName, userUuid)
+}
+
+// CancelContactRequest mocks base method.
+func (m *MockContact) CancelContactRequest(ctx context.Context, requestUUID, userUUID string) (entity.ContactRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelContactRequest", ctx, requestUUID, userUUID)
+	ret0, _ := ret[0].(entity.ContactRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelContactRequest indicates an expected call of CancelContactRequest.
+func (mr *MockContactMockRecorder) CancelContactRequest(ctx, requestUUID, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelContactRequest", reflect.TypeOf((*MockContact)(nil).CancelContactRequest), ctx, requestUUID, userUUID)
+}
+
+// DeclineContactRequest mocks base method.
+func (m *MockContact) DeclineContactRequest(ctx context.Context, requestUUID, userUUID string) (entity.ContactRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineContactRequest", ctx, requestUUID, userUUID)
+	ret0, _ := ret[0].(entity.ContactRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclineContactRequest indicates an expected call of DeclineContactRequest.
+func (mr *MockContactMockRecorder) DeclineContactRequest(ctx, requestUUID, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineContactRequest", reflect.TypeOf((*MockContact)(nil).DeclineContactRequest), ctx, requestUUID, userUUID)
+}
+
+// GetContactRequests mocks base method.
+func (m *MockContact) GetContactRequests(ctx context.Context, userUUID, direction string) ([]entity.ContactRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactRequests", ctx, userUUID, direction)
+	ret0, _ := ret[0].([]entity.ContactRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactRequests indicates an expected call of GetContactRequests.
+func (mr *MockContactMockRecorder) GetContactRequests(ctx, userUUID, direction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactRequests", reflect.TypeOf((*MockContact)(nil).GetContactRequests), ctx, userUUID, direction)
+}
+
+// GetContacts mocks base method.
+func (m *MockContact) GetContacts(ctx context.Context, query entity.ContactsQuery) (entity.ContactsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContacts", ctx, query)
+	ret0, _ := ret[0].(entity.ContactsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContacts indicates an expected call of GetContacts.
+func (mr *MockContactMockRecorder) GetContacts(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockContact)(nil).GetContacts), ctx, query)
+}
+
+// ImportContacts mocks base method.
+func (m *MockContact) ImportContacts(ctx context.Context, contactImport entity.ContactImport) ([]entity.ContactMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportContacts", ctx, contactImport)
+	ret0, _ := ret[0].([]entity.ContactMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportContacts indicates an expected call of ImportContacts.
+func (mr *MockContactMockRecorder) ImportContacts(ctx, contactImport interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportContacts", reflect.TypeOf((*MockContact)(nil).ImportContacts), ctx, contactImport)
+}
+
+// RemoveContact mocks base method.
+func (m *MockContact) RemoveContact(ctx context.Context, contactUserName, userUuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveContact", ctx, contactUserName, userUuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveContact indicates an expected call of RemoveContact.
+func (mr *MockContactMockRecorder) RemoveContact(ctx, contactUserName, userUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContact", reflect.TypeOf((*MockContact)(nil).RemoveContact), ctx, contactUserName, userUuid)
+}
+
+// UpdateBlockContact mocks base method.
+func (m *MockContact) UpdateBlockContact(ctx context.Context, contactUserName, userUuid string, block bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBlockContact", ctx, contactUserName, userUuid, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBlockContact indicates an expected call of UpdateBlockContact.
+func (mr *MockContactMockRecorder) UpdateBlockContact(ctx, contactUserName, userUuid, block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBlockContact", reflect.TypeOf((*MockContact)(nil).UpdateBlockContact), ctx, contactUserName, userUuid, block)
+}
+
+// UpdateContactDetails mocks base method.
+func (m *MockContact) UpdateContactDetails(ctx context.Context, contactUserName, userUuid string, details entity.ContactDetails) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContactDetails", ctx, contactUserName, userUuid, details)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContactDetails indicates an expected call of UpdateContactDetails.
+func (mr *MockContactMockRecorder) UpdateContactDetails(ctx, contactUserName, userUuid, details interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContactDetails", reflect.TypeOf((*MockContact)(nil).UpdateContactDetails), ctx, contactUserName, userUuid, details)
+}
+
+// MockContactsRepo is a mock of ContactsRepo interface.
+type MockContactsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockContactsRepoMockRecorder
+}
+
+// MockContactsRepoMockRecorder is the mock recorder for MockContactsRepo.
+type MockContactsRepoMockRecorder struct {
+	mock *MockContactsRepo
+}
+
+// NewMockContactsRepo creates a new mock instance.
+func NewMockContactsRepo(ctrl *gomock.Controller) *MockContactsRepo {
+	mock := &MockContactsRepo{ctrl: ctrl}
+	mock.recorder = &MockContactsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContactsRepo) EXPECT() *MockContactsRepoMockRecorder {
+	return m.recorder
+}
+
+// AcceptContactRequest mocks base method.
+func (m *MockContactsRepo) AcceptContactRequest(ctx context.Context, request entity.ContactRequestDTO) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptContactRequest", ctx, request)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptContactRequest indicates an expected call of AcceptContactRequest.
+func (mr *MockContactsRepoMockRecorder) AcceptContactRequest(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptContactRequest", reflect.TypeOf((*MockContactsRepo)(nil).AcceptContactRequest), ctx, request)
+}
+
+// CheckBlocked mocks base method.
+func (m *MockContactsRepo) CheckBlocked(ctx context.Context, userUUID, otherUserUUID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckBlocked", ctx, userUUID, otherUserUUID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckBlocked indicates an expected call of CheckBlocked.
+func (mr *MockContactsRepoMockRecorder) CheckBlocked(ctx, userUUID, otherUserUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckBlocked", reflect.TypeOf((*MockContactsRepo)(nil).CheckBlocked), ctx, userUUID, otherUserUUID)
+}
+
+// CheckContactExist mocks base method.
+func (m *MockContactsRepo) CheckContactExist(ctx context.Context, userUuid, contactUserUuid string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckContactExist", ctx, userUuid, contactUserUuid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckContactExist indicates an expected call of CheckContactExist.
+func (mr *MockContactsRepoMockRecorder) CheckContactExist(ctx, userUuid, contactUserUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckContactExist", reflect.TypeOf((*MockContactsRepo)(nil).CheckContactExist), ctx, userUuid, contactUserUuid)
+}
+
+// CheckContactOfContact mocks base method.
+func (m *MockContactsRepo) CheckContactOfContact(ctx context.Context, userUUID, otherUserUUID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckContactOfContact", ctx, userUUID, otherUserUUID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckContactOfContact indicates an expected call of CheckContactOfContact.
+func (mr *MockContactsRepoMockRecorder) CheckContactOfContact(ctx, userUUID, otherUserUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckContactOfContact", reflect.TypeOf((*MockContactsRepo)(nil).CheckContactOfContact), ctx, userUUID, otherUserUUID)
+}
+
+// CheckConversationBlocked mocks base method.
+func (m *MockContactsRepo) CheckConversationBlocked(ctx context.Context, conversationUUID, userUUID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckConversationBlocked", ctx, conversationUUID, userUUID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckConversationBlocked indicates an expected call of CheckConversationBlocked.
+func (mr *MockContactsRepoMockRecorder) CheckConversationBlocked(ctx, conversationUUID, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckConversationBlocked", reflect.TypeOf((*MockContactsRepo)(nil).CheckConversationBlocked), ctx, conversationUUID, userUUID)
+}
+
+// CheckMessageBlocked mocks base method.
+func (m *MockContactsRepo) CheckMessageBlocked(ctx context.Context, messageUUID, userUUID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckMessageBlocked", ctx, messageUUID, userUUID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckMessageBlocked indicates an expected call of CheckMessageBlocked.
+func (mr *MockContactsRepoMockRecorder) CheckMessageBlocked(ctx, messageUUID, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMessageBlocked", reflect.TypeOf((*MockContactsRepo)(nil).CheckMessageBlocked), ctx, messageUUID, userUUID)
+}
+
+// GetContact mocks base method.
+func (m *MockContactsRepo) GetContact(ctx context.Context, userUUID, contactUserUUID string) (*entity.ContactsDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContact", ctx, userUUID, contactUserUUID)
+	ret0, _ := ret[0].(*entity.ContactsDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContact indicates an expected call of GetContact.
+func (mr *MockContactsRepoMockRecorder) GetContact(ctx, userUUID, contactUserUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContact", reflect.TypeOf((*MockContactsRepo)(nil).GetContact), ctx, userUUID, contactUserUUID)
+}
+
+// GetContactRequest mocks base method.
+func (m *MockContactsRepo) GetContactRequest(ctx context.Context, requestUUID string) (*entity.ContactRequestDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactRequest", ctx, requestUUID)
+	ret0, _ := ret[0].(*entity.ContactRequestDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactRequest indicates an expected call of GetContactRequest.
+func (mr *MockContactsRepoMockRecorder) GetContactRequest(ctx, requestUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactRequest", reflect.TypeOf((*MockContactsRepo)(nil).GetContactRequest), ctx, requestUUID)
+}
+
+// GetContactRequests mocks base method.
+func (m *MockContactsRepo) GetContactRequests(ctx context.Context, userUUID, direction string) ([]entity.ContactRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactRequests", ctx, userUUID, direction)
+	ret0, _ := ret[0].([]entity.ContactRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactRequests indicates an expected call of GetContactRequests.
+func (mr *MockContactsRepoMockRecorder) GetContactRequests(ctx, userUUID, direction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactRequests", reflect.TypeOf((*MockContactsRepo)(nil).GetContactRequests), ctx, userUUID, direction)
+}
+
+// GetContactUUIDs mocks base method.
+func (m *MockContactsRepo) GetContactUUIDs(ctx context.Context, userUUID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactUUIDs", ctx, userUUID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactUUIDs indicates an expected call of GetContactUUIDs.
+func (mr *MockContactsRepoMockRecorder) GetContactUUIDs(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactUUIDs", reflect.TypeOf((*MockContactsRepo)(nil).GetContactUUIDs), ctx, userUUID)
+}
+
+// GetContactsByUserUUID mocks base method.
+func (m *MockContactsRepo) GetContactsByUserUUID(ctx context.Context, query entity.ContactsQuery) ([]entity.Contacts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactsByUserUUID", ctx, query)
+	ret0, _ := ret[0].([]entity.Contacts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactsByUserUUID indicates an expected call of GetContactsByUserUUID.
+func (mr *MockContactsRepoMockRecorder) GetContactsByUserUUID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsByUserUUID", reflect.TypeOf((*MockContactsRepo)(nil).GetContactsByUserUUID), ctx, query)
+}
+
+// GetPendingContactRequest mocks base method.
+func (m *MockContactsRepo) GetPendingContactRequest(ctx context.Context, fromUserUUID, toUserUUID string) (*entity.ContactRequestDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingContactRequest", ctx, fromUserUUID, toUserUUID)
+	ret0, _ := ret[0].(*entity.ContactRequestDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingContactRequest indicates an expected call of GetPendingContactRequest.
+func (mr *MockContactsRepoMockRecorder) GetPendingContactRequest(ctx, fromUserUUID, toUserUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingContactRequest", reflect.TypeOf((*MockContactsRepo)(nil).GetPendingContactRequest), ctx, fromUserUUID, toUserUUID)
+}
+
+// MatchEmailHashes mocks base method.
+func (m *MockContactsRepo) MatchEmailHashes(ctx context.Context, userUUID, salt string, hashes []string) ([]entity.ContactMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchEmailHashes", ctx, userUUID, salt, hashes)
+	ret0, _ := ret[0].([]entity.ContactMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchEmailHashes indicates an expected call of MatchEmailHashes.
+func (mr *MockContactsRepoMockRecorder) MatchEmailHashes(ctx, userUUID, salt, hashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchEmailHashes", reflect.TypeOf((*MockContactsRepo)(nil).MatchEmailHashes), ctx, userUUID, salt, hashes)
+}
+
+// StoreContactRequest mocks base method.
+func (m *MockContactsRepo) StoreContactRequest(ctx context.Context, request entity.ContactRequestDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreContactRequest", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreContactRequest indicates an expected call of StoreContactRequest.
+func (mr *MockContactsRepoMockRecorder) StoreContactRequest(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreContactRequest", reflect.TypeOf((*MockContactsRepo)(nil).StoreContactRequest), ctx, request)
+}
+
+// StoreContactRequests mocks base method.
+func (m *MockContactsRepo) StoreContactRequests(ctx context.Context, requests []entity.ContactRequestDTO) ([]entity.ContactRequestDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreContactRequests", ctx, requests)
+	ret0, _ := ret[0].([]entity.ContactRequestDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreContactRequests indicates an expected call of StoreContactRequests.
+func (mr *MockContactsRepoMockRecorder) StoreContactRequests(ctx, requests interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreContactRequests", reflect.TypeOf((*MockContactsRepo)(nil).StoreContactRequests), ctx, requests)
+}
+
+// UpdateBlockedStatus mocks base method.
+func (m *MockContactsRepo) UpdateBlockedStatus(arg0 context.Context, arg1 entity.ContactsDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBlockedStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBlockedStatus indicates an expected call of UpdateBlockedStatus.
+func (mr *MockContactsRepoMockRecorder) UpdateBlockedStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBlockedStatus", reflect.TypeOf((*MockContactsRepo)(nil).UpdateBlockedStatus), arg0, arg1)
+}
+
+// UpdateContactDetails mocks base method.
+func (m *MockContactsRepo) UpdateContactDetails(ctx context.Context, userUUID, contactUserUUID string, details entity.ContactDetails) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContactDetails", ctx, userUUID, contactUserUUID, details)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateContactDetails indicates an expected call of UpdateContactDetails.
+func (mr *MockContactsRepoMockRecorder) UpdateContactDetails(ctx, userUUID, contactUserUUID, details interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContactDetails", reflect.TypeOf((*MockContactsRepo)(nil).UpdateContactDetails), ctx, userUUID, contactUserUUID, details)
+}
+
+// UpdateContactRequestStatus mocks base method.
+func (m *MockContactsRepo) UpdateContactRequestStatus(ctx context.Context, requestUUID, status string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContactRequestStatus", ctx, requestUUID, status)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateContactRequestStatus indicates an expected call of UpdateContactRequestStatus.
+func (mr *MockContactsRepoMockRecorder) UpdateContactRequestStatus(ctx, requestUUID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContactRequestStatus", reflect.TypeOf((*MockContactsRepo)(nil).UpdateContactRequestStatus), ctx, requestUUID, status)
+}
+
+// UpdateRemovedStatus mocks base method.
+func (m *MockContactsRepo) UpdateRemovedStatus(arg0 context.Context, arg1 entity.ContactsDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRemovedStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRemovedStatus indicates an expected call of UpdateRemovedStatus.
+func (mr *MockContactsRepoMockRecorder) UpdateRemovedStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRemovedStatus", reflect.TypeOf((*MockContactsRepo)(nil).UpdateRemovedStatus), arg0, arg1)
+}
+
+// MockMessageRepo is a mock of MessageRepo interface.
+type MockMessageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageRepoMockRecorder
+}
+
+// MockMessageRepoMockRecorder is the mock recorder for MockMessageRepo.
+type MockMessageRepoMockRecorder struct {
+	mock *MockMessageRepo
+}
+
+// NewMockMessageRepo creates a new mock instance.
+func NewMockMessageRepo(ctrl *gomock.Controller) *MockMessageRepo {
+	mock := &MockMessageRepo{ctrl: ctrl}
+	mock.recorder = &MockMessageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageRepo) EXPECT() *MockMessageRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteMessage mocks base method.
+func (m *MockMessageRepo) DeleteMessage(ctx context.Context, msg entity.MessageDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessage", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMessage indicates an expected call of DeleteMessage.
+func (mr *MockMessageRepoMockRecorder) DeleteMessage(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockMessageRepo)(nil).DeleteMessage), ctx, msg)
+}
+
+// GetMessages mocks base method.
+func (m *MockMessageRepo) GetMessages(ctx context.Context, reqParam entity.RequestParamsDTO, conversationUUID string) ([]entity.GetMessageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessages", ctx, reqParam, conversationUUID)
+	ret0, _ := ret[0].([]entity.GetMessageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessages indicates an expected call of GetMessages.
+func (mr *MockMessageRepoMockRecorder) GetMessages(ctx, reqParam, conversationUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockMessageRepo)(nil).GetMessages), ctx, reqParam, conversationUUID)
+}
+
+// GetSeenStatus mocks base method.
+func (m *MockMessageRepo) GetSeenStatus(ctx context.Context, messageUUID string) ([]entity.GetSeenStatusDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeenStatus", ctx, messageUUID)
+	ret0, _ := ret[0].([]entity.GetSeenStatusDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeenStatus indicates an expected call of GetSeenStatus.
+func (mr *MockMessageRepoMockRecorder) GetSeenStatus(ctx, messageUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeenStatus", reflect.TypeOf((*MockMessageRepo)(nil).GetSeenStatus), ctx, messageUUID)
+}
+
+// SearchMessage mocks base method.
+func (m *MockMessageRepo) SearchMessage(ctx context.Context, keyword, conversationUUID string) ([]entity.SearchMessageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMessage", ctx, keyword, conversationUUID)
+	ret0, _ := ret[0].([]entity.SearchMessageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMessage indicates an expected call of SearchMessage.
+func (mr *MockMessageRepoMockRecorder) SearchMessage(ctx, keyword, conversationUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMessage", reflect.TypeOf((*MockMessageRepo)(nil).SearchMessage), ctx, keyword, conversationUUID)
+}
+
+// UpdateSeenStatus mocks base method.
+func (m *MockMessageRepo) UpdateSeenStatus(ctx context.Context, seenStatus entity.SeenStatusDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeenStatus", ctx, seenStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSeenStatus indicates an expected call of UpdateSeenStatus.
+func (mr *MockMessageRepoMockRecorder) UpdateSeenStatus(ctx, seenStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeenStatus", reflect.TypeOf((*MockMessageRepo)(nil).UpdateSeenStatus), ctx, seenStatus)
+}
+
+// ValidateMessageSentByUser mocks base method.
+func (m *MockMessageRepo) ValidateMessageSentByUser(ctx context.Context, msg entity.MessageDTO) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateMessageSentByUser", ctx, msg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateMessageSentByUser indicates an expected call of ValidateMessageSentByUser.
+func (mr *MockMessageRepoMockRecorder) ValidateMessageSentByUser(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateMessageSentByUser", reflect.TypeOf((*MockMessageRepo)(nil).ValidateMessageSentByUser), ctx, msg)
+}
+
+// MockMessage is a mock of Message interface.
+type MockMessage struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageMockRecorder
+}
+
+// MockMessageMockRecorder is the mock recorder for MockMessage.
+type MockMessageMockRecorder struct {
+	mock *MockMessage
+}
+
+// NewMockMessage creates a new mock instance.
+func NewMockMessage(ctrl *gomock.Controller) *MockMessage {
+	mock := &MockMessage{ctrl: ctrl}
+	mock.recorder = &MockMessageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessage) EXPECT() *MockMessageMockRecorder {
+	return m.recorder
+}
+
+// DeleteMessage mocks base method.
+func (m *MockMessage) DeleteMessage(ctx context.Context, msg entity.Message) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessage", ctx, msg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMessage indicates an expected call of DeleteMessage.
+func (mr *MockMessageMockRecorder) DeleteMessage(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockMessage)(nil).DeleteMessage), ctx, msg)
+}
+
+// GetMessagesFromConversation mocks base method.
+func (m *MockMessage) GetMessagesFromConversation(ctx context.Context, reqParam entity.RequestParams, conversationUUID string) ([]entity.GetMessageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessagesFromConversation", ctx, reqParam, conversationUUID)
+	ret0, _ := ret[0].([]entity.GetMessageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessagesFromConversation indicates an expected call of GetMessagesFromConversation.
+func (mr *MockMessageMockRecorder) GetMessagesFromConversation(ctx, reqParam, conversationUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesFromConversation", reflect.TypeOf((*MockMessage)(nil).GetMessagesFromConversation), ctx, reqParam, conversationUUID)
+}
+
+// GetSeenStatus mocks base method.
+func (m *MockMessage) GetSeenStatus(ctx context.Context, userUUID, messageUUID string) ([]entity.GetSeenStatusDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeenStatus", ctx, userUUID, messageUUID)
+	ret0, _ := ret[0].([]entity.GetSeenStatusDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeenStatus indicates an expected call of GetSeenStatus.
+func (mr *MockMessageMockRecorder) GetSeenStatus(ctx, userUUID, messageUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeenStatus", reflect.TypeOf((*MockMessage)(nil).GetSeenStatus), ctx, userUUID, messageUUID)
+}
+
+// SearchMessage mocks base method.
+func (m *MockMessage) SearchMessage(ctx context.Context, keyword, conversationUUID string) ([]entity.SearchMessageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMessage", ctx, keyword, conversationUUID)
+	ret0, _ := ret[0].([]entity.SearchMessageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMessage indicates an expected call of SearchMessage.
+func (mr *MockMessageMockRecorder) SearchMessage(ctx, keyword, conversationUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMessage", reflect.TypeOf((*MockMessage)(nil).SearchMessage), ctx, keyword, conversationUUID)
+}
+
+// UpdateSeenStatus mocks base method.
+func (m *MockMessage) UpdateSeenStatus(ctx context.Context, seenStatus entity.SeenStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeenStatus", ctx, seenStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSeenStatus indicates an expected call of UpdateSeenStatus.
+func (mr *MockMessageMockRecorder) UpdateSeenStatus(ctx, seenStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeenStatus", reflect.TypeOf((*MockMessage)(nil).UpdateSeenStatus), ctx, seenStatus)
+}
+
+// MockReactionRepo is a mock of ReactionRepo interface.
+type MockReactionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReactionRepoMockRecorder
+}
+
+// MockReactionRepoMockRecorder is the mock recorder for MockReactionRepo.
+type MockReactionRepoMockRecorder struct {
+	mock *MockReactionRepo
+}
+
+// NewMockReactionRepo creates a new mock instance.
+func NewMockReactionRepo(ctrl *gomock.Controller) *MockReactionRepo {
+	mock := &MockReactionRepo{ctrl: ctrl}
+	mock.recorder = &MockReactionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReactionRepo) EXPECT() *MockReactionRepoMockRecorder {
+	return m.recorder
+}
+
+// GetReactions mocks base method.
+func (m *MockReactionRepo) GetReactions(ctx context.Context, messageUUID string) ([]entity.GetReactionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactions", ctx, messageUUID)
+	ret0, _ := ret[0].([]entity.GetReactionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactions indicates an expected call of GetReactions.
+func (mr *MockReactionRepoMockRecorder) GetReactions(ctx, messageUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactions", reflect.TypeOf((*MockReactionRepo)(nil).GetReactions), ctx, messageUUID)
+}
+
+// RemoveReaction mocks base method.
+func (m *MockReactionRepo) RemoveReaction(ctx context.Context, rr entity.RemoveReactionDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", ctx, rr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockReactionRepoMockRecorder) RemoveReaction(ctx, rr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockReactionRepo)(nil).RemoveReaction), ctx, rr)
+}
+
+// StoreReaction mocks base method.
+func (m *MockReactionRepo) StoreReaction(ctx context.Context, srDTO entity.StoreReactionDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreReaction", ctx, srDTO)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreReaction indicates an expected call of StoreReaction.
+func (mr *MockReactionRepoMockRecorder) StoreReaction(ctx, srDTO interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreReaction", reflect.TypeOf((*MockReactionRepo)(nil).StoreReaction), ctx, srDTO)
+}
+
+// MockReaction is a mock of Reaction interface.
+type MockReaction struct {
+	ctrl     *gomock.Controller
+	recorder *MockReactionMockRecorder
+}
+
+// MockReactionMockRecorder is the mock recorder for MockReaction.
+type MockReactionMockRecorder struct {
+	mock *MockReaction
+}
+
+// NewMockReaction creates a new mock instance.
+func NewMockReaction(ctrl *gomock.Controller) *MockReaction {
+	mock := &MockReaction{ctrl: ctrl}
+	mock.recorder = &MockReactionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReaction) EXPECT() *MockReactionMockRecorder {
+	return m.recorder
+}
+
+// RemoveReaction mocks base method.
+func (m *MockReaction) RemoveReaction(ctx context.Context, reaction entity.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", ctx, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockReactionMockRecorder) RemoveReaction(ctx, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockReaction)(nil).RemoveReaction), ctx, reaction)
+}
+
+// StoreReaction mocks base method.
+func (m *MockReaction) StoreReaction(ctx context.Context, reaction entity.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreReaction", ctx, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreReaction indicates an expected call of StoreReaction.
+func (mr *MockReactionMockRecorder) StoreReaction(ctx, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreReaction", reflect.TypeOf((*MockReaction)(nil).StoreReaction), ctx, reaction)
+}
+
+// MockGroupChatRepo is a mock of GroupChatRepo interface.
+type MockGroupChatRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupChatRepoMockRecorder
+}
+
+// MockGroupChatRepoMockRecorder is the mock recorder for MockGroupChatRepo.
+type MockGroupChatRepoMockRecorder struct {
+	mock *MockGroupChatRepo
+}
+
+// NewMockGroupChatRepo creates a new mock instance.
+func NewMockGroupChatRepo(ctrl *gomock.Controller) *MockGroupChatRepo {
+	mock := &MockGroupChatRepo{ctrl: ctrl}
+	mock.recorder = &MockGroupChatRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupChatRepo) EXPECT() *MockGroupChatRepoMockRecorder {
+	return m.recorder
+}
+
+// AddParticipants mocks base method.
+func (m *MockGroupChatRepo) AddParticipants(ctx context.Context, groupChat entity.GroupChatDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddParticipants", ctx, groupChat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddParticipants indicates an expected call of AddParticipants.
+func (mr *MockGroupChatRepoMockRecorder) AddParticipants(ctx, groupChat interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddParticipants", reflect.TypeOf((*MockGroupChatRepo)(nil).AddParticipants), ctx, groupChat)
+}
+
+// CreateGroupChat mocks base method.
+func (m *MockGroupChatRepo) CreateGroupChat(ctx context.Context, groupChat entity.GroupChatDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupChat", ctx, groupChat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGroupChat indicates an expected call of CreateGroupChat.
+func (mr *MockGroupChatRepoMockRecorder) CreateGroupChat(ctx, groupChat interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupChat", reflect.TypeOf((*MockGroupChatRepo)(nil).CreateGroupChat), ctx, groupChat)
+}
+
+// GetGroupPicture mocks base method.
+func (m *MockGroupChatRepo) GetGroupPicture(ctx context.Context, conversationUUID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupPicture", ctx, conversationUUID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupPicture indicates an expected call of GetGroupPicture.
+func (mr *MockGroupChatRepoMockRecorder) GetGroupPicture(ctx, conversationUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupPicture", reflect.TypeOf((*MockGroupChatRepo)(nil).GetGroupPicture), ctx, conversationUUID)
+}
+
+// RemoveParticipants mocks base method.
+func (m *MockGroupChatRepo) RemoveParticipants(ctx context.Context, groupChat entity.GroupChatDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveParticipants", ctx, groupChat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveParticipants indicates an expected call of RemoveParticipants.
+func (mr *MockGroupChatRepoMockRecorder) RemoveParticipants(ctx, groupChat interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveParticipants", reflect.TypeOf((*MockGroupChatRepo)(nil).RemoveParticipants), ctx, groupChat)
+}
+
+// UpdateGroupPicture mocks base method.
+func (m *MockGroupChatRepo) UpdateGroupPicture(ctx context.Context, conversationUUID, picture string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroupPicture", ctx, conversationUUID, picture)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGroupPicture indicates an expected call of UpdateGroupPicture.
+func (mr *MockGroupChatRepoMockRecorder) UpdateGroupPicture(ctx, conversationUUID, picture interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupPicture", reflect.TypeOf((*MockGroupChatRepo)(nil).UpdateGroupPicture), ctx, conversationUUID, picture)
+}
+
+// UpdateGroupTitle mocks base method.
+func (m *MockGroupChatRepo) UpdateGroupTitle(ctx context.Context, groupChat entity.GroupChatDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroupTitle", ctx, groupChat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGroupTitle indicates an expected call of UpdateGroupTitle.
+func (mr *MockGroupChatRepoMockRecorder) UpdateGroupTitle(ctx, groupChat interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupTitle", reflect.TypeOf((*MockGroupChatRepo)(nil).UpdateGroupTitle), ctx, groupChat)
+}
+
+// ValidateUserInGroupChat mocks base method.
+func (m *MockGroupChatRepo) ValidateUserInGroupChat(ctx context.Context, conversationUUID, userUUID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateUserInGroupChat", ctx, conversationUUID, userUUID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateUserInGroupChat indicates an expected call of ValidateUserInGroupChat.
+func (mr *MockGroupChatRepoMockRecorder) ValidateUserInGroupChat(ctx, conversationUUID, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUserInGroupChat", reflect.TypeOf((*MockGroupChatRepo)(nil).ValidateUserInGroupChat), ctx, conversationUUID, userUUID)
+}
+
+// MockGroupChat is a mock of GroupChat interface.
+type MockGroupChat struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupChatMockRecorder
+}
+
+// MockGroupChatMockRecorder is the mock recorder for MockGroupChat.
+type MockGroupChatMockRecorder struct {
+	mock *MockGroupChat
+}
+
+// NewMockGroupChat creates a new mock instance.
+func NewMockGroupChat(ctrl *gomock.Controller) *MockGroupChat {
+	mock := &MockGroupChat{ctrl: ctrl}
+	mock.recorder = &MockGroupChatMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupChat) EXPECT() *MockGroupChatMockRecorder {
+	return m.recorder
+}
+
+// AddParticipant mocks base method.
+func (m *MockGroupChat) AddParticipant(ctx context.Context, groupChat entity.GroupChat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddParticipant", ctx, groupChat)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // AddParticipant indicates an expected call of AddParticipant.
 func (mr *MockGroupChatMockRecorder) AddParticipant(ctx, groupChat interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddParticipant", reflect.TypeOf((*MockGroupChat)(nil).AddParticipant), ctx, groupChat)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddParticipant", reflect.TypeOf((*MockGroupChat)(nil).AddParticipant), ctx, groupChat)
+}
+
+// CreateGroupChat mocks base method.
+func (m *MockGroupChat) CreateGroupChat(ctx context.Context, groupChat entity.GroupChat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupChat", ctx, groupChat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGroupChat indicates an expected call of CreateGroupChat.
+func (mr *MockGroupChatMockRecorder) CreateGroupChat(ctx, groupChat interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupChat", reflect.TypeOf((*MockGroupChat)(nil).CreateGroupChat), ctx, groupChat)
+}
+
+// RemoveParticipant mocks base method.
+func (m *MockGroupChat) RemoveParticipant(ctx context.Context, groupChat entity.GroupChat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveParticipant", ctx, groupChat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveParticipant indicates an expected call of RemoveParticipant.
+func (mr *MockGroupChatMockRecorder) RemoveParticipant(ctx, groupChat interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveParticipant", reflect.TypeOf((*MockGroupChat)(nil).RemoveParticipant), ctx, groupChat)
+}
+
+// UpdateGroupTitle mocks base method.
+func (m *MockGroupChat) UpdateGroupTitle(ctx context.Context, groupChat entity.GroupChat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroupTitle", ctx, groupChat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGroupTitle indicates an expected call of UpdateGroupTitle.
+func (mr *MockGroupChatMockRecorder) UpdateGroupTitle(ctx, groupChat interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupTitle", reflect.TypeOf((*MockGroupChat)(nil).UpdateGroupTitle), ctx, groupChat)
+}
+
+// MockUserProfile is a mock of UserProfile interface.
+type MockUserProfile struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserProfileMockRecorder
+}
+
+// MockUserProfileMockRecorder is the mock recorder for MockUserProfile.
+type MockUserProfileMockRecorder struct {
+	mock *MockUserProfile
+}
+
+// NewMockUserProfile creates a new mock instance.
+func NewMockUserProfile(ctrl *gomock.Controller) *MockUserProfile {
+	mock := &MockUserProfile{ctrl: ctrl}
+	mock.recorder = &MockUserProfileMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserProfile) EXPECT() *MockUserProfileMockRecorder {
+	return m.recorder
+}
+
+// ClearExpiredStatuses mocks base method.
+func (m *MockUserProfile) ClearExpiredStatuses(ctx context.Context) ([]entity.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearExpiredStatuses", ctx)
+	ret0, _ := ret[0].([]entity.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearExpiredStatuses indicates an expected call of ClearExpiredStatuses.
+func (mr *MockUserProfileMockRecorder) ClearExpiredStatuses(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearExpiredStatuses", reflect.TypeOf((*MockUserProfile)(nil).ClearExpiredStatuses), ctx)
+}
+
+// ClearStatus mocks base method.
+func (m *MockUserProfile) ClearStatus(ctx context.Context, userUUID string) (entity.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearStatus", ctx, userUUID)
+	ret0, _ := ret[0].(entity.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearStatus indicates an expected call of ClearStatus.
+func (mr *MockUserProfileMockRecorder) ClearStatus(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearStatus", reflect.TypeOf((*MockUserProfile)(nil).ClearStatus), ctx, userUUID)
+}
+
+// GetUserProfile mocks base method.
+func (m *MockUserProfile) GetUserProfile(ctx context.Context, userUUID string) (entity.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfile", ctx, userUUID)
+	ret0, _ := ret[0].(entity.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProfile indicates an expected call of GetUserProfile.
+func (mr *MockUserProfileMockRecorder) GetUserProfile(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockUserProfile)(nil).GetUserProfile), ctx, userUUID)
+}
+
+// SetStatus mocks base method.
+func (m *MockUserProfile) SetStatus(ctx context.Context, userUUID string, status entity.UserStatus) (entity.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, userUUID, status)
+	ret0, _ := ret[0].(entity.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockUserProfileMockRecorder) SetStatus(ctx, userUUID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockUserProfile)(nil).SetStatus), ctx, userUUID, status)
+}
+
+// UpdateUserProfile mocks base method.
+func (m *MockUserProfile) UpdateUserProfile(ctx context.Context, userInfo entity.UserProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProfile", ctx, userInfo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockUserProfileMockRecorder) UpdateUserProfile(ctx, userInfo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUserProfile)(nil).UpdateUserProfile), ctx, userInfo)
+}
+
+// MockUserSearch is a mock of UserSearch interface.
+type MockUserSearch struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSearchMockRecorder
+}
+
+// MockUserSearchMockRecorder is the mock recorder for MockUserSearch.
+type MockUserSearchMockRecorder struct {
+	mock *MockUserSearch
+}
+
+// NewMockUserSearch creates a new mock instance.
+func NewMockUserSearch(ctrl *gomock.Controller) *MockUserSearch {
+	mock := &MockUserSearch{ctrl: ctrl}
+	mock.recorder = &MockUserSearchMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSearch) EXPECT() *MockUserSearchMockRecorder {
+	return m.recorder
+}
+
+// SearchUsers mocks base method.
+func (m *MockUserSearch) SearchUsers(ctx context.Context, query entity.UserSearchQuery) (entity.UserSearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUsers", ctx, query)
+	ret0, _ := ret[0].(entity.UserSearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockUserSearchMockRecorder) SearchUsers(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockUserSearch)(nil).SearchUsers), ctx, query)
+}
+
+// MockUserSearchRepo is a mock of UserSearchRepo interface.
+type MockUserSearchRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSearchRepoMockRecorder
+}
+
+// MockUserSearchRepoMockRecorder is the mock recorder for MockUserSearchRepo.
+type MockUserSearchRepoMockRecorder struct {
+	mock *MockUserSearchRepo
+}
+
+// NewMockUserSearchRepo creates a new mock instance.
+func NewMockUserSearchRepo(ctrl *gomock.Controller) *MockUserSearchRepo {
+	mock := &MockUserSearchRepo{ctrl: ctrl}
+	mock.recorder = &MockUserSearchRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSearchRepo) EXPECT() *MockUserSearchRepoMockRecorder {
+	return m.recorder
+}
+
+// SearchUsers mocks base method.
+func (m *MockUserSearchRepo) SearchUsers(ctx context.Context, query entity.UserSearchQuery) ([]entity.UserSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUsers", ctx, query)
+	ret0, _ := ret[0].([]entity.UserSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockUserSearchRepoMockRecorder) SearchUsers(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockUserSearchRepo)(nil).SearchUsers), ctx, query)
+}
+
+// MockContactSuggestion is a mock of ContactSuggestion interface.
+type MockContactSuggestion struct {
+	ctrl     *gomock.Controller
+	recorder *MockContactSuggestionMockRecorder
+}
+
+// MockContactSuggestionMockRecorder is the mock recorder for MockContactSuggestion.
+type MockContactSuggestionMockRecorder struct {
+	mock *MockContactSuggestion
+}
+
+// NewMockContactSuggestion creates a new mock instance.
+func NewMockContactSuggestion(ctrl *gomock.Controller) *MockContactSuggestion {
+	mock := &MockContactSuggestion{ctrl: ctrl}
+	mock.recorder = &MockContactSuggestionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContactSuggestion) EXPECT() *MockContactSuggestionMockRecorder {
+	return m.recorder
+}
+
+// GetSuggestions mocks base method.
+func (m *MockContactSuggestion) GetSuggestions(ctx context.Context, userUUID string, limit int) ([]entity.ContactSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuggestions", ctx, userUUID, limit)
+	ret0, _ := ret[0].([]entity.ContactSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuggestions indicates an expected call of GetSuggestions.
+func (mr *MockContactSuggestionMockRecorder) GetSuggestions(ctx, userUUID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuggestions", reflect.TypeOf((*MockContactSuggestion)(nil).GetSuggestions), ctx, userUUID, limit)
+}
+
+// MockContactSuggestionRepo is a mock of ContactSuggestionRepo interface.
+type MockContactSuggestionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockContactSuggestionRepoMockRecorder
+}
+
+// MockContactSuggestionRepoMockRecorder is the mock recorder for MockContactSuggestionRepo.
+type MockContactSuggestionRepoMockRecorder struct {
+	mock *MockContactSuggestionRepo
+}
+
+// NewMockContactSuggestionRepo creates a new mock instance.
+func NewMockContactSuggestionRepo(ctrl *gomock.Controller) *MockContactSuggestionRepo {
+	mock := &MockContactSuggestionRepo{ctrl: ctrl}
+	mock.recorder = &MockContactSuggestionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContactSuggestionRepo) EXPECT() *MockContactSuggestionRepoMockRecorder {
+	return m.recorder
+}
+
+// GetSuggestionState mocks base method.
+func (m *MockContactSuggestionRepo) GetSuggestionState(ctx context.Context, userUUID string) (*entity.ContactSuggestionState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuggestionState", ctx, userUUID)
+	ret0, _ := ret[0].(*entity.ContactSuggestionState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuggestionState indicates an expected call of GetSuggestionState.
+func (mr *MockContactSuggestionRepoMockRecorder) GetSuggestionState(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuggestionState", reflect.TypeOf((*MockContactSuggestionRepo)(nil).GetSuggestionState), ctx, userUUID)
+}
+
+// GetSuggestions mocks base method.
+func (m *MockContactSuggestionRepo) GetSuggestions(ctx context.Context, userUUID string, limit int) ([]entity.ContactSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuggestions", ctx, userUUID, limit)
+	ret0, _ := ret[0].([]entity.ContactSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuggestions indicates an expected call of GetSuggestions.
+func (mr *MockContactSuggestionRepoMockRecorder) GetSuggestions(ctx, userUUID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuggestions", reflect.TypeOf((*MockContactSuggestionRepo)(nil).GetSuggestions), ctx, userUUID, limit)
+}
+
+// RefreshSuggestions mocks base method.
+func (m *MockContactSuggestionRepo) RefreshSuggestions(ctx context.Context, userUUID string, limit int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSuggestions", ctx, userUUID, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshSuggestions indicates an expected call of RefreshSuggestions.
+func (mr *MockContactSuggestionRepoMockRecorder) RefreshSuggestions(ctx, userUUID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSuggestions", reflect.TypeOf((*MockContactSuggestionRepo)(nil).RefreshSuggestions), ctx, userUUID, limit)
+}
+
+// MockPrivacy is a mock of Privacy interface.
+type MockPrivacy struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacyMockRecorder
+}
+
+// MockPrivacyMockRecorder is the mock recorder for MockPrivacy.
+type MockPrivacyMockRecorder struct {
+	mock *MockPrivacy
+}
+
+// NewMockPrivacy creates a new mock instance.
+func NewMockPrivacy(ctrl *gomock.Controller) *MockPrivacy {
+	mock := &MockPrivacy{ctrl: ctrl}
+	mock.recorder = &MockPrivacyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacy) EXPECT() *MockPrivacyMockRecorder {
+	return m.recorder
+}
+
+// GetPrivacySettings mocks base method.
+func (m *MockPrivacy) GetPrivacySettings(ctx context.Context, userUUID string) (entity.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivacySettings", ctx, userUUID)
+	ret0, _ := ret[0].(entity.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivacySettings indicates an expected call of GetPrivacySettings.
+func (mr *MockPrivacyMockRecorder) GetPrivacySettings(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivacySettings", reflect.TypeOf((*MockPrivacy)(nil).GetPrivacySettings), ctx, userUUID)
+}
+
+// UpdatePrivacySettings mocks base method.
+func (m *MockPrivacy) UpdatePrivacySettings(ctx context.Context, userUUID string, update entity.PrivacySettingsUpdate) (entity.PrivacySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrivacySettings", ctx, userUUID, update)
+	ret0, _ := ret[0].(entity.PrivacySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePrivacySettings indicates an expected call of UpdatePrivacySettings.
+func (mr *MockPrivacyMockRecorder) UpdatePrivacySettings(ctx, userUUID, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrivacySettings", reflect.TypeOf((*MockPrivacy)(nil).UpdatePrivacySettings), ctx, userUUID, update)
+}
+
+// MockPrivacyRepo is a mock of PrivacyRepo interface.
+type MockPrivacyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacyRepoMockRecorder
+}
+
+// MockPrivacyRepoMockRecorder is the mock recorder for MockPrivacyRepo.
+type MockPrivacyRepoMockRecorder struct {
+	mock *MockPrivacyRepo
+}
+
+// NewMockPrivacyRepo creates a new mock instance.
+func NewMockPrivacyRepo(ctrl *gomock.Controller) *MockPrivacyRepo {
+	mock := &MockPrivacyRepo{ctrl: ctrl}
+	mock.recorder = &MockPrivacyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacyRepo) EXPECT() *MockPrivacyRepoMockRecorder {
+	return m.recorder
+}
+
+// GetPrivacySettings mocks base method.
+func (m *MockPrivacyRepo) GetPrivacySettings(ctx context.Context, userUUID string) (*entity.PrivacySettingsDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivacySettings", ctx, userUUID)
+	ret0, _ := ret[0].(*entity.PrivacySettingsDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivacySettings indicates an expected call of GetPrivacySettings.
+func (mr *MockPrivacyRepoMockRecorder) GetPrivacySettings(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivacySettings", reflect.TypeOf((*MockPrivacyRepo)(nil).GetPrivacySettings), ctx, userUUID)
+}
+
+// GetPrivacySettingsByUserUUIDs mocks base method.
+func (m *MockPrivacyRepo) GetPrivacySettingsByUserUUIDs(ctx context.Context, userUUIDs []string) ([]entity.PrivacySettingsDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivacySettingsByUserUUIDs", ctx, userUUIDs)
+	ret0, _ := ret[0].([]entity.PrivacySettingsDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivacySettingsByUserUUIDs indicates an expected call of GetPrivacySettingsByUserUUIDs.
+func (mr *MockPrivacyRepoMockRecorder) GetPrivacySettingsByUserUUIDs(ctx, userUUIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivacySettingsByUserUUIDs", reflect.TypeOf((*MockPrivacyRepo)(nil).GetPrivacySettingsByUserUUIDs), ctx, userUUIDs)
+}
+
+// StorePrivacySettings mocks base method.
+func (m *MockPrivacyRepo) StorePrivacySettings(ctx context.Context, settings entity.PrivacySettingsDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorePrivacySettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorePrivacySettings indicates an expected call of StorePrivacySettings.
+func (mr *MockPrivacyRepoMockRecorder) StorePrivacySettings(ctx, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePrivacySettings", reflect.TypeOf((*MockPrivacyRepo)(nil).StorePrivacySettings), ctx, settings)
+}
+
+// MockPresence is a mock of Presence interface.
+type MockPresence struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceMockRecorder
+}
+
+// MockPresenceMockRecorder is the mock recorder for MockPresence.
+type MockPresenceMockRecorder struct {
+	mock *MockPresence
+}
+
+// NewMockPresence creates a new mock instance.
+func NewMockPresence(ctrl *gomock.Controller) *MockPresence {
+	mock := &MockPresence{ctrl: ctrl}
+	mock.recorder = &MockPresenceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresence) EXPECT() *MockPresenceMockRecorder {
+	return m.recorder
+}
+
+// Connect mocks base method.
+func (m *MockPresence) Connect(ctx context.Context, userUUID string) (*entity.PresenceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Connect", ctx, userUUID)
+	ret0, _ := ret[0].(*entity.PresenceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Connect indicates an expected call of Connect.
+func (mr *MockPresenceMockRecorder) Connect(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockPresence)(nil).Connect), ctx, userUUID)
+}
+
+// Disconnect mocks base method.
+func (m *MockPresence) Disconnect(ctx context.Context, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disconnect", ctx, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disconnect indicates an expected call of Disconnect.
+func (mr *MockPresenceMockRecorder) Disconnect(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnect", reflect.TypeOf((*MockPresence)(nil).Disconnect), ctx, userUUID)
+}
+
+// GetContactsPresence mocks base method.
+func (m *MockPresence) GetContactsPresence(ctx context.Context, userUUID string) ([]entity.Presence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactsPresence", ctx, userUUID)
+	ret0, _ := ret[0].([]entity.Presence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactsPresence indicates an expected call of GetContactsPresence.
+func (mr *MockPresenceMockRecorder) GetContactsPresence(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsPresence", reflect.TypeOf((*MockPresence)(nil).GetContactsPresence), ctx, userUUID)
+}
+
+// Sweep mocks base method.
+func (m *MockPresence) Sweep(ctx context.Context) ([]entity.PresenceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sweep", ctx)
+	ret0, _ := ret[0].([]entity.PresenceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sweep indicates an expected call of Sweep.
+func (mr *MockPresenceMockRecorder) Sweep(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sweep", reflect.TypeOf((*MockPresence)(nil).Sweep), ctx)
+}
+
+// Touch mocks base method.
+func (m *MockPresence) Touch(ctx context.Context, userUUID string) (*entity.PresenceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, userUUID)
+	ret0, _ := ret[0].(*entity.PresenceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockPresenceMockRecorder) Touch(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockPresence)(nil).Touch), ctx, userUUID)
+}
+
+// MockPresenceRepo is a mock of PresenceRepo interface.
+type MockPresenceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceRepoMockRecorder
+}
+
+// MockPresenceRepoMockRecorder is the mock recorder for MockPresenceRepo.
+type MockPresenceRepoMockRecorder struct {
+	mock *MockPresenceRepo
+}
+
+// NewMockPresenceRepo creates a new mock instance.
+func NewMockPresenceRepo(ctrl *gomock.Controller) *MockPresenceRepo {
+	mock := &MockPresenceRepo{ctrl: ctrl}
+	mock.recorder = &MockPresenceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenceRepo) EXPECT() *MockPresenceRepoMockRecorder {
+	return m.recorder
+}
+
+// GetLastSeen mocks base method.
+func (m *MockPresenceRepo) GetLastSeen(ctx context.Context, userUUIDs []string) ([]entity.LastSeenDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastSeen", ctx, userUUIDs)
+	ret0, _ := ret[0].([]entity.LastSeenDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastSeen indicates an expected call of GetLastSeen.
+func (mr *MockPresenceRepoMockRecorder) GetLastSeen(ctx, userUUIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastSeen", reflect.TypeOf((*MockPresenceRepo)(nil).GetLastSeen), ctx, userUUIDs)
+}
+
+// StoreLastSeen mocks base method.
+func (m *MockPresenceRepo) StoreLastSeen(ctx context.Context, userUUID string, lastSeenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreLastSeen", ctx, userUUID, lastSeenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreLastSeen indicates an expected call of StoreLastSeen.
+func (mr *MockPresenceRepoMockRecorder) StoreLastSeen(ctx, userUUID, lastSeenAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreLastSeen", reflect.TypeOf((*MockPresenceRepo)(nil).StoreLastSeen), ctx, userUUID, lastSeenAt)
+}
+
+// MockImage is a mock of Image interface.
+type MockImage struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageMockRecorder
+}
+
+// MockImageMockRecorder is the mock recorder for MockImage.
+type MockImageMockRecorder struct {
+	mock *MockImage
+}
+
+// NewMockImage creates a new mock instance.
+func NewMockImage(ctrl *gomock.Controller) *MockImage {
+	mock := &MockImage{ctrl: ctrl}
+	mock.recorder = &MockImageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImage) EXPECT() *MockImageMockRecorder {
+	return m.recorder
+}
+
+// OpenImage mocks base method.
+func (m *MockImage) OpenImage(ctx context.Context, imageUUID string, size int) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenImage", ctx, imageUUID, size)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenImage indicates an expected call of OpenImage.
+func (mr *MockImageMockRecorder) OpenImage(ctx, imageUUID, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenImage", reflect.TypeOf((*MockImage)(nil).OpenImage), ctx, imageUUID, size)
+}
+
+// UploadAvatar mocks base method.
+func (m *MockImage) UploadAvatar(ctx context.Context, userUUID string, r io.Reader) (entity.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAvatar", ctx, userUUID, r)
+	ret0, _ := ret[0].(entity.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAvatar indicates an expected call of UploadAvatar.
+func (mr *MockImageMockRecorder) UploadAvatar(ctx, userUUID, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAvatar", reflect.TypeOf((*MockImage)(nil).UploadAvatar), ctx, userUUID, r)
+}
+
+// UploadGroupPicture mocks base method.
+func (m *MockImage) UploadGroupPicture(ctx context.Context, userUUID, conversationUUID string, r io.Reader) (entity.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadGroupPicture", ctx, userUUID, conversationUUID, r)
+	ret0, _ := ret[0].(entity.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadGroupPicture indicates an expected call of UploadGroupPicture.
+func (mr *MockImageMockRecorder) UploadGroupPicture(ctx, userUUID, conversationUUID, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadGroupPicture", reflect.TypeOf((*MockImage)(nil).UploadGroupPicture), ctx, userUUID, conversationUUID, r)
+}
+
+// MockAdmin is a mock of Admin interface.
+type MockAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminMockRecorder
+}
+
+// MockAdminMockRecorder is the mock recorder for MockAdmin.
+type MockAdminMockRecorder struct {
+	mock *MockAdmin
+}
+
+// NewMockAdmin creates a new mock instance.
+func NewMockAdmin(ctrl *gomock.Controller) *MockAdmin {
+	mock := &MockAdmin{ctrl: ctrl}
+	mock.recorder = &MockAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdmin) EXPECT() *MockAdminMockRecorder {
+	return m.recorder
+}
+
+// DeleteConversation mocks base method.
+func (m *MockAdmin) DeleteConversation(ctx context.Context, action entity.AdminAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteConversation", ctx, action)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteConversation indicates an expected call of DeleteConversation.
+func (mr *MockAdminMockRecorder) DeleteConversation(ctx, action interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConversation", reflect.TypeOf((*MockAdmin)(nil).DeleteConversation), ctx, action)
 }
 
-// CreateGroupChat mocks base method.
-func (m *MockGroupChat) CreateGroupChat(ctx context.Context, groupChat entity.GroupChat) error {
+// DeleteMessage mocks base method.
+func (m *MockAdmin) DeleteMessage(ctx context.Context, action entity.AdminAction) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateGroupChat", ctx, groupChat)
+	ret := m.ctrl.Call(m, "DeleteMessage", ctx, action)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMessage indicates an expected call of DeleteMessage.
+func (mr *MockAdminMockRecorder) DeleteMessage(ctx, action interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockAdmin)(nil).DeleteMessage), ctx, action)
+}
+
+// ForceLogout mocks base method.
+func (m *MockAdmin) ForceLogout(ctx context.Context, action entity.AdminAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceLogout", ctx, action)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateGroupChat indicates an expected call of CreateGroupChat.
-func (mr *MockGroupChatMockRecorder) CreateGroupChat(ctx, groupChat interface{}) *gomock.Call {
+// ForceLogout indicates an expected call of ForceLogout.
+func (mr *MockAdminMockRecorder) ForceLogout(ctx, action interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupChat", reflect.TypeOf((*MockGroupChat)(nil).CreateGroupChat), ctx, groupChat)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceLogout", reflect.TypeOf((*MockAdmin)(nil).ForceLogout), ctx, action)
 }
 
-// RemoveParticipant mocks base method.
-func (m *MockGroupChat) RemoveParticipant(ctx context.Context, groupChat entity.GroupChat) error {
+// GetAuditLog mocks base method.
+func (m *MockAdmin) GetAuditLog(ctx context.Context, query entity.AdminAuditQuery) (entity.AdminAuditPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveParticipant", ctx, groupChat)
+	ret := m.ctrl.Call(m, "GetAuditLog", ctx, query)
+	ret0, _ := ret[0].(entity.AdminAuditPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog.
+func (mr *MockAdminMockRecorder) GetAuditLog(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockAdmin)(nil).GetAuditLog), ctx, query)
+}
+
+// GetUserAccess mocks base method.
+func (m *MockAdmin) GetUserAccess(ctx context.Context, userUUID string) (entity.UserAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAccess", ctx, userUUID)
+	ret0, _ := ret[0].(entity.UserAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAccess indicates an expected call of GetUserAccess.
+func (mr *MockAdminMockRecorder) GetUserAccess(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAccess", reflect.TypeOf((*MockAdmin)(nil).GetUserAccess), ctx, userUUID)
+}
+
+// ListUsers mocks base method.
+func (m *MockAdmin) ListUsers(ctx context.Context, query entity.AdminUserQuery) (entity.AdminUserPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, query)
+	ret0, _ := ret[0].(entity.AdminUserPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockAdminMockRecorder) ListUsers(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAdmin)(nil).ListUsers), ctx, query)
+}
+
+// SuspendUser mocks base method.
+func (m *MockAdmin) SuspendUser(ctx context.Context, action entity.AdminAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendUser", ctx, action)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RemoveParticipant indicates an expected call of RemoveParticipant.
-func (mr *MockGroupChatMockRecorder) RemoveParticipant(ctx, groupChat interface{}) *gomock.Call {
+// SuspendUser indicates an expected call of SuspendUser.
+func (mr *MockAdminMockRecorder) SuspendUser(ctx, action interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveParticipant", reflect.TypeOf((*MockGroupChat)(nil).RemoveParticipant), ctx, groupChat)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUser", reflect.TypeOf((*MockAdmin)(nil).SuspendUser), ctx, action)
 }
 
-// UpdateGroupTitle mocks base method.
-func (m *MockGroupChat) UpdateGroupTitle(ctx context.Context, groupChat entity.GroupChat) error {
+// UnsuspendUser mocks base method.
+func (m *MockAdmin) UnsuspendUser(ctx context.Context, action entity.AdminAction) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGroupTitle", ctx, groupChat)
+	ret := m.ctrl.Call(m, "UnsuspendUser", ctx, action)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateGroupTitle indicates an expected call of UpdateGroupTitle.
-func (mr *MockGroupChatMockRecorder) UpdateGroupTitle(ctx, groupChat interface{}) *gomock.Call {
+// UnsuspendUser indicates an expected call of UnsuspendUser.
+func (mr *MockAdminMockRecorder) UnsuspendUser(ctx, action interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupTitle", reflect.TypeOf((*MockGroupChat)(nil).UpdateGroupTitle), ctx, groupChat)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsuspendUser", reflect.TypeOf((*MockAdmin)(nil).UnsuspendUser), ctx, action)
 }
 
-// MockUserProfile is a mock of UserProfile interface.
-type MockUserProfile struct {
+// MockAdminRepo is a mock of AdminRepo interface.
+type MockAdminRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockUserProfileMockRecorder
+	recorder *MockAdminRepoMockRecorder
 }
 
-// MockUserProfileMockRecorder is the mock recorder for MockUserProfile.
-type MockUserProfileMockRecorder struct {
-	mock *MockUserProfile
+// MockAdminRepoMockRecorder is the mock recorder for MockAdminRepo.
+type MockAdminRepoMockRecorder struct {
+	mock *MockAdminRepo
 }
 
-// NewMockUserProfile creates a new mock instance.
-func NewMockUserProfile(ctrl *gomock.Controller) *MockUserProfile {
-	mock := &MockUserProfile{ctrl: ctrl}
-	mock.recorder = &MockUserProfileMockRecorder{mock}
+// NewMockAdminRepo creates a new mock instance.
+func NewMockAdminRepo(ctrl *gomock.Controller) *MockAdminRepo {
+	mock := &MockAdminRepo{ctrl: ctrl}
+	mock.recorder = &MockAdminRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUserProfile) EXPECT() *MockUserProfileMockRecorder {
+func (m *MockAdminRepo) EXPECT() *MockAdminRepoMockRecorder {
 	return m.recorder
 }
 
-// GetUserProfile mocks base method.
-func (m *MockUserProfile) GetUserProfile(ctx context.Context, userUUID string) (entity.UserProfile, error) {
+// DeleteConversation mocks base method.
+func (m *MockAdminRepo) DeleteConversation(ctx context.Context, audit entity.AdminAuditDTO) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserProfile", ctx, userUUID)
-	ret0, _ := ret[0].(entity.UserProfile)
+	ret := m.ctrl.Call(m, "DeleteConversation", ctx, audit)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserProfile indicates an expected call of GetUserProfile.
-func (mr *MockUserProfileMockRecorder) GetUserProfile(ctx, userUUID interface{}) *gomock.Call {
+// DeleteConversation indicates an expected call of DeleteConversation.
+func (mr *MockAdminRepoMockRecorder) DeleteConversation(ctx, audit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockUserProfile)(nil).GetUserProfile), ctx, userUUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConversation", reflect.TypeOf((*MockAdminRepo)(nil).DeleteConversation), ctx, audit)
 }
 
-// UpdateUserProfile mocks base method.
-func (m *MockUserProfile) UpdateUserProfile(ctx context.Context, userInfo entity.UserProfile) error {
+// DeleteMessage mocks base method.
+func (m *MockAdminRepo) DeleteMessage(ctx context.Context, audit entity.AdminAuditDTO) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserProfile", ctx, userInfo)
+	ret := m.ctrl.Call(m, "DeleteMessage", ctx, audit)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMessage indicates an expected call of DeleteMessage.
+func (mr *MockAdminRepoMockRecorder) DeleteMessage(ctx, audit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockAdminRepo)(nil).DeleteMessage), ctx, audit)
+}
+
+// GetAuditLog mocks base method.
+func (m *MockAdminRepo) GetAuditLog(ctx context.Context, query entity.AdminAuditQuery) ([]entity.AdminAuditDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLog", ctx, query)
+	ret0, _ := ret[0].([]entity.AdminAuditDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog.
+func (mr *MockAdminRepoMockRecorder) GetAuditLog(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockAdminRepo)(nil).GetAuditLog), ctx, query)
+}
+
+// GetUserAccess mocks base method.
+func (m *MockAdminRepo) GetUserAccess(ctx context.Context, userUUID string) (*entity.UserAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAccess", ctx, userUUID)
+	ret0, _ := ret[0].(*entity.UserAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAccess indicates an expected call of GetUserAccess.
+func (mr *MockAdminRepoMockRecorder) GetUserAccess(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAccess", reflect.TypeOf((*MockAdminRepo)(nil).GetUserAccess), ctx, userUUID)
+}
+
+// ListUsers mocks base method.
+func (m *MockAdminRepo) ListUsers(ctx context.Context, query entity.AdminUserQuery) ([]entity.AdminUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, query)
+	ret0, _ := ret[0].([]entity.AdminUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockAdminRepoMockRecorder) ListUsers(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAdminRepo)(nil).ListUsers), ctx, query)
+}
+
+// StoreAuditEntry mocks base method.
+func (m *MockAdminRepo) StoreAuditEntry(ctx context.Context, audit entity.AdminAuditDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreAuditEntry", ctx, audit)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateUserProfile indicates an expected call of UpdateUserProfile.
-func (mr *MockUserProfileMockRecorder) UpdateUserProfile(ctx, userInfo interface{}) *gomock.Call {
+// StoreAuditEntry indicates an expected call of StoreAuditEntry.
+func (mr *MockAdminRepoMockRecorder) StoreAuditEntry(ctx, audit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUserProfile)(nil).UpdateUserProfile), ctx, userInfo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreAuditEntry", reflect.TypeOf((*MockAdminRepo)(nil).StoreAuditEntry), ctx, audit)
+}
+
+// SuspendUser mocks base method.
+func (m *MockAdminRepo) SuspendUser(ctx context.Context, audit entity.AdminAuditDTO) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendUser", ctx, audit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuspendUser indicates an expected call of SuspendUser.
+func (mr *MockAdminRepoMockRecorder) SuspendUser(ctx, audit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUser", reflect.TypeOf((*MockAdminRepo)(nil).SuspendUser), ctx, audit)
+}
+
+// UnsuspendUser mocks base method.
+func (m *MockAdminRepo) UnsuspendUser(ctx context.Context, audit entity.AdminAuditDTO) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsuspendUser", ctx, audit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnsuspendUser indicates an expected call of UnsuspendUser.
+func (mr *MockAdminRepoMockRecorder) UnsuspendUser(ctx, audit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsuspendUser", reflect.TypeOf((*MockAdminRepo)(nil).UnsuspendUser), ctx, audit)
+}
+
+// MockReport is a mock of Report interface.
+type MockReport struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportMockRecorder
+}
+
+// MockReportMockRecorder is the mock recorder for MockReport.
+type MockReportMockRecorder struct {
+	mock *MockReport
+}
+
+// NewMockReport creates a new mock instance.
+func NewMockReport(ctrl *gomock.Controller) *MockReport {
+	mock := &MockReport{ctrl: ctrl}
+	mock.recorder = &MockReportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReport) EXPECT() *MockReportMockRecorder {
+	return m.recorder
+}
+
+// GetReport mocks base method.
+func (m *MockReport) GetReport(ctx context.Context, reportUUID string) (entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", ctx, reportUUID)
+	ret0, _ := ret[0].(entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockReportMockRecorder) GetReport(ctx, reportUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockReport)(nil).GetReport), ctx, reportUUID)
+}
+
+// ListReports mocks base method.
+func (m *MockReport) ListReports(ctx context.Context, query entity.ReportQuery) (entity.ReportPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReports", ctx, query)
+	ret0, _ := ret[0].(entity.ReportPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReports indicates an expected call of ListReports.
+func (mr *MockReportMockRecorder) ListReports(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReports", reflect.TypeOf((*MockReport)(nil).ListReports), ctx, query)
+}
+
+// ReportAbuse mocks base method.
+func (m *MockReport) ReportAbuse(ctx context.Context, report entity.Report) (entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportAbuse", ctx, report)
+	ret0, _ := ret[0].(entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportAbuse indicates an expected call of ReportAbuse.
+func (mr *MockReportMockRecorder) ReportAbuse(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportAbuse", reflect.TypeOf((*MockReport)(nil).ReportAbuse), ctx, report)
+}
+
+// UpdateReportStatus mocks base method.
+func (m *MockReport) UpdateReportStatus(ctx context.Context, update entity.ReportStatusUpdate) (entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReportStatus", ctx, update)
+	ret0, _ := ret[0].(entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReportStatus indicates an expected call of UpdateReportStatus.
+func (mr *MockReportMockRecorder) UpdateReportStatus(ctx, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReportStatus", reflect.TypeOf((*MockReport)(nil).UpdateReportStatus), ctx, update)
+}
+
+// MockReportRepo is a mock of ReportRepo interface.
+type MockReportRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepoMockRecorder
+}
+
+// MockReportRepoMockRecorder is the mock recorder for MockReportRepo.
+type MockReportRepoMockRecorder struct {
+	mock *MockReportRepo
+}
+
+// NewMockReportRepo creates a new mock instance.
+func NewMockReportRepo(ctrl *gomock.Controller) *MockReportRepo {
+	mock := &MockReportRepo{ctrl: ctrl}
+	mock.recorder = &MockReportRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepo) EXPECT() *MockReportRepoMockRecorder {
+	return m.recorder
+}
+
+// CheckParticipant mocks base method.
+func (m *MockReportRepo) CheckParticipant(ctx context.Context, conversationUUID, userUUID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckParticipant", ctx, conversationUUID, userUUID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckParticipant indicates an expected call of CheckParticipant.
+func (mr *MockReportRepoMockRecorder) CheckParticipant(ctx, conversationUUID, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckParticipant", reflect.TypeOf((*MockReportRepo)(nil).CheckParticipant), ctx, conversationUUID, userUUID)
+}
+
+// CheckUserExists mocks base method.
+func (m *MockReportRepo) CheckUserExists(ctx context.Context, userUUID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckUserExists", ctx, userUUID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckUserExists indicates an expected call of CheckUserExists.
+func (mr *MockReportRepoMockRecorder) CheckUserExists(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserExists", reflect.TypeOf((*MockReportRepo)(nil).CheckUserExists), ctx, userUUID)
+}
+
+// GetReport mocks base method.
+func (m *MockReportRepo) GetReport(ctx context.Context, reportUUID string) (*entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", ctx, reportUUID)
+	ret0, _ := ret[0].(*entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockReportRepoMockRecorder) GetReport(ctx, reportUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockReportRepo)(nil).GetReport), ctx, reportUUID)
+}
+
+// GetReportedMessage mocks base method.
+func (m *MockReportRepo) GetReportedMessage(ctx context.Context, messageUUID string) (*entity.ReportedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportedMessage", ctx, messageUUID)
+	ret0, _ := ret[0].(*entity.ReportedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportedMessage indicates an expected call of GetReportedMessage.
+func (mr *MockReportRepoMockRecorder) GetReportedMessage(ctx, messageUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportedMessage", reflect.TypeOf((*MockReportRepo)(nil).GetReportedMessage), ctx, messageUUID)
+}
+
+// ListReports mocks base method.
+func (m *MockReportRepo) ListReports(ctx context.Context, query entity.ReportQuery) ([]entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReports", ctx, query)
+	ret0, _ := ret[0].([]entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReports indicates an expected call of ListReports.
+func (mr *MockReportRepoMockRecorder) ListReports(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReports", reflect.TypeOf((*MockReportRepo)(nil).ListReports), ctx, query)
+}
+
+// StoreReport mocks base method.
+func (m *MockReportRepo) StoreReport(ctx context.Context, report entity.Report) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreReport", ctx, report)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreReport indicates an expected call of StoreReport.
+func (mr *MockReportRepoMockRecorder) StoreReport(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreReport", reflect.TypeOf((*MockReportRepo)(nil).StoreReport), ctx, report)
+}
+
+// UpdateReportStatus mocks base method.
+func (m *MockReportRepo) UpdateReportStatus(ctx context.Context, report entity.Report, fromStatus string, audit entity.AdminAuditDTO) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReportStatus", ctx, report, fromStatus, audit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReportStatus indicates an expected call of UpdateReportStatus.
+func (mr *MockReportRepoMockRecorder) UpdateReportStatus(ctx, report, fromStatus, audit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReportStatus", reflect.TypeOf((*MockReportRepo)(nil).UpdateReportStatus), ctx, report, fromStatus, audit)
 }
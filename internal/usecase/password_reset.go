@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+type PasswordResetUseCase struct {
+	userRepo    UserRepo
+	tokenRepo   UserTokenRepo
+	sessionRepo SessionRepo
+	mailer      Mailer
+	hasher      PasswordHasher
+	publicURL   string
+	tokenTTL    time.Duration
+	// Reset emails are limited per email and per client IP
+	emailLimiter *rateLimiter
+	ipLimiter    *rateLimiter
+	now          func() time.Time
+}
+
+// NewPasswordReset -.
+// Reset emails can be requested rateLimit times per rateWindow for each email, and rateLimitPerIP times for each client IP.
+// A limit of zero disables it.
+func NewPasswordReset(u UserRepo, t UserTokenRepo, s SessionRepo, m Mailer, h PasswordHasher, publicURL string, tokenTTL time.Duration,
+	rateLimit int, rateLimitPerIP int, rateWindow time.Duration) *PasswordResetUseCase {
+	return &PasswordResetUseCase{
+		userRepo:     u,
+		tokenRepo:    t,
+		sessionRepo:  s,
+		mailer:       m,
+		hasher:       h,
+		publicURL:    publicURL,
+		tokenTTL:     tokenTTL,
+		emailLimiter: newRateLimiter(rateLimit, rateWindow),
+		ipLimiter:    newRateLimiter(rateLimitPerIP, rateWindow),
+		now:          time.Now,
+	}
+}
+
+func (uc *PasswordResetUseCase) RequestPasswordReset(ctx context.Context, request entity.PasswordResetRequest) error {
+	// Return error if the email or the client IP asked too often. Unknown emails are counted as well,
+	// so that the limit does not tell who has an account. Will be handled by controller
+	now := uc.now()
+	emailAllowed := uc.emailLimiter.allow(strings.ToLower(request.Email), now)
+	ipAllowed := uc.ipLimiter.allow(request.IP, now)
+	if !emailAllowed || !ipAllowed {
+		return entity.ErrTooManyRequests
+	}
+
+	// Get user credentials from user data repository by querying 'user_credentials' table
+	userInfo, err := uc.userRepo.GetUserCredentials(ctx, entity.UserCredentialsDTO{Email: request.Email})
+	if err != nil {
+		return fmt.Errorf("PasswordResetUseCase - RequestPasswordReset - uc.userRepo.GetUserCredentials: %w", err)
+	}
+
+	// Unknown emails are silently ignored so the endpoint cannot be used to find out who has an account
+	if userInfo == nil {
+		return nil
+	}
+
+	// Issue a reset token, only its hash is stored
+	token, err := issueUserToken(ctx, uc.tokenRepo, userInfo.UserUuid, entity.TokenPurposePasswordReset, "", uc.tokenTTL)
+	if err != nil {
+		return fmt.Errorf("PasswordResetUseCase - RequestPasswordReset - issueUserToken: %w", err)
+	}
+
+	// Send the reset link to the user
+	err = uc.mailer.Send(ctx, entity.Mail{
+		To:      userInfo.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to choose a new password. It expires in %s.\n\n%s/reset-password?token=%s\n\n"+
+			"If you did not ask for a password reset, you can ignore this email.\n",
+			userInfo.Username, uc.tokenTTL, uc.publicURL, url.QueryEscape(token)),
+	})
+	if err != nil {
+		return fmt.Errorf("PasswordResetUseCase - RequestPasswordReset - uc.mailer.Send: %w", err)
+	}
+	return nil
+}
+
+func (uc *PasswordResetUseCase) ResetPassword(ctx context.Context, passwordReset entity.PasswordReset) error {
+	// Hash password before storing into database
+	hashedPassword, err := uc.hasher.Hash(passwordReset.NewPassword)
+	if err != nil {
+		return fmt.Errorf("PasswordResetUseCase - ResetPassword - uc.hasher.Hash: %w", err)
+	}
+
+	// Mark reset token as used and update password in 'user_tokens' and 'user_credentials' tables in one transaction,
+	// a token can only be consumed once and stays valid if the password could not be updated
+	userToken, err := uc.tokenRepo.ResetPassword(ctx, hashToken(passwordReset.Token), hashedPassword)
+	if err != nil {
+		return fmt.Errorf("PasswordResetUseCase - ResetPassword - uc.tokenRepo.ResetPassword: %w", err)
+	}
+
+	// Return error if token is unknown, already used or expired. Will be handled by controller
+	if userToken == nil {
+		return entity.ErrInvalidResetToken
+	}
+
+	// Whoever knew the old password must not stay logged in
+	err = uc.sessionRepo.RevokeUserSessions(ctx, userToken.UserUUID)
+	if err != nil {
+		return fmt.Errorf("PasswordResetUseCase - ResetPassword - uc.sessionRepo.RevokeUserSessions: %w", err)
+	}
+	return nil
+}
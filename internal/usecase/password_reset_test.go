@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/internal/usecase/mailer"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/password"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordResetUseCase_RequestPasswordReset(t *testing.T) {
+	// Captures the stored token so it can be compared with the one that was mailed
+	var storedToken entity.UserTokenDTO
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                                                         // Name of the test case
+		setupMocks func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) // Function to set up mock behavior
+		wantMail   bool                                                                           // Whether a reset mail is expected to be sent
+		wantErr    bool                                                                           // Whether an error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - reset mail sent",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) {
+				mockUserRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Email: "test@example.com"}).
+					Return(&entity.UserCredentialsDTO{UserUuid: testUserUUID, Username: "testuser", Email: "test@example.com"}, nil)
+				mockTokenRepo.EXPECT().
+					StoreUserToken(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, userToken entity.UserTokenDTO) { storedToken = userToken }).
+					Return(nil)
+			},
+			wantMail: true,
+			wantErr:  false,
+		},
+		{
+			name: "success - unknown email is ignored",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) {
+				mockUserRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Email: "test@example.com"}).
+					Return(nil, nil)
+			},
+			wantMail: false,
+			wantErr:  false,
+		},
+		{
+			name: "error storing reset token",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo) {
+				mockUserRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Email: "test@example.com"}).
+					Return(&entity.UserCredentialsDTO{UserUuid: testUserUUID, Email: "test@example.com"}, nil)
+				mockTokenRepo.EXPECT().
+					StoreUserToken(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("some error"))
+			},
+			wantMail: false,
+			wantErr:  true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories and an in-memory mailer
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			mockTokenRepo := mocks.NewMockUserTokenRepo(ctrl)
+			memoryMailer := mailer.NewMemory()
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockUserRepo, mockTokenRepo)
+			}
+
+			uc := NewPasswordReset(mockUserRepo, mockTokenRepo, nil, memoryMailer, nil, "http://localhost", time.Hour, 0, 0, 0)
+
+			// Call the method under test
+			err := uc.RequestPasswordReset(context.Background(), entity.PasswordResetRequest{Email: "test@example.com", IP: "192.0.2.1"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PasswordResetUseCase.RequestPasswordReset() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			sent := memoryMailer.Sent()
+			if (len(sent) == 1) != tt.wantMail {
+				t.Fatalf("PasswordResetUseCase.RequestPasswordReset() sent %d mails, wantMail %v", len(sent), tt.wantMail)
+			}
+			if !tt.wantMail {
+				return
+			}
+
+			// Only the hash of the mailed token may be stored
+			link := sent[0].Body[strings.Index(sent[0].Body, "http://localhost/reset-password?token="):]
+			link = strings.Fields(link)[0]
+			parsed, _ := url.Parse(link)
+			token := parsed.Query().Get("token")
+			if storedToken.TokenHash != hashToken(token) || storedToken.Purpose != entity.TokenPurposePasswordReset {
+				t.Errorf("PasswordResetUseCase.RequestPasswordReset() stored %+v, want hash of mailed token", storedToken)
+			}
+		})
+	}
+}
+
+func TestPasswordResetUseCase_RequestPasswordResetRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Every email is unknown, the limit must not depend on whether the email belongs to an account
+	mockUserRepo := mocks.NewMockUserRepo(ctrl)
+	mockUserRepo.EXPECT().GetUserCredentials(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	// One email per email address and three per client IP
+	uc := NewPasswordReset(mockUserRepo, nil, nil, nil, nil, "http://localhost", time.Hour, 1, 3, time.Hour)
+
+	requests := []struct {
+		request entity.PasswordResetRequest
+		wantErr error
+	}{
+		{entity.PasswordResetRequest{Email: "one@example.com", IP: "192.0.2.1"}, nil},
+		{entity.PasswordResetRequest{Email: "ONE@example.com", IP: "192.0.2.2"}, entity.ErrTooManyRequests},
+		{entity.PasswordResetRequest{Email: "two@example.com", IP: "192.0.2.1"}, nil},
+		{entity.PasswordResetRequest{Email: "three@example.com", IP: "192.0.2.1"}, nil},
+		{entity.PasswordResetRequest{Email: "four@example.com", IP: "192.0.2.1"}, entity.ErrTooManyRequests},
+		{entity.PasswordResetRequest{Email: "five@example.com", IP: "192.0.2.2"}, nil},
+	}
+	for i, r := range requests {
+		err := uc.RequestPasswordReset(context.Background(), r.request)
+		if err != r.wantErr {
+			t.Errorf("PasswordResetUseCase.RequestPasswordReset() request %d error = %v, want %v", i, err, r.wantErr)
+		}
+	}
+}
+
+func TestPasswordResetUseCase_ResetPassword(t *testing.T) {
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                                                                                                 // Name of the test case
+		setupMocks func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo, mockSessionRepo *mocks.MockSessionRepo) // Function to set up mock behavior
+		wantErr    error                                                                                                                  // Expected sentinel error, if any
+		wantAnyErr bool                                                                                                                   // Whether any error is expected
+	}
+
+	passwordReset := entity.PasswordReset{Token: "reset_token", NewPassword: "newpassword123"}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - password changed and sessions revoked",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo, mockSessionRepo *mocks.MockSessionRepo) {
+				mockTokenRepo.EXPECT().
+					ResetPassword(gomock.Any(), hashToken("reset_token"), gomock.Any()).
+					Do(func(_ context.Context, _ string, hashedPassword string) {
+						// The token is consumed together with the hash of the new password, never with the password itself
+						if bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte("newpassword123")) != nil {
+							t.Errorf("PasswordResetUseCase.ResetPassword() stored %q", hashedPassword)
+						}
+					}).
+					Return(&entity.UserTokenDTO{UserUUID: testUserUUID}, nil)
+				mockSessionRepo.EXPECT().
+					RevokeUserSessions(gomock.Any(), testUserUUID).
+					Return(nil)
+			},
+		},
+		{
+			name: "error - used or expired token",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo, mockSessionRepo *mocks.MockSessionRepo) {
+				mockTokenRepo.EXPECT().
+					ResetPassword(gomock.Any(), hashToken("reset_token"), gomock.Any()).
+					Return(nil, nil)
+			},
+			wantErr:    entity.ErrInvalidResetToken,
+			wantAnyErr: true,
+		},
+		{
+			name: "error updating password",
+			setupMocks: func(mockUserRepo *mocks.MockUserRepo, mockTokenRepo *mocks.MockUserTokenRepo, mockSessionRepo *mocks.MockSessionRepo) {
+				// The repository rolls back the consumed token, and the sessions are kept
+				mockTokenRepo.EXPECT().
+					ResetPassword(gomock.Any(), hashToken("reset_token"), gomock.Any()).
+					Return(nil, fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			mockTokenRepo := mocks.NewMockUserTokenRepo(ctrl)
+			mockSessionRepo := mocks.NewMockSessionRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockUserRepo, mockTokenRepo, mockSessionRepo)
+			}
+
+			uc := &PasswordResetUseCase{userRepo: mockUserRepo, tokenRepo: mockTokenRepo, sessionRepo: mockSessionRepo, hasher: password.NewBcrypt(bcrypt.MinCost)}
+
+			// Call the method under test
+			err := uc.ResetPassword(context.Background(), passwordReset)
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("PasswordResetUseCase.ResetPassword() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("PasswordResetUseCase.ResetPassword() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
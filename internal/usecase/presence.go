@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// _lastSeenStoreInterval limits how often the activity of a connected user is stored as their last seen time
+const _lastSeenStoreInterval = time.Minute
+
+// PresenceUseCase keeps the presence of the users connected to this server in memory.
+// Users are online from their first websocket connection, away once they have been idle for awayAfter,
+// and offline once their last connection has been closed for offlineAfter, so that reconnecting does not flicker.
+// Users who hide their presence always appear offline to their contacts, without a last seen time.
+type PresenceUseCase struct {
+	repo         PresenceRepo
+	contactsRepo ContactsRepo
+	privacyRepo  PrivacyRepo
+	awayAfter    time.Duration
+	offlineAfter time.Duration
+	now          func() time.Time
+
+	mu    sync.Mutex
+	users map[string]*presenceState
+}
+
+type presenceState struct {
+	status         string
+	connections    int
+	lastActiveAt   time.Time
+	disconnectedAt time.Time
+	lastStoredAt   time.Time
+}
+
+// NewPresence -.
+func NewPresence(r PresenceRepo, contactsRepo ContactsRepo, privacyRepo PrivacyRepo, awayAfter, offlineAfter time.Duration) *PresenceUseCase {
+	return &PresenceUseCase{
+		repo:         r,
+		contactsRepo: contactsRepo,
+		privacyRepo:  privacyRepo,
+		awayAfter:    awayAfter,
+		offlineAfter: offlineAfter,
+		now:          time.Now,
+		users:        make(map[string]*presenceState),
+	}
+}
+
+// Connect registers a new websocket connection of the user.
+// It returns the change to push to their contacts, or nil if they were already online.
+func (uc *PresenceUseCase) Connect(ctx context.Context, userUUID string) (*entity.PresenceChange, error) {
+	now := uc.now()
+
+	uc.mu.Lock()
+	state, ok := uc.users[userUUID]
+	if !ok {
+		state = &presenceState{status: entity.PresenceOffline}
+		uc.users[userUUID] = state
+	}
+	state.connections++
+	state.lastActiveAt = now
+	changed := state.status != entity.PresenceOnline
+	state.status = entity.PresenceOnline
+	state.lastStoredAt = now
+	uc.mu.Unlock()
+
+	// Store the time into 'user_presence' table using presence data repository
+	err := uc.repo.StoreLastSeen(ctx, userUUID, now)
+	if err != nil {
+		return nil, fmt.Errorf("PresenceUseCase - Connect - uc.repo.StoreLastSeen: %w", err)
+	}
+
+	if !changed {
+		return nil, nil
+	}
+	return uc.buildChange(ctx, entity.Presence{UserUUID: userUUID, Status: entity.PresenceOnline})
+}
+
+// Disconnect unregisters a websocket connection of the user.
+// The user stays in their current status until Sweep finds their grace period has ended.
+func (uc *PresenceUseCase) Disconnect(ctx context.Context, userUUID string) error {
+	now := uc.now()
+
+	uc.mu.Lock()
+	state, ok := uc.users[userUUID]
+	if !ok || state.connections == 0 {
+		uc.mu.Unlock()
+		return nil
+	}
+	state.connections--
+	if state.connections == 0 {
+		state.disconnectedAt = now
+	}
+	state.lastStoredAt = now
+	uc.mu.Unlock()
+
+	// Store the time into 'user_presence' table using presence data repository
+	err := uc.repo.StoreLastSeen(ctx, userUUID, now)
+	if err != nil {
+		return fmt.Errorf("PresenceUseCase - Disconnect - uc.repo.StoreLastSeen: %w", err)
+	}
+	return nil
+}
+
+// Touch records an activity of a connected user.
+// It returns the change to push to their contacts if they were away, or nil.
+func (uc *PresenceUseCase) Touch(ctx context.Context, userUUID string) (*entity.PresenceChange, error) {
+	now := uc.now()
+
+	uc.mu.Lock()
+	state, ok := uc.users[userUUID]
+	if !ok || state.connections == 0 {
+		uc.mu.Unlock()
+		return nil, nil
+	}
+	state.lastActiveAt = now
+	changed := state.status != entity.PresenceOnline
+	state.status = entity.PresenceOnline
+	store := now.Sub(state.lastStoredAt) >= _lastSeenStoreInterval
+	if store {
+		state.lastStoredAt = now
+	}
+	uc.mu.Unlock()
+
+	// The last seen time only has to be roughly right while the user is connected
+	if store {
+		err := uc.repo.StoreLastSeen(ctx, userUUID, now)
+		if err != nil {
+			return nil, fmt.Errorf("PresenceUseCase - Touch - uc.repo.StoreLastSeen: %w", err)
+		}
+	}
+
+	if !changed {
+		return nil, nil
+	}
+	return uc.buildChange(ctx, entity.Presence{UserUUID: userUUID, Status: entity.PresenceOnline})
+}
+
+// Sweep marks idle users as away and users whose grace period has ended as offline.
+// It returns the changes to push to their contacts.
+func (uc *PresenceUseCase) Sweep(ctx context.Context) ([]entity.PresenceChange, error) {
+	now := uc.now()
+
+	var presences []entity.Presence
+	uc.mu.Lock()
+	for userUUID, state := range uc.users {
+		switch {
+		case state.connections == 0 && now.Sub(state.disconnectedAt) >= uc.offlineAfter:
+			// Forget the user, they are offline until their next connection
+			delete(uc.users, userUUID)
+			lastSeenAt := state.disconnectedAt
+			presences = append(presences, entity.Presence{UserUUID: userUUID, Status: entity.PresenceOffline, LastSeenAt: &lastSeenAt})
+		case state.connections > 0 && state.status == entity.PresenceOnline && now.Sub(state.lastActiveAt) >= uc.awayAfter:
+			state.status = entity.PresenceAway
+			lastSeenAt := state.lastActiveAt
+			presences = append(presences, entity.Presence{UserUUID: userUUID, Status: entity.PresenceAway, LastSeenAt: &lastSeenAt})
+		}
+	}
+	uc.mu.Unlock()
+
+	changes := make([]entity.PresenceChange, 0, len(presences))
+	for _, presence := range presences {
+		change, err := uc.buildChange(ctx, presence)
+		if err != nil {
+			return changes, fmt.Errorf("PresenceUseCase - Sweep - uc.buildChange: %w", err)
+		}
+		changes = append(changes, *change)
+	}
+	return changes, nil
+}
+
+// GetContactsPresence returns the presence of every contact of the user.
+func (uc *PresenceUseCase) GetContactsPresence(ctx context.Context, userUUID string) ([]entity.Presence, error) {
+	// Get the contacts from contacts data repository by querying 'contacts' table
+	contactUUIDs, err := uc.contactsRepo.GetContactUUIDs(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("PresenceUseCase - GetContactsPresence - uc.contactsRepo.GetContactUUIDs: %w", err)
+	}
+
+	// Get the last seen times of the contacts by querying 'user_presence' table
+	lastSeen, err := uc.repo.GetLastSeen(ctx, contactUUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("PresenceUseCase - GetContactsPresence - uc.repo.GetLastSeen: %w", err)
+	}
+	lastSeenByUser := make(map[string]time.Time, len(lastSeen))
+	for _, dto := range lastSeen {
+		lastSeenByUser[dto.UserUUID] = dto.LastSeenAt
+	}
+
+	// Get the privacy settings of the contacts by querying 'privacy_settings' table
+	settingsList, err := uc.privacyRepo.GetPrivacySettingsByUserUUIDs(ctx, contactUUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("PresenceUseCase - GetContactsPresence - uc.privacyRepo.GetPrivacySettingsByUserUUIDs: %w", err)
+	}
+	hidden := make(map[string]bool)
+	for _, settings := range settingsList {
+		if !settings.ShowPresence {
+			hidden[settings.UserUUID] = true
+		}
+	}
+
+	presences := make([]entity.Presence, 0, len(contactUUIDs))
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	for _, contactUUID := range contactUUIDs {
+		presence := entity.Presence{UserUUID: contactUUID, Status: entity.PresenceOffline}
+		if hidden[contactUUID] {
+			presences = append(presences, presence)
+			continue
+		}
+		if state, ok := uc.users[contactUUID]; ok {
+			presence.Status = state.status
+		}
+
+		switch presence.Status {
+		case entity.PresenceAway:
+			lastSeenAt := uc.users[contactUUID].lastActiveAt
+			presence.LastSeenAt = &lastSeenAt
+		case entity.PresenceOffline:
+			if lastSeenAt, ok := lastSeenByUser[contactUUID]; ok {
+				presence.LastSeenAt = &lastSeenAt
+			}
+		}
+		presences = append(presences, presence)
+	}
+	return presences, nil
+}
+
+// buildChange adds the contacts who have to be told about the presence, nobody is told if the user hides it.
+func (uc *PresenceUseCase) buildChange(ctx context.Context, presence entity.Presence) (*entity.PresenceChange, error) {
+	settings, err := getPrivacySettings(ctx, uc.privacyRepo, presence.UserUUID)
+	if err != nil {
+		return nil, fmt.Errorf("PresenceUseCase - buildChange - getPrivacySettings: %w", err)
+	}
+	if !settings.ShowPresence {
+		return &entity.PresenceChange{Presence: presence}, nil
+	}
+
+	// Get the contacts from contacts data repository by querying 'contacts' table
+	contactUUIDs, err := uc.contactsRepo.GetContactUUIDs(ctx, presence.UserUUID)
+	if err != nil {
+		return nil, fmt.Errorf("PresenceUseCase - buildChange - uc.contactsRepo.GetContactUUIDs: %w", err)
+	}
+
+	return &entity.PresenceChange{
+		Presence:     presence,
+		ContactUUIDs: contactUUIDs,
+	}, nil
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+)
+
+func TestPresenceUseCase_Lifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPresenceRepo(ctrl)
+	mockRepo.EXPECT().StoreLastSeen(gomock.Any(), testUserUUID, gomock.Any()).Return(nil).AnyTimes()
+	mockContactsRepo := mocks.NewMockContactsRepo(ctrl)
+	mockContactsRepo.EXPECT().GetContactUUIDs(gomock.Any(), testUserUUID).Return([]string{"contact-uuid"}, nil).AnyTimes()
+	mockPrivacyRepo := mocks.NewMockPrivacyRepo(ctrl)
+	mockPrivacyRepo.EXPECT().GetPrivacySettings(gomock.Any(), testUserUUID).Return(nil, nil).AnyTimes()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	uc := NewPresence(mockRepo, mockContactsRepo, mockPrivacyRepo, 5*time.Minute, 30*time.Second)
+	uc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// The first connection makes the user online
+	change, err := uc.Connect(ctx, testUserUUID)
+	if err != nil || change == nil || change.Status != entity.PresenceOnline || len(change.ContactUUIDs) != 1 {
+		t.Fatalf("PresenceUseCase.Connect() = %+v, %v, want online change", change, err)
+	}
+
+	// Other connections do not change anything
+	change, err = uc.Connect(ctx, testUserUUID)
+	if err != nil || change != nil {
+		t.Fatalf("PresenceUseCase.Connect() second connection = %+v, %v, want no change", change, err)
+	}
+
+	// Idle users become away
+	now = now.Add(5 * time.Minute)
+	changes, err := uc.Sweep(ctx)
+	if err != nil || len(changes) != 1 || changes[0].Status != entity.PresenceAway {
+		t.Fatalf("PresenceUseCase.Sweep() idle = %+v, %v, want away change", changes, err)
+	}
+
+	// Activity makes them online again
+	change, err = uc.Touch(ctx, testUserUUID)
+	if err != nil || change == nil || change.Status != entity.PresenceOnline {
+		t.Fatalf("PresenceUseCase.Touch() = %+v, %v, want online change", change, err)
+	}
+
+	// Users stay online during the grace period after their last connection is closed
+	if err := uc.Disconnect(ctx, testUserUUID); err != nil {
+		t.Fatalf("PresenceUseCase.Disconnect() error = %v", err)
+	}
+	if err := uc.Disconnect(ctx, testUserUUID); err != nil {
+		t.Fatalf("PresenceUseCase.Disconnect() error = %v", err)
+	}
+	now = now.Add(10 * time.Second)
+	changes, err = uc.Sweep(ctx)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("PresenceUseCase.Sweep() within grace period = %+v, %v, want no change", changes, err)
+	}
+
+	// And are offline after it, last seen when they disconnected
+	disconnectedAt := now.Add(-10 * time.Second)
+	now = now.Add(30 * time.Second)
+	changes, err = uc.Sweep(ctx)
+	if err != nil || len(changes) != 1 || changes[0].Status != entity.PresenceOffline {
+		t.Fatalf("PresenceUseCase.Sweep() after grace period = %+v, %v, want offline change", changes, err)
+	}
+	if !changes[0].LastSeenAt.Equal(disconnectedAt) {
+		t.Errorf("PresenceUseCase.Sweep() last seen = %v, want %v", changes[0].LastSeenAt, disconnectedAt)
+	}
+}
+
+func TestPresenceUseCase_GetContactsPresence(t *testing.T) {
+	lastSeenAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                                                                                                   // Name of the test case
+		connected  []string                                                                                                                 // Users connected before the call
+		setupMocks func(mockRepo *mocks.MockPresenceRepo, mockContactsRepo *mocks.MockContactsRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) // Function to set up mock behavior
+		want       map[string]string                                                                                                        // Expected status of each contact
+		hidden     bool                                                                                                                     // Whether the last seen times have to be hidden
+		wantErr    bool                                                                                                                     // Whether an error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:      "success",
+			connected: []string{"online-uuid"},
+			setupMocks: func(mockRepo *mocks.MockPresenceRepo, mockContactsRepo *mocks.MockContactsRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockContactsRepo.EXPECT().GetContactUUIDs(gomock.Any(), testUserUUID).Return([]string{"online-uuid", "offline-uuid"}, nil)
+				mockRepo.EXPECT().GetLastSeen(gomock.Any(), []string{"online-uuid", "offline-uuid"}).
+					Return([]entity.LastSeenDTO{{UserUUID: "offline-uuid", LastSeenAt: lastSeenAt}}, nil)
+				mockPrivacyRepo.EXPECT().GetPrivacySettingsByUserUUIDs(gomock.Any(), []string{"online-uuid", "offline-uuid"}).Return(nil, nil)
+			},
+			want: map[string]string{"online-uuid": entity.PresenceOnline, "offline-uuid": entity.PresenceOffline},
+		},
+		{
+			name:      "success - hidden presence appears offline",
+			connected: []string{"hidden-uuid"},
+			setupMocks: func(mockRepo *mocks.MockPresenceRepo, mockContactsRepo *mocks.MockContactsRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockContactsRepo.EXPECT().GetContactUUIDs(gomock.Any(), testUserUUID).Return([]string{"hidden-uuid"}, nil)
+				mockRepo.EXPECT().GetLastSeen(gomock.Any(), []string{"hidden-uuid"}).
+					Return([]entity.LastSeenDTO{{UserUUID: "hidden-uuid", LastSeenAt: lastSeenAt}}, nil)
+				mockPrivacyRepo.EXPECT().GetPrivacySettingsByUserUUIDs(gomock.Any(), []string{"hidden-uuid"}).
+					Return([]entity.PrivacySettingsDTO{{UserUUID: "hidden-uuid", ShowPresence: false}}, nil)
+			},
+			want:   map[string]string{"hidden-uuid": entity.PresenceOffline},
+			hidden: true,
+		},
+		{
+			name: "error getting contacts",
+			setupMocks: func(mockRepo *mocks.MockPresenceRepo, mockContactsRepo *mocks.MockContactsRepo, mockPrivacyRepo *mocks.MockPrivacyRepo) {
+				mockContactsRepo.EXPECT().GetContactUUIDs(gomock.Any(), testUserUUID).Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories
+			mockRepo := mocks.NewMockPresenceRepo(ctrl)
+			mockRepo.EXPECT().StoreLastSeen(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockContactsRepo := mocks.NewMockContactsRepo(ctrl)
+			mockContactsRepo.EXPECT().GetContactUUIDs(gomock.Any(), gomock.Not(testUserUUID)).Return(nil, nil).AnyTimes()
+			mockPrivacyRepo := mocks.NewMockPrivacyRepo(ctrl)
+			mockPrivacyRepo.EXPECT().GetPrivacySettings(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo, mockContactsRepo, mockPrivacyRepo)
+			}
+
+			uc := NewPresence(mockRepo, mockContactsRepo, mockPrivacyRepo, 5*time.Minute, 30*time.Second)
+			for _, userUUID := range tt.connected {
+				if _, err := uc.Connect(context.Background(), userUUID); err != nil {
+					t.Fatalf("PresenceUseCase.Connect() error = %v", err)
+				}
+			}
+
+			// Call the method under test
+			got, err := uc.GetContactsPresence(context.Background(), testUserUUID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PresenceUseCase.GetContactsPresence() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("PresenceUseCase.GetContactsPresence() = %+v, want %v", got, tt.want)
+			}
+			for _, presence := range got {
+				if presence.Status != tt.want[presence.UserUUID] {
+					t.Errorf("PresenceUseCase.GetContactsPresence() status of %s = %s, want %s", presence.UserUUID, presence.Status, tt.want[presence.UserUUID])
+				}
+				if tt.hidden {
+					if presence.LastSeenAt != nil {
+						t.Errorf("PresenceUseCase.GetContactsPresence() last seen of %s = %v, want none", presence.UserUUID, presence.LastSeenAt)
+					}
+					continue
+				}
+				if presence.Status == entity.PresenceOffline && (presence.LastSeenAt == nil || !presence.LastSeenAt.Equal(lastSeenAt)) {
+					t.Errorf("PresenceUseCase.GetContactsPresence() last seen of %s = %v, want %v", presence.UserUUID, presence.LastSeenAt, lastSeenAt)
+				}
+			}
+		})
+	}
+}
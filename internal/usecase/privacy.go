@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+type PrivacyUseCase struct {
+	repo PrivacyRepo
+}
+
+// NewPrivacy -.
+func NewPrivacy(r PrivacyRepo) *PrivacyUseCase {
+	return &PrivacyUseCase{
+		repo: r,
+	}
+}
+
+// GetPrivacySettings returns the default settings if the user never changed them.
+func (uc *PrivacyUseCase) GetPrivacySettings(ctx context.Context, userUUID string) (entity.PrivacySettings, error) {
+	settings, err := getPrivacySettings(ctx, uc.repo, userUUID)
+	if err != nil {
+		return entity.PrivacySettings{}, fmt.Errorf("PrivacyUseCase - GetPrivacySettings - getPrivacySettings: %w", err)
+	}
+	return settings, nil
+}
+
+// UpdatePrivacySettings changes the settings that are set in the update and returns the resulting settings.
+func (uc *PrivacyUseCase) UpdatePrivacySettings(ctx context.Context, userUUID string, update entity.PrivacySettingsUpdate) (entity.PrivacySettings, error) {
+	// Return error if an audience is not one of its options. Will be handled by controller
+	if update.WhoCanAddContact != nil && !isAudience(*update.WhoCanAddContact, entity.PrivacyEveryone, entity.PrivacyContactsOfContacts, entity.PrivacyNobody) {
+		return entity.PrivacySettings{}, entity.ErrInvalidPrivacySetting
+	}
+	if update.WhoCanAddToGroups != nil && !isAudience(*update.WhoCanAddToGroups, entity.PrivacyEveryone, entity.PrivacyContacts, entity.PrivacyNobody) {
+		return entity.PrivacySettings{}, entity.ErrInvalidPrivacySetting
+	}
+
+	settings, err := uc.GetPrivacySettings(ctx, userUUID)
+	if err != nil {
+		return entity.PrivacySettings{}, err
+	}
+
+	if update.Discoverable != nil {
+		settings.Discoverable = *update.Discoverable
+	}
+	if update.WhoCanAddContact != nil {
+		settings.WhoCanAddContact = *update.WhoCanAddContact
+	}
+	if update.WhoCanAddToGroups != nil {
+		settings.WhoCanAddToGroups = *update.WhoCanAddToGroups
+	}
+	if update.ReadReceipts != nil {
+		settings.ReadReceipts = *update.ReadReceipts
+	}
+	if update.ShowPresence != nil {
+		settings.ShowPresence = *update.ShowPresence
+	}
+
+	// Store the settings into 'privacy_settings' table using privacy data repository
+	err = uc.repo.StorePrivacySettings(ctx, entity.PrivacySettingsDTO{
+		UserUUID:          userUUID,
+		Discoverable:      settings.Discoverable,
+		WhoCanAddContact:  settings.WhoCanAddContact,
+		WhoCanAddToGroups: settings.WhoCanAddToGroups,
+		ReadReceipts:      settings.ReadReceipts,
+		ShowPresence:      settings.ShowPresence,
+		UpdatedAt:         time.Now(),
+	})
+	if err != nil {
+		return entity.PrivacySettings{}, fmt.Errorf("PrivacyUseCase - UpdatePrivacySettings - uc.repo.StorePrivacySettings: %w", err)
+	}
+	return settings, nil
+}
+
+// getPrivacySettings is shared by the use cases that enforce the settings of other users.
+func getPrivacySettings(ctx context.Context, r PrivacyRepo, userUUID string) (entity.PrivacySettings, error) {
+	// Get the settings from privacy data repository by querying 'privacy_settings' table
+	settingsDTO, err := r.GetPrivacySettings(ctx, userUUID)
+	if err != nil {
+		return entity.PrivacySettings{}, fmt.Errorf("r.GetPrivacySettings: %w", err)
+	}
+
+	if settingsDTO == nil {
+		return entity.DefaultPrivacySettings(), nil
+	}
+	return toPrivacySettings(*settingsDTO), nil
+}
+
+func toPrivacySettings(settings entity.PrivacySettingsDTO) entity.PrivacySettings {
+	return entity.PrivacySettings{
+		Discoverable:      settings.Discoverable,
+		WhoCanAddContact:  settings.WhoCanAddContact,
+		WhoCanAddToGroups: settings.WhoCanAddToGroups,
+		ReadReceipts:      settings.ReadReceipts,
+		ShowPresence:      settings.ShowPresence,
+	}
+}
+
+func isAudience(audience string, options ...string) bool {
+	for _, option := range options {
+		if audience == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+)
+
+func TestPrivacyUseCase_UpdatePrivacySettings(t *testing.T) {
+	notDiscoverable := false
+	contactsOfContacts := entity.PrivacyContactsOfContacts
+	invalidAudience := "friends"
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                // Name of the test case
+		update     entity.PrivacySettingsUpdate          // Input settings update
+		setupMocks func(mockRepo *mocks.MockPrivacyRepo) // Function to set up mock behavior
+		want       entity.PrivacySettings                // Expected settings after the update
+		wantErr    bool                                  // Whether an error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:   "success - defaults changed",
+			update: entity.PrivacySettingsUpdate{Discoverable: &notDiscoverable},
+			setupMocks: func(mockRepo *mocks.MockPrivacyRepo) {
+				mockRepo.EXPECT().GetPrivacySettings(gomock.Any(), testUserUUID).Return(nil, nil)
+				mockRepo.EXPECT().StorePrivacySettings(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, settings entity.PrivacySettingsDTO) {
+						if settings.UserUUID != testUserUUID || settings.Discoverable || !settings.ReadReceipts {
+							t.Errorf("PrivacyUseCase.UpdatePrivacySettings() stored %+v", settings)
+						}
+					}).
+					Return(nil)
+			},
+			want: entity.PrivacySettings{
+				Discoverable:      false,
+				WhoCanAddContact:  entity.PrivacyEveryone,
+				WhoCanAddToGroups: entity.PrivacyEveryone,
+				ReadReceipts:      true,
+				ShowPresence:      true,
+			},
+		},
+		{
+			name:   "success - settings left out are kept",
+			update: entity.PrivacySettingsUpdate{WhoCanAddContact: &contactsOfContacts},
+			setupMocks: func(mockRepo *mocks.MockPrivacyRepo) {
+				mockRepo.EXPECT().GetPrivacySettings(gomock.Any(), testUserUUID).
+					Return(&entity.PrivacySettingsDTO{
+						UserUUID:          testUserUUID,
+						Discoverable:      false,
+						WhoCanAddContact:  entity.PrivacyNobody,
+						WhoCanAddToGroups: entity.PrivacyContacts,
+						ReadReceipts:      false,
+						ShowPresence:      true,
+					}, nil)
+				mockRepo.EXPECT().StorePrivacySettings(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: entity.PrivacySettings{
+				Discoverable:      false,
+				WhoCanAddContact:  entity.PrivacyContactsOfContacts,
+				WhoCanAddToGroups: entity.PrivacyContacts,
+				ReadReceipts:      false,
+				ShowPresence:      true,
+			},
+		},
+		{
+			name:    "invalid audience",
+			update:  entity.PrivacySettingsUpdate{WhoCanAddToGroups: &invalidAudience},
+			wantErr: true,
+		},
+		{
+			name:    "contacts of contacts can not add to groups",
+			update:  entity.PrivacySettingsUpdate{WhoCanAddToGroups: &contactsOfContacts},
+			wantErr: true,
+		},
+		{
+			name:   "error storing settings",
+			update: entity.PrivacySettingsUpdate{Discoverable: &notDiscoverable},
+			setupMocks: func(mockRepo *mocks.MockPrivacyRepo) {
+				mockRepo.EXPECT().GetPrivacySettings(gomock.Any(), testUserUUID).Return(nil, nil)
+				mockRepo.EXPECT().StorePrivacySettings(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create a mock instance of the repository
+			mockRepo := mocks.NewMockPrivacyRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := NewPrivacy(mockRepo)
+
+			// Call the method under test
+			got, err := uc.UpdatePrivacySettings(context.Background(), testUserUUID, tt.update)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PrivacyUseCase.UpdatePrivacySettings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PrivacyUseCase.UpdatePrivacySettings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
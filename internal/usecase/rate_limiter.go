@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter allows a number of requests per key in fixed time windows. Counters are kept in memory,
+// so the limit applies per instance.
+type rateLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	windows   map[string]rateWindow
+	lastPrune time.Time
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// A limit of zero or less disables the limiter
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:   limit,
+		window:  window,
+		windows: make(map[string]rateWindow),
+	}
+}
+
+// allow records a request for the key and reports whether it is within the limit
+func (l *rateLimiter) allow(key string, now time.Time) bool {
+	if l.limit <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop the windows that ended once per window, so the map does not grow with every key ever seen
+	if now.Sub(l.lastPrune) >= l.window {
+		l.prune(now)
+		l.lastPrune = now
+	}
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		w = rateWindow{start: now}
+	}
+	if w.count >= l.limit {
+		return false
+	}
+
+	w.count++
+	l.windows[key] = w
+	return true
+}
+
+func (l *rateLimiter) prune(now time.Time) {
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, key)
+		}
+	}
+}
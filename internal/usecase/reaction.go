@@ -9,15 +9,28 @@ import (
 
 type ReactionUseCase struct {
 	reactionRepo ReactionRepo
+	contactsRepo ContactsRepo
 }
 
-func NewReaction(r ReactionRepo) *ReactionUseCase {
+func NewReaction(r ReactionRepo, contactsRepo ContactsRepo) *ReactionUseCase {
 	return &ReactionUseCase{
 		reactionRepo: r,
+		contactsRepo: contactsRepo,
 	}
 }
 
 func (uc *ReactionUseCase) StoreReaction(ctx context.Context, reaction entity.Reaction) error {
+	// Check the message is not in a direct conversation that either user blocked by querying 'contacts' table
+	blocked, err := uc.contactsRepo.CheckMessageBlocked(ctx, reaction.MessageUUID, reaction.SenderUUID)
+	if err != nil {
+		return fmt.Errorf("ReactionUseCase - StoreReaction - uc.contactsRepo.CheckMessageBlocked: %w", err)
+	}
+
+	// Return error if the conversation is blocked. Will be handled by controller
+	if blocked {
+		return entity.ErrConversationBlocked
+	}
+
 	// Convert reaction entity object into storeReactionDTO
 	storeReactionDTO := entity.StoreReactionDTO{
 		MessageUUID:  reaction.MessageUUID,
@@ -26,7 +39,7 @@ func (uc *ReactionUseCase) StoreReaction(ctx context.Context, reaction entity.Re
 	}
 
 	// Store reaction into 'reaction' table using reaction data repository
-	err := uc.reactionRepo.StoreReaction(ctx, storeReactionDTO)
+	err = uc.reactionRepo.StoreReaction(ctx, storeReactionDTO)
 	if err != nil {
 		return fmt.Errorf("ReactionUseCase - StoreReaction - uc.reactionRepo.StoreReaction: %w", err)
 	}
@@ -19,10 +19,10 @@ func TestReactionUseCase_StoreReaction(t *testing.T) {
 
 	// Define the structure of each test case
 	type testCase struct {
-		name       string                                         // Name of the test case, used to identify the test in the output
-		args       args                                           // The input arguments for the test case
-		setupMocks func(mockReactionRepo *mocks.MockReactionRepo) // Function to set up mock behavior for the test
-		wantErr    bool                                           // Whether the test expects an error to occur
+		name       string                                                                                   // Name of the test case, used to identify the test in the output
+		args       args                                                                                     // The input arguments for the test case
+		setupMocks func(mockReactionRepo *mocks.MockReactionRepo, mockContactsRepo *mocks.MockContactsRepo) // Function to set up mock behavior for the test
+		wantErr    bool                                                                                     // Whether the test expects an error to occur
 	}
 
 	// Define the test cases
@@ -38,7 +38,7 @@ func TestReactionUseCase_StoreReaction(t *testing.T) {
 				},
 			},
 			// This function sets up the expected behavior of the mock repository for this test case
-			setupMocks: func(mockReactionRepo *mocks.MockReactionRepo) {
+			setupMocks: func(mockReactionRepo *mocks.MockReactionRepo, mockContactsRepo *mocks.MockContactsRepo) {
 				storeReactionDTO := entity.StoreReactionDTO{
 					MessageUUID:  "msg_uuid_1234",
 					SenderUUID:   "user_uuid_1234",
@@ -62,7 +62,7 @@ func TestReactionUseCase_StoreReaction(t *testing.T) {
 				},
 			},
 			// This function sets up the mock to simulate an error when storing the reaction
-			setupMocks: func(mockReactionRepo *mocks.MockReactionRepo) {
+			setupMocks: func(mockReactionRepo *mocks.MockReactionRepo, mockContactsRepo *mocks.MockContactsRepo) {
 				storeReactionDTO := entity.StoreReactionDTO{
 					MessageUUID:  "msg_uuid_1234",
 					SenderUUID:   "user_uuid_1234",
@@ -74,6 +74,24 @@ func TestReactionUseCase_StoreReaction(t *testing.T) {
 			},
 			wantErr: true, // The test expects an error to occur
 		},
+		{
+			name: "blocked conversation", // Test case for when the message is in a direct conversation that is blocked
+			args: args{
+				ctx: context.Background(),
+				reaction: entity.Reaction{
+					MessageUUID:  "msg_uuid_1234",
+					SenderUUID:   "user_uuid_1234",
+					ReactionType: "like",
+				},
+			},
+			// This function sets up the mock so that the reaction is not stored
+			setupMocks: func(mockReactionRepo *mocks.MockReactionRepo, mockContactsRepo *mocks.MockContactsRepo) {
+				mockContactsRepo.EXPECT().
+					CheckMessageBlocked(gomock.Any(), "msg_uuid_1234", "user_uuid_1234").
+					Return(true, nil)
+			},
+			wantErr: true, // The test expects an error to occur
+		},
 	}
 
 	// Iterate over each test case and run it
@@ -83,17 +101,21 @@ func TestReactionUseCase_StoreReaction(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish() // Ensure that the mock expectations are checked and cleaned up after the test
 
-			// Create a mock instance of the ReactionRepo interface
+			// Create mock instances of the ReactionRepo and ContactsRepo interfaces
 			mockReactionRepo := mocks.NewMockReactionRepo(ctrl)
+			mockContactsRepo := mocks.NewMockContactsRepo(ctrl)
 
 			// Set up the mock expectations using the setupMocks function provided in the test case
 			if tt.setupMocks != nil {
-				tt.setupMocks(mockReactionRepo)
+				tt.setupMocks(mockReactionRepo, mockContactsRepo)
 			}
+			// Unless a test case says otherwise, the conversation of the message is not blocked
+			mockContactsRepo.EXPECT().CheckMessageBlocked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
 
-			// Create an instance of ReactionUseCase using the mock repository
+			// Create an instance of ReactionUseCase using the mock repositories
 			uc := &ReactionUseCase{
 				reactionRepo: mockReactionRepo,
+				contactsRepo: mockContactsRepo,
 			}
 
 			// Call the method under test with the provided arguments
@@ -0,0 +1,277 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// AccountDeletionRepo -.
+type AccountDeletionRepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewAccountDeletion(pg *sql.DB) *AccountDeletionRepo {
+	return &AccountDeletionRepo{pg}
+}
+
+// ScheduleDeletion -.
+func (r *AccountDeletionRepo) ScheduleDeletion(ctx context.Context, userUUID string, deleteAfter time.Time) error {
+	scheduleDeletionSQL := `
+		UPDATE user_credentials
+		SET delete_after = $1
+		WHERE user_uuid = $2
+	`
+
+	_, err := r.ExecContext(ctx, scheduleDeletionSQL, deleteAfter, userUUID)
+	if err != nil {
+		return fmt.Errorf("AccountDeletionRepo - ScheduleDeletion - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// GetDueDeletions -.
+func (r *AccountDeletionRepo) GetDueDeletions(ctx context.Context, now time.Time) ([]string, error) {
+	getDueDeletionsSQL := `
+		SELECT user_uuid
+		FROM user_credentials
+		WHERE delete_after <= $1
+	`
+
+	rows, err := r.QueryContext(ctx, getDueDeletionsSQL, now)
+	if err != nil {
+		return nil, fmt.Errorf("AccountDeletionRepo - GetDueDeletions - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var userUUIDs []string
+	for rows.Next() {
+		var userUUID string
+		if err := rows.Scan(&userUUID); err != nil {
+			return nil, fmt.Errorf("AccountDeletionRepo - GetDueDeletions - rows.Scan: %w", err)
+		}
+		userUUIDs = append(userUUIDs, userUUID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("AccountDeletionRepo - GetDueDeletions - rows.Err: %w", err)
+	}
+
+	return userUUIDs, nil
+}
+
+// EraseUser removes the user's personal data. Rows that other users' conversations depend on are anonymised instead.
+func (r *AccountDeletionRepo) EraseUser(ctx context.Context, userUUID string) error {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("AccountDeletionRepo - EraseUser - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	// Failed login counters are keyed by the lowercased username or email, and by the user uuid for the second factor
+	deleteLoginAttemptsSQL := `
+		DELETE FROM login_attempts
+		WHERE attempt_key IN (
+			SELECT 'user:' || LOWER(username) FROM user_credentials WHERE user_uuid = $1
+			UNION
+			SELECT 'user:' || LOWER(email) FROM user_credentials WHERE user_uuid = $1
+			UNION
+			SELECT 'user:' || LOWER($1)
+		)
+	`
+	_, err = tx.ExecContext(ctx, deleteLoginAttemptsSQL, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete deleteLoginAttemptsSQL query: %w", err)
+	}
+
+	// The lockout audit keeps the identifier that was tried, also on lockouts of the client IP
+	deleteLoginLockoutsSQL := `
+		DELETE FROM login_lockouts
+		WHERE LOWER(identifier) IN (
+			SELECT LOWER(username) FROM user_credentials WHERE user_uuid = $1
+			UNION
+			SELECT LOWER(email) FROM user_credentials WHERE user_uuid = $1
+			UNION
+			SELECT LOWER($1)
+		)
+	`
+	_, err = tx.ExecContext(ctx, deleteLoginLockoutsSQL, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete deleteLoginLockoutsSQL query: %w", err)
+	}
+
+	// The profile is kept so that messages and conversation lists can still show the sender
+	anonymiseUserInfoSQL := `
+		UPDATE user_info
+		SET first_name = $2, last_name = $3, email = '', avatar = ''
+		WHERE user_uuid = $1
+	`
+	_, err = tx.ExecContext(ctx, anonymiseUserInfoSQL, userUUID, entity.DeletedUserFirstName, entity.DeletedUserLastName)
+	if err != nil {
+		return fmt.Errorf("failed to execute update anonymiseUserInfoSQL query: %w", err)
+	}
+
+	// Replace the content of the user's messages with a tombstone
+	tombstoneMessagesSQL := `
+		UPDATE messages
+		SET content = $2, deleted = TRUE
+		WHERE user_uuid = $1
+	`
+	_, err = tx.ExecContext(ctx, tombstoneMessagesSQL, userUUID, entity.DeletedMessageContent)
+	if err != nil {
+		return fmt.Errorf("failed to execute update tombstoneMessagesSQL query: %w", err)
+	}
+
+	tombstoneLastMessageSQL := `
+		UPDATE conversations
+		SET last_message = $2
+		WHERE last_sent_user_uuid = $1
+	`
+	_, err = tx.ExecContext(ctx, tombstoneLastMessageSQL, userUUID, entity.DeletedMessageContent)
+	if err != nil {
+		return fmt.Errorf("failed to execute update tombstoneLastMessageSQL query: %w", err)
+	}
+
+	// Other users keep their direct conversation with the deleted user, but no longer list them as a contact
+	removeFromContactsSQL := `
+		UPDATE contacts
+		SET removed = TRUE
+		WHERE contact_user_uuid = $1
+	`
+	_, err = tx.ExecContext(ctx, removeFromContactsSQL, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute update removeFromContactsSQL query: %w", err)
+	}
+
+	// Contact requests are deleted in both directions, so that the deleted user no longer shows up in other users' requests
+	deleteContactRequestsSQL := `
+		DELETE FROM contact_requests
+		WHERE from_user_uuid = $1 OR to_user_uuid = $1
+	`
+	_, err = tx.ExecContext(ctx, deleteContactRequestsSQL, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete deleteContactRequestsSQL query: %w", err)
+	}
+
+	// The suggestions of the users who were suggested the deleted user, or who had them as a mutual contact,
+	// are recomputed without them. It runs before the suggestions and the contacts of the user are deleted.
+	invalidateSuggestionOwnersSQL := `
+		UPDATE contact_suggestion_state
+		SET invalidated_at = clock_timestamp()
+		WHERE user_uuid IN (
+			SELECT user_uuid
+			FROM contact_suggestions
+			WHERE suggested_user_uuid = $1
+		)
+	`
+	_, err = tx.ExecContext(ctx, invalidateSuggestionOwnersSQL, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute update invalidateSuggestionOwnersSQL query: %w", err)
+	}
+	err = invalidateSuggestions(ctx, tx, userUUID)
+	if err != nil {
+		return err
+	}
+
+	deleteSuggestionsSQL := `
+		DELETE FROM contact_suggestions
+		WHERE user_uuid = $1 OR suggested_user_uuid = $1
+	`
+	_, err = tx.ExecContext(ctx, deleteSuggestionsSQL, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete deleteSuggestionsSQL query: %w", err)
+	}
+
+	// Reports filed by the user and reports about their account are deleted. Reported messages of the user
+	// stay in the moderation queue like the messages themselves, with the copied content replaced by a tombstone.
+	deleteAbuseReportsSQL := `
+		DELETE FROM abuse_reports
+		WHERE reporter_uuid = $1
+		OR (target_type = $2 AND target_uuid = $1)
+	`
+	_, err = tx.ExecContext(ctx, deleteAbuseReportsSQL, userUUID, entity.AdminTargetUser)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete deleteAbuseReportsSQL query: %w", err)
+	}
+
+	tombstoneReportedMessagesSQL := `
+		UPDATE abuse_reports
+		SET message_content = $2
+		WHERE message_sender_uuid = $1
+	`
+	_, err = tx.ExecContext(ctx, tombstoneReportedMessagesSQL, userUUID, entity.DeletedMessageContent)
+	if err != nil {
+		return fmt.Errorf("failed to execute update tombstoneReportedMessagesSQL query: %w", err)
+	}
+
+	// Export archives are expired instead of deleted, so that the expired export purge also removes their files
+	expireDataExportsSQL := `
+		UPDATE data_exports
+		SET expires_at = NOW()
+		WHERE user_uuid = $1
+	`
+	_, err = tx.ExecContext(ctx, expireDataExportsSQL, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute update expireDataExportsSQL query: %w", err)
+	}
+
+	// The user's bots stop working, their messages are kept like the user's own
+	deleteBotsSQL := []string{
+		`UPDATE api_keys SET revoked_at = NOW() WHERE revoked_at IS NULL AND bot_uuid IN (SELECT bot_uuid FROM bots WHERE owner_uuid = $1)`,
+		`DELETE FROM participants WHERE user_uuid IN (SELECT bot_uuid FROM bots WHERE owner_uuid = $1)`,
+		`UPDATE bots SET deleted_at = NOW() WHERE owner_uuid = $1 AND deleted_at IS NULL`,
+	}
+	for _, deleteSQL := range deleteBotsSQL {
+		_, err = tx.ExecContext(ctx, deleteSQL, userUUID)
+		if err != nil {
+			return fmt.Errorf("failed to execute %q query: %w", deleteSQL, err)
+		}
+	}
+
+	// Everything else that belongs to the user only is deleted
+	deleteUserRowsSQL := []string{
+		`DELETE FROM contacts WHERE user_uuid = $1`,
+		`DELETE FROM participants WHERE user_uuid = $1`,
+		`DELETE FROM seen_status WHERE user_uuid = $1`,
+		`DELETE FROM reaction WHERE user_uuid = $1`,
+		`DELETE FROM user_tokens WHERE user_uuid = $1`,
+		`DELETE FROM user_mfa WHERE user_uuid = $1`,
+		`DELETE FROM mfa_recovery_codes WHERE user_uuid = $1`,
+		`DELETE FROM user_identities WHERE user_uuid = $1`,
+		`DELETE FROM username_history WHERE user_uuid = $1`,
+		`DELETE FROM privacy_settings WHERE user_uuid = $1`,
+		`DELETE FROM user_presence WHERE user_uuid = $1`,
+		`DELETE FROM contact_suggestion_state WHERE user_uuid = $1`,
+		`DELETE FROM refresh_tokens WHERE user_uuid = $1`,
+		`DELETE FROM sessions WHERE user_uuid = $1`,
+		`DELETE FROM user_credentials WHERE user_uuid = $1`,
+	}
+	for _, deleteSQL := range deleteUserRowsSQL {
+		_, err = tx.ExecContext(ctx, deleteSQL, userUUID)
+		if err != nil {
+			return fmt.Errorf("failed to execute %q query: %w", deleteSQL, err)
+		}
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("AccountDeletionRepo - EraseUser - failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
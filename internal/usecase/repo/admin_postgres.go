@@ -0,0 +1,338 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// AdminRepo -.
+type AdminRepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewAdmin(pg *sql.DB) *AdminRepo {
+	return &AdminRepo{pg}
+}
+
+// GetUserAccess -.
+func (r *AdminRepo) GetUserAccess(ctx context.Context, userUUID string) (*entity.UserAccess, error) {
+	getUserAccessSQL := `
+		SELECT role, suspended_at IS NOT NULL
+		FROM user_credentials
+		WHERE user_uuid = $1
+	`
+
+	var access entity.UserAccess
+	err := r.QueryRowContext(ctx, getUserAccessSQL, userUUID).Scan(&access.Role, &access.Suspended)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("AdminRepo - GetUserAccess - r.QueryRowContext: %w", err)
+	}
+
+	return &access, nil
+}
+
+// ListUsers returns every user whose username, email, first or last name contains the query, an empty query lists everyone.
+// Unlike the user directory it also lists suspended users, users who are not discoverable and accounts about to be deleted.
+func (r *AdminRepo) ListUsers(ctx context.Context, query entity.AdminUserQuery) ([]entity.AdminUser, error) {
+	listUsersSQL := `
+		SELECT uc.id, uc.user_uuid, uc.username, uc.email, COALESCE(ui.first_name, ''), COALESCE(ui.last_name, ''),
+			uc.role, uc.verified_at, uc.suspended_at, uc.suspension_reason, uc.delete_after
+		FROM user_credentials uc
+		LEFT JOIN user_info ui ON ui.user_uuid = uc.user_uuid
+		WHERE uc.id > $1
+		AND (
+			$2 = ''
+			OR LOWER(uc.username) LIKE $3
+			OR LOWER(uc.email) LIKE $3
+			OR LOWER(ui.first_name) LIKE $3
+			OR LOWER(ui.last_name) LIKE $3
+		)
+		ORDER BY uc.id
+		LIMIT $4
+	`
+
+	rows, err := r.QueryContext(ctx, listUsersSQL, query.Cursor, query.Query, containsPattern(query.Query), query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("AdminRepo - ListUsers - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var users []entity.AdminUser
+	for rows.Next() {
+		var user entity.AdminUser
+		if err := rows.Scan(&user.ID, &user.UserUUID, &user.Username, &user.Email, &user.FirstName, &user.LastName,
+			&user.Role, &user.VerifiedAt, &user.SuspendedAt, &user.SuspensionReason, &user.DeleteAfter); err != nil {
+			return nil, fmt.Errorf("AdminRepo - ListUsers - rows.Scan: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// SuspendUser suspends the target user of the audit entry and stores the entry.
+// It returns false if the user does not exist, suspending a suspended user keeps the original suspension time.
+func (r *AdminRepo) SuspendUser(ctx context.Context, audit entity.AdminAuditDTO) (bool, error) {
+	suspendUserSQL := `
+		UPDATE user_credentials
+		SET suspended_at = COALESCE(suspended_at, NOW()), suspension_reason = $2
+		WHERE user_uuid = $1
+	`
+	found, err := r.updateWithAudit(ctx, audit, suspendUserSQL, audit.TargetUUID, audit.Reason)
+	if err != nil {
+		return false, fmt.Errorf("AdminRepo - SuspendUser - r.updateWithAudit: %w", err)
+	}
+	return found, nil
+}
+
+// UnsuspendUser lifts the suspension of the target user of the audit entry and stores the entry.
+// It returns false if the user does not exist.
+func (r *AdminRepo) UnsuspendUser(ctx context.Context, audit entity.AdminAuditDTO) (bool, error) {
+	unsuspendUserSQL := `
+		UPDATE user_credentials
+		SET suspended_at = NULL, suspension_reason = ''
+		WHERE user_uuid = $1
+	`
+	found, err := r.updateWithAudit(ctx, audit, unsuspendUserSQL, audit.TargetUUID)
+	if err != nil {
+		return false, fmt.Errorf("AdminRepo - UnsuspendUser - r.updateWithAudit: %w", err)
+	}
+	return found, nil
+}
+
+// DeleteMessage deletes the target message of the audit entry together with its reactions and seen statuses, and stores the entry.
+// It returns the conversation of the message, or an empty string if the message does not exist.
+func (r *AdminRepo) DeleteMessage(ctx context.Context, audit entity.AdminAuditDTO) (string, error) {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("AdminRepo - DeleteMessage - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	deleteMessageSQL := `
+		DELETE FROM messages
+		WHERE message_uuid = $1
+		RETURNING conversation_uuid
+	`
+	var conversationUUID string
+	err = tx.QueryRowContext(ctx, deleteMessageSQL, audit.TargetUUID).Scan(&conversationUUID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// Nothing was changed, the rollback only ends the transaction
+			tx.Rollback()
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to execute delete deleteMessageSQL query: %w", err)
+	}
+
+	for _, deleteSQL := range []string{
+		`DELETE FROM reaction WHERE message_uuid = $1`,
+		`DELETE FROM seen_status WHERE message_uuid = $1`,
+	} {
+		_, err = tx.ExecContext(ctx, deleteSQL, audit.TargetUUID)
+		if err != nil {
+			return "", fmt.Errorf("failed to execute %q query: %w", deleteSQL, err)
+		}
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		return "", err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return "", fmt.Errorf("AdminRepo - DeleteMessage - failed to commit transaction: %w", err)
+	}
+
+	return conversationUUID, nil
+}
+
+// DeleteConversation deletes the target conversation of the audit entry with its participants and messages, and stores the entry.
+// Contacts whose direct conversation is deleted are removed, so that neither user keeps a contact without a conversation.
+// It returns false if the conversation does not exist.
+func (r *AdminRepo) DeleteConversation(ctx context.Context, audit entity.AdminAuditDTO) (bool, error) {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("AdminRepo - DeleteConversation - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	deleteConversationSQL := `
+		DELETE FROM conversations
+		WHERE conversation_uuid = $1
+	`
+	result, err := tx.ExecContext(ctx, deleteConversationSQL, audit.TargetUUID)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute delete deleteConversationSQL query: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows of deleteConversationSQL query: %w", err)
+	}
+	if affected == 0 {
+		// Nothing was changed, the rollback only ends the transaction
+		tx.Rollback()
+		return false, nil
+	}
+
+	// Reactions and seen statuses are deleted before the messages they belong to
+	for _, deleteSQL := range []string{
+		`DELETE FROM reaction WHERE message_uuid IN (SELECT message_uuid FROM messages WHERE conversation_uuid = $1)`,
+		`DELETE FROM seen_status WHERE message_uuid IN (SELECT message_uuid FROM messages WHERE conversation_uuid = $1)`,
+		`DELETE FROM messages WHERE conversation_uuid = $1`,
+		`DELETE FROM participants WHERE conversation_uuid = $1`,
+		`UPDATE contacts SET removed = TRUE WHERE conversation_uuid = $1`,
+	} {
+		_, err = tx.ExecContext(ctx, deleteSQL, audit.TargetUUID)
+		if err != nil {
+			return false, fmt.Errorf("failed to execute %q query: %w", deleteSQL, err)
+		}
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		return false, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return false, fmt.Errorf("AdminRepo - DeleteConversation - failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// StoreAuditEntry stores an entry for an action that did not change anything in this repository.
+func (r *AdminRepo) StoreAuditEntry(ctx context.Context, audit entity.AdminAuditDTO) error {
+	err := insertAuditEntry(ctx, r.DB, audit)
+	if err != nil {
+		return fmt.Errorf("AdminRepo - StoreAuditEntry - insertAuditEntry: %w", err)
+	}
+	return nil
+}
+
+// GetAuditLog returns the entries of the audit log from the newest.
+func (r *AdminRepo) GetAuditLog(ctx context.Context, query entity.AdminAuditQuery) ([]entity.AdminAuditDTO, error) {
+	getAuditLogSQL := `
+		SELECT id, audit_uuid, admin_uuid, action, target_type, target_uuid, reason, created_at
+		FROM admin_audit_log
+		WHERE ($1 = 0 OR id < $1)
+		AND ($2 = '' OR target_uuid = $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.QueryContext(ctx, getAuditLogSQL, query.Cursor, query.TargetUUID, query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("AdminRepo - GetAuditLog - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []entity.AdminAuditDTO
+	for rows.Next() {
+		var entry entity.AdminAuditDTO
+		if err := rows.Scan(&entry.ID, &entry.AuditUUID, &entry.AdminUUID, &entry.Action, &entry.TargetType,
+			&entry.TargetUUID, &entry.Reason, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("AdminRepo - GetAuditLog - rows.Scan: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// updateWithAudit runs an update of one row and stores the audit entry in the same transaction.
+// It returns false and stores nothing if the update did not match any row.
+func (r *AdminRepo) updateWithAudit(ctx context.Context, audit entity.AdminAuditDTO, updateSQL string, args ...interface{}) (bool, error) {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	result, err := tx.ExecContext(ctx, updateSQL, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute update query: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows of update query: %w", err)
+	}
+	if affected == 0 {
+		// Nothing was changed, the rollback only ends the transaction
+		tx.Rollback()
+		return false, nil
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		return false, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insertAuditEntry stores an entry of the audit log, within the transaction of the change it records if there is one.
+func insertAuditEntry(ctx context.Context, db execer, audit entity.AdminAuditDTO) error {
+	insertAuditEntrySQL := `
+		INSERT INTO admin_audit_log (audit_uuid, admin_uuid, action, target_type, target_uuid, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := db.ExecContext(ctx, insertAuditEntrySQL, audit.AuditUUID, audit.AdminUUID, audit.Action, audit.TargetType,
+		audit.TargetUUID, audit.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to execute insert insertAuditEntrySQL query: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// BotRepo -.
+type BotRepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewBot(pg *sql.DB) *BotRepo {
+	return &BotRepo{pg}
+}
+
+const selectAPIKeySQL = `
+	SELECT key_uuid, bot_uuid, name, key_hash, scopes, conversation_uuids, created_at, last_used_at, expires_at, revoked_at
+	FROM api_keys
+`
+
+// CreateBot -.
+func (r *BotRepo) CreateBot(ctx context.Context, bot entity.BotDTO) error {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("BotRepo - CreateBot - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	insertBotSQL := `
+		INSERT INTO bots (bot_uuid, owner_uuid, name, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err = tx.ExecContext(ctx, insertBotSQL, bot.BotUUID, bot.OwnerUUID, bot.Name, bot.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to execute insert insertBotSQL query: %w", err)
+	}
+
+	// The profile is what other users see as the sender of the bot's messages
+	insertUserInfoSQL := `
+		INSERT INTO user_info (user_uuid, first_name, last_name, email, avatar)
+		VALUES ($1, $2, '', '', '')
+	`
+	_, err = tx.ExecContext(ctx, insertUserInfoSQL, bot.BotUUID, bot.Name)
+	if err != nil {
+		return fmt.Errorf("failed to execute insert insertUserInfoSQL query: %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("BotRepo - CreateBot - failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetBot -.
+func (r *BotRepo) GetBot(ctx context.Context, botUUID string) (*entity.BotDTO, error) {
+	getBotSQL := `
+		SELECT bot_uuid, owner_uuid, name, created_at, deleted_at
+		FROM bots
+		WHERE bot_uuid = $1
+	`
+
+	var bot entity.BotDTO
+	err := r.QueryRowContext(ctx, getBotSQL, botUUID).
+		Scan(&bot.BotUUID, &bot.OwnerUUID, &bot.Name, &bot.CreatedAt, &bot.DeletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("BotRepo - GetBot - r.QueryRowContext: %w", err)
+	}
+
+	return &bot, nil
+}
+
+// GetOwnerBots returns the bots of the owner that were not deleted.
+func (r *BotRepo) GetOwnerBots(ctx context.Context, ownerUUID string) ([]entity.BotDTO, error) {
+	getOwnerBotsSQL := `
+		SELECT bot_uuid, owner_uuid, name, created_at, deleted_at
+		FROM bots
+		WHERE owner_uuid = $1
+		AND deleted_at IS NULL
+		ORDER BY created_at
+	`
+
+	rows, err := r.QueryContext(ctx, getOwnerBotsSQL, ownerUUID)
+	if err != nil {
+		return nil, fmt.Errorf("BotRepo - GetOwnerBots - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var bots []entity.BotDTO
+	for rows.Next() {
+		var bot entity.BotDTO
+		if err := rows.Scan(&bot.BotUUID, &bot.OwnerUUID, &bot.Name, &bot.CreatedAt, &bot.DeletedAt); err != nil {
+			return nil, fmt.Errorf("BotRepo - GetOwnerBots - rows.Scan: %w", err)
+		}
+		bots = append(bots, bot)
+	}
+
+	return bots, rows.Err()
+}
+
+// DeleteBot revokes the bot's API keys and removes it from its conversations. Its messages are kept.
+func (r *BotRepo) DeleteBot(ctx context.Context, botUUID string) error {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("BotRepo - DeleteBot - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	deleteBotSQL := []string{
+		`UPDATE bots SET deleted_at = NOW() WHERE bot_uuid = $1 AND deleted_at IS NULL`,
+		`UPDATE api_keys SET revoked_at = NOW() WHERE bot_uuid = $1 AND revoked_at IS NULL`,
+		`DELETE FROM participants WHERE user_uuid = $1`,
+	}
+	for _, query := range deleteBotSQL {
+		_, err = tx.ExecContext(ctx, query, botUUID)
+		if err != nil {
+			return fmt.Errorf("failed to execute %q query: %w", query, err)
+		}
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("BotRepo - DeleteBot - failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// StoreAPIKey -.
+func (r *BotRepo) StoreAPIKey(ctx context.Context, key entity.APIKeyDTO) error {
+	insertAPIKeySQL := `
+		INSERT INTO api_keys (key_uuid, bot_uuid, name, key_hash, scopes, conversation_uuids, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.ExecContext(ctx, insertAPIKeySQL, key.KeyUUID, key.BotUUID, key.Name, key.KeyHash,
+		pq.Array(key.Scopes), pq.Array(key.ConversationUUIDs), key.CreatedAt, key.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("BotRepo - StoreAPIKey - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// GetAPIKeyByHash returns the key together with the state of its bot and of the bot's owner.
+// Keys of bots whose owner was erased are not found.
+func (r *BotRepo) GetAPIKeyByHash(ctx context.Context, keyHash string) (*entity.APIKeyDTO, error) {
+	getAPIKeyByHashSQL := `
+		SELECT k.key_uuid, k.bot_uuid, k.name, k.key_hash, k.scopes, k.conversation_uuids, k.created_at, k.last_used_at,
+			k.expires_at, k.revoked_at, b.deleted_at, uc.suspended_at, uc.delete_after
+		FROM api_keys k
+		JOIN bots b ON b.bot_uuid = k.bot_uuid
+		JOIN user_credentials uc ON uc.user_uuid = b.owner_uuid
+		WHERE k.key_hash = $1
+	`
+
+	var key entity.APIKeyDTO
+	err := r.QueryRowContext(ctx, getAPIKeyByHashSQL, keyHash).Scan(&key.KeyUUID, &key.BotUUID, &key.Name, &key.KeyHash,
+		pq.Array(&key.Scopes), pq.Array(&key.ConversationUUIDs), &key.CreatedAt, &key.LastUsedAt, &key.ExpiresAt, &key.RevokedAt,
+		&key.BotDeletedAt, &key.OwnerSuspendedAt, &key.OwnerDeleteAfter)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("BotRepo - GetAPIKeyByHash - r.QueryRowContext: %w", err)
+	}
+
+	return &key, nil
+}
+
+// GetBotAPIKeys returns the bot's API keys that were not revoked.
+func (r *BotRepo) GetBotAPIKeys(ctx context.Context, botUUID string) ([]entity.APIKeyDTO, error) {
+	keys, err := r.queryAPIKeys(ctx, selectAPIKeySQL+`WHERE bot_uuid = $1 AND revoked_at IS NULL ORDER BY created_at`, botUUID)
+	if err != nil {
+		return nil, fmt.Errorf("BotRepo - GetBotAPIKeys - r.queryAPIKeys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *BotRepo) queryAPIKeys(ctx context.Context, query string, args ...interface{}) ([]entity.APIKeyDTO, error) {
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []entity.APIKeyDTO
+	for rows.Next() {
+		var key entity.APIKeyDTO
+		if err := rows.Scan(&key.KeyUUID, &key.BotUUID, &key.Name, &key.KeyHash, pq.Array(&key.Scopes),
+			pq.Array(&key.ConversationUUIDs), &key.CreatedAt, &key.LastUsedAt, &key.ExpiresAt, &key.RevokedAt); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey -.
+func (r *BotRepo) RevokeAPIKey(ctx context.Context, botUUID string, keyUUID string) (bool, error) {
+	revokeAPIKeySQL := `
+		UPDATE api_keys
+		SET revoked_at = NOW()
+		WHERE bot_uuid = $1
+		AND key_uuid = $2
+		AND revoked_at IS NULL
+	`
+
+	result, err := r.ExecContext(ctx, revokeAPIKeySQL, botUUID, keyUUID)
+	if err != nil {
+		return false, fmt.Errorf("BotRepo - RevokeAPIKey - r.ExecContext: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("BotRepo - RevokeAPIKey - result.RowsAffected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// TouchAPIKey -.
+func (r *BotRepo) TouchAPIKey(ctx context.Context, keyUUID string, usedAt time.Time) error {
+	touchAPIKeySQL := `
+		UPDATE api_keys
+		SET last_used_at = $1
+		WHERE key_uuid = $2
+	`
+
+	_, err := r.ExecContext(ctx, touchAPIKeySQL, usedAt, keyUUID)
+	if err != nil {
+		return fmt.Errorf("BotRepo - TouchAPIKey - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
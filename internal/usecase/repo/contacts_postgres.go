@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/lib/pq"
+
 	"github.com/maxyong7/chat-messaging-app/internal/entity"
 )
 
@@ -40,93 +42,135 @@ func (r *ContactsRepo) CheckContactExist(ctx context.Context, userUuid string, c
 
 }
 
-func (r *ContactsRepo) GetContactsByUserUUID(ctx context.Context, userUuid string) ([]entity.Contacts, error) {
+// GetContact returns the contact row of the user, including removed contacts. It is nil if there is none.
+func (r *ContactsRepo) GetContact(ctx context.Context, userUUID string, contactUserUUID string) (*entity.ContactsDTO, error) {
+	getContactSQL := `
+		SELECT user_uuid, contact_user_uuid, conversation_uuid, blocked, removed
+		FROM contacts
+		WHERE user_uuid = $1
+		AND contact_user_uuid = $2
+	`
+
+	var contact entity.ContactsDTO
+	err := r.QueryRowContext(ctx, getContactSQL, userUUID, contactUserUUID).Scan(
+		&contact.UserUUID, &contact.ContactUserUUID, &contact.ConversationUUID, &contact.Blocked, &contact.Removed)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ContactsRepo - GetContact - r.QueryRowContext: %w", err)
+	}
+
+	return &contact, nil
+}
+
+// GetContactsByUserUUID returns a page of the contacts of the user matching the query.
+// The page starts after the contact of the cursor, the sort key of that contact is looked up again so that the cursor stays short.
+func (r *ContactsRepo) GetContactsByUserUUID(ctx context.Context, query entity.ContactsQuery) ([]entity.Contacts, error) {
 	// Define the SQL query.
-	query := `
-		SELECT 
-			ct.user_uuid,
-			ui.first_name,
-			ui.last_name,
-			ui.avatar,
-			ct.conversation_uuid,
-			ct.blocked
-		FROM contacts ct
-		LEFT JOIN user_info ui ON ct.contact_user_uuid = ui.user_uuid
-		WHERE ct.user_uuid = $1
-		AND ct.removed != true
-		ORDER BY ui.first_name;
+	// Contacts without a nickname are ordered by their name, favourites come first when sorting by favourite.
+	getContactsSQL := `
+		WITH listed AS (
+			SELECT
+				ct.contact_user_uuid,
+				ui.first_name,
+				ui.last_name,
+				ui.avatar,
+				ct.conversation_uuid,
+				ct.blocked,
+				ct.removed,
+				ct.nickname,
+				ct.favourite,
+				ct.labels,
+				ui.status_emoji,
+				ui.status_text,
+				ui.status_expires_at,
+				CASE WHEN $2::TEXT = 'favourite' AND ct.favourite THEN 0 ELSE 1 END AS sort_rank,
+				LOWER(COALESCE(NULLIF(ct.nickname, ''), ui.first_name || ' ' || ui.last_name, '')) AS sort_name
+			FROM contacts ct
+			LEFT JOIN user_info ui ON ct.contact_user_uuid = ui.user_uuid
+			WHERE ct.user_uuid = $1
+		)
+		SELECT
+			contact_user_uuid,
+			first_name,
+			last_name,
+			avatar,
+			conversation_uuid,
+			blocked,
+			nickname,
+			favourite,
+			labels,
+			status_emoji,
+			status_text,
+			status_expires_at
+		FROM listed
+		WHERE removed != true
+		AND ($3::TEXT = '' OR $3::TEXT = ANY(labels))
+		AND (NOT $4::BOOLEAN OR favourite)
+		AND ($5::TEXT = '' OR (sort_rank, sort_name, contact_user_uuid) > (
+			SELECT sort_rank, sort_name, contact_user_uuid
+			FROM listed
+			WHERE contact_user_uuid = $5
+			LIMIT 1
+		))
+		ORDER BY sort_rank, sort_name, contact_user_uuid
+		LIMIT $6;
 		`
 
-	rows, err := r.QueryContext(ctx, query, userUuid)
+	rows, err := r.QueryContext(ctx, getContactsSQL, query.UserUUID, query.Sort, query.Label, query.Favourite, query.Cursor, query.Limit)
 	if err != nil {
 		return nil, fmt.Errorf("ContactsRepo - GetContactsByUserUUID - r.QueryContext: %w", err)
 	}
 	defer rows.Close()
-	// Collect the conversation UUIDs.
+
 	var contacts []entity.Contacts
 	for rows.Next() {
 		var contact entity.Contacts
-		if err := rows.Scan(&contact.UserUUID, &contact.FirstName, &contact.LastName, &contact.Avatar, &contact.ConversationUUID, &contact.Blocked); err != nil {
+		var labels pq.StringArray
+		var statusEmoji, statusText sql.NullString
+		var statusExpiresAt sql.NullTime
+		if err := rows.Scan(&contact.UserUUID, &contact.FirstName, &contact.LastName, &contact.Avatar, &contact.ConversationUUID, &contact.Blocked,
+			&contact.Nickname, &contact.Favourite, &labels, &statusEmoji, &statusText, &statusExpiresAt); err != nil {
 			return nil, fmt.Errorf("ContactsRepo - GetContactsByUserUUID - rows.Scan: %w", err)
 		}
+		contact.Labels = []string(labels)
+		contact.Status = toUserStatus(statusEmoji, statusText, statusExpiresAt)
 		contacts = append(contacts, contact)
 	}
-	return contacts, nil
+	return contacts, rows.Err()
 }
 
-// StoreContacts -.
-func (r *ContactsRepo) StoreContacts(ctx context.Context, contacts entity.ContactsDTO) error {
-	// Begin a transaction
-	tx, err := r.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("ContactsRepo - StoreContacts - failed to begin transaction: %w", err)
-	}
-
-	// Ensure transaction is rolled back if it doesn't commit
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p) // Re-throw panic after rollback
-		} else if err != nil {
-			tx.Rollback() // err is non-nil; rollback
-		}
-	}()
-
-	insertContactAsUserSQL := `
-		INSERT INTO contacts (user_uuid, contact_user_uuid, conversation_uuid, blocked)
-		VALUES ($1, $2, $3, $4)
-		`
-	_, err = tx.ExecContext(ctx, insertContactAsUserSQL, contacts.UserUUID, contacts.ContactUserUUID, contacts.ConversationUUID, contacts.Blocked)
-	if err != nil {
-		return fmt.Errorf("failed to execute insert insertContactAsUserSQL query: %w", err)
-	}
+// UpdateContactDetails sets the nickname, favourite and labels of the contact, nil details are left unchanged.
+// It returns false if the user has no such contact.
+func (r *ContactsRepo) UpdateContactDetails(ctx context.Context, userUUID string, contactUserUUID string, details entity.ContactDetails) (bool, error) {
+	updateContactDetailsSQL := `
+		UPDATE contacts
+		SET nickname = COALESCE($3, nickname),
+			favourite = COALESCE($4, favourite),
+			labels = COALESCE($5::TEXT[], labels)
+		WHERE user_uuid = $1
+		AND contact_user_uuid = $2
+		AND removed != true
+	`
 
-	insertContactAsContactSQL := `
-		INSERT INTO contacts (user_uuid, contact_user_uuid, conversation_uuid, blocked)
-		VALUES ($1, $2, $3, $4)
-		`
-	_, err = tx.ExecContext(ctx, insertContactAsContactSQL, contacts.ContactUserUUID, contacts.UserUUID, contacts.ConversationUUID, contacts.Blocked)
-	if err != nil {
-		return fmt.Errorf("failed to execute insert insertContactAsContactSQL query: %w", err)
+	// A nil array is stored as NULL, which keeps the current labels
+	var labels interface{}
+	if details.Labels != nil {
+		labels = pq.Array(*details.Labels)
 	}
 
-	insertConversationsSQL := `
-	INSERT INTO conversations (
-		conversation_uuid, conversation_type
-	) VALUES ($1, $2)
-	`
-	_, err = tx.ExecContext(ctx, insertConversationsSQL, contacts.ConversationUUID, entity.DirectMessageConversationType)
+	result, err := r.ExecContext(ctx, updateContactDetailsSQL, userUUID, contactUserUUID, details.Nickname, details.Favourite, labels)
 	if err != nil {
-		return fmt.Errorf("failed to execute insert insertConversationsSQL query: %w", err)
+		return false, fmt.Errorf("failed to execute updateContactDetailsSQL query: %w", err)
 	}
 
-	// Commit the transaction
-	err = tx.Commit()
+	affected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("ContactsRepo - StoreContacts - failed to commit transaction: %w", err)
+		return false, fmt.Errorf("ContactsRepo - UpdateContactDetails - result.RowsAffected: %w", err)
 	}
 
-	return nil
+	return affected > 0, nil
 }
 
 // UpdateBlockedStatus -.
@@ -158,6 +202,11 @@ func (r *ContactsRepo) UpdateBlockedStatus(ctx context.Context, contacts entity.
 		return fmt.Errorf("failed to execute updateBlockedSQL query: %w", err)
 	}
 
+	err = invalidateSuggestions(ctx, tx, contacts.UserUUID, contacts.ContactUserUUID)
+	if err != nil {
+		return err
+	}
+
 	// Commit the transaction
 	err = tx.Commit()
 	if err != nil {
@@ -196,6 +245,11 @@ func (r *ContactsRepo) UpdateRemovedStatus(ctx context.Context, contacts entity.
 		return fmt.Errorf("failed to execute insert updateRemovedSQL query: %w", err)
 	}
 
+	err = invalidateSuggestions(ctx, tx, contacts.UserUUID, contacts.ContactUserUUID)
+	if err != nil {
+		return err
+	}
+
 	// Commit the transaction
 	err = tx.Commit()
 	if err != nil {
@@ -204,3 +258,487 @@ func (r *ContactsRepo) UpdateRemovedStatus(ctx context.Context, contacts entity.
 
 	return nil
 }
+
+// GetContactUUIDs returns the users in the contact list of the user who are told about changes of their presence and status.
+// Removed contacts and contacts with a block in either direction are left out.
+func (r *ContactsRepo) GetContactUUIDs(ctx context.Context, userUUID string) ([]string, error) {
+	getContactUUIDsSQL := `
+		SELECT ct.contact_user_uuid
+		FROM contacts ct
+		WHERE ct.user_uuid = $1
+		AND ct.removed != true
+		AND NOT EXISTS (
+			SELECT 1
+			FROM contacts b
+			WHERE b.blocked
+			AND (
+				(b.user_uuid = $1 AND b.contact_user_uuid = ct.contact_user_uuid)
+				OR (b.user_uuid = ct.contact_user_uuid AND b.contact_user_uuid = $1)
+			)
+		)
+	`
+
+	rows, err := r.QueryContext(ctx, getContactUUIDsSQL, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("ContactsRepo - GetContactUUIDs - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var contactUUIDs []string
+	for rows.Next() {
+		var contactUUID string
+		if err := rows.Scan(&contactUUID); err != nil {
+			return nil, fmt.Errorf("ContactsRepo - GetContactUUIDs - rows.Scan: %w", err)
+		}
+		contactUUIDs = append(contactUUIDs, contactUUID)
+	}
+
+	return contactUUIDs, rows.Err()
+}
+
+// CheckContactOfContact returns whether the other user is a contact of the user or a contact of one of their contacts.
+// Removed contacts do not count.
+func (r *ContactsRepo) CheckContactOfContact(ctx context.Context, userUUID string, otherUserUUID string) (bool, error) {
+	checkContactOfContactSQL := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM contacts ct
+			WHERE ct.user_uuid = $1
+			AND ct.contact_user_uuid = $2
+			AND ct.removed != true
+		) OR EXISTS (
+			SELECT 1
+			FROM contacts ct
+			JOIN contacts cc ON cc.user_uuid = ct.contact_user_uuid
+			WHERE ct.user_uuid = $1
+			AND ct.removed != true
+			AND cc.contact_user_uuid = $2
+			AND cc.removed != true
+		)
+	`
+
+	var exist bool
+	err := r.QueryRowContext(ctx, checkContactOfContactSQL, userUUID, otherUserUUID).Scan(&exist)
+	if err != nil {
+		return false, fmt.Errorf("ContactsRepo - CheckContactOfContact - r.QueryRowContext: %w", err)
+	}
+
+	return exist, nil
+}
+
+// CheckBlocked returns whether either user blocked the other.
+func (r *ContactsRepo) CheckBlocked(ctx context.Context, userUUID string, otherUserUUID string) (bool, error) {
+	checkBlockedSQL := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM contacts
+			WHERE blocked
+			AND (
+				(user_uuid = $1 AND contact_user_uuid = $2)
+				OR (user_uuid = $2 AND contact_user_uuid = $1)
+			)
+		)
+	`
+
+	var blocked bool
+	err := r.QueryRowContext(ctx, checkBlockedSQL, userUUID, otherUserUUID).Scan(&blocked)
+	if err != nil {
+		return false, fmt.Errorf("ContactsRepo - CheckBlocked - r.QueryRowContext: %w", err)
+	}
+
+	return blocked, nil
+}
+
+// CheckConversationBlocked returns whether the conversation is the direct conversation of the user with someone,
+// and either of them blocked the other. Group conversations are never blocked.
+func (r *ContactsRepo) CheckConversationBlocked(ctx context.Context, conversationUUID string, userUUID string) (bool, error) {
+	// Both contact rows of a direct conversation share its uuid
+	checkConversationBlockedSQL := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM contacts
+			WHERE conversation_uuid = $1
+			AND blocked
+			AND (user_uuid = $2 OR contact_user_uuid = $2)
+		)
+	`
+
+	var blocked bool
+	err := r.QueryRowContext(ctx, checkConversationBlockedSQL, conversationUUID, userUUID).Scan(&blocked)
+	if err != nil {
+		return false, fmt.Errorf("ContactsRepo - CheckConversationBlocked - r.QueryRowContext: %w", err)
+	}
+
+	return blocked, nil
+}
+
+// CheckMessageBlocked is CheckConversationBlocked for the conversation of the message.
+func (r *ContactsRepo) CheckMessageBlocked(ctx context.Context, messageUUID string, userUUID string) (bool, error) {
+	checkMessageBlockedSQL := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM messages m
+			JOIN contacts ct ON ct.conversation_uuid = m.conversation_uuid
+			WHERE m.message_uuid = $1
+			AND ct.blocked
+			AND (ct.user_uuid = $2 OR ct.contact_user_uuid = $2)
+		)
+	`
+
+	var blocked bool
+	err := r.QueryRowContext(ctx, checkMessageBlockedSQL, messageUUID, userUUID).Scan(&blocked)
+	if err != nil {
+		return false, fmt.Errorf("ContactsRepo - CheckMessageBlocked - r.QueryRowContext: %w", err)
+	}
+
+	return blocked, nil
+}
+
+// GetContactRequest returns the contact request, whatever its status. It is nil if there is none.
+func (r *ContactsRepo) GetContactRequest(ctx context.Context, requestUUID string) (*entity.ContactRequestDTO, error) {
+	getContactRequestSQL := `
+		SELECT request_uuid, from_user_uuid, to_user_uuid, status, created_at, responded_at
+		FROM contact_requests
+		WHERE request_uuid = $1
+	`
+
+	return r.scanContactRequest(r.QueryRowContext(ctx, getContactRequestSQL, requestUUID), "GetContactRequest")
+}
+
+// GetPendingContactRequest returns the pending contact request from the user to the other user. It is nil if there is none.
+func (r *ContactsRepo) GetPendingContactRequest(ctx context.Context, fromUserUUID string, toUserUUID string) (*entity.ContactRequestDTO, error) {
+	getPendingContactRequestSQL := `
+		SELECT request_uuid, from_user_uuid, to_user_uuid, status, created_at, responded_at
+		FROM contact_requests
+		WHERE from_user_uuid = $1
+		AND to_user_uuid = $2
+		AND status = 'pending'
+	`
+
+	return r.scanContactRequest(r.QueryRowContext(ctx, getPendingContactRequestSQL, fromUserUUID, toUserUUID), "GetPendingContactRequest")
+}
+
+func (r *ContactsRepo) scanContactRequest(row *sql.Row, method string) (*entity.ContactRequestDTO, error) {
+	var request entity.ContactRequestDTO
+	var respondedAt sql.NullTime
+	err := row.Scan(&request.RequestUUID, &request.FromUserUUID, &request.ToUserUUID, &request.Status, &request.CreatedAt, &respondedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ContactsRepo - %s - row.Scan: %w", method, err)
+	}
+	if respondedAt.Valid {
+		request.RespondedAt = &respondedAt.Time
+	}
+
+	return &request, nil
+}
+
+// StoreContactRequest stores a pending contact request.
+func (r *ContactsRepo) StoreContactRequest(ctx context.Context, request entity.ContactRequestDTO) error {
+	insertContactRequestSQL := `
+		INSERT INTO contact_requests (request_uuid, from_user_uuid, to_user_uuid, status, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.ExecContext(ctx, insertContactRequestSQL,
+		request.RequestUUID, request.FromUserUUID, request.ToUserUUID, request.Status, request.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to execute insert insertContactRequestSQL query: %w", err)
+	}
+
+	return nil
+}
+
+// GetContactRequests returns the pending contact requests sent to the user or sent by the user, from the newest.
+// The profile of the other user of each request is joined from 'user_info' table.
+func (r *ContactsRepo) GetContactRequests(ctx context.Context, userUUID string, direction string) ([]entity.ContactRequest, error) {
+	// The other user is the sender of incoming requests and the recipient of outgoing requests
+	userColumn, otherUserColumn := "cr.to_user_uuid", "cr.from_user_uuid"
+	if direction == entity.ContactRequestsOutgoing {
+		userColumn, otherUserColumn = otherUserColumn, userColumn
+	}
+
+	getContactRequestsSQL := fmt.Sprintf(`
+		SELECT
+			cr.request_uuid,
+			cr.from_user_uuid,
+			cr.to_user_uuid,
+			cr.status,
+			cr.created_at,
+			uc.username,
+			COALESCE(ui.first_name, ''),
+			COALESCE(ui.last_name, ''),
+			COALESCE(ui.avatar, '')
+		FROM contact_requests cr
+		JOIN user_credentials uc ON uc.user_uuid = %[2]s
+		LEFT JOIN user_info ui ON ui.user_uuid = %[2]s
+		WHERE %[1]s = $1
+		AND cr.status = 'pending'
+		ORDER BY cr.created_at DESC
+	`, userColumn, otherUserColumn)
+
+	rows, err := r.QueryContext(ctx, getContactRequestsSQL, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("ContactsRepo - GetContactRequests - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []entity.ContactRequest
+	for rows.Next() {
+		var request entity.ContactRequest
+		if err := rows.Scan(&request.RequestUUID, &request.FromUserUUID, &request.ToUserUUID, &request.Status, &request.CreatedAt,
+			&request.Username, &request.User.FirstName, &request.User.LastName, &request.User.Avatar); err != nil {
+			return nil, fmt.Errorf("ContactsRepo - GetContactRequests - rows.Scan: %w", err)
+		}
+		request.User.UserUUID = request.FromUserUUID
+		if direction == entity.ContactRequestsOutgoing {
+			request.User.UserUUID = request.ToUserUUID
+		}
+		requests = append(requests, request)
+	}
+
+	return requests, rows.Err()
+}
+
+// UpdateContactRequestStatus answers or cancels a pending contact request.
+// It returns false if the request is not pending anymore.
+func (r *ContactsRepo) UpdateContactRequestStatus(ctx context.Context, requestUUID string, status string) (bool, error) {
+	updateContactRequestStatusSQL := `
+		UPDATE contact_requests
+		SET status = $1, responded_at = NOW()
+		WHERE request_uuid = $2
+		AND status = 'pending'
+	`
+	result, err := r.ExecContext(ctx, updateContactRequestStatusSQL, status, requestUUID)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute updateContactRequestStatusSQL query: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ContactsRepo - UpdateContactRequestStatus - result.RowsAffected: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// AcceptContactRequest accepts the pending contact request and adds both users to the contacts of each other.
+// Contacts that were removed before are restored with their direct conversation, otherwise the conversation of the request is created.
+// It returns the direct conversation of both users, it is empty if the request is not pending anymore.
+func (r *ContactsRepo) AcceptContactRequest(ctx context.Context, request entity.ContactRequestDTO) (string, error) {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("ContactsRepo - AcceptContactRequest - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	acceptContactRequestSQL := `
+		UPDATE contact_requests
+		SET status = 'accepted', responded_at = NOW()
+		WHERE request_uuid = $1
+		AND status = 'pending'
+	`
+	result, err := tx.ExecContext(ctx, acceptContactRequestSQL, request.RequestUUID)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute acceptContactRequestSQL query: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return "", fmt.Errorf("ContactsRepo - AcceptContactRequest - result.RowsAffected: %w", err)
+	}
+	if affected == 0 {
+		tx.Rollback()
+		return "", nil
+	}
+
+	// Both users keep the direct conversation they had before one of them removed the other
+	getConversationSQL := `
+		SELECT conversation_uuid
+		FROM contacts
+		WHERE (user_uuid = $1 AND contact_user_uuid = $2)
+		OR (user_uuid = $2 AND contact_user_uuid = $1)
+		LIMIT 1
+	`
+	conversationUUID := request.ConversationUUID
+	err = tx.QueryRowContext(ctx, getConversationSQL, request.FromUserUUID, request.ToUserUUID).Scan(&conversationUUID)
+	switch {
+	case err == sql.ErrNoRows:
+		insertConversationsSQL := `
+		INSERT INTO conversations (
+			conversation_uuid, conversation_type
+		) VALUES ($1, $2)
+		`
+		_, err = tx.ExecContext(ctx, insertConversationsSQL, conversationUUID, entity.DirectMessageConversationType)
+		if err != nil {
+			return "", fmt.Errorf("failed to execute insert insertConversationsSQL query: %w", err)
+		}
+	case err != nil:
+		return "", fmt.Errorf("ContactsRepo - AcceptContactRequest - tx.QueryRowContext: %w", err)
+	}
+
+	restoreContactsSQL := `
+		UPDATE contacts
+		SET removed = false
+		WHERE (user_uuid = $1 AND contact_user_uuid = $2)
+		OR (user_uuid = $2 AND contact_user_uuid = $1)
+	`
+	_, err = tx.ExecContext(ctx, restoreContactsSQL, request.FromUserUUID, request.ToUserUUID)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute restoreContactsSQL query: %w", err)
+	}
+
+	insertContactSQL := `
+		INSERT INTO contacts (user_uuid, contact_user_uuid, conversation_uuid)
+		SELECT $1, $2, $3
+		WHERE NOT EXISTS (
+			SELECT 1 FROM contacts WHERE user_uuid = $1 AND contact_user_uuid = $2
+		)
+	`
+	_, err = tx.ExecContext(ctx, insertContactSQL, request.FromUserUUID, request.ToUserUUID, conversationUUID)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute insert insertContactSQL query: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, insertContactSQL, request.ToUserUUID, request.FromUserUUID, conversationUUID)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute insert insertContactSQL query: %w", err)
+	}
+
+	// Both users now have mutual contacts with the contacts of the other user
+	err = invalidateSuggestions(ctx, tx, request.FromUserUUID, request.ToUserUUID)
+	if err != nil {
+		return "", err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return "", fmt.Errorf("ContactsRepo - AcceptContactRequest - failed to commit transaction: %w", err)
+	}
+
+	return conversationUUID, nil
+}
+
+// MatchEmailHashes returns the discoverable users whose email address hashed with the salt is one of the hashes.
+// The hashes are only compared in the query, they are never stored.
+// Users who blocked the user or were blocked by them, and suspended accounts or accounts that are about to be deleted are left out.
+func (r *ContactsRepo) MatchEmailHashes(ctx context.Context, userUUID string, salt string, hashes []string) ([]entity.ContactMatch, error) {
+	matchEmailHashesSQL := `
+		SELECT matches.hash, matches.user_uuid, matches.username,
+			COALESCE(ui.first_name, ''), COALESCE(ui.last_name, ''), COALESCE(ui.avatar, ''),
+			EXISTS (
+				SELECT 1
+				FROM contacts ct
+				WHERE ct.user_uuid = $1
+				AND ct.contact_user_uuid = matches.user_uuid
+				AND ct.removed != true
+			)
+		FROM (
+			SELECT uc.user_uuid, uc.username,
+				encode(sha256(convert_to($2 || LOWER(TRIM(uc.email)), 'UTF8')), 'hex') AS hash
+			FROM user_credentials uc
+			WHERE uc.user_uuid <> $1
+			AND uc.delete_after IS NULL
+			AND uc.suspended_at IS NULL
+		) matches
+		LEFT JOIN user_info ui ON ui.user_uuid = matches.user_uuid
+		LEFT JOIN privacy_settings ps ON ps.user_uuid = matches.user_uuid
+		WHERE matches.hash = ANY($3)
+		AND COALESCE(ps.discoverable, TRUE)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM contacts b
+			WHERE b.blocked
+			AND (
+				(b.user_uuid = $1 AND b.contact_user_uuid = matches.user_uuid)
+				OR (b.user_uuid = matches.user_uuid AND b.contact_user_uuid = $1)
+			)
+		)
+		ORDER BY matches.username
+	`
+
+	rows, err := r.QueryContext(ctx, matchEmailHashesSQL, userUUID, salt, pq.Array(hashes))
+	if err != nil {
+		return nil, fmt.Errorf("ContactsRepo - MatchEmailHashes - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []entity.ContactMatch
+	for rows.Next() {
+		var match entity.ContactMatch
+		if err := rows.Scan(&match.Hash, &match.User.UserUUID, &match.Username,
+			&match.User.FirstName, &match.User.LastName, &match.User.Avatar, &match.Contact); err != nil {
+			return nil, fmt.Errorf("ContactsRepo - MatchEmailHashes - rows.Scan: %w", err)
+		}
+		matches = append(matches, match)
+	}
+
+	return matches, rows.Err()
+}
+
+// StoreContactRequests stores the pending contact requests in one transaction and returns the ones that were stored.
+// Requests are skipped if a request between the same users is already pending, in either direction.
+func (r *ContactsRepo) StoreContactRequests(ctx context.Context, requests []entity.ContactRequestDTO) ([]entity.ContactRequestDTO, error) {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ContactsRepo - StoreContactRequests - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	insertContactRequestSQL := `
+		INSERT INTO contact_requests (request_uuid, from_user_uuid, to_user_uuid, status, created_at)
+		SELECT $1, $2, $3, $4, $5
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM contact_requests
+			WHERE from_user_uuid = $3
+			AND to_user_uuid = $2
+			AND status = 'pending'
+		)
+		ON CONFLICT (from_user_uuid, to_user_uuid) WHERE status = 'pending' DO NOTHING
+		RETURNING request_uuid
+	`
+
+	var stored []entity.ContactRequestDTO
+	for _, request := range requests {
+		var requestUUID string
+		err = tx.QueryRowContext(ctx, insertContactRequestSQL,
+			request.RequestUUID, request.FromUserUUID, request.ToUserUUID, request.Status, request.CreatedAt).Scan(&requestUUID)
+		if err == sql.ErrNoRows {
+			err = nil
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute insert insertContactRequestSQL query: %w", err)
+		}
+		stored = append(stored, request)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("ContactsRepo - StoreContactRequests - failed to commit transaction: %w", err)
+	}
+
+	return stored, nil
+}
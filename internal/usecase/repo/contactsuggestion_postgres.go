@@ -0,0 +1,252 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// ContactSuggestionRepo -.
+type ContactSuggestionRepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewContactSuggestion(pg *sql.DB) *ContactSuggestionRepo {
+	return &ContactSuggestionRepo{pg}
+}
+
+// GetSuggestionState returns when the suggestions of the user were computed, nil if they never were.
+func (r *ContactSuggestionRepo) GetSuggestionState(ctx context.Context, userUUID string) (*entity.ContactSuggestionState, error) {
+	getSuggestionStateSQL := `
+		SELECT computed_at, invalidated_at
+		FROM contact_suggestion_state
+		WHERE user_uuid = $1
+	`
+
+	var state entity.ContactSuggestionState
+	var invalidatedAt sql.NullTime
+	err := r.QueryRowContext(ctx, getSuggestionStateSQL, userUUID).Scan(&state.ComputedAt, &invalidatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ContactSuggestionRepo - GetSuggestionState - r.QueryRowContext: %w", err)
+	}
+	if invalidatedAt.Valid {
+		state.InvalidatedAt = &invalidatedAt.Time
+	}
+
+	return &state, nil
+}
+
+// RefreshSuggestions replaces the cached suggestions of the user with the limit best ranked users.
+// Only the contacts and the group chats around the user are joined, so the cost does not grow with the number of users.
+func (r *ContactSuggestionRepo) RefreshSuggestions(ctx context.Context, userUUID string, limit int) error {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ContactSuggestionRepo - RefreshSuggestions - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	deleteSuggestionsSQL := `
+		DELETE FROM contact_suggestions
+		WHERE user_uuid = $1
+	`
+	_, err = tx.ExecContext(ctx, deleteSuggestionsSQL, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute deleteSuggestionsSQL query: %w", err)
+	}
+
+	// Mutual contacts are the contacts of the user who have the suggested user as a contact,
+	// shared groups are the group chats both users take part in.
+	// Users the user already has a row for in 'contacts' are never suggested, also once removed or blocked.
+	insertSuggestionsSQL := `
+		WITH my_contacts AS (
+			SELECT contact_user_uuid
+			FROM contacts
+			WHERE user_uuid = $1
+			AND removed != true
+			AND blocked != true
+		),
+		mutual AS (
+			SELECT ct.contact_user_uuid AS user_uuid, COUNT(DISTINCT ct.user_uuid) AS mutual_contacts
+			FROM contacts ct
+			JOIN my_contacts mc ON mc.contact_user_uuid = ct.user_uuid
+			WHERE ct.removed != true
+			AND ct.blocked != true
+			GROUP BY ct.contact_user_uuid
+		),
+		shared AS (
+			SELECT other.user_uuid, COUNT(DISTINCT other.conversation_uuid) AS shared_groups
+			FROM participants mine
+			JOIN conversations cv ON cv.conversation_uuid = mine.conversation_uuid
+			JOIN participants other ON other.conversation_uuid = mine.conversation_uuid
+			WHERE mine.user_uuid = $1
+			AND cv.conversation_type = $3
+			AND other.user_uuid <> $1
+			AND mine.left_date IS NULL
+			AND other.left_date IS NULL
+			GROUP BY other.user_uuid
+		)
+		INSERT INTO contact_suggestions (user_uuid, suggested_user_uuid, mutual_contacts, shared_groups)
+		SELECT $1, candidates.user_uuid, candidates.mutual_contacts, candidates.shared_groups
+		FROM (
+			SELECT COALESCE(m.user_uuid, s.user_uuid) AS user_uuid,
+				COALESCE(m.mutual_contacts, 0) AS mutual_contacts,
+				COALESCE(s.shared_groups, 0) AS shared_groups
+			FROM mutual m
+			FULL OUTER JOIN shared s ON s.user_uuid = m.user_uuid
+		) candidates
+		WHERE candidates.user_uuid <> $1
+		AND NOT EXISTS (
+			SELECT 1
+			FROM contacts ct
+			WHERE ct.user_uuid = $1
+			AND ct.contact_user_uuid = candidates.user_uuid
+		)
+		ORDER BY candidates.mutual_contacts DESC, candidates.shared_groups DESC, candidates.user_uuid
+		LIMIT $2
+		ON CONFLICT (user_uuid, suggested_user_uuid) DO UPDATE
+		SET mutual_contacts = EXCLUDED.mutual_contacts, shared_groups = EXCLUDED.shared_groups
+	`
+	_, err = tx.ExecContext(ctx, insertSuggestionsSQL, userUUID, limit, entity.GroupMessageConversationType)
+	if err != nil {
+		return fmt.Errorf("failed to execute insert insertSuggestionsSQL query: %w", err)
+	}
+
+	// NOW() is the start of the transaction, changes made while computing are invalidated later with clock_timestamp(),
+	// so the suggestions are computed again on the next request
+	storeSuggestionStateSQL := `
+		INSERT INTO contact_suggestion_state (user_uuid, computed_at)
+		VALUES ($1, NOW())
+		ON CONFLICT (user_uuid) DO UPDATE
+		SET computed_at = EXCLUDED.computed_at
+	`
+	_, err = tx.ExecContext(ctx, storeSuggestionStateSQL, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute insert storeSuggestionStateSQL query: %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("ContactSuggestionRepo - RefreshSuggestions - failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetSuggestions returns the cached suggestions of the user, best ranked first.
+// Blocks, privacy settings, pending requests and deleted or suspended accounts are checked on every read, so that they apply right away.
+func (r *ContactSuggestionRepo) GetSuggestions(ctx context.Context, userUUID string, limit int) ([]entity.ContactSuggestion, error) {
+	// Users who only accept contacts of their contacts are suggested when they have mutual contacts.
+	// Bots have no credentials, so they are never suggested.
+	getSuggestionsSQL := `
+		SELECT cs.suggested_user_uuid, uc.username,
+			COALESCE(ui.first_name, ''), COALESCE(ui.last_name, ''), COALESCE(ui.avatar, ''),
+			cs.mutual_contacts, cs.shared_groups
+		FROM contact_suggestions cs
+		JOIN user_credentials uc ON uc.user_uuid = cs.suggested_user_uuid
+		LEFT JOIN user_info ui ON ui.user_uuid = cs.suggested_user_uuid
+		LEFT JOIN privacy_settings ps ON ps.user_uuid = cs.suggested_user_uuid
+		WHERE cs.user_uuid = $1
+		AND uc.delete_after IS NULL
+		AND uc.suspended_at IS NULL
+		AND COALESCE(ps.discoverable, TRUE)
+		AND (
+			COALESCE(ps.who_can_add_contact, $3) = $3
+			OR (ps.who_can_add_contact = $4 AND cs.mutual_contacts > 0)
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM contacts ct
+			WHERE (ct.user_uuid = $1 AND ct.contact_user_uuid = cs.suggested_user_uuid)
+			OR (ct.blocked AND ct.user_uuid = cs.suggested_user_uuid AND ct.contact_user_uuid = $1)
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM contact_requests cr
+			WHERE cr.status = $5
+			AND (
+				(cr.from_user_uuid = $1 AND cr.to_user_uuid = cs.suggested_user_uuid)
+				OR (cr.from_user_uuid = cs.suggested_user_uuid AND cr.to_user_uuid = $1)
+			)
+		)
+		ORDER BY cs.mutual_contacts DESC, cs.shared_groups DESC, cs.suggested_user_uuid
+		LIMIT $2
+	`
+
+	rows, err := r.QueryContext(ctx, getSuggestionsSQL, userUUID, limit,
+		entity.PrivacyEveryone, entity.PrivacyContactsOfContacts, entity.ContactRequestPending)
+	if err != nil {
+		return nil, fmt.Errorf("ContactSuggestionRepo - GetSuggestions - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var suggestions []entity.ContactSuggestion
+	for rows.Next() {
+		var suggestion entity.ContactSuggestion
+		if err := rows.Scan(&suggestion.User.UserUUID, &suggestion.Username,
+			&suggestion.User.FirstName, &suggestion.User.LastName, &suggestion.User.Avatar,
+			&suggestion.MutualContacts, &suggestion.SharedGroups); err != nil {
+			return nil, fmt.Errorf("ContactSuggestionRepo - GetSuggestions - rows.Scan: %w", err)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, rows.Err()
+}
+
+// invalidateSuggestions invalidates the cached suggestions of the users and of every user who has one of them as a contact,
+// since the mutual contacts of all of them may have changed. It runs in the transaction of the change.
+func invalidateSuggestions(ctx context.Context, tx *sql.Tx, userUUIDs ...string) error {
+	invalidateSuggestionsSQL := `
+		UPDATE contact_suggestion_state
+		SET invalidated_at = clock_timestamp()
+		WHERE user_uuid = ANY($1)
+		OR user_uuid IN (
+			SELECT user_uuid
+			FROM contacts
+			WHERE contact_user_uuid = ANY($1)
+		)
+	`
+	_, err := tx.ExecContext(ctx, invalidateSuggestionsSQL, pq.Array(userUUIDs))
+	if err != nil {
+		return fmt.Errorf("failed to execute invalidateSuggestionsSQL query: %w", err)
+	}
+	return nil
+}
+
+// invalidateGroupSuggestions invalidates the cached suggestions of every participant of the group chat,
+// since their shared groups may have changed. It runs in the transaction of the change.
+func invalidateGroupSuggestions(ctx context.Context, tx *sql.Tx, conversationUUID string) error {
+	invalidateGroupSuggestionsSQL := `
+		UPDATE contact_suggestion_state
+		SET invalidated_at = clock_timestamp()
+		WHERE user_uuid IN (
+			SELECT user_uuid
+			FROM participants
+			WHERE conversation_uuid = $1
+		)
+	`
+	_, err := tx.ExecContext(ctx, invalidateGroupSuggestionsSQL, conversationUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute invalidateGroupSuggestionsSQL query: %w", err)
+	}
+	return nil
+}
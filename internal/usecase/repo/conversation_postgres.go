@@ -65,6 +65,7 @@ func (r *ConversationRepo) GetConversationList(ctx context.Context, reqParam ent
 			c.title,
 			c.last_message_created_at,
 			c.conversation_type,
+			c.picture,
 			ui.first_name,
 			ui.last_name,
 			ui.avatar
@@ -95,6 +96,7 @@ func (r *ConversationRepo) GetConversationList(ctx context.Context, reqParam ent
 			&conv.Title,
 			&conv.LastMessageCreatedAt,
 			&conv.Type,
+			&conv.Picture,
 			&conv.LastSentUser.FirstName,
 			&conv.LastSentUser.LastName,
 			&conv.LastSentUser.Avatar,
@@ -0,0 +1,266 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// DataExportRepo -.
+type DataExportRepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewDataExport(pg *sql.DB) *DataExportRepo {
+	return &DataExportRepo{pg}
+}
+
+const selectDataExportSQL = `
+	SELECT export_uuid, user_uuid, status, storage_key, error, created_at, completed_at, expires_at
+	FROM data_exports
+`
+
+// StoreExport -.
+func (r *DataExportRepo) StoreExport(ctx context.Context, export entity.DataExportDTO) error {
+	insertExportSQL := `
+		INSERT INTO data_exports (export_uuid, user_uuid, status, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := r.ExecContext(ctx, insertExportSQL, export.ExportUUID, export.UserUUID, export.Status, export.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("DataExportRepo - StoreExport - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// GetExport -.
+func (r *DataExportRepo) GetExport(ctx context.Context, exportUUID string) (*entity.DataExportDTO, error) {
+	exports, err := r.queryExports(ctx, selectDataExportSQL+`WHERE export_uuid = $1`, exportUUID)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportRepo - GetExport - r.queryExports: %w", err)
+	}
+	if len(exports) == 0 {
+		return nil, nil
+	}
+
+	return &exports[0], nil
+}
+
+// GetUserExports -.
+func (r *DataExportRepo) GetUserExports(ctx context.Context, userUUID string) ([]entity.DataExportDTO, error) {
+	exports, err := r.queryExports(ctx, selectDataExportSQL+`WHERE user_uuid = $1 ORDER BY created_at DESC`, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportRepo - GetUserExports - r.queryExports: %w", err)
+	}
+
+	return exports, nil
+}
+
+// GetExpiredExports -.
+func (r *DataExportRepo) GetExpiredExports(ctx context.Context, now time.Time) ([]entity.DataExportDTO, error) {
+	exports, err := r.queryExports(ctx, selectDataExportSQL+`WHERE expires_at <= $1`, now)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportRepo - GetExpiredExports - r.queryExports: %w", err)
+	}
+
+	return exports, nil
+}
+
+func (r *DataExportRepo) queryExports(ctx context.Context, query string, args ...interface{}) ([]entity.DataExportDTO, error) {
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exports []entity.DataExportDTO
+	for rows.Next() {
+		var export entity.DataExportDTO
+		if err := rows.Scan(&export.ExportUUID, &export.UserUUID, &export.Status, &export.StorageKey, &export.Error,
+			&export.CreatedAt, &export.CompletedAt, &export.ExpiresAt); err != nil {
+			return nil, err
+		}
+		exports = append(exports, export)
+	}
+
+	return exports, rows.Err()
+}
+
+// UpdateExport -.
+func (r *DataExportRepo) UpdateExport(ctx context.Context, export entity.DataExportDTO) error {
+	updateExportSQL := `
+		UPDATE data_exports
+		SET status = $1, storage_key = $2, error = $3, completed_at = $4, expires_at = $5
+		WHERE export_uuid = $6
+	`
+	_, err := r.ExecContext(ctx, updateExportSQL, export.Status, export.StorageKey, export.Error, export.CompletedAt,
+		export.ExpiresAt, export.ExportUUID)
+	if err != nil {
+		return fmt.Errorf("DataExportRepo - UpdateExport - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExport -.
+func (r *DataExportRepo) DeleteExport(ctx context.Context, exportUUID string) error {
+	deleteExportSQL := `
+		DELETE FROM data_exports
+		WHERE export_uuid = $1
+	`
+	_, err := r.ExecContext(ctx, deleteExportSQL, exportUUID)
+	if err != nil {
+		return fmt.Errorf("DataExportRepo - DeleteExport - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// GetExportContacts returns every contact of the user, including blocked and removed ones.
+func (r *DataExportRepo) GetExportContacts(ctx context.Context, userUUID string) ([]entity.ExportContact, error) {
+	getContactsSQL := `
+		SELECT COALESCE(contact_user_uuid, ''), COALESCE(conversation_uuid, ''), COALESCE(blocked, FALSE), COALESCE(removed, FALSE)
+		FROM contacts
+		WHERE user_uuid = $1
+		ORDER BY id
+	`
+
+	rows, err := r.QueryContext(ctx, getContactsSQL, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportRepo - GetExportContacts - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	contacts := []entity.ExportContact{}
+	for rows.Next() {
+		var contact entity.ExportContact
+		if err := rows.Scan(&contact.ContactUserUUID, &contact.ConversationUUID, &contact.Blocked, &contact.Removed); err != nil {
+			return nil, fmt.Errorf("DataExportRepo - GetExportContacts - rows.Scan: %w", err)
+		}
+		contacts = append(contacts, contact)
+	}
+
+	return contacts, rows.Err()
+}
+
+// GetExportGroupMemberships -.
+func (r *DataExportRepo) GetExportGroupMemberships(ctx context.Context, userUUID string) ([]entity.ExportGroupMembership, error) {
+	getGroupMembershipsSQL := `
+		SELECT p.conversation_uuid, COALESCE(c.title, ''), p.join_date, p.left_date
+		FROM participants p
+		LEFT JOIN conversations c ON p.conversation_uuid = c.conversation_uuid
+		WHERE p.user_uuid = $1
+		ORDER BY p.join_date
+	`
+
+	rows, err := r.QueryContext(ctx, getGroupMembershipsSQL, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportRepo - GetExportGroupMemberships - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []entity.ExportGroupMembership{}
+	for rows.Next() {
+		var group entity.ExportGroupMembership
+		if err := rows.Scan(&group.ConversationUUID, &group.Title, &group.JoinDate, &group.LeftDate); err != nil {
+			return nil, fmt.Errorf("DataExportRepo - GetExportGroupMemberships - rows.Scan: %w", err)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, rows.Err()
+}
+
+// GetSentMessages returns up to limit messages sent by the user, in the order they were sent, starting after the given message.
+func (r *DataExportRepo) GetSentMessages(ctx context.Context, userUUID string, after *entity.ExportMessage, limit int) ([]entity.ExportMessage, error) {
+	// Start before the first message if no cursor is given
+	afterCreatedAt, afterMessageUUID := time.Time{}, ""
+	if after != nil {
+		afterCreatedAt, afterMessageUUID = after.CreatedAt, after.MessageUUID
+	}
+
+	getSentMessagesSQL := `
+		SELECT message_uuid, COALESCE(conversation_uuid, ''), COALESCE(content, ''), created_at, COALESCE(deleted, FALSE)
+		FROM messages
+		WHERE user_uuid = $1
+		AND (created_at, message_uuid) > ($2, $3)
+		ORDER BY created_at, message_uuid
+		LIMIT $4
+	`
+
+	rows, err := r.QueryContext(ctx, getSentMessagesSQL, userUUID, afterCreatedAt, afterMessageUUID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportRepo - GetSentMessages - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []entity.ExportMessage
+	for rows.Next() {
+		var message entity.ExportMessage
+		if err := rows.Scan(&message.MessageUUID, &message.ConversationUUID, &message.Content, &message.CreatedAt, &message.Deleted); err != nil {
+			return nil, fmt.Errorf("DataExportRepo - GetSentMessages - rows.Scan: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+// GetExportReactions -.
+func (r *DataExportRepo) GetExportReactions(ctx context.Context, userUUID string) ([]entity.ExportReaction, error) {
+	getReactionsSQL := `
+		SELECT COALESCE(message_uuid, ''), COALESCE(reaction_type, '')
+		FROM reaction
+		WHERE user_uuid = $1
+		ORDER BY id
+	`
+
+	rows, err := r.QueryContext(ctx, getReactionsSQL, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportRepo - GetExportReactions - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	reactions := []entity.ExportReaction{}
+	for rows.Next() {
+		var reaction entity.ExportReaction
+		if err := rows.Scan(&reaction.MessageUUID, &reaction.ReactionType); err != nil {
+			return nil, fmt.Errorf("DataExportRepo - GetExportReactions - rows.Scan: %w", err)
+		}
+		reactions = append(reactions, reaction)
+	}
+
+	return reactions, rows.Err()
+}
+
+// GetExportSeenReceipts -.
+func (r *DataExportRepo) GetExportSeenReceipts(ctx context.Context, userUUID string) ([]entity.ExportSeenReceipt, error) {
+	getSeenReceiptsSQL := `
+		SELECT COALESCE(message_uuid, ''), seen_timestamp
+		FROM seen_status
+		WHERE user_uuid = $1
+		ORDER BY seen_timestamp
+	`
+
+	rows, err := r.QueryContext(ctx, getSeenReceiptsSQL, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportRepo - GetExportSeenReceipts - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	seenReceipts := []entity.ExportSeenReceipt{}
+	for rows.Next() {
+		var seenReceipt entity.ExportSeenReceipt
+		if err := rows.Scan(&seenReceipt.MessageUUID, &seenReceipt.SeenTimestamp); err != nil {
+			return nil, fmt.Errorf("DataExportRepo - GetExportSeenReceipts - rows.Scan: %w", err)
+		}
+		seenReceipts = append(seenReceipts, seenReceipt)
+	}
+
+	return seenReceipts, rows.Err()
+}
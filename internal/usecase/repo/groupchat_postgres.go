@@ -56,6 +56,12 @@ func (r *GroupChatRepo) CreateGroupChat(ctx context.Context, groupChat entity.Gr
 		}
 	}
 
+	// The participants now share a group chat
+	err = invalidateGroupSuggestions(ctx, tx, conversationUUID)
+	if err != nil {
+		return err
+	}
+
 	// Insert row in conversations table
 	insertConversationsSQL := `
 	INSERT INTO conversations (
@@ -106,6 +112,12 @@ func (r *GroupChatRepo) AddParticipants(ctx context.Context, groupChat entity.Gr
 		}
 	}
 
+	// The new participants now share the group chat with the other participants
+	err = invalidateGroupSuggestions(ctx, tx, groupChat.ConversationUUID)
+	if err != nil {
+		return err
+	}
+
 	// Commit the transaction
 	err = tx.Commit()
 	if err != nil {
@@ -133,6 +145,12 @@ func (r *GroupChatRepo) RemoveParticipants(ctx context.Context, groupChat entity
 		}
 	}()
 
+	// Invalidated before the removed participants leave, so that theirs are invalidated as well
+	err = invalidateGroupSuggestions(ctx, tx, groupChat.ConversationUUID)
+	if err != nil {
+		return err
+	}
+
 	// Insert rows for participants
 	for _, participant := range groupChat.Participants {
 		removeParticipantsSQL := `
@@ -192,13 +210,52 @@ func (r *GroupChatRepo) UpdateGroupTitle(ctx context.Context, groupChat entity.G
 	return nil
 }
 
-// ValidateUserInGroupChat -.
+// GetGroupPicture returns an empty string if the group chat has no picture.
+func (r *GroupChatRepo) GetGroupPicture(ctx context.Context, conversationUUID string) (string, error) {
+	getGroupPictureSQL := `
+		SELECT COALESCE(picture, '')
+		FROM conversations
+		WHERE conversation_uuid = $1
+	`
+
+	var picture string
+	err := r.QueryRowContext(ctx, getGroupPictureSQL, conversationUUID).Scan(&picture)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("GroupChatRepo - GetGroupPicture - r.QueryRowContext: %w", err)
+	}
+
+	return picture, nil
+}
+
+// UpdateGroupPicture -.
+func (r *GroupChatRepo) UpdateGroupPicture(ctx context.Context, conversationUUID string, picture string) error {
+	updateGroupPictureSQL := `
+		UPDATE conversations
+		SET picture = $1
+		WHERE conversation_uuid = $2
+	`
+
+	_, err := r.ExecContext(ctx, updateGroupPictureSQL, picture, conversationUUID)
+	if err != nil {
+		return fmt.Errorf("GroupChatRepo - UpdateGroupPicture - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateUserInGroupChat reports whether the user is a participant of the conversation.
+// Both columns have to match, a participant of any other conversation is not in this one.
 func (r *GroupChatRepo) ValidateUserInGroupChat(ctx context.Context, conversationUUID string, userUUID string) (bool, error) {
-	// Check if the user already exists
+	// Check if the user is a participant of the group chat
 	validateUserInGroupChatSQL := `
 	SELECT 1 
 	FROM participants
-	WHERE (conversation_uuid = $1 or user_uuid = $2)
+	WHERE conversation_uuid = $1
+	AND user_uuid = $2
+	LIMIT 1
 	`
 
 	var exists int
@@ -1,6 +1,11 @@
 package repo
 
-import "database/sql"
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
 
 func NewNullString(s string) sql.NullString {
 	if len(s) == 0 {
@@ -11,3 +16,31 @@ func NewNullString(s string) sql.NullString {
 		Valid:  true,
 	}
 }
+
+// prefixPattern returns a LIKE pattern that matches values starting with s, wildcards in s are matched literally
+func prefixPattern(s string) string {
+	return likeEscaper.Replace(s) + "%"
+}
+
+// containsPattern returns a LIKE pattern that matches values containing s, wildcards in s are matched literally
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// toUserStatus builds the custom status from the nullable status columns of 'user_info', it is nil if the status was cleared
+func toUserStatus(emoji sql.NullString, text sql.NullString, expiresAt sql.NullTime) *entity.UserStatus {
+	if !emoji.Valid && !text.Valid {
+		return nil
+	}
+
+	status := &entity.UserStatus{
+		Emoji: emoji.String,
+		Text:  text.String,
+	}
+	if expiresAt.Valid {
+		status.ExpiresAt = &expiresAt.Time
+	}
+	return status
+}
@@ -0,0 +1,125 @@
+package repo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// Entries that have not failed for this long and are not locked are dropped
+const _loginAttemptMemoryTTL = 24 * time.Hour
+
+// LoginAttemptMemory keeps failed login counters in memory, for single instance deployments and local runs.
+// Counters are lost on restart and are not shared between instances.
+type LoginAttemptMemory struct {
+	mu        sync.Mutex
+	attempts  map[string]*entity.LoginAttemptDTO
+	lockouts  []entity.LoginLockoutDTO
+	lastPrune time.Time
+}
+
+// NewLoginAttemptMemory -.
+func NewLoginAttemptMemory() *LoginAttemptMemory {
+	return &LoginAttemptMemory{
+		attempts:  make(map[string]*entity.LoginAttemptDTO),
+		lastPrune: time.Now(),
+	}
+}
+
+// GetLoginAttempt -.
+func (r *LoginAttemptMemory) GetLoginAttempt(ctx context.Context, key string) (*entity.LoginAttemptDTO, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loginAttempt, ok := r.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+
+	// Return a copy so callers can't modify the stored counter
+	copied := *loginAttempt
+	return &copied, nil
+}
+
+// IncrementFailedLogin -.
+func (r *LoginAttemptMemory) IncrementFailedLogin(ctx context.Context, key string, resetBefore time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prune()
+
+	loginAttempt, ok := r.attempts[key]
+	if !ok {
+		loginAttempt = &entity.LoginAttemptDTO{Key: key}
+		r.attempts[key] = loginAttempt
+	}
+
+	// The counter starts over when the previous failure is older than resetBefore
+	if loginAttempt.LastFailedAt.Before(resetBefore) {
+		loginAttempt.Failures = 0
+	}
+	loginAttempt.Failures++
+	loginAttempt.LastFailedAt = time.Now()
+
+	return loginAttempt.Failures, nil
+}
+
+// LockLogin -.
+func (r *LoginAttemptMemory) LockLogin(ctx context.Context, key string, lockedUntil time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if loginAttempt, ok := r.attempts[key]; ok {
+		loginAttempt.LockedUntil = &lockedUntil
+	}
+	return nil
+}
+
+// ResetLoginAttempts -.
+func (r *LoginAttemptMemory) ResetLoginAttempts(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
+
+// StoreLockoutAudit -.
+func (r *LoginAttemptMemory) StoreLockoutAudit(ctx context.Context, lockout entity.LoginLockoutDTO) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lockouts = append(r.lockouts, lockout)
+	return nil
+}
+
+// Lockouts returns a copy of every lockout recorded so far.
+func (r *LoginAttemptMemory) Lockouts() []entity.LoginLockoutDTO {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lockouts := make([]entity.LoginLockoutDTO, len(r.lockouts))
+	copy(lockouts, r.lockouts)
+	return lockouts
+}
+
+// prune drops stale counters so that sprayed usernames and IPs don't grow the map forever.
+// Must be called with the mutex held.
+func (r *LoginAttemptMemory) prune() {
+	now := time.Now()
+	if now.Sub(r.lastPrune) < time.Hour {
+		return
+	}
+	r.lastPrune = now
+
+	for key, loginAttempt := range r.attempts {
+		if loginAttempt.LockedUntil != nil && loginAttempt.LockedUntil.After(now) {
+			continue
+		}
+		if now.Sub(loginAttempt.LastFailedAt) > _loginAttemptMemoryTTL {
+			delete(r.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// LoginAttemptRepo -.
+type LoginAttemptRepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewLoginAttempt(pg *sql.DB) *LoginAttemptRepo {
+	return &LoginAttemptRepo{pg}
+}
+
+// GetLoginAttempt -.
+func (r *LoginAttemptRepo) GetLoginAttempt(ctx context.Context, key string) (*entity.LoginAttemptDTO, error) {
+	getLoginAttemptSQL := `
+		SELECT attempt_key, failures, last_failed_at, locked_until
+		FROM login_attempts
+		WHERE attempt_key = $1
+	`
+
+	var loginAttempt entity.LoginAttemptDTO
+	err := r.QueryRowContext(ctx, getLoginAttemptSQL, key).
+		Scan(&loginAttempt.Key, &loginAttempt.Failures, &loginAttempt.LastFailedAt, &loginAttempt.LockedUntil)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("LoginAttemptRepo - GetLoginAttempt - r.QueryRowContext: %w", err)
+	}
+
+	return &loginAttempt, nil
+}
+
+// IncrementFailedLogin -.
+func (r *LoginAttemptRepo) IncrementFailedLogin(ctx context.Context, key string, resetBefore time.Time) (int, error) {
+	// The counter starts over when the previous failure is older than resetBefore
+	incrementFailedLoginSQL := `
+		INSERT INTO login_attempts (attempt_key, failures, last_failed_at)
+		VALUES ($1, 1, NOW())
+		ON CONFLICT (attempt_key) DO UPDATE
+		SET failures = CASE WHEN login_attempts.last_failed_at < $2 THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failed_at = NOW()
+		RETURNING failures
+	`
+
+	var failures int
+	err := r.QueryRowContext(ctx, incrementFailedLoginSQL, key, resetBefore).Scan(&failures)
+	if err != nil {
+		return 0, fmt.Errorf("LoginAttemptRepo - IncrementFailedLogin - r.QueryRowContext: %w", err)
+	}
+	return failures, nil
+}
+
+// LockLogin -.
+func (r *LoginAttemptRepo) LockLogin(ctx context.Context, key string, lockedUntil time.Time) error {
+	lockLoginSQL := `
+		UPDATE login_attempts
+		SET locked_until = $2
+		WHERE attempt_key = $1
+	`
+	_, err := r.ExecContext(ctx, lockLoginSQL, key, lockedUntil)
+	if err != nil {
+		return fmt.Errorf("LoginAttemptRepo - LockLogin - r.ExecContext: %w", err)
+	}
+	return nil
+}
+
+// ResetLoginAttempts -.
+func (r *LoginAttemptRepo) ResetLoginAttempts(ctx context.Context, key string) error {
+	resetLoginAttemptsSQL := `
+		DELETE FROM login_attempts
+		WHERE attempt_key = $1
+	`
+	_, err := r.ExecContext(ctx, resetLoginAttemptsSQL, key)
+	if err != nil {
+		return fmt.Errorf("LoginAttemptRepo - ResetLoginAttempts - r.ExecContext: %w", err)
+	}
+	return nil
+}
+
+// StoreLockoutAudit -.
+func (r *LoginAttemptRepo) StoreLockoutAudit(ctx context.Context, lockout entity.LoginLockoutDTO) error {
+	insertLockoutSQL := `
+		INSERT INTO login_lockouts (attempt_key, identifier, ip_address, failures, locked_until)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.ExecContext(ctx, insertLockoutSQL, lockout.Key, lockout.Identifier, lockout.IP,
+		lockout.Failures, lockout.LockedUntil)
+	if err != nil {
+		return fmt.Errorf("LoginAttemptRepo - StoreLockoutAudit - r.ExecContext: %w", err)
+	}
+	return nil
+}
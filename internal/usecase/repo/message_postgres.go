@@ -168,6 +168,7 @@ func (r *MessageRepo) UpdateSeenStatus(ctx context.Context, seenStatus entity.Se
 	return nil
 }
 
+// GetSeenStatus leaves out the users who do not share read receipts.
 func (r *MessageRepo) GetSeenStatus(ctx context.Context, messageUUID string) ([]entity.GetSeenStatusDTO, error) {
 	getSeenStatusSQL := `
 		SELECT
@@ -177,7 +178,9 @@ func (r *MessageRepo) GetSeenStatus(ctx context.Context, messageUUID string) ([]
 			ui.avatar
 		FROM seen_status s
 		LEFT JOIN user_info ui ON s.user_uuid = ui.user_uuid
+		LEFT JOIN privacy_settings ps ON ps.user_uuid = s.user_uuid
 		WHERE s.message_uuid = $1
+		AND COALESCE(ps.read_receipts, TRUE)
 		ORDER BY s.seen_timestamp DESC
 	`
 
@@ -0,0 +1,160 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// MFARepo -.
+type MFARepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewMFA(pg *sql.DB) *MFARepo {
+	return &MFARepo{pg}
+}
+
+// StorePendingMFA -.
+func (r *MFARepo) StorePendingMFA(ctx context.Context, userUUID string, secret string) error {
+	// Starting a new enrollment replaces an unconfirmed secret, but never a confirmed one
+	storePendingMFASQL := `
+		INSERT INTO user_mfa (user_uuid, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_uuid) DO UPDATE
+		SET secret = EXCLUDED.secret,
+		last_used_step = 0,
+		created_at = NOW()
+		WHERE user_mfa.confirmed_at IS NULL
+	`
+	_, err := r.ExecContext(ctx, storePendingMFASQL, userUUID, secret)
+	if err != nil {
+		return fmt.Errorf("MFARepo - StorePendingMFA - r.ExecContext: %w", err)
+	}
+	return nil
+}
+
+// GetMFA -.
+func (r *MFARepo) GetMFA(ctx context.Context, userUUID string) (*entity.MFADTO, error) {
+	getMFASQL := `
+		SELECT user_uuid, secret, last_used_step, confirmed_at
+		FROM user_mfa
+		WHERE user_uuid = $1
+	`
+
+	var mfa entity.MFADTO
+	err := r.QueryRowContext(ctx, getMFASQL, userUUID).
+		Scan(&mfa.UserUUID, &mfa.Secret, &mfa.LastUsedStep, &mfa.ConfirmedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("MFARepo - GetMFA - r.QueryRowContext: %w", err)
+	}
+
+	return &mfa, nil
+}
+
+// ConfirmMFA -.
+func (r *MFARepo) ConfirmMFA(ctx context.Context, userUUID string, step int64, recoveryCodeHashes []string) error {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("MFARepo - ConfirmMFA - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	confirmMFASQL := `
+		UPDATE user_mfa
+		SET confirmed_at = NOW(),
+		last_used_step = $2
+		WHERE user_uuid = $1
+	`
+	_, err = tx.ExecContext(ctx, confirmMFASQL, userUUID, step)
+	if err != nil {
+		return fmt.Errorf("failed to execute update confirmMFASQL query: %w", err)
+	}
+
+	deleteRecoveryCodesSQL := `
+		DELETE FROM mfa_recovery_codes
+		WHERE user_uuid = $1
+	`
+	_, err = tx.ExecContext(ctx, deleteRecoveryCodesSQL, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete deleteRecoveryCodesSQL query: %w", err)
+	}
+
+	insertRecoveryCodesSQL := `
+		INSERT INTO mfa_recovery_codes (user_uuid, code_hash)
+		SELECT $1, UNNEST($2::TEXT[])
+	`
+	_, err = tx.ExecContext(ctx, insertRecoveryCodesSQL, userUUID, pq.Array(recoveryCodeHashes))
+	if err != nil {
+		return fmt.Errorf("failed to execute insert insertRecoveryCodesSQL query: %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("MFARepo - ConfirmMFA - failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateLastUsedStep -.
+func (r *MFARepo) UpdateLastUsedStep(ctx context.Context, userUUID string, step int64) (bool, error) {
+	// A code can only be used once, so the time step must move forward
+	updateLastUsedStepSQL := `
+		UPDATE user_mfa
+		SET last_used_step = $2
+		WHERE user_uuid = $1
+		AND last_used_step < $2
+	`
+	result, err := r.ExecContext(ctx, updateLastUsedStepSQL, userUUID, step)
+	if err != nil {
+		return false, fmt.Errorf("MFARepo - UpdateLastUsedStep - r.ExecContext: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("MFARepo - UpdateLastUsedStep - result.RowsAffected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ConsumeRecoveryCode -.
+func (r *MFARepo) ConsumeRecoveryCode(ctx context.Context, userUUID string, codeHash string) (bool, error) {
+	consumeRecoveryCodeSQL := `
+		UPDATE mfa_recovery_codes
+		SET used_at = NOW()
+		WHERE user_uuid = $1
+		AND code_hash = $2
+		AND used_at IS NULL
+	`
+	result, err := r.ExecContext(ctx, consumeRecoveryCodeSQL, userUUID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("MFARepo - ConsumeRecoveryCode - r.ExecContext: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("MFARepo - ConsumeRecoveryCode - result.RowsAffected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
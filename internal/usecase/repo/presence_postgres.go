@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// PresenceRepo -.
+type PresenceRepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewPresence(pg *sql.DB) *PresenceRepo {
+	return &PresenceRepo{pg}
+}
+
+// StoreLastSeen -.
+func (r *PresenceRepo) StoreLastSeen(ctx context.Context, userUUID string, lastSeenAt time.Time) error {
+	storeLastSeenSQL := `
+		INSERT INTO user_presence (user_uuid, last_seen_at)
+		VALUES ($1, $2)
+		ON CONFLICT (user_uuid) DO UPDATE
+		SET last_seen_at = EXCLUDED.last_seen_at
+	`
+
+	_, err := r.ExecContext(ctx, storeLastSeenSQL, userUUID, lastSeenAt)
+	if err != nil {
+		return fmt.Errorf("PresenceRepo - StoreLastSeen - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// GetLastSeen returns the users of the list who were seen before, the others are left out.
+func (r *PresenceRepo) GetLastSeen(ctx context.Context, userUUIDs []string) ([]entity.LastSeenDTO, error) {
+	getLastSeenSQL := `
+		SELECT user_uuid, last_seen_at
+		FROM user_presence
+		WHERE user_uuid = ANY($1)
+	`
+
+	rows, err := r.QueryContext(ctx, getLastSeenSQL, pq.Array(userUUIDs))
+	if err != nil {
+		return nil, fmt.Errorf("PresenceRepo - GetLastSeen - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var lastSeen []entity.LastSeenDTO
+	for rows.Next() {
+		var dto entity.LastSeenDTO
+		if err := rows.Scan(&dto.UserUUID, &dto.LastSeenAt); err != nil {
+			return nil, fmt.Errorf("PresenceRepo - GetLastSeen - rows.Scan: %w", err)
+		}
+		lastSeen = append(lastSeen, dto)
+	}
+
+	return lastSeen, rows.Err()
+}
@@ -0,0 +1,116 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// PrivacyRepo -.
+type PrivacyRepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewPrivacy(pg *sql.DB) *PrivacyRepo {
+	return &PrivacyRepo{pg}
+}
+
+// GetPrivacySettings returns nil if the user never changed their settings.
+func (r *PrivacyRepo) GetPrivacySettings(ctx context.Context, userUUID string) (*entity.PrivacySettingsDTO, error) {
+	getPrivacySettingsSQL := `
+		SELECT user_uuid, discoverable, who_can_add_contact, who_can_add_to_groups, read_receipts, show_presence, updated_at
+		FROM privacy_settings
+		WHERE user_uuid = $1
+	`
+
+	var settings entity.PrivacySettingsDTO
+	err := r.QueryRowContext(ctx, getPrivacySettingsSQL, userUUID).
+		Scan(
+			&settings.UserUUID,
+			&settings.Discoverable,
+			&settings.WhoCanAddContact,
+			&settings.WhoCanAddToGroups,
+			&settings.ReadReceipts,
+			&settings.ShowPresence,
+			&settings.UpdatedAt,
+		)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("PrivacyRepo - GetPrivacySettings - r.QueryRowContext: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// GetPrivacySettingsByUserUUIDs only returns the settings of the users who changed them.
+func (r *PrivacyRepo) GetPrivacySettingsByUserUUIDs(ctx context.Context, userUUIDs []string) ([]entity.PrivacySettingsDTO, error) {
+	getPrivacySettingsSQL := `
+		SELECT user_uuid, discoverable, who_can_add_contact, who_can_add_to_groups, read_receipts, show_presence, updated_at
+		FROM privacy_settings
+		WHERE user_uuid = ANY($1)
+	`
+
+	rows, err := r.QueryContext(ctx, getPrivacySettingsSQL, pq.Array(userUUIDs))
+	if err != nil {
+		return nil, fmt.Errorf("PrivacyRepo - GetPrivacySettingsByUserUUIDs - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var settingsList []entity.PrivacySettingsDTO
+	for rows.Next() {
+		var settings entity.PrivacySettingsDTO
+		if err := rows.Scan(
+			&settings.UserUUID,
+			&settings.Discoverable,
+			&settings.WhoCanAddContact,
+			&settings.WhoCanAddToGroups,
+			&settings.ReadReceipts,
+			&settings.ShowPresence,
+			&settings.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("PrivacyRepo - GetPrivacySettingsByUserUUIDs - rows.Scan: %w", err)
+		}
+		settingsList = append(settingsList, settings)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("PrivacyRepo - GetPrivacySettingsByUserUUIDs - rows.Err: %w", err)
+	}
+
+	return settingsList, nil
+}
+
+// StorePrivacySettings -.
+func (r *PrivacyRepo) StorePrivacySettings(ctx context.Context, settings entity.PrivacySettingsDTO) error {
+	storePrivacySettingsSQL := `
+		INSERT INTO privacy_settings (user_uuid, discoverable, who_can_add_contact, who_can_add_to_groups, read_receipts, show_presence, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_uuid) DO UPDATE
+		SET discoverable = EXCLUDED.discoverable,
+			who_can_add_contact = EXCLUDED.who_can_add_contact,
+			who_can_add_to_groups = EXCLUDED.who_can_add_to_groups,
+			read_receipts = EXCLUDED.read_receipts,
+			show_presence = EXCLUDED.show_presence,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.ExecContext(ctx, storePrivacySettingsSQL,
+		settings.UserUUID,
+		settings.Discoverable,
+		settings.WhoCanAddContact,
+		settings.WhoCanAddToGroups,
+		settings.ReadReceipts,
+		settings.ShowPresence,
+		settings.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("PrivacyRepo - StorePrivacySettings - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// ReportRepo -.
+type ReportRepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewReport(pg *sql.DB) *ReportRepo {
+	return &ReportRepo{pg}
+}
+
+// CheckUserExists -.
+// Bots have a profile but no credentials, so that they can be reported too.
+func (r *ReportRepo) CheckUserExists(ctx context.Context, userUUID string) (bool, error) {
+	checkUserExistsSQL := `
+		SELECT 1
+		FROM user_info
+		WHERE user_uuid = $1
+		LIMIT 1
+	`
+
+	var exists int
+	err := r.QueryRowContext(ctx, checkUserExistsSQL, userUUID).Scan(&exists)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("ReportRepo - CheckUserExists - r.QueryRowContext: %w", err)
+	}
+
+	return true, nil
+}
+
+// CheckParticipant returns whether the user takes or took part in the conversation.
+// Users who left a group chat can still report what happened while they were in it.
+func (r *ReportRepo) CheckParticipant(ctx context.Context, conversationUUID string, userUUID string) (bool, error) {
+	checkParticipantSQL := `
+		SELECT 1
+		FROM participants
+		WHERE conversation_uuid = $1
+		AND user_uuid = $2
+		LIMIT 1
+	`
+
+	var exists int
+	err := r.QueryRowContext(ctx, checkParticipantSQL, conversationUUID, userUUID).Scan(&exists)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("ReportRepo - CheckParticipant - r.QueryRowContext: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetReportedMessage returns the message as it is now, nil if it does not exist.
+func (r *ReportRepo) GetReportedMessage(ctx context.Context, messageUUID string) (*entity.ReportedMessage, error) {
+	getReportedMessageSQL := `
+		SELECT conversation_uuid, COALESCE(user_uuid, ''), COALESCE(content, ''), created_at
+		FROM messages
+		WHERE message_uuid = $1
+	`
+
+	var msg entity.ReportedMessage
+	var createdAt sql.NullTime
+	err := r.QueryRowContext(ctx, getReportedMessageSQL, messageUUID).Scan(&msg.ConversationUUID, &msg.SenderUUID,
+		&msg.Content, &createdAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ReportRepo - GetReportedMessage - r.QueryRowContext: %w", err)
+	}
+	msg.CreatedAt = createdAt.Time
+
+	return &msg, nil
+}
+
+// StoreReport stores a new open report.
+// It returns false and stores nothing if the reporter already has an open or in review report of the same target.
+func (r *ReportRepo) StoreReport(ctx context.Context, report entity.Report) (bool, error) {
+	storeReportSQL := `
+		INSERT INTO abuse_reports (report_uuid, reporter_uuid, target_type, target_uuid, conversation_uuid, reason, details,
+			message_sender_uuid, message_content, message_created_at, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+		ON CONFLICT (reporter_uuid, target_type, target_uuid) WHERE status IN ('open', 'in_review') DO NOTHING
+	`
+
+	var senderUUID, content string
+	var createdAt *time.Time
+	if report.Message != nil {
+		senderUUID = report.Message.SenderUUID
+		content = report.Message.Content
+		if !report.Message.CreatedAt.IsZero() {
+			createdAt = &report.Message.CreatedAt
+		}
+	}
+
+	result, err := r.ExecContext(ctx, storeReportSQL, report.ReportUUID, report.ReporterUUID, report.TargetType, report.TargetUUID,
+		report.ConversationUUID, report.Reason, report.Details, senderUUID, content, createdAt, report.Status, report.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("ReportRepo - StoreReport - r.ExecContext: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ReportRepo - StoreReport - result.RowsAffected: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// GetReport returns the report, nil if it does not exist.
+func (r *ReportRepo) GetReport(ctx context.Context, reportUUID string) (*entity.Report, error) {
+	getReportSQL := `
+		SELECT ` + reportColumns + `
+		FROM abuse_reports
+		WHERE report_uuid = $1
+	`
+
+	report, err := scanReport(r.QueryRowContext(ctx, getReportSQL, reportUUID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ReportRepo - GetReport - r.QueryRowContext: %w", err)
+	}
+
+	return &report, nil
+}
+
+// ListReports returns the reports from the oldest, so that the queue is worked through in the order it was filled.
+func (r *ReportRepo) ListReports(ctx context.Context, query entity.ReportQuery) ([]entity.Report, error) {
+	listReportsSQL := `
+		SELECT ` + reportColumns + `
+		FROM abuse_reports
+		WHERE id > $1
+		AND ($2 = '' OR status = $2)
+		AND ($3 = '' OR target_type = $3)
+		ORDER BY id
+		LIMIT $4
+	`
+
+	rows, err := r.QueryContext(ctx, listReportsSQL, query.Cursor, query.Status, query.TargetType, query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("ReportRepo - ListReports - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []entity.Report
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ReportRepo - ListReports - rows.Scan: %w", err)
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, rows.Err()
+}
+
+// UpdateReportStatus moves the report from the status it had when it was read to its new status, and stores the audit entry.
+// It returns false and stores nothing if the report was changed since it was read, e.g. by another admin.
+func (r *ReportRepo) UpdateReportStatus(ctx context.Context, report entity.Report, fromStatus string, audit entity.AdminAuditDTO) (bool, error) {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("ReportRepo - UpdateReportStatus - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	updateReportStatusSQL := `
+		UPDATE abuse_reports
+		SET status = $3, reviewer_uuid = $4, resolution_note = $5, updated_at = $6
+		WHERE report_uuid = $1
+		AND status = $2
+	`
+	result, err := tx.ExecContext(ctx, updateReportStatusSQL, report.ReportUUID, fromStatus, report.Status,
+		report.ReviewerUUID, report.ResolutionNote, report.UpdatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute update updateReportStatusSQL query: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows of updateReportStatusSQL query: %w", err)
+	}
+	if affected == 0 {
+		// Nothing was changed, the rollback only ends the transaction
+		tx.Rollback()
+		return false, nil
+	}
+
+	err = insertAuditEntry(ctx, tx, audit)
+	if err != nil {
+		return false, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return false, fmt.Errorf("ReportRepo - UpdateReportStatus - failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+const reportColumns = `id, report_uuid, reporter_uuid, target_type, target_uuid, conversation_uuid, reason, details,
+	message_sender_uuid, message_content, message_created_at, status, reviewer_uuid, resolution_note, created_at, updated_at`
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanReport reads a row of reportColumns
+func scanReport(row scanner) (entity.Report, error) {
+	var report entity.Report
+	var msg entity.ReportedMessage
+	var messageCreatedAt sql.NullTime
+	err := row.Scan(&report.ID, &report.ReportUUID, &report.ReporterUUID, &report.TargetType, &report.TargetUUID,
+		&report.ConversationUUID, &report.Reason, &report.Details, &msg.SenderUUID, &msg.Content, &messageCreatedAt,
+		&report.Status, &report.ReviewerUUID, &report.ResolutionNote, &report.CreatedAt, &report.UpdatedAt)
+	if err != nil {
+		return entity.Report{}, err
+	}
+
+	// Only message reports have a copy of the message
+	if report.TargetType == entity.AdminTargetMessage {
+		msg.ConversationUUID = report.ConversationUUID
+		msg.CreatedAt = messageCreatedAt.Time
+		report.Message = &msg
+	}
+	return report, nil
+}
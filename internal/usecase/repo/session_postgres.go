@@ -0,0 +1,334 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// SessionRepo -.
+type SessionRepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewSession(pg *sql.DB) *SessionRepo {
+	return &SessionRepo{pg}
+}
+
+// StoreSession -.
+func (r *SessionRepo) StoreSession(ctx context.Context, session entity.SessionDTO, refreshToken entity.RefreshTokenDTO) error {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("SessionRepo - StoreSession - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	insertSessionSQL := `
+		INSERT INTO sessions (session_uuid, user_uuid, device_name, user_agent, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err = tx.ExecContext(ctx, insertSessionSQL, session.SessionUUID, session.UserUUID, session.DeviceName,
+		session.UserAgent, session.IP, session.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to execute insert insertSessionSQL query: %w", err)
+	}
+
+	insertRefreshTokenSQL := `
+		INSERT INTO refresh_tokens (token_hash, session_uuid, user_uuid, access_token_jti, access_token_expires_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err = tx.ExecContext(ctx, insertRefreshTokenSQL, refreshToken.TokenHash, refreshToken.SessionUUID, refreshToken.UserUUID,
+		refreshToken.AccessTokenID, refreshToken.AccessTokenExpiresAt, refreshToken.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to execute insert insertRefreshTokenSQL query: %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("SessionRepo - StoreSession - failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetRefreshToken -.
+func (r *SessionRepo) GetRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshTokenDTO, error) {
+	getRefreshTokenSQL := `
+		SELECT token_hash, session_uuid, user_uuid, access_token_jti, access_token_expires_at, expires_at, rotated_at, revoked_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+
+	var refreshToken entity.RefreshTokenDTO
+	err := r.QueryRowContext(ctx, getRefreshTokenSQL, tokenHash).
+		Scan(&refreshToken.TokenHash, &refreshToken.SessionUUID, &refreshToken.UserUUID, &refreshToken.AccessTokenID,
+			&refreshToken.AccessTokenExpiresAt, &refreshToken.ExpiresAt, &refreshToken.RotatedAt, &refreshToken.RevokedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("SessionRepo - GetRefreshToken - r.QueryRowContext: %w", err)
+	}
+
+	return &refreshToken, nil
+}
+
+// RotateRefreshToken -.
+func (r *SessionRepo) RotateRefreshToken(ctx context.Context, oldTokenHash string, refreshToken entity.RefreshTokenDTO) (bool, error) {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("SessionRepo - RotateRefreshToken - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	// Only an unused token can be rotated, this guards against two requests rotating the same token
+	rotateRefreshTokenSQL := `
+		UPDATE refresh_tokens
+		SET rotated_at = NOW()
+		WHERE token_hash = $1
+		AND rotated_at IS NULL
+		AND revoked_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, rotateRefreshTokenSQL, oldTokenHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute update rotateRefreshTokenSQL query: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected for rotateRefreshTokenSQL query: %w", err)
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	insertRefreshTokenSQL := `
+		INSERT INTO refresh_tokens (token_hash, session_uuid, user_uuid, access_token_jti, access_token_expires_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err = tx.ExecContext(ctx, insertRefreshTokenSQL, refreshToken.TokenHash, refreshToken.SessionUUID, refreshToken.UserUUID,
+		refreshToken.AccessTokenID, refreshToken.AccessTokenExpiresAt, refreshToken.ExpiresAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute insert insertRefreshTokenSQL query: %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return false, fmt.Errorf("SessionRepo - RotateRefreshToken - failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// RevokeSession -.
+func (r *SessionRepo) RevokeSession(ctx context.Context, sessionUUID string) error {
+	err := r.revokeTokens(ctx, "session_uuid", sessionUUID)
+	if err != nil {
+		return fmt.Errorf("SessionRepo - RevokeSession - r.revokeTokens: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserSessions -.
+func (r *SessionRepo) RevokeUserSessions(ctx context.Context, userUUID string) error {
+	err := r.revokeTokens(ctx, "user_uuid", userUUID)
+	if err != nil {
+		return fmt.Errorf("SessionRepo - RevokeUserSessions - r.revokeTokens: %w", err)
+	}
+	return nil
+}
+
+// revokeTokens revokes every refresh token matching the given column, and every access token issued from them.
+// column is never user input, it is either 'session_uuid' or 'user_uuid'.
+func (r *SessionRepo) revokeTokens(ctx context.Context, column string, value string) error {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	// Add every access token issued from the matching refresh tokens that has not expired yet into the revocation list
+	revokeAccessTokensSQL := fmt.Sprintf(`
+		INSERT INTO revoked_tokens (jti, expires_at)
+		SELECT access_token_jti, access_token_expires_at
+		FROM refresh_tokens
+		WHERE %s = $1
+		AND access_token_expires_at > NOW()
+		ON CONFLICT (jti) DO NOTHING
+	`, column)
+	_, err = tx.ExecContext(ctx, revokeAccessTokensSQL, value)
+	if err != nil {
+		return fmt.Errorf("failed to execute insert revokeAccessTokensSQL query: %w", err)
+	}
+
+	revokeRefreshTokensSQL := fmt.Sprintf(`
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE %s = $1
+		AND revoked_at IS NULL
+	`, column)
+	_, err = tx.ExecContext(ctx, revokeRefreshTokensSQL, value)
+	if err != nil {
+		return fmt.Errorf("failed to execute update revokeRefreshTokensSQL query: %w", err)
+	}
+
+	revokeSessionsSQL := fmt.Sprintf(`
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE %s = $1
+		AND revoked_at IS NULL
+	`, column)
+	_, err = tx.ExecContext(ctx, revokeSessionsSQL, value)
+	if err != nil {
+		return fmt.Errorf("failed to execute update revokeSessionsSQL query: %w", err)
+	}
+
+	// Expired access tokens are rejected by their 'exp' claim, so they no longer need to be in the revocation list
+	deleteExpiredRevokedTokensSQL := `
+		DELETE FROM revoked_tokens
+		WHERE expires_at < NOW()
+	`
+	_, err = tx.ExecContext(ctx, deleteExpiredRevokedTokensSQL)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete deleteExpiredRevokedTokensSQL query: %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CheckTokenRevoked -.
+func (r *SessionRepo) CheckTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	checkTokenRevokedSQL := `
+		SELECT 1
+		FROM revoked_tokens
+		WHERE jti = $1
+	`
+
+	var exists int
+	err := r.QueryRowContext(ctx, checkTokenRevokedSQL, jti).Scan(&exists)
+	if err != nil && err != sql.ErrNoRows {
+		return false, fmt.Errorf("SessionRepo - CheckTokenRevoked - r.QueryRowContext: %w", err)
+	}
+
+	if exists > 0 {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// TouchSession -.
+func (r *SessionRepo) TouchSession(ctx context.Context, sessionUUID string, device entity.SessionDevice, expiresAt time.Time) error {
+	touchSessionSQL := `
+		UPDATE sessions
+		SET last_seen_at = NOW(), user_agent = $2, ip_address = $3, expires_at = $4
+		WHERE session_uuid = $1
+	`
+	_, err := r.ExecContext(ctx, touchSessionSQL, sessionUUID, device.UserAgent, device.IP, expiresAt)
+	if err != nil {
+		return fmt.Errorf("SessionRepo - TouchSession - r.ExecContext: %w", err)
+	}
+	return nil
+}
+
+// GetSession -.
+func (r *SessionRepo) GetSession(ctx context.Context, sessionUUID string) (*entity.SessionDTO, error) {
+	getSessionSQL := `
+		SELECT session_uuid, user_uuid, COALESCE(device_name, ''), COALESCE(user_agent, ''), COALESCE(ip_address, ''),
+			created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions
+		WHERE session_uuid = $1
+	`
+
+	var session entity.SessionDTO
+	err := r.QueryRowContext(ctx, getSessionSQL, sessionUUID).
+		Scan(&session.SessionUUID, &session.UserUUID, &session.DeviceName, &session.UserAgent, &session.IP,
+			&session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt, &session.RevokedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("SessionRepo - GetSession - r.QueryRowContext: %w", err)
+	}
+
+	return &session, nil
+}
+
+// GetActiveSessions -.
+func (r *SessionRepo) GetActiveSessions(ctx context.Context, userUUID string) ([]entity.SessionDTO, error) {
+	getActiveSessionsSQL := `
+		SELECT session_uuid, user_uuid, COALESCE(device_name, ''), COALESCE(user_agent, ''), COALESCE(ip_address, ''),
+			created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions
+		WHERE user_uuid = $1
+		AND revoked_at IS NULL
+		AND expires_at > NOW()
+		ORDER BY last_seen_at DESC
+	`
+
+	rows, err := r.QueryContext(ctx, getActiveSessionsSQL, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("SessionRepo - GetActiveSessions - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []entity.SessionDTO
+	for rows.Next() {
+		var session entity.SessionDTO
+		err := rows.Scan(&session.SessionUUID, &session.UserUUID, &session.DeviceName, &session.UserAgent, &session.IP,
+			&session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt, &session.RevokedAt)
+		if err != nil {
+			return nil, fmt.Errorf("SessionRepo - GetActiveSessions - rows.Scan: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SessionRepo - GetActiveSessions - rows.Err: %w", err)
+	}
+
+	return sessions, nil
+}
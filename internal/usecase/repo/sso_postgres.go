@@ -0,0 +1,160 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// SSORepo -.
+type SSORepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewSSO(pg *sql.DB) *SSORepo {
+	return &SSORepo{pg}
+}
+
+// StoreLoginState -.
+func (r *SSORepo) StoreLoginState(ctx context.Context, loginState entity.SSOLoginStateDTO) error {
+	insertLoginStateSQL := `
+		INSERT INTO sso_login_states (state_hash, nonce, code_verifier, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := r.ExecContext(ctx, insertLoginStateSQL, loginState.StateHash, loginState.Nonce, loginState.CodeVerifier,
+		loginState.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("SSORepo - StoreLoginState - r.ExecContext: %w", err)
+	}
+
+	// Logins that were started but never completed are of no use once expired
+	deleteExpiredLoginStatesSQL := `
+		DELETE FROM sso_login_states
+		WHERE expires_at < NOW()
+	`
+	_, err = r.ExecContext(ctx, deleteExpiredLoginStatesSQL)
+	if err != nil {
+		return fmt.Errorf("SSORepo - StoreLoginState - r.ExecContext: %w", err)
+	}
+	return nil
+}
+
+// ConsumeLoginState -.
+func (r *SSORepo) ConsumeLoginState(ctx context.Context, stateHash string) (*entity.SSOLoginStateDTO, error) {
+	// Deleting the state and reading it in one statement guarantees it can only be used once
+	consumeLoginStateSQL := `
+		DELETE FROM sso_login_states
+		WHERE state_hash = $1
+		RETURNING state_hash, nonce, code_verifier, expires_at
+	`
+
+	var loginState entity.SSOLoginStateDTO
+	err := r.QueryRowContext(ctx, consumeLoginStateSQL, stateHash).
+		Scan(&loginState.StateHash, &loginState.Nonce, &loginState.CodeVerifier, &loginState.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("SSORepo - ConsumeLoginState - r.QueryRowContext: %w", err)
+	}
+
+	return &loginState, nil
+}
+
+// GetIdentityUserUUID -.
+func (r *SSORepo) GetIdentityUserUUID(ctx context.Context, issuer string, subject string) (*string, error) {
+	// Record the login while looking up the identity
+	getIdentityUserUUIDSQL := `
+		UPDATE user_identities
+		SET last_login_at = NOW()
+		WHERE issuer = $1
+		AND subject = $2
+		RETURNING user_uuid
+	`
+
+	var userUUID string
+	err := r.QueryRowContext(ctx, getIdentityUserUUIDSQL, issuer, subject).Scan(&userUUID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("SSORepo - GetIdentityUserUUID - r.QueryRowContext: %w", err)
+	}
+
+	return &userUUID, nil
+}
+
+// LinkIdentity -.
+func (r *SSORepo) LinkIdentity(ctx context.Context, identity entity.UserIdentityDTO) error {
+	insertIdentitySQL := `
+		INSERT INTO user_identities (issuer, subject, user_uuid, email)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := r.ExecContext(ctx, insertIdentitySQL, identity.Issuer, identity.Subject, identity.UserUUID, identity.Email)
+	if err != nil {
+		return fmt.Errorf("SSORepo - LinkIdentity - r.ExecContext: %w", err)
+	}
+	return nil
+}
+
+// CreateUserWithIdentity -.
+func (r *SSORepo) CreateUserWithIdentity(ctx context.Context, userRegis entity.UserRegistrationDTO, identity entity.UserIdentityDTO,
+	emailVerified bool) (string, error) {
+	userUuid := uuid.New().String()
+
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("SSORepo - CreateUserWithIdentity - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	// The email is already verified if the identity provider says so
+	insertUserCredentialsSQL := `
+		INSERT INTO user_credentials (email, username, password, user_uuid, verified_at)
+		VALUES ($1, $2, $3, $4, CASE WHEN $5::BOOLEAN THEN NOW() END)
+	`
+	_, err = tx.ExecContext(ctx, insertUserCredentialsSQL, userRegis.Email, userRegis.Username, userRegis.Password, userUuid, emailVerified)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute insert insertUserCredentialsSQL query: %w", err)
+	}
+
+	insertUserInfoSQL := `
+		INSERT INTO user_info (user_uuid, first_name, last_name, email, avatar)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err = tx.ExecContext(ctx, insertUserInfoSQL, userUuid, userRegis.FirstName, userRegis.LastName, userRegis.Email, userRegis.Avatar)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute insert insertUserInfoSQL query: %w", err)
+	}
+
+	insertIdentitySQL := `
+		INSERT INTO user_identities (issuer, subject, user_uuid, email)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err = tx.ExecContext(ctx, insertIdentitySQL, identity.Issuer, identity.Subject, userUuid, identity.Email)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute insert insertIdentitySQL query: %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return "", fmt.Errorf("SSORepo - CreateUserWithIdentity - failed to commit transaction: %w", err)
+	}
+
+	return userUuid, nil
+}
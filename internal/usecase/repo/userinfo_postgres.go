@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/maxyong7/chat-messaging-app/internal/entity"
@@ -23,14 +24,15 @@ func NewUserInfo(pg *sql.DB) *UserInfoRepo {
 // GetUserCredentials -.
 func (r *UserInfoRepo) GetUserCredentials(ctx context.Context, userInfo entity.UserCredentialsDTO) (*entity.UserCredentialsDTO, error) {
 	getUserCredentialsSQL := `
-		SELECT email, username, password, user_uuid
+		SELECT email, username, password, user_uuid, verified_at, delete_after, suspended_at
 		FROM user_credentials
 		WHERE (username = $1 OR email = $2) 
 	`
 
 	var userInfoDTO entity.UserCredentialsDTO
 	err := r.QueryRowContext(ctx, getUserCredentialsSQL, userInfo.Username, userInfo.Email).
-		Scan(&userInfoDTO.Email, &userInfoDTO.Username, &userInfoDTO.Password, &userInfoDTO.UserUuid)
+		Scan(&userInfoDTO.Email, &userInfoDTO.Username, &userInfoDTO.Password, &userInfoDTO.UserUuid, &userInfoDTO.VerifiedAt,
+			&userInfoDTO.DeleteAfter, &userInfoDTO.SuspendedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -89,11 +91,17 @@ func (r *UserInfoRepo) StoreUserInfo(ctx context.Context, userRegis entity.UserR
 
 // CheckUserExist -.
 func (r *UserInfoRepo) CheckUserExist(ctx context.Context, userRegis entity.UserRegistrationDTO) (bool, error) {
-	// Check if the user already exists
+	// Check if the user already exists, usernames that another user gave up recently are still reserved
 	checkUserExistSQL := `
 	SELECT 1 
 	FROM user_credentials
 	WHERE (username = $1 or email = $2)
+	UNION ALL
+	SELECT 1
+	FROM username_history
+	WHERE LOWER(username) = LOWER($1)
+	AND reserved_until > NOW()
+	LIMIT 1
 	`
 
 	var exists int
@@ -112,24 +120,103 @@ func (r *UserInfoRepo) CheckUserExist(ctx context.Context, userRegis entity.User
 // GetUserProfile -.
 func (r *UserInfoRepo) GetUserProfile(ctx context.Context, userUuid string) (*entity.UserProfileDTO, error) {
 	getUserProfileSQL := `
-		SELECT user_uuid, first_name, last_name, avatar
+		SELECT user_uuid, first_name, last_name, avatar,
+		EXISTS (SELECT 1 FROM bots b WHERE b.bot_uuid = user_info.user_uuid),
+		status_emoji, status_text, status_expires_at
 		FROM user_info
 		WHERE (user_uuid = $1) 
 	`
 
 	var userInfoDTO entity.UserProfileDTO
+	var statusEmoji, statusText sql.NullString
+	var statusExpiresAt sql.NullTime
 	err := r.QueryRowContext(ctx, getUserProfileSQL, userUuid).
-		Scan(&userInfoDTO.UserUUID, &userInfoDTO.FirstName, &userInfoDTO.LastName, &userInfoDTO.Avatar)
+		Scan(&userInfoDTO.UserUUID, &userInfoDTO.FirstName, &userInfoDTO.LastName, &userInfoDTO.Avatar, &userInfoDTO.IsBot,
+			&statusEmoji, &statusText, &statusExpiresAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("UserInfoRepo - GetUserProfile - r.QueryRowContext: %w", err)
 	}
+	userInfoDTO.Status = toUserStatus(statusEmoji, statusText, statusExpiresAt)
 
 	return &userInfoDTO, nil
 }
 
+// UpdateAvatar -.
+func (r *UserInfoRepo) UpdateAvatar(ctx context.Context, userUUID string, avatar string) error {
+	updateAvatarSQL := `
+		UPDATE user_info
+		SET avatar = $1
+		WHERE user_uuid = $2
+	`
+
+	_, err := r.ExecContext(ctx, updateAvatarSQL, avatar, userUUID)
+	if err != nil {
+		return fmt.Errorf("UserInfoRepo - UpdateAvatar - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateUserStatus replaces the custom status of the user, a nil status clears it.
+func (r *UserInfoRepo) UpdateUserStatus(ctx context.Context, userUUID string, status *entity.UserStatus) error {
+	updateUserStatusSQL := `
+		UPDATE user_info
+		SET status_emoji = $1,
+		status_text = $2,
+		status_expires_at = $3
+		WHERE user_uuid = $4
+	`
+
+	var emoji, text sql.NullString
+	var expiresAt sql.NullTime
+	if status != nil {
+		emoji = sql.NullString{String: status.Emoji, Valid: true}
+		text = sql.NullString{String: status.Text, Valid: true}
+		if status.ExpiresAt != nil {
+			expiresAt = sql.NullTime{Time: *status.ExpiresAt, Valid: true}
+		}
+	}
+
+	_, err := r.ExecContext(ctx, updateUserStatusSQL, emoji, text, expiresAt, userUUID)
+	if err != nil {
+		return fmt.Errorf("UserInfoRepo - UpdateUserStatus - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// ClearExpiredStatuses clears the custom statuses that expired at or before now and returns their users.
+func (r *UserInfoRepo) ClearExpiredStatuses(ctx context.Context, now time.Time) ([]string, error) {
+	clearExpiredStatusesSQL := `
+		UPDATE user_info
+		SET status_emoji = NULL,
+		status_text = NULL,
+		status_expires_at = NULL
+		WHERE status_expires_at <= $1
+		RETURNING user_uuid
+	`
+
+	rows, err := r.QueryContext(ctx, clearExpiredStatusesSQL, now)
+	if err != nil {
+		return nil, fmt.Errorf("UserInfoRepo - ClearExpiredStatuses - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var userUUIDs []string
+	for rows.Next() {
+		var userUUID string
+		if err := rows.Scan(&userUUID); err != nil {
+			return nil, fmt.Errorf("UserInfoRepo - ClearExpiredStatuses - rows.Scan: %w", err)
+		}
+		userUUIDs = append(userUUIDs, userUUID)
+	}
+
+	return userUUIDs, rows.Err()
+}
+
 // GetUserUUIDByUsername -.
 func (r *UserInfoRepo) GetUserUUIDByUsername(ctx context.Context, userName string) (*string, error) {
 	getUserUUIDByUsernameSQL := `
@@ -189,3 +276,221 @@ func (r *UserInfoRepo) UpdateUserProfile(ctx context.Context, userInfo entity.Us
 
 	return nil
 }
+
+// UpdatePassword -.
+func (r *UserInfoRepo) UpdatePassword(ctx context.Context, userUUID string, password string) error {
+	updatePasswordSQL := `
+		UPDATE user_credentials
+		SET password = $1
+		WHERE user_uuid = $2
+	`
+
+	_, err := r.ExecContext(ctx, updatePasswordSQL, password, userUUID)
+	if err != nil {
+		return fmt.Errorf("UserInfoRepo - UpdatePassword - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// ReplacePasswordHash replaces the password hash only if it was not changed since oldHash was read.
+func (r *UserInfoRepo) ReplacePasswordHash(ctx context.Context, userUUID string, oldHash string, newHash string) error {
+	replacePasswordHashSQL := `
+		UPDATE user_credentials
+		SET password = $1
+		WHERE user_uuid = $2
+		AND password = $3
+	`
+
+	_, err := r.ExecContext(ctx, replacePasswordHashSQL, newHash, userUUID, oldHash)
+	if err != nil {
+		return fmt.Errorf("UserInfoRepo - ReplacePasswordHash - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// MarkEmailVerified -.
+func (r *UserInfoRepo) MarkEmailVerified(ctx context.Context, userUUID string, email string) (bool, error) {
+	// The email must still be the one the verification was sent to
+	markEmailVerifiedSQL := `
+		UPDATE user_credentials
+		SET verified_at = NOW()
+		WHERE user_uuid = $1
+		AND email = $2
+		AND verified_at IS NULL
+	`
+
+	result, err := r.ExecContext(ctx, markEmailVerifiedSQL, userUUID, email)
+	if err != nil {
+		return false, fmt.Errorf("UserInfoRepo - MarkEmailVerified - r.ExecContext: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("UserInfoRepo - MarkEmailVerified - result.RowsAffected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetUserCredentialsByUUID -.
+func (r *UserInfoRepo) GetUserCredentialsByUUID(ctx context.Context, userUUID string) (*entity.UserCredentialsDTO, error) {
+	getUserCredentialsByUUIDSQL := `
+		SELECT email, username, password, user_uuid, verified_at, delete_after
+		FROM user_credentials
+		WHERE user_uuid = $1
+	`
+
+	var userInfoDTO entity.UserCredentialsDTO
+	err := r.QueryRowContext(ctx, getUserCredentialsByUUIDSQL, userUUID).
+		Scan(&userInfoDTO.Email, &userInfoDTO.Username, &userInfoDTO.Password, &userInfoDTO.UserUuid, &userInfoDTO.VerifiedAt,
+			&userInfoDTO.DeleteAfter)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("UserInfoRepo - GetUserCredentialsByUUID - r.QueryRowContext: %w", err)
+	}
+
+	return &userInfoDTO, nil
+}
+
+// CancelAccountDeletion -.
+func (r *UserInfoRepo) CancelAccountDeletion(ctx context.Context, userUUID string) error {
+	cancelAccountDeletionSQL := `
+		UPDATE user_credentials
+		SET delete_after = NULL
+		WHERE user_uuid = $1
+		AND delete_after IS NOT NULL
+	`
+
+	_, err := r.ExecContext(ctx, cancelAccountDeletionSQL, userUUID)
+	if err != nil {
+		return fmt.Errorf("UserInfoRepo - CancelAccountDeletion - r.ExecContext: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateEmail -.
+func (r *UserInfoRepo) UpdateEmail(ctx context.Context, userUUID string, email string) error {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("UserInfoRepo - UpdateEmail - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	// The new email was confirmed with a token sent to it, so it is verified right away
+	updateUserCredentialsSQL := `
+		UPDATE user_credentials
+		SET email = $1, verified_at = NOW()
+		WHERE user_uuid = $2
+	`
+	_, err = tx.ExecContext(ctx, updateUserCredentialsSQL, email, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute update updateUserCredentialsSQL query: %w", err)
+	}
+
+	updateUserInfoSQL := `
+		UPDATE user_info
+		SET email = $1
+		WHERE user_uuid = $2
+	`
+	_, err = tx.ExecContext(ctx, updateUserInfoSQL, email, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute update updateUserInfoSQL query: %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("UserInfoRepo - UpdateEmail - failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// IsUsernameAvailable -.
+func (r *UserInfoRepo) IsUsernameAvailable(ctx context.Context, username string, userUUID string) (bool, error) {
+	// A username is available if no other user has it and no other user gave it up recently
+	isUsernameAvailableSQL := `
+		SELECT NOT EXISTS (
+			SELECT 1
+			FROM user_credentials
+			WHERE LOWER(username) = LOWER($1)
+			AND user_uuid <> $2
+			UNION ALL
+			SELECT 1
+			FROM username_history
+			WHERE LOWER(username) = LOWER($1)
+			AND user_uuid <> $2
+			AND reserved_until > NOW()
+		)
+	`
+
+	var available bool
+	err := r.QueryRowContext(ctx, isUsernameAvailableSQL, username, userUUID).Scan(&available)
+	if err != nil {
+		return false, fmt.Errorf("UserInfoRepo - IsUsernameAvailable - r.QueryRowContext: %w", err)
+	}
+
+	return available, nil
+}
+
+// UpdateUsername -.
+func (r *UserInfoRepo) UpdateUsername(ctx context.Context, userUUID string, oldUsername string, newUsername string, reservedUntil time.Time) error {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("UserInfoRepo - UpdateUsername - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	updateUsernameSQL := `
+		UPDATE user_credentials
+		SET username = $1
+		WHERE user_uuid = $2
+	`
+	_, err = tx.ExecContext(ctx, updateUsernameSQL, newUsername, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to execute update updateUsernameSQL query: %w", err)
+	}
+
+	// Keep the old username reserved for this user
+	insertUsernameHistorySQL := `
+		INSERT INTO username_history (user_uuid, username, reserved_until)
+		VALUES ($1, $2, $3)
+	`
+	_, err = tx.ExecContext(ctx, insertUsernameHistorySQL, userUUID, oldUsername, reservedUntil)
+	if err != nil {
+		return fmt.Errorf("failed to execute insert insertUsernameHistorySQL query: %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("UserInfoRepo - UpdateUsername - failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// UserSearchRepo -.
+type UserSearchRepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewUserSearch(pg *sql.DB) *UserSearchRepo {
+	return &UserSearchRepo{pg}
+}
+
+// SearchUsers returns the discoverable users whose username, first or last name start with or resemble the query.
+// Users who blocked the searcher or were blocked by them, and accounts that are about to be deleted are left out.
+func (r *UserSearchRepo) SearchUsers(ctx context.Context, query entity.UserSearchQuery) ([]entity.UserSearchResult, error) {
+	// Prefix matches rank above fuzzy matches, both use the trigram indexes on the lower cased columns
+	searchUsersSQL := `
+		SELECT user_uuid, username, first_name, last_name, avatar, score
+		FROM (
+			SELECT uc.user_uuid, uc.username, ui.first_name, ui.last_name, COALESCE(ui.avatar, '') AS avatar,
+				CASE WHEN LOWER(uc.username) LIKE $6
+					OR LOWER(ui.first_name) LIKE $6
+					OR LOWER(ui.last_name) LIKE $6 THEN 1 ELSE 0 END
+				+ GREATEST(
+					similarity(LOWER(uc.username), $2),
+					similarity(LOWER(ui.first_name), $2),
+					similarity(LOWER(ui.last_name), $2)
+				)::FLOAT8 AS score
+			FROM user_credentials uc
+			JOIN user_info ui ON ui.user_uuid = uc.user_uuid
+			LEFT JOIN privacy_settings ps ON ps.user_uuid = uc.user_uuid
+			WHERE uc.user_uuid <> $1
+			AND uc.delete_after IS NULL
+			AND COALESCE(ps.discoverable, TRUE)
+			AND (
+				LOWER(uc.username) LIKE $6
+				OR LOWER(ui.first_name) LIKE $6
+				OR LOWER(ui.last_name) LIKE $6
+				OR LOWER(uc.username) % $2
+				OR LOWER(ui.first_name) % $2
+				OR LOWER(ui.last_name) % $2
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM contacts ct
+				WHERE ct.blocked
+				AND (
+					(ct.user_uuid = $1 AND ct.contact_user_uuid = uc.user_uuid)
+					OR (ct.user_uuid = uc.user_uuid AND ct.contact_user_uuid = $1)
+				)
+			)
+		) results
+		WHERE $3::FLOAT8 IS NULL
+		OR score < $3
+		OR (score = $3 AND user_uuid > $4)
+		ORDER BY score DESC, user_uuid
+		LIMIT $5
+	`
+
+	// The first page has no cursor
+	var cursorScore sql.NullFloat64
+	var cursorUserUUID string
+	if query.Cursor != nil {
+		cursorScore = sql.NullFloat64{Float64: query.Cursor.Score, Valid: true}
+		cursorUserUUID = query.Cursor.UserUUID
+	}
+
+	rows, err := r.QueryContext(ctx, searchUsersSQL, query.UserUUID, query.Query, cursorScore, cursorUserUUID, query.Limit, prefixPattern(query.Query))
+	if err != nil {
+		return nil, fmt.Errorf("UserSearchRepo - SearchUsers - r.QueryContext: %w", err)
+	}
+	defer rows.Close()
+
+	var results []entity.UserSearchResult
+	for rows.Next() {
+		var result entity.UserSearchResult
+		if err := rows.Scan(&result.UserUUID, &result.Username, &result.FirstName, &result.LastName, &result.Avatar, &result.Score); err != nil {
+			return nil, fmt.Errorf("UserSearchRepo - SearchUsers - rows.Scan: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
@@ -0,0 +1,175 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// UserTokenRepo -.
+type UserTokenRepo struct {
+	*sql.DB
+}
+
+// New -.
+func NewUserToken(pg *sql.DB) *UserTokenRepo {
+	return &UserTokenRepo{pg}
+}
+
+// StoreUserToken -.
+func (r *UserTokenRepo) StoreUserToken(ctx context.Context, userToken entity.UserTokenDTO) error {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("UserTokenRepo - StoreUserToken - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	// Only the latest token for a purpose stays usable, older unused ones are invalidated
+	invalidateUserTokensSQL := `
+		UPDATE user_tokens
+		SET used_at = NOW()
+		WHERE user_uuid = $1
+		AND purpose = $2
+		AND used_at IS NULL
+	`
+	_, err = tx.ExecContext(ctx, invalidateUserTokensSQL, userToken.UserUUID, userToken.Purpose)
+	if err != nil {
+		return fmt.Errorf("failed to execute update invalidateUserTokensSQL query: %w", err)
+	}
+
+	insertUserTokenSQL := `
+		INSERT INTO user_tokens (token_hash, user_uuid, purpose, payload, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err = tx.ExecContext(ctx, insertUserTokenSQL, userToken.TokenHash, userToken.UserUUID, userToken.Purpose,
+		userToken.Payload, userToken.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to execute insert insertUserTokenSQL query: %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("UserTokenRepo - StoreUserToken - failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeUserToken -.
+func (r *UserTokenRepo) ConsumeUserToken(ctx context.Context, tokenHash string, purpose string) (*entity.UserTokenDTO, error) {
+	// Marking the token as used and reading it in one statement guarantees it can only be consumed once
+	consumeUserTokenSQL := `
+		UPDATE user_tokens
+		SET used_at = NOW()
+		WHERE token_hash = $1
+		AND purpose = $2
+		AND used_at IS NULL
+		AND expires_at > NOW()
+		RETURNING token_hash, user_uuid, purpose, payload, expires_at, used_at
+	`
+
+	var userToken entity.UserTokenDTO
+	err := r.QueryRowContext(ctx, consumeUserTokenSQL, tokenHash, purpose).
+		Scan(&userToken.TokenHash, &userToken.UserUUID, &userToken.Purpose, &userToken.Payload,
+			&userToken.ExpiresAt, &userToken.UsedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("UserTokenRepo - ConsumeUserToken - r.QueryRowContext: %w", err)
+	}
+
+	return &userToken, nil
+}
+
+// ResetPassword consumes the password reset token and sets the new password of its user in one transaction,
+// so that the token stays valid if the password could not be updated.
+func (r *UserTokenRepo) ResetPassword(ctx context.Context, tokenHash string, password string) (*entity.UserTokenDTO, error) {
+	// Begin a transaction
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("UserTokenRepo - ResetPassword - failed to begin transaction: %w", err)
+	}
+
+	// Ensure transaction is rolled back if it doesn't commit
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p) // Re-throw panic after rollback
+		} else if err != nil {
+			tx.Rollback() // err is non-nil; rollback
+		}
+	}()
+
+	consumeUserTokenSQL := `
+		UPDATE user_tokens
+		SET used_at = NOW()
+		WHERE token_hash = $1
+		AND purpose = $2
+		AND used_at IS NULL
+		AND expires_at > NOW()
+		RETURNING token_hash, user_uuid, purpose, payload, expires_at, used_at
+	`
+
+	var userToken entity.UserTokenDTO
+	err = tx.QueryRowContext(ctx, consumeUserTokenSQL, tokenHash, entity.TokenPurposePasswordReset).
+		Scan(&userToken.TokenHash, &userToken.UserUUID, &userToken.Purpose, &userToken.Payload,
+			&userToken.ExpiresAt, &userToken.UsedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to execute update consumeUserTokenSQL query: %w", err)
+	}
+
+	updatePasswordSQL := `
+		UPDATE user_credentials
+		SET password = $1
+		WHERE user_uuid = $2
+	`
+	_, err = tx.ExecContext(ctx, updatePasswordSQL, password, userToken.UserUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update updatePasswordSQL query: %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("UserTokenRepo - ResetPassword - failed to commit transaction: %w", err)
+	}
+
+	return &userToken, nil
+}
+
+// CountUserTokensSince -.
+func (r *UserTokenRepo) CountUserTokensSince(ctx context.Context, userUUID string, purpose string, since time.Time) (int, error) {
+	countUserTokensSinceSQL := `
+		SELECT COUNT(*)
+		FROM user_tokens
+		WHERE user_uuid = $1
+		AND purpose = $2
+		AND created_at > $3
+	`
+
+	var count int
+	err := r.QueryRowContext(ctx, countUserTokensSinceSQL, userUUID, purpose, since).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("UserTokenRepo - CountUserTokensSince - r.QueryRowContext: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+const _reportMaxDetailsLength = 1000
+
+// Reasons a report can be given
+var _reportReasons = map[string]bool{
+	entity.ReportReasonSpam:          true,
+	entity.ReportReasonHarassment:    true,
+	entity.ReportReasonHateSpeech:    true,
+	entity.ReportReasonSexualContent: true,
+	entity.ReportReasonViolence:      true,
+	entity.ReportReasonImpersonation: true,
+	entity.ReportReasonOther:         true,
+}
+
+// Statuses a report can be moved to from each status of the moderation queue.
+// Reports in review can be given back to the queue, handled reports can be reopened.
+var _reportTransitions = map[string][]string{
+	entity.ReportStatusOpen:      {entity.ReportStatusInReview, entity.ReportStatusResolved, entity.ReportStatusDismissed},
+	entity.ReportStatusInReview:  {entity.ReportStatusOpen, entity.ReportStatusResolved, entity.ReportStatusDismissed},
+	entity.ReportStatusResolved:  {entity.ReportStatusOpen},
+	entity.ReportStatusDismissed: {entity.ReportStatusOpen},
+}
+
+// ReportUseCase lets users report users, messages and conversations, and admins work through the reports.
+// Reported messages are copied into the report, so that they can be reviewed after their sender deleted them.
+type ReportUseCase struct {
+	repo    ReportRepo
+	limiter *rateLimiter
+	now     func() time.Time
+}
+
+// NewReport -.
+// Each user can report rateLimit times per rateWindow, a rateLimit of zero disables the limit.
+func NewReport(r ReportRepo, rateLimit int, rateWindow time.Duration) *ReportUseCase {
+	return &ReportUseCase{
+		repo:    r,
+		limiter: newRateLimiter(rateLimit, rateWindow),
+		now:     time.Now,
+	}
+}
+
+// ReportAbuse stores an open report of the target by the reporter.
+// Users can only report messages and conversations they take or took part in.
+// ConversationUUID of user reports is optional, it tells in which conversation the abuse happened.
+func (uc *ReportUseCase) ReportAbuse(ctx context.Context, report entity.Report) (entity.Report, error) {
+	// Return error if the user reported too often. Will be handled by controller
+	if !uc.limiter.allow(report.ReporterUUID, uc.now()) {
+		return entity.Report{}, entity.ErrTooManyRequests
+	}
+
+	report.Reason = strings.ToLower(strings.TrimSpace(report.Reason))
+	report.Details = strings.TrimSpace(report.Details)
+
+	// Return error if the reason is unknown, or if 'other' is not explained. Will be handled by controller
+	if !_reportReasons[report.Reason] || utf8.RuneCountInString(report.Details) > _reportMaxDetailsLength ||
+		(report.Reason == entity.ReportReasonOther && report.Details == "") {
+		return entity.Report{}, entity.ErrInvalidReport
+	}
+
+	var err error
+	switch report.TargetType {
+	case entity.AdminTargetUser:
+		err = uc.checkUserTarget(ctx, report)
+	case entity.AdminTargetMessage:
+		report, err = uc.snapshotMessageTarget(ctx, report)
+	case entity.AdminTargetConversation:
+		report.ConversationUUID = report.TargetUUID
+		err = uc.checkParticipant(ctx, report.ConversationUUID, report.ReporterUUID)
+	default:
+		err = entity.ErrInvalidReport
+	}
+	if err != nil {
+		return entity.Report{}, err
+	}
+
+	report.ReportUUID = uuid.New().String()
+	report.Status = entity.ReportStatusOpen
+	report.CreatedAt = uc.now()
+	report.UpdatedAt = report.CreatedAt
+
+	// Store the report into 'abuse_reports' table using report data repository
+	stored, err := uc.repo.StoreReport(ctx, report)
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("ReportUseCase - ReportAbuse - uc.repo.StoreReport: %w", err)
+	}
+
+	// Return error if the same target was already reported by the user and is not handled yet. Will be handled by controller
+	if !stored {
+		return entity.Report{}, entity.ErrReportExists
+	}
+	return report, nil
+}
+
+// ListReports returns a page of the moderation queue from the oldest report.
+func (uc *ReportUseCase) ListReports(ctx context.Context, query entity.ReportQuery) (entity.ReportPage, error) {
+	// Return error if the status or the target type is unknown. Will be handled by controller
+	if query.Status != "" && _reportTransitions[query.Status] == nil {
+		return entity.ReportPage{}, entity.ErrInvalidReportsQuery
+	}
+	switch query.TargetType {
+	case "", entity.AdminTargetUser, entity.AdminTargetMessage, entity.AdminTargetConversation:
+	default:
+		return entity.ReportPage{}, entity.ErrInvalidReportsQuery
+	}
+
+	query.Limit = adminLimit(query.Limit)
+	limit := query.Limit
+
+	// One more report than requested tells whether there is a next page
+	query.Limit++
+
+	// Get the reports from report data repository by querying 'abuse_reports' table
+	reports, err := uc.repo.ListReports(ctx, query)
+	if err != nil {
+		return entity.ReportPage{}, fmt.Errorf("ReportUseCase - ListReports - uc.repo.ListReports: %w", err)
+	}
+
+	page := entity.ReportPage{Reports: reports, Limit: limit}
+	if len(reports) > limit {
+		page.Reports = reports[:limit]
+		page.NextCursor = page.Reports[limit-1].ID
+	}
+	return page, nil
+}
+
+// GetReport returns the report with the copy of the reported message.
+func (uc *ReportUseCase) GetReport(ctx context.Context, reportUUID string) (entity.Report, error) {
+	// Get the report from report data repository by querying 'abuse_reports' table
+	report, err := uc.repo.GetReport(ctx, reportUUID)
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("ReportUseCase - GetReport - uc.repo.GetReport: %w", err)
+	}
+
+	// Return error if report is not found. Will be handled by controller
+	if report == nil {
+		return entity.Report{}, entity.ErrReportNotFound
+	}
+	return *report, nil
+}
+
+// UpdateReportStatus moves the report to another status of the moderation queue.
+// The change is stored in the admin audit log with the status and the note as its reason.
+func (uc *ReportUseCase) UpdateReportStatus(ctx context.Context, update entity.ReportStatusUpdate) (entity.Report, error) {
+	report, err := uc.GetReport(ctx, update.ReportUUID)
+	if err != nil {
+		return entity.Report{}, err
+	}
+
+	// Return error if the report can not be moved from its status to the new one. Will be handled by controller
+	if !canMoveReport(report.Status, update.Status) {
+		return entity.Report{}, entity.ErrInvalidReportTransition
+	}
+
+	fromStatus := report.Status
+	report.Status = update.Status
+	report.ReviewerUUID = update.AdminUUID
+	report.ResolutionNote = strings.TrimSpace(update.Note)
+	report.UpdatedAt = uc.now()
+
+	reason := update.Status
+	if report.ResolutionNote != "" {
+		reason += ": " + report.ResolutionNote
+	}
+	audit := newAuditEntry(entity.AdminAction{
+		AdminUUID:  update.AdminUUID,
+		TargetUUID: report.ReportUUID,
+		Reason:     reason,
+	}, entity.AdminActionUpdateReport, entity.AdminTargetReport)
+
+	// Update the report in 'abuse_reports' table and store the audit entry using report data repository
+	updated, err := uc.repo.UpdateReportStatus(ctx, report, fromStatus, audit)
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("ReportUseCase - UpdateReportStatus - uc.repo.UpdateReportStatus: %w", err)
+	}
+
+	// Return error if another admin moved the report in the meantime. Will be handled by controller
+	if !updated {
+		return entity.Report{}, entity.ErrInvalidReportTransition
+	}
+	return report, nil
+}
+
+// checkUserTarget returns an error if the reported user does not exist or is the reporter.
+func (uc *ReportUseCase) checkUserTarget(ctx context.Context, report entity.Report) error {
+	// Return error if the user reports themselves. Will be handled by controller
+	if report.TargetUUID == report.ReporterUUID {
+		return entity.ErrInvalidReport
+	}
+
+	// Check if the user exists from report data repository by querying 'user_info' table
+	exists, err := uc.repo.CheckUserExists(ctx, report.TargetUUID)
+	if err != nil {
+		return fmt.Errorf("ReportUseCase - checkUserTarget - uc.repo.CheckUserExists: %w", err)
+	}
+
+	// Return error if user is not found. Will be handled by controller
+	if !exists {
+		return entity.ErrUserNotFound
+	}
+
+	if report.ConversationUUID == "" {
+		return nil
+	}
+	return uc.checkParticipant(ctx, report.ConversationUUID, report.ReporterUUID)
+}
+
+// snapshotMessageTarget copies the reported message into the report.
+// A message the reporter can not see is not found, so that reports do not tell which messages exist.
+func (uc *ReportUseCase) snapshotMessageTarget(ctx context.Context, report entity.Report) (entity.Report, error) {
+	// Get the message from report data repository by querying 'messages' table
+	msg, err := uc.repo.GetReportedMessage(ctx, report.TargetUUID)
+	if err != nil {
+		return entity.Report{}, fmt.Errorf("ReportUseCase - snapshotMessageTarget - uc.repo.GetReportedMessage: %w", err)
+	}
+
+	// Return error if message is not found, or not in the conversation it was reported from. Will be handled by controller
+	if msg == nil || (report.ConversationUUID != "" && report.ConversationUUID != msg.ConversationUUID) {
+		return entity.Report{}, entity.ErrMessageNotFound
+	}
+
+	// Return error if the user reports their own message. Will be handled by controller
+	if msg.SenderUUID == report.ReporterUUID {
+		return entity.Report{}, entity.ErrInvalidReport
+	}
+
+	err = uc.checkParticipant(ctx, msg.ConversationUUID, report.ReporterUUID)
+	if err == entity.ErrConversationNotFound {
+		return entity.Report{}, entity.ErrMessageNotFound
+	}
+	if err != nil {
+		return entity.Report{}, err
+	}
+
+	report.ConversationUUID = msg.ConversationUUID
+	report.Message = msg
+	return report, nil
+}
+
+// checkParticipant returns ErrConversationNotFound if the user never took part in the conversation.
+func (uc *ReportUseCase) checkParticipant(ctx context.Context, conversationUUID string, userUUID string) error {
+	// Check if the user is a participant from report data repository by querying 'participants' table
+	participant, err := uc.repo.CheckParticipant(ctx, conversationUUID, userUUID)
+	if err != nil {
+		return fmt.Errorf("ReportUseCase - checkParticipant - uc.repo.CheckParticipant: %w", err)
+	}
+
+	// Return error if the user is not a participant, the conversation may as well not exist. Will be handled by controller
+	if !participant {
+		return entity.ErrConversationNotFound
+	}
+	return nil
+}
+
+func canMoveReport(from string, to string) bool {
+	for _, status := range _reportTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
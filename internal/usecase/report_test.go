@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+)
+
+func TestReportUseCase_ReportAbuse(t *testing.T) {
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                   // Name of the test case
+		report     entity.Report                            // Report sent by the user
+		setupMocks func(mockRepo *mocks.MockReportRepo)     // Function to set up mock behavior
+		wantErr    error                                    // Expected error, if any
+		wantAnyErr bool                                     // Whether any error is expected
+		check      func(t *testing.T, report entity.Report) // Checks on the stored report, if any
+	}
+
+	message := &entity.ReportedMessage{ConversationUUID: "conv-uuid", SenderUUID: testContactUserUUID, Content: "go away", CreatedAt: time.Now()}
+	messageReport := entity.Report{ReporterUUID: testUserUUID, TargetType: entity.AdminTargetMessage, TargetUUID: "msg-uuid", Reason: " Harassment "}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:   "success - message is copied into the report",
+			report: messageReport,
+			setupMocks: func(mockRepo *mocks.MockReportRepo) {
+				mockRepo.EXPECT().GetReportedMessage(gomock.Any(), "msg-uuid").Return(message, nil)
+				mockRepo.EXPECT().CheckParticipant(gomock.Any(), "conv-uuid", testUserUUID).Return(true, nil)
+				mockRepo.EXPECT().StoreReport(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			check: func(t *testing.T, report entity.Report) {
+				if report.Message != message || report.ConversationUUID != "conv-uuid" || report.Reason != entity.ReportReasonHarassment ||
+					report.Status != entity.ReportStatusOpen || report.ReportUUID == "" {
+					t.Errorf("ReportUseCase.ReportAbuse() = %+v", report)
+				}
+			},
+		},
+		{
+			name:   "error - message of a conversation the user is not in",
+			report: messageReport,
+			setupMocks: func(mockRepo *mocks.MockReportRepo) {
+				mockRepo.EXPECT().GetReportedMessage(gomock.Any(), "msg-uuid").Return(message, nil)
+				mockRepo.EXPECT().CheckParticipant(gomock.Any(), "conv-uuid", testUserUUID).Return(false, nil)
+			},
+			wantErr: entity.ErrMessageNotFound,
+		},
+		{
+			name:   "error - message already deleted",
+			report: messageReport,
+			setupMocks: func(mockRepo *mocks.MockReportRepo) {
+				mockRepo.EXPECT().GetReportedMessage(gomock.Any(), "msg-uuid").Return(nil, nil)
+			},
+			wantErr: entity.ErrMessageNotFound,
+		},
+		{
+			name:   "error - own message",
+			report: entity.Report{ReporterUUID: testContactUserUUID, TargetType: entity.AdminTargetMessage, TargetUUID: "msg-uuid", Reason: "spam"},
+			setupMocks: func(mockRepo *mocks.MockReportRepo) {
+				mockRepo.EXPECT().GetReportedMessage(gomock.Any(), "msg-uuid").Return(message, nil)
+			},
+			wantErr: entity.ErrInvalidReport,
+		},
+		{
+			name:   "success - conversation",
+			report: entity.Report{ReporterUUID: testUserUUID, TargetType: entity.AdminTargetConversation, TargetUUID: "conv-uuid", Reason: "spam"},
+			setupMocks: func(mockRepo *mocks.MockReportRepo) {
+				mockRepo.EXPECT().CheckParticipant(gomock.Any(), "conv-uuid", testUserUUID).Return(true, nil)
+				mockRepo.EXPECT().StoreReport(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			check: func(t *testing.T, report entity.Report) {
+				if report.ConversationUUID != "conv-uuid" || report.Message != nil {
+					t.Errorf("ReportUseCase.ReportAbuse() = %+v", report)
+				}
+			},
+		},
+		{
+			name:   "error - user does not exist",
+			report: entity.Report{ReporterUUID: testUserUUID, TargetType: entity.AdminTargetUser, TargetUUID: "unknown-uuid", Reason: "spam"},
+			setupMocks: func(mockRepo *mocks.MockReportRepo) {
+				mockRepo.EXPECT().CheckUserExists(gomock.Any(), "unknown-uuid").Return(false, nil)
+			},
+			wantErr: entity.ErrUserNotFound,
+		},
+		{
+			name:    "error - reporting yourself",
+			report:  entity.Report{ReporterUUID: testUserUUID, TargetType: entity.AdminTargetUser, TargetUUID: testUserUUID, Reason: "spam"},
+			wantErr: entity.ErrInvalidReport,
+		},
+		{
+			name:    "error - unknown reason",
+			report:  entity.Report{ReporterUUID: testUserUUID, TargetType: entity.AdminTargetUser, TargetUUID: testContactUserUUID, Reason: "boring"},
+			wantErr: entity.ErrInvalidReport,
+		},
+		{
+			name:    "error - other reason without details",
+			report:  entity.Report{ReporterUUID: testUserUUID, TargetType: entity.AdminTargetUser, TargetUUID: testContactUserUUID, Reason: "other", Details: "  "},
+			wantErr: entity.ErrInvalidReport,
+		},
+		{
+			name:   "error - already reported",
+			report: entity.Report{ReporterUUID: testUserUUID, TargetType: entity.AdminTargetUser, TargetUUID: testContactUserUUID, Reason: "spam"},
+			setupMocks: func(mockRepo *mocks.MockReportRepo) {
+				mockRepo.EXPECT().CheckUserExists(gomock.Any(), testContactUserUUID).Return(true, nil)
+				mockRepo.EXPECT().StoreReport(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr: entity.ErrReportExists,
+		},
+		{
+			name:   "error - storing the report",
+			report: entity.Report{ReporterUUID: testUserUUID, TargetType: entity.AdminTargetUser, TargetUUID: testContactUserUUID, Reason: "spam"},
+			setupMocks: func(mockRepo *mocks.MockReportRepo) {
+				mockRepo.EXPECT().CheckUserExists(gomock.Any(), testContactUserUUID).Return(true, nil)
+				mockRepo.EXPECT().StoreReport(gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instance of the repository
+			mockRepo := mocks.NewMockReportRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := NewReport(mockRepo, 0, time.Hour)
+
+			// Call the method under test
+			report, err := uc.ReportAbuse(context.Background(), tt.report)
+			if tt.wantErr != nil || tt.wantAnyErr {
+				if err == nil || (tt.wantErr != nil && err != tt.wantErr) {
+					t.Errorf("ReportUseCase.ReportAbuse() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReportUseCase.ReportAbuse() unexpected error = %v", err)
+			}
+			if tt.check != nil {
+				tt.check(t, report)
+			}
+		})
+	}
+}
+
+func TestReportUseCase_ReportAbuseRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReportRepo(ctrl)
+	uc := NewReport(mockRepo, 1, time.Hour)
+	report := entity.Report{ReporterUUID: testUserUUID, TargetType: entity.AdminTargetUser, TargetUUID: testContactUserUUID, Reason: "spam"}
+
+	mockRepo.EXPECT().CheckUserExists(gomock.Any(), testContactUserUUID).Return(true, nil)
+	mockRepo.EXPECT().StoreReport(gomock.Any(), gomock.Any()).Return(true, nil)
+	if _, err := uc.ReportAbuse(context.Background(), report); err != nil {
+		t.Fatalf("ReportUseCase.ReportAbuse() error = %v", err)
+	}
+
+	// The second report within the window is rejected before anything is checked
+	if _, err := uc.ReportAbuse(context.Background(), report); err != entity.ErrTooManyRequests {
+		t.Errorf("ReportUseCase.ReportAbuse() error = %v, want %v", err, entity.ErrTooManyRequests)
+	}
+}
+
+func TestReportUseCase_UpdateReportStatus(t *testing.T) {
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                               // Name of the test case
+		status     string                               // Current status of the report
+		update     string                               // Status the report is moved to
+		setupMocks func(mockRepo *mocks.MockReportRepo) // Function to set up mock behavior
+		wantErr    error                                // Expected error, if any
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:   "success - open report is resolved and audited",
+			status: entity.ReportStatusOpen,
+			update: entity.ReportStatusResolved,
+			setupMocks: func(mockRepo *mocks.MockReportRepo) {
+				mockRepo.EXPECT().UpdateReportStatus(gomock.Any(), gomock.Any(), entity.ReportStatusOpen, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, report entity.Report, fromStatus string, audit entity.AdminAuditDTO) (bool, error) {
+						if report.Status != entity.ReportStatusResolved || report.ReviewerUUID != testAdminUUID || report.ResolutionNote != "user warned" {
+							t.Errorf("ReportRepo.UpdateReportStatus() report = %+v", report)
+						}
+						if audit.Action != entity.AdminActionUpdateReport || audit.TargetType != entity.AdminTargetReport ||
+							audit.TargetUUID != "report-uuid" || audit.Reason != "resolved: user warned" {
+							t.Errorf("ReportRepo.UpdateReportStatus() audit = %+v", audit)
+						}
+						return true, nil
+					})
+			},
+		},
+		{
+			name:   "success - dismissed report is reopened",
+			status: entity.ReportStatusDismissed,
+			update: entity.ReportStatusOpen,
+			setupMocks: func(mockRepo *mocks.MockReportRepo) {
+				mockRepo.EXPECT().UpdateReportStatus(gomock.Any(), gomock.Any(), entity.ReportStatusDismissed, gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name:    "error - resolved report can not be dismissed",
+			status:  entity.ReportStatusResolved,
+			update:  entity.ReportStatusDismissed,
+			wantErr: entity.ErrInvalidReportTransition,
+		},
+		{
+			name:    "error - unknown status",
+			status:  entity.ReportStatusOpen,
+			update:  "closed",
+			wantErr: entity.ErrInvalidReportTransition,
+		},
+		{
+			name:   "error - moved by another admin in the meantime",
+			status: entity.ReportStatusOpen,
+			update: entity.ReportStatusInReview,
+			setupMocks: func(mockRepo *mocks.MockReportRepo) {
+				mockRepo.EXPECT().UpdateReportStatus(gomock.Any(), gomock.Any(), entity.ReportStatusOpen, gomock.Any()).Return(false, nil)
+			},
+			wantErr: entity.ErrInvalidReportTransition,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instance of the repository
+			mockRepo := mocks.NewMockReportRepo(ctrl)
+			mockRepo.EXPECT().GetReport(gomock.Any(), "report-uuid").Return(&entity.Report{ReportUUID: "report-uuid", Status: tt.status}, nil)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := NewReport(mockRepo, 0, time.Hour)
+
+			// Call the method under test
+			report, err := uc.UpdateReportStatus(context.Background(), entity.ReportStatusUpdate{
+				AdminUUID:  testAdminUUID,
+				ReportUUID: "report-uuid",
+				Status:     tt.update,
+				Note:       " user warned ",
+			})
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("ReportUseCase.UpdateReportStatus() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || report.Status != tt.update {
+				t.Errorf("ReportUseCase.UpdateReportStatus() = %+v, %v, want status %s", report, err, tt.update)
+			}
+		})
+	}
+}
+
+func TestReportUseCase_ListReports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReportRepo(ctrl)
+	uc := NewReport(mockRepo, 0, time.Hour)
+
+	// One more report than the limit is requested to know whether there is a next page
+	mockRepo.EXPECT().ListReports(gomock.Any(), entity.ReportQuery{Status: entity.ReportStatusOpen, Limit: 3}).
+		Return([]entity.Report{{ID: 2}, {ID: 5}, {ID: 6}}, nil)
+
+	page, err := uc.ListReports(context.Background(), entity.ReportQuery{Status: entity.ReportStatusOpen, Limit: 2})
+	if err != nil {
+		t.Fatalf("ReportUseCase.ListReports() error = %v", err)
+	}
+	if len(page.Reports) != 2 || page.NextCursor != 5 || page.Limit != 2 {
+		t.Errorf("ReportUseCase.ListReports() = %+v, want 2 reports and cursor 5", page)
+	}
+
+	_, err = uc.ListReports(context.Background(), entity.ReportQuery{Status: "closed"})
+	if err != entity.ErrInvalidReportsQuery {
+		t.Errorf("ReportUseCase.ListReports() unknown status error = %v, want %v", err, entity.ErrInvalidReportsQuery)
+	}
+}
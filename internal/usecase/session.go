@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+const _refreshTokenBytes = 32
+
+type SessionUseCase struct {
+	repo            SessionRepo
+	userRepo        UserRepo
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
+
+func NewSession(r SessionRepo, u UserRepo, accessTokenTTL time.Duration, refreshTokenTTL time.Duration) *SessionUseCase {
+	return &SessionUseCase{
+		repo:            r,
+		userRepo:        u,
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+	}
+}
+
+func (uc *SessionUseCase) CreateSession(ctx context.Context, userUUID string, device entity.SessionDevice) (entity.Session, error) {
+	// Every login starts a new session, which is the family that all rotated refresh tokens belong to
+	session, refreshTokenDTO, err := uc.newSessionTokens(userUUID, uuid.New().String())
+	if err != nil {
+		return entity.Session{}, fmt.Errorf("SessionUseCase - CreateSession - uc.newSessionTokens: %w", err)
+	}
+
+	// Convert session entity object into sessionDTO, keeping the device so the user can tell their sessions apart
+	sessionDTO := entity.SessionDTO{
+		SessionUUID: session.SessionUUID,
+		UserUUID:    userUUID,
+		DeviceName:  device.DeviceName,
+		UserAgent:   device.UserAgent,
+		IP:          device.IP,
+		ExpiresAt:   session.RefreshTokenExpiresAt,
+	}
+
+	// Store the session and its hashed refresh token into 'sessions' and 'refresh_tokens' table using session data repository
+	err = uc.repo.StoreSession(ctx, sessionDTO, refreshTokenDTO)
+	if err != nil {
+		return entity.Session{}, fmt.Errorf("SessionUseCase - CreateSession - uc.repo.StoreSession: %w", err)
+	}
+
+	// Logging in during the deletion grace period keeps the account. This is only done here, after every
+	// step of the login including the second factor, so that the password alone can not cancel a deletion
+	err = uc.userRepo.CancelAccountDeletion(ctx, userUUID)
+	if err != nil {
+		return entity.Session{}, fmt.Errorf("SessionUseCase - CreateSession - uc.userRepo.CancelAccountDeletion: %w", err)
+	}
+	return session, nil
+}
+
+func (uc *SessionUseCase) RefreshSession(ctx context.Context, refreshToken string, device entity.SessionDevice) (entity.Session, error) {
+	tokenHash := hashToken(refreshToken)
+
+	// Get refresh token from session data repository by querying 'refresh_tokens' table
+	current, err := uc.repo.GetRefreshToken(ctx, tokenHash)
+	if err != nil {
+		return entity.Session{}, fmt.Errorf("SessionUseCase - RefreshSession - uc.repo.GetRefreshToken: %w", err)
+	}
+
+	// Return error if token is unknown, revoked or expired. Will be handled by controller
+	if current == nil || current.RevokedAt != nil || time.Now().After(current.ExpiresAt) {
+		return entity.Session{}, entity.ErrInvalidRefreshToken
+	}
+
+	// A refresh token that was already rotated is being replayed, so it has most likely been stolen.
+	// Revoke the whole session so that neither the thief nor the owner can keep using it.
+	if current.RotatedAt != nil {
+		return entity.Session{}, uc.revokeReusedSession(ctx, current.SessionUUID)
+	}
+
+	session, refreshTokenDTO, err := uc.newSessionTokens(current.UserUUID, current.SessionUUID)
+	if err != nil {
+		return entity.Session{}, fmt.Errorf("SessionUseCase - RefreshSession - uc.newSessionTokens: %w", err)
+	}
+
+	// Mark the current refresh token as rotated and store the new one in the same session
+	rotated, err := uc.repo.RotateRefreshToken(ctx, tokenHash, refreshTokenDTO)
+	if err != nil {
+		return entity.Session{}, fmt.Errorf("SessionUseCase - RefreshSession - uc.repo.RotateRefreshToken: %w", err)
+	}
+
+	// Another request rotated the same token concurrently, treat it as reuse
+	if !rotated {
+		return entity.Session{}, uc.revokeReusedSession(ctx, current.SessionUUID)
+	}
+
+	// Clients refresh their access token while they are in use, so this is when the session was last seen
+	err = uc.repo.TouchSession(ctx, current.SessionUUID, device, session.RefreshTokenExpiresAt)
+	if err != nil {
+		return entity.Session{}, fmt.Errorf("SessionUseCase - RefreshSession - uc.repo.TouchSession: %w", err)
+	}
+	return session, nil
+}
+
+func (uc *SessionUseCase) RevokeSession(ctx context.Context, refreshToken string) (string, error) {
+	// Get refresh token from session data repository by querying 'refresh_tokens' table
+	current, err := uc.repo.GetRefreshToken(ctx, hashToken(refreshToken))
+	if err != nil {
+		return "", fmt.Errorf("SessionUseCase - RevokeSession - uc.repo.GetRefreshToken: %w", err)
+	}
+
+	// Return error if token is unknown. Will be handled by controller
+	if current == nil {
+		return "", entity.ErrInvalidRefreshToken
+	}
+
+	// Revoke every refresh token of the session and every access token issued from it
+	err = uc.repo.RevokeSession(ctx, current.SessionUUID)
+	if err != nil {
+		return "", fmt.Errorf("SessionUseCase - RevokeSession - uc.repo.RevokeSession: %w", err)
+	}
+	return current.SessionUUID, nil
+}
+
+func (uc *SessionUseCase) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	// Check if access token is in 'revoked_tokens' table from session data repository
+	revoked, err := uc.repo.CheckTokenRevoked(ctx, jti)
+	if err != nil {
+		return false, fmt.Errorf("SessionUseCase - IsTokenRevoked - uc.repo.CheckTokenRevoked: %w", err)
+	}
+	return revoked, nil
+}
+
+func (uc *SessionUseCase) ListSessions(ctx context.Context, userUUID string, currentSessionUUID string) ([]entity.SessionInfo, error) {
+	// Get sessions that are neither revoked nor expired from session data repository by querying 'sessions' table
+	sessionDTOs, err := uc.repo.GetActiveSessions(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("SessionUseCase - ListSessions - uc.repo.GetActiveSessions: %w", err)
+	}
+
+	// Convert sessionDTO into session info entity object
+	sessions := make([]entity.SessionInfo, 0, len(sessionDTOs))
+	for _, sessionDTO := range sessionDTOs {
+		sessions = append(sessions, entity.SessionInfo{
+			SessionUUID: sessionDTO.SessionUUID,
+			DeviceName:  sessionDTO.DeviceName,
+			UserAgent:   sessionDTO.UserAgent,
+			IP:          sessionDTO.IP,
+			CreatedAt:   sessionDTO.CreatedAt,
+			LastSeenAt:  sessionDTO.LastSeenAt,
+			Current:     sessionDTO.SessionUUID == currentSessionUUID,
+		})
+	}
+	return sessions, nil
+}
+
+func (uc *SessionUseCase) RevokeUserSession(ctx context.Context, userUUID string, sessionUUID string) error {
+	// Get session from session data repository by querying 'sessions' table
+	session, err := uc.repo.GetSession(ctx, sessionUUID)
+	if err != nil {
+		return fmt.Errorf("SessionUseCase - RevokeUserSession - uc.repo.GetSession: %w", err)
+	}
+
+	// Return error if the session doesn't exist or belongs to someone else. Will be handled by controller
+	if session == nil || session.UserUUID != userUUID || session.RevokedAt != nil {
+		return entity.ErrSessionNotFound
+	}
+
+	// Revoke every refresh token of the session and every access token issued from it
+	err = uc.repo.RevokeSession(ctx, sessionUUID)
+	if err != nil {
+		return fmt.Errorf("SessionUseCase - RevokeUserSession - uc.repo.RevokeSession: %w", err)
+	}
+	return nil
+}
+
+func (uc *SessionUseCase) RevokeOtherSessions(ctx context.Context, userUUID string, currentSessionUUID string) ([]string, error) {
+	// Get sessions that are neither revoked nor expired from session data repository by querying 'sessions' table
+	sessions, err := uc.repo.GetActiveSessions(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("SessionUseCase - RevokeOtherSessions - uc.repo.GetActiveSessions: %w", err)
+	}
+
+	// Revoke every session except the one making the request
+	var revoked []string
+	for _, session := range sessions {
+		if session.SessionUUID == currentSessionUUID {
+			continue
+		}
+
+		err = uc.repo.RevokeSession(ctx, session.SessionUUID)
+		if err != nil {
+			return revoked, fmt.Errorf("SessionUseCase - RevokeOtherSessions - uc.repo.RevokeSession: %w", err)
+		}
+		revoked = append(revoked, session.SessionUUID)
+	}
+	return revoked, nil
+}
+
+func (uc *SessionUseCase) revokeReusedSession(ctx context.Context, sessionUUID string) error {
+	err := uc.repo.RevokeSession(ctx, sessionUUID)
+	if err != nil {
+		return fmt.Errorf("SessionUseCase - revokeReusedSession - uc.repo.RevokeSession: %w", err)
+	}
+	return entity.ErrRefreshTokenReused
+}
+
+// Generates a new access token id and refresh token pair for the given session
+func (uc *SessionUseCase) newSessionTokens(userUUID string, sessionUUID string) (entity.Session, entity.RefreshTokenDTO, error) {
+	refreshToken, err := generateToken()
+	if err != nil {
+		return entity.Session{}, entity.RefreshTokenDTO{}, err
+	}
+
+	now := time.Now()
+	session := entity.Session{
+		SessionUUID:           sessionUUID,
+		UserUUID:              userUUID,
+		AccessTokenID:         uuid.New().String(),
+		AccessTokenExpiresAt:  now.Add(uc.accessTokenTTL),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: now.Add(uc.refreshTokenTTL),
+	}
+	refreshTokenDTO := entity.RefreshTokenDTO{
+		TokenHash:            hashToken(refreshToken),
+		SessionUUID:          session.SessionUUID,
+		UserUUID:             session.UserUUID,
+		AccessTokenID:        session.AccessTokenID,
+		AccessTokenExpiresAt: session.AccessTokenExpiresAt,
+		ExpiresAt:            session.RefreshTokenExpiresAt,
+	}
+	return session, refreshTokenDTO, nil
+}
+
+// Generates an opaque, URL safe random token
+func generateToken() (string, error) {
+	b := make([]byte, _refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Tokens are only ever stored as SHA-256 hashes so a database leak does not leak usable tokens
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Generates a single-use token for the given purpose, stores its hash and returns the plain token to be sent to the user
+func issueUserToken(ctx context.Context, repo UserTokenRepo, userUUID string, purpose string, payload string, ttl time.Duration) (string, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+
+	// Store hashed token into 'user_tokens' table using user token data repository
+	err = repo.StoreUserToken(ctx, entity.UserTokenDTO{
+		TokenHash: hashToken(token),
+		UserUUID:  userUUID,
+		Purpose:   purpose,
+		Payload:   payload,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
@@ -0,0 +1,440 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+)
+
+// Variables for test data used in the session test cases
+var (
+	testRefreshToken = "test_refresh_token"   // Refresh token presented by the client
+	testSessionUUID  = "test_session_uuid_12" // Session the refresh token belongs to
+	testDevice       = entity.SessionDevice{DeviceName: "test laptop", UserAgent: "test-agent/1.0", IP: "192.0.2.1"}
+)
+
+func TestSessionUseCase_CreateSession(t *testing.T) {
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                                                  // Name of the test case
+		setupMocks func(mockRepo *mocks.MockSessionRepo, mockUserRepo *mocks.MockUserRepo) // Function to set up mock behavior
+		wantErr    bool                                                                    // Whether an error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo, mockUserRepo *mocks.MockUserRepo) {
+				mockRepo.EXPECT().
+					StoreSession(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, session entity.SessionDTO, refreshToken entity.RefreshTokenDTO) {
+						// The device is stored with the session so the user can recognise it later
+						if session.DeviceName != testDevice.DeviceName || session.IP != testDevice.IP ||
+							session.SessionUUID != refreshToken.SessionUUID {
+							t.Errorf("SessionUseCase.CreateSession() stored session %+v", session)
+						}
+					}).
+					Return(nil)
+				// Completing the login keeps an account whose deletion is pending
+				mockUserRepo.EXPECT().CancelAccountDeletion(gomock.Any(), testUserUUID).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error storing session",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo, mockUserRepo *mocks.MockUserRepo) {
+				mockRepo.EXPECT().
+					StoreSession(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error cancelling account deletion",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo, mockUserRepo *mocks.MockUserRepo) {
+				mockRepo.EXPECT().
+					StoreSession(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockUserRepo.EXPECT().CancelAccountDeletion(gomock.Any(), testUserUUID).Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the SessionRepo and UserRepo interfaces
+			mockRepo := mocks.NewMockSessionRepo(ctrl)
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo, mockUserRepo)
+			}
+
+			uc := NewSession(mockRepo, mockUserRepo, 15*time.Minute, time.Hour)
+
+			// Call the method under test
+			got, err := uc.CreateSession(context.Background(), testUserUUID, testDevice)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SessionUseCase.CreateSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			// A new session must carry a user, an access token id and a refresh token
+			if got.UserUUID != testUserUUID || got.SessionUUID == "" || got.AccessTokenID == "" || got.RefreshToken == "" {
+				t.Errorf("SessionUseCase.CreateSession() returned incomplete session %+v", got)
+			}
+		})
+	}
+}
+
+func TestSessionUseCase_RefreshSession(t *testing.T) {
+	// A refresh token row that has not been used yet
+	activeToken := entity.RefreshTokenDTO{
+		TokenHash:   hashToken(testRefreshToken),
+		SessionUUID: testSessionUUID,
+		UserUUID:    testUserUUID,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	rotatedAt := time.Now().Add(-time.Minute)
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                // Name of the test case
+		setupMocks func(mockRepo *mocks.MockSessionRepo) // Function to set up mock behavior
+		wantErr    error                                 // Expected sentinel error, if any
+		wantAnyErr bool                                  // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success - token rotated",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().
+					GetRefreshToken(gomock.Any(), hashToken(testRefreshToken)).
+					Return(&activeToken, nil)
+				mockRepo.EXPECT().
+					RotateRefreshToken(gomock.Any(), hashToken(testRefreshToken), gomock.Any()).
+					Return(true, nil)
+				mockRepo.EXPECT().
+					TouchSession(gomock.Any(), testSessionUUID, testDevice, gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name: "error - unknown token",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().
+					GetRefreshToken(gomock.Any(), hashToken(testRefreshToken)).
+					Return(nil, nil)
+			},
+			wantErr:    entity.ErrInvalidRefreshToken,
+			wantAnyErr: true,
+		},
+		{
+			name: "error - expired token",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				expiredToken := activeToken
+				expiredToken.ExpiresAt = time.Now().Add(-time.Minute)
+				mockRepo.EXPECT().
+					GetRefreshToken(gomock.Any(), hashToken(testRefreshToken)).
+					Return(&expiredToken, nil)
+			},
+			wantErr:    entity.ErrInvalidRefreshToken,
+			wantAnyErr: true,
+		},
+		{
+			name: "error - reused token revokes session",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				reusedToken := activeToken
+				reusedToken.RotatedAt = &rotatedAt
+				mockRepo.EXPECT().
+					GetRefreshToken(gomock.Any(), hashToken(testRefreshToken)).
+					Return(&reusedToken, nil)
+				mockRepo.EXPECT().
+					RevokeSession(gomock.Any(), testSessionUUID).
+					Return(nil)
+			},
+			wantErr:    entity.ErrRefreshTokenReused,
+			wantAnyErr: true,
+		},
+		{
+			name: "error - concurrent rotation revokes session",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().
+					GetRefreshToken(gomock.Any(), hashToken(testRefreshToken)).
+					Return(&activeToken, nil)
+				mockRepo.EXPECT().
+					RotateRefreshToken(gomock.Any(), hashToken(testRefreshToken), gomock.Any()).
+					Return(false, nil)
+				mockRepo.EXPECT().
+					RevokeSession(gomock.Any(), testSessionUUID).
+					Return(nil)
+			},
+			wantErr:    entity.ErrRefreshTokenReused,
+			wantAnyErr: true,
+		},
+		{
+			name: "error - repository failure",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().
+					GetRefreshToken(gomock.Any(), hashToken(testRefreshToken)).
+					Return(nil, fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instance of the SessionRepo interface
+			mockRepo := mocks.NewMockSessionRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := NewSession(mockRepo, nil, 15*time.Minute, time.Hour)
+
+			// Call the method under test
+			got, err := uc.RefreshSession(context.Background(), testRefreshToken, testDevice)
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("SessionUseCase.RefreshSession() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("SessionUseCase.RefreshSession() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// A rotated session keeps its session id but gets a new refresh token
+			if err == nil && (got.SessionUUID != testSessionUUID || got.RefreshToken == testRefreshToken) {
+				t.Errorf("SessionUseCase.RefreshSession() = %+v, want rotated token in session %s", got, testSessionUUID)
+			}
+		})
+	}
+}
+
+func TestSessionUseCase_RevokeSession(t *testing.T) {
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                // Name of the test case
+		setupMocks func(mockRepo *mocks.MockSessionRepo) // Function to set up mock behavior
+		wantErr    bool                                  // Whether an error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().
+					GetRefreshToken(gomock.Any(), hashToken(testRefreshToken)).
+					Return(&entity.RefreshTokenDTO{SessionUUID: testSessionUUID}, nil)
+				mockRepo.EXPECT().
+					RevokeSession(gomock.Any(), testSessionUUID).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error - unknown token",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().
+					GetRefreshToken(gomock.Any(), hashToken(testRefreshToken)).
+					Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error revoking session",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().
+					GetRefreshToken(gomock.Any(), hashToken(testRefreshToken)).
+					Return(&entity.RefreshTokenDTO{SessionUUID: testSessionUUID}, nil)
+				mockRepo.EXPECT().
+					RevokeSession(gomock.Any(), testSessionUUID).
+					Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instance of the SessionRepo interface
+			mockRepo := mocks.NewMockSessionRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := &SessionUseCase{repo: mockRepo}
+
+			// Call the method under test
+			_, err := uc.RevokeSession(context.Background(), testRefreshToken)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SessionUseCase.RevokeSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSessionUseCase_ListSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSessionRepo(ctrl)
+	mockRepo.EXPECT().
+		GetActiveSessions(gomock.Any(), testUserUUID).
+		Return([]entity.SessionDTO{
+			{SessionUUID: testSessionUUID, UserUUID: testUserUUID, DeviceName: "test laptop"},
+			{SessionUUID: "other_session_uuid", UserUUID: testUserUUID, DeviceName: "test phone"},
+		}, nil)
+
+	uc := &SessionUseCase{repo: mockRepo}
+
+	// Call the method under test
+	got, err := uc.ListSessions(context.Background(), testUserUUID, testSessionUUID)
+	if err != nil {
+		t.Fatalf("SessionUseCase.ListSessions() error = %v", err)
+	}
+
+	// Only the session making the request is marked as current
+	if len(got) != 2 || !got[0].Current || got[1].Current || got[1].DeviceName != "test phone" {
+		t.Errorf("SessionUseCase.ListSessions() = %+v", got)
+	}
+}
+
+func TestSessionUseCase_RevokeUserSession(t *testing.T) {
+	revokedAt := time.Now().Add(-time.Minute)
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                // Name of the test case
+		setupMocks func(mockRepo *mocks.MockSessionRepo) // Function to set up mock behavior
+		wantErr    error                                 // Expected sentinel error, if any
+		wantAnyErr bool                                  // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name: "success",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().
+					GetSession(gomock.Any(), testSessionUUID).
+					Return(&entity.SessionDTO{SessionUUID: testSessionUUID, UserUUID: testUserUUID}, nil)
+				mockRepo.EXPECT().
+					RevokeSession(gomock.Any(), testSessionUUID).
+					Return(nil)
+			},
+		},
+		{
+			name: "error - session of another user",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().
+					GetSession(gomock.Any(), testSessionUUID).
+					Return(&entity.SessionDTO{SessionUUID: testSessionUUID, UserUUID: "another_user_uuid"}, nil)
+			},
+			wantErr:    entity.ErrSessionNotFound,
+			wantAnyErr: true,
+		},
+		{
+			name: "error - session already revoked",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().
+					GetSession(gomock.Any(), testSessionUUID).
+					Return(&entity.SessionDTO{SessionUUID: testSessionUUID, UserUUID: testUserUUID, RevokedAt: &revokedAt}, nil)
+			},
+			wantErr:    entity.ErrSessionNotFound,
+			wantAnyErr: true,
+		},
+		{
+			name: "error - unknown session",
+			setupMocks: func(mockRepo *mocks.MockSessionRepo) {
+				mockRepo.EXPECT().
+					GetSession(gomock.Any(), testSessionUUID).
+					Return(nil, nil)
+			},
+			wantErr:    entity.ErrSessionNotFound,
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instance of the SessionRepo interface
+			mockRepo := mocks.NewMockSessionRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := &SessionUseCase{repo: mockRepo}
+
+			// Call the method under test
+			err := uc.RevokeUserSession(context.Background(), testUserUUID, testSessionUUID)
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("SessionUseCase.RevokeUserSession() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && err != tt.wantErr {
+				t.Errorf("SessionUseCase.RevokeUserSession() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSessionUseCase_RevokeOtherSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSessionRepo(ctrl)
+	mockRepo.EXPECT().
+		GetActiveSessions(gomock.Any(), testUserUUID).
+		Return([]entity.SessionDTO{
+			{SessionUUID: testSessionUUID, UserUUID: testUserUUID},
+			{SessionUUID: "other_session_uuid", UserUUID: testUserUUID},
+		}, nil)
+	// The current session must not be revoked
+	mockRepo.EXPECT().
+		RevokeSession(gomock.Any(), "other_session_uuid").
+		Return(nil)
+
+	uc := &SessionUseCase{repo: mockRepo}
+
+	// Call the method under test
+	revoked, err := uc.RevokeOtherSessions(context.Background(), testUserUUID, testSessionUUID)
+	if err != nil {
+		t.Fatalf("SessionUseCase.RevokeOtherSessions() error = %v", err)
+	}
+	if len(revoked) != 1 || revoked[0] != "other_session_uuid" {
+		t.Errorf("SessionUseCase.RevokeOtherSessions() = %v, want [other_session_uuid]", revoked)
+	}
+}
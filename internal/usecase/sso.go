@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+	"github.com/maxyong7/chat-messaging-app/pkg/oidc"
+)
+
+const (
+	_maxUsernameLength   = 30
+	_usernameSuffixTries = 5
+)
+
+type SSOUseCase struct {
+	provider      IdentityProvider
+	repo          SSORepo
+	userRepo      UserRepo
+	loginStateTTL time.Duration
+	// Same as for password logins, SSO users are refused until their email is verified
+	requireVerifiedEmail bool
+	l                    logger.Interface
+}
+
+// NewSSO -.
+// Failed logins are only reported as ErrSSOLoginFailed to the browser, their cause is logged with l.
+func NewSSO(p IdentityProvider, r SSORepo, u UserRepo, loginStateTTL time.Duration, requireVerifiedEmail bool, l logger.Interface) *SSOUseCase {
+	return &SSOUseCase{
+		provider:             p,
+		repo:                 r,
+		userRepo:             u,
+		loginStateTTL:        loginStateTTL,
+		requireVerifiedEmail: requireVerifiedEmail,
+		l:                    l,
+	}
+}
+
+func (uc *SSOUseCase) BeginLogin(ctx context.Context) (entity.SSOLogin, error) {
+	// The state protects the callback against forged requests, the nonce ties the ID token to this login
+	// and the code verifier makes an intercepted authorization code useless
+	state, err := generateToken()
+	if err != nil {
+		return entity.SSOLogin{}, fmt.Errorf("SSOUseCase - BeginLogin - generateToken: %w", err)
+	}
+	nonce, err := generateToken()
+	if err != nil {
+		return entity.SSOLogin{}, fmt.Errorf("SSOUseCase - BeginLogin - generateToken: %w", err)
+	}
+	codeVerifier, err := generateToken()
+	if err != nil {
+		return entity.SSOLogin{}, fmt.Errorf("SSOUseCase - BeginLogin - generateToken: %w", err)
+	}
+
+	// Store hashed state into 'sso_login_states' table using sso data repository
+	err = uc.repo.StoreLoginState(ctx, entity.SSOLoginStateDTO{
+		StateHash:    hashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(uc.loginStateTTL),
+	})
+	if err != nil {
+		return entity.SSOLogin{}, fmt.Errorf("SSOUseCase - BeginLogin - uc.repo.StoreLoginState: %w", err)
+	}
+
+	authURL, err := uc.provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(codeVerifier))
+	if err != nil {
+		return entity.SSOLogin{}, fmt.Errorf("SSOUseCase - BeginLogin - uc.provider.AuthCodeURL: %w", err)
+	}
+
+	return entity.SSOLogin{AuthURL: authURL, State: state}, nil
+}
+
+func (uc *SSOUseCase) CompleteLogin(ctx context.Context, state string, code string) (string, error) {
+	// Get and delete the login state from sso data repository, so that it can only be used once
+	loginState, err := uc.repo.ConsumeLoginState(ctx, hashToken(state))
+	if err != nil {
+		return "", fmt.Errorf("SSOUseCase - CompleteLogin - uc.repo.ConsumeLoginState: %w", err)
+	}
+
+	// Return error if state is unknown, used or expired. Will be handled by controller
+	if loginState == nil || time.Now().After(loginState.ExpiresAt) {
+		return "", entity.ErrInvalidSSOState
+	}
+
+	// Redeem the authorization code and verify the ID token
+	claims, err := uc.provider.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		// Return error if the code or the ID token is rejected. Will be handled by controller
+		if errors.Is(err, oidc.ErrInvalidIDToken) || errors.Is(err, oidc.ErrTokenExchange) {
+			uc.l.Warn("SSOUseCase - CompleteLogin - uc.provider.Exchange: %v", err)
+			return "", entity.ErrSSOLoginFailed
+		}
+		return "", fmt.Errorf("SSOUseCase - CompleteLogin - uc.provider.Exchange: %w", err)
+	}
+
+	// Accounts are matched by email, so an identity without one can't be used. Will be handled by controller
+	if claims.Email == "" {
+		uc.l.Warn("SSOUseCase - CompleteLogin - id token of %s has no email claim", claims.Subject)
+		return "", entity.ErrSSOLoginFailed
+	}
+
+	userUUID, err := uc.identityUser(ctx, claims)
+	if err != nil {
+		return "", err
+	}
+
+	// Get user credentials from user data repository by querying 'user_credentials' table
+	userInfo, err := uc.userRepo.GetUserCredentialsByUUID(ctx, userUUID)
+	if err != nil {
+		return "", fmt.Errorf("SSOUseCase - CompleteLogin - uc.userRepo.GetUserCredentialsByUUID: %w", err)
+	}
+
+	// Return error if user is not found. Will be handled by controller
+	if userInfo == nil {
+		return "", entity.ErrUserNotFound
+	}
+
+	// Return error if the account may not log in, the same as for a password login. Will be handled by controller
+	err = checkAccountState(uc.requireVerifiedEmail, userInfo)
+	if err != nil {
+		return "", err
+	}
+	return userUUID, nil
+}
+
+// identityUser returns the user of the identity. On the first login of the identity, it is linked to
+// the account with the same email, or a new account is created from the claims.
+func (uc *SSOUseCase) identityUser(ctx context.Context, claims *oidc.Claims) (string, error) {
+	// Get the user the identity was linked to on a previous login from sso data repository
+	userUUID, err := uc.repo.GetIdentityUserUUID(ctx, claims.Issuer, claims.Subject)
+	if err != nil {
+		return "", fmt.Errorf("SSOUseCase - identityUser - uc.repo.GetIdentityUserUUID: %w", err)
+	}
+	if userUUID != nil {
+		return *userUUID, nil
+	}
+
+	identity := entity.UserIdentityDTO{
+		Issuer:  claims.Issuer,
+		Subject: claims.Subject,
+		Email:   claims.Email,
+	}
+
+	// Get an existing account with the same email from user data repository by querying 'user_credentials' table
+	existing, err := uc.userRepo.GetUserCredentials(ctx, entity.UserCredentialsDTO{Email: claims.Email})
+	if err != nil {
+		return "", fmt.Errorf("SSOUseCase - identityUser - uc.userRepo.GetUserCredentials: %w", err)
+	}
+	if existing != nil {
+		// Only link to an existing account if the provider vouches for the email address,
+		// otherwise anyone could take over an account by setting its email at the provider
+		if !claims.EmailVerified {
+			return "", entity.ErrUserAlreadyExists
+		}
+
+		identity.UserUUID = existing.UserUuid
+		err = uc.repo.LinkIdentity(ctx, identity)
+		if err != nil {
+			return "", fmt.Errorf("SSOUseCase - identityUser - uc.repo.LinkIdentity: %w", err)
+		}
+		return existing.UserUuid, nil
+	}
+
+	// First login, create the account from the ID token claims
+	username, err := uc.availableUsername(ctx, claims)
+	if err != nil {
+		return "", fmt.Errorf("SSOUseCase - identityUser - uc.availableUsername: %w", err)
+	}
+
+	firstName := claims.GivenName
+	if firstName == "" {
+		firstName = claims.Name
+	}
+	// SSO users have no password, they can set one later through the password reset flow
+	userRegistrationDTO := entity.UserRegistrationDTO{
+		Username:  username,
+		Email:     claims.Email,
+		FirstName: firstName,
+		LastName:  claims.FamilyName,
+		Avatar:    claims.Picture,
+	}
+	// Uploaded images can only become an avatar through an upload
+	if entity.IsImageURL(userRegistrationDTO.Avatar) {
+		userRegistrationDTO.Avatar = ""
+	}
+
+	newUserUUID, err := uc.repo.CreateUserWithIdentity(ctx, userRegistrationDTO, identity, claims.EmailVerified)
+	if err != nil {
+		return "", fmt.Errorf("SSOUseCase - identityUser - uc.repo.CreateUserWithIdentity: %w", err)
+	}
+	return newUserUUID, nil
+}
+
+// availableUsername derives a username from the ID token and adds a random suffix if it is taken
+func (uc *SSOUseCase) availableUsername(ctx context.Context, claims *oidc.Claims) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base = strings.SplitN(claims.Email, "@", 2)[0]
+	}
+	base = sanitizeUsername(base)
+
+	candidate := base
+	for i := 0; i <= _usernameSuffixTries; i++ {
+		// Check if username is taken from user data repository by querying 'user_credentials' table
+		existing, err := uc.userRepo.GetUserUUIDByUsername(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+
+		suffix, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", err
+		}
+		candidate = fmt.Sprintf("%s-%04d", base, suffix.Int64())
+	}
+	return "", fmt.Errorf("no available username for %q", base)
+}
+
+// Keeps lowercase letters, digits, '.', '_' and '-'
+func sanitizeUsername(username string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(username) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+		if b.Len() == _maxUsernameLength {
+			break
+		}
+	}
+	if b.Len() == 0 {
+		return "user"
+	}
+	return b.String()
+}
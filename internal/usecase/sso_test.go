@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/logger"
+	"github.com/maxyong7/chat-messaging-app/pkg/oidc"
+)
+
+// Variables for test data used in the single sign-on test cases
+var (
+	testSSOClientID     = "chat-app"            // Client id registered at the identity provider
+	testSSOCode         = "test_auth_code"      // Authorization code the provider redirects back with
+	testSSOState        = "test_sso_state"      // State sent to and returned by the provider
+	testSSONonce        = "test_sso_nonce"      // Nonce the ID token must carry
+	testSSOCodeVerifier = "test_code_verifier"  // PKCE verifier the token endpoint expects
+	testSSOSubject      = "provider-subject-42" // Stable id of the user at the identity provider
+)
+
+// newTestIdentityProvider starts an OpenID provider that serves discovery, its signing key and a token endpoint.
+// The token endpoint only accepts testSSOCode with testSSOCodeVerifier and returns an ID token with the given claims.
+func newTestIdentityProvider(t *testing.T, claims jwt.MapClaims) *httptest.Server {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 server.URL,
+			"authorization_endpoint": server.URL + "/authorize",
+			"token_endpoint":         server.URL + "/token",
+			"jwks_uri":               server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test-key",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("code") != testSSOCode || r.PostFormValue("code_verifier") != testSSOCodeVerifier {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		idClaims := jwt.MapClaims{
+			"iss": server.URL,
+			"aud": testSSOClientID,
+			"sub": testSSOSubject,
+			"exp": time.Now().Add(time.Minute).Unix(),
+			"iat": time.Now().Unix(),
+		}
+		for k, v := range claims {
+			idClaims[k] = v
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idClaims)
+		token.Header["kid"] = "test-key"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+	})
+
+	return server
+}
+
+func TestSSOUseCase_BeginLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestIdentityProvider(t, nil)
+	mockSSORepo := mocks.NewMockSSORepo(ctrl)
+
+	// Captures the stored login state so it can be compared with the redirect
+	var storedState entity.SSOLoginStateDTO
+	mockSSORepo.EXPECT().
+		StoreLoginState(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, loginState entity.SSOLoginStateDTO) { storedState = loginState }).
+		Return(nil)
+
+	provider := oidc.New(oidc.Config{IssuerURL: server.URL, ClientID: testSSOClientID, RedirectURL: "http://localhost/user/sso/callback"})
+	uc := NewSSO(provider, mockSSORepo, nil, time.Minute, true, logger.New("debug"))
+
+	// Call the method under test
+	login, err := uc.BeginLogin(context.Background())
+	if err != nil {
+		t.Fatalf("SSOUseCase.BeginLogin() error = %v", err)
+	}
+
+	authURL, err := url.Parse(login.AuthURL)
+	if err != nil || !strings.HasPrefix(login.AuthURL, server.URL+"/authorize?") {
+		t.Fatalf("SSOUseCase.BeginLogin() AuthURL = %q", login.AuthURL)
+	}
+	query := authURL.Query()
+
+	// Only the hash of the state may be stored, the verifier never leaves the server
+	if storedState.StateHash != hashToken(login.State) || query.Get("state") != login.State {
+		t.Errorf("SSOUseCase.BeginLogin() stored %+v for state %q", storedState, login.State)
+	}
+	if query.Get("nonce") != storedState.Nonce || query.Get("code_challenge_method") != "S256" ||
+		query.Get("code_challenge") != oidc.CodeChallenge(storedState.CodeVerifier) {
+		t.Errorf("SSOUseCase.BeginLogin() AuthURL = %q does not match the stored state", login.AuthURL)
+	}
+	if strings.Contains(login.AuthURL, storedState.CodeVerifier) {
+		t.Errorf("SSOUseCase.BeginLogin() leaked the code verifier")
+	}
+}
+
+func TestSSOUseCase_CompleteLogin(t *testing.T) {
+	loginState := &entity.SSOLoginStateDTO{
+		StateHash:    hashToken(testSSOState),
+		Nonce:        testSSONonce,
+		CodeVerifier: testSSOCodeVerifier,
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}
+	verifiedClaims := jwt.MapClaims{"nonce": testSSONonce, "email": "test@example.com", "email_verified": true, "preferred_username": "Test.User"}
+	verifiedAt := time.Now().Add(-time.Hour)
+	verifiedUser := &entity.UserCredentialsDTO{UserUuid: testUserUUID, Email: "test@example.com", VerifiedAt: &verifiedAt}
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                                                 // Name of the test case
+		claims     jwt.MapClaims                                                          // Claims of the ID token the provider returns
+		code       string                                                                 // Authorization code sent to the token endpoint
+		setupMocks func(mockSSORepo *mocks.MockSSORepo, mockUserRepo *mocks.MockUserRepo) // Function to set up mock behavior
+		want       string                                                                 // Expected user UUID
+		wantErr    error                                                                  // Expected sentinel error, if any
+		wantAnyErr bool                                                                   // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:   "success - returning user",
+			claims: verifiedClaims,
+			code:   testSSOCode,
+			setupMocks: func(mockSSORepo *mocks.MockSSORepo, mockUserRepo *mocks.MockUserRepo) {
+				mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken(testSSOState)).Return(loginState, nil)
+				mockSSORepo.EXPECT().GetIdentityUserUUID(gomock.Any(), gomock.Any(), testSSOSubject).Return(&testUserUUID, nil)
+				mockUserRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(verifiedUser, nil)
+			},
+			want: testUserUUID,
+		},
+		{
+			name:   "success - returning user with pending account deletion",
+			claims: verifiedClaims,
+			code:   testSSOCode,
+			setupMocks: func(mockSSORepo *mocks.MockSSORepo, mockUserRepo *mocks.MockUserRepo) {
+				deleteAfter := time.Now().Add(24 * time.Hour)
+				mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken(testSSOState)).Return(loginState, nil)
+				mockSSORepo.EXPECT().GetIdentityUserUUID(gomock.Any(), gomock.Any(), testSSOSubject).Return(&testUserUUID, nil)
+				mockUserRepo.EXPECT().
+					GetUserCredentialsByUUID(gomock.Any(), testUserUUID).
+					Return(&entity.UserCredentialsDTO{UserUuid: testUserUUID, VerifiedAt: &verifiedAt, DeleteAfter: &deleteAfter}, nil)
+				// The deletion is cancelled when the session is created, after the second factor
+			},
+			want: testUserUUID,
+		},
+		{
+			name:   "error - returning user is suspended",
+			claims: verifiedClaims,
+			code:   testSSOCode,
+			setupMocks: func(mockSSORepo *mocks.MockSSORepo, mockUserRepo *mocks.MockUserRepo) {
+				suspendedAt := time.Now().Add(-time.Minute)
+				mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken(testSSOState)).Return(loginState, nil)
+				mockSSORepo.EXPECT().GetIdentityUserUUID(gomock.Any(), gomock.Any(), testSSOSubject).Return(&testUserUUID, nil)
+				mockUserRepo.EXPECT().
+					GetUserCredentialsByUUID(gomock.Any(), testUserUUID).
+					Return(&entity.UserCredentialsDTO{UserUuid: testUserUUID, VerifiedAt: &verifiedAt, SuspendedAt: &suspendedAt}, nil)
+			},
+			wantErr:    entity.ErrAccountSuspended,
+			wantAnyErr: true,
+		},
+		{
+			name:   "error - new user with unverified email",
+			claims: jwt.MapClaims{"nonce": testSSONonce, "email": "test@example.com", "email_verified": false, "preferred_username": "Test.User"},
+			code:   testSSOCode,
+			setupMocks: func(mockSSORepo *mocks.MockSSORepo, mockUserRepo *mocks.MockUserRepo) {
+				mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken(testSSOState)).Return(loginState, nil)
+				mockSSORepo.EXPECT().GetIdentityUserUUID(gomock.Any(), gomock.Any(), testSSOSubject).Return(nil, nil)
+				mockUserRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Email: "test@example.com"}).
+					Return(nil, nil)
+				mockUserRepo.EXPECT().GetUserUUIDByUsername(gomock.Any(), "test.user").Return(nil, nil)
+				mockSSORepo.EXPECT().CreateUserWithIdentity(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(testUserUUID, nil)
+				mockUserRepo.EXPECT().
+					GetUserCredentialsByUUID(gomock.Any(), testUserUUID).
+					Return(&entity.UserCredentialsDTO{UserUuid: testUserUUID, Email: "test@example.com"}, nil)
+			},
+			wantErr:    entity.ErrEmailNotVerified,
+			wantAnyErr: true,
+		},
+		{
+			name:   "success - verified email linked to existing account",
+			claims: verifiedClaims,
+			code:   testSSOCode,
+			setupMocks: func(mockSSORepo *mocks.MockSSORepo, mockUserRepo *mocks.MockUserRepo) {
+				mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken(testSSOState)).Return(loginState, nil)
+				mockSSORepo.EXPECT().GetIdentityUserUUID(gomock.Any(), gomock.Any(), testSSOSubject).Return(nil, nil)
+				mockUserRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Email: "test@example.com"}).
+					Return(&entity.UserCredentialsDTO{UserUuid: testUserUUID, Email: "test@example.com"}, nil)
+				mockSSORepo.EXPECT().
+					LinkIdentity(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, identity entity.UserIdentityDTO) {
+						if identity.UserUUID != testUserUUID || identity.Subject != testSSOSubject {
+							t.Errorf("SSOUseCase.CompleteLogin() linked %+v", identity)
+						}
+					}).
+					Return(nil)
+				mockUserRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(verifiedUser, nil)
+			},
+			want: testUserUUID,
+		},
+		{
+			name:   "success - new user created",
+			claims: verifiedClaims,
+			code:   testSSOCode,
+			setupMocks: func(mockSSORepo *mocks.MockSSORepo, mockUserRepo *mocks.MockUserRepo) {
+				mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken(testSSOState)).Return(loginState, nil)
+				mockSSORepo.EXPECT().GetIdentityUserUUID(gomock.Any(), gomock.Any(), testSSOSubject).Return(nil, nil)
+				mockUserRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Email: "test@example.com"}).
+					Return(nil, nil)
+				mockUserRepo.EXPECT().GetUserUUIDByUsername(gomock.Any(), "test.user").Return(nil, nil)
+				mockSSORepo.EXPECT().
+					CreateUserWithIdentity(gomock.Any(), gomock.Any(), gomock.Any(), true).
+					Do(func(_ context.Context, userRegis entity.UserRegistrationDTO, _ entity.UserIdentityDTO, _ bool) {
+						if userRegis.Username != "test.user" || userRegis.Password != "" {
+							t.Errorf("SSOUseCase.CompleteLogin() registered %+v", userRegis)
+						}
+					}).
+					Return(testUserUUID, nil)
+				mockUserRepo.EXPECT().GetUserCredentialsByUUID(gomock.Any(), testUserUUID).Return(verifiedUser, nil)
+			},
+			want: testUserUUID,
+		},
+		{
+			name:   "error - unverified email matches existing account",
+			claims: jwt.MapClaims{"nonce": testSSONonce, "email": "test@example.com", "email_verified": false},
+			code:   testSSOCode,
+			setupMocks: func(mockSSORepo *mocks.MockSSORepo, mockUserRepo *mocks.MockUserRepo) {
+				mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken(testSSOState)).Return(loginState, nil)
+				mockSSORepo.EXPECT().GetIdentityUserUUID(gomock.Any(), gomock.Any(), testSSOSubject).Return(nil, nil)
+				mockUserRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Email: "test@example.com"}).
+					Return(&entity.UserCredentialsDTO{UserUuid: testUserUUID, Email: "test@example.com"}, nil)
+			},
+			wantErr:    entity.ErrUserAlreadyExists,
+			wantAnyErr: true,
+		},
+		{
+			name:   "error - unknown or used state",
+			claims: verifiedClaims,
+			code:   testSSOCode,
+			setupMocks: func(mockSSORepo *mocks.MockSSORepo, mockUserRepo *mocks.MockUserRepo) {
+				mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken(testSSOState)).Return(nil, nil)
+			},
+			wantErr:    entity.ErrInvalidSSOState,
+			wantAnyErr: true,
+		},
+		{
+			name:   "error - nonce mismatch",
+			claims: jwt.MapClaims{"nonce": "replayed_nonce", "email": "test@example.com", "email_verified": true},
+			code:   testSSOCode,
+			setupMocks: func(mockSSORepo *mocks.MockSSORepo, mockUserRepo *mocks.MockUserRepo) {
+				mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken(testSSOState)).Return(loginState, nil)
+			},
+			wantErr:    entity.ErrSSOLoginFailed,
+			wantAnyErr: true,
+		},
+		{
+			name:   "error - code rejected by provider",
+			claims: verifiedClaims,
+			code:   "stolen_code",
+			setupMocks: func(mockSSORepo *mocks.MockSSORepo, mockUserRepo *mocks.MockUserRepo) {
+				mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken(testSSOState)).Return(loginState, nil)
+			},
+			wantErr:    entity.ErrSSOLoginFailed,
+			wantAnyErr: true,
+		},
+		{
+			name:   "error consuming login state",
+			claims: verifiedClaims,
+			code:   testSSOCode,
+			setupMocks: func(mockSSORepo *mocks.MockSSORepo, mockUserRepo *mocks.MockUserRepo) {
+				mockSSORepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken(testSSOState)).Return(nil, fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories and a provider backed by a test identity provider
+			mockSSORepo := mocks.NewMockSSORepo(ctrl)
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockSSORepo, mockUserRepo)
+			}
+
+			server := newTestIdentityProvider(t, tt.claims)
+			provider := oidc.New(oidc.Config{IssuerURL: server.URL, ClientID: testSSOClientID})
+			uc := NewSSO(provider, mockSSORepo, mockUserRepo, time.Minute, true, logger.New("debug"))
+
+			// Call the method under test
+			got, err := uc.CompleteLogin(context.Background(), testSSOState, tt.code)
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("SSOUseCase.CompleteLogin() error = %v, wantErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("SSOUseCase.CompleteLogin() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SSOUseCase.CompleteLogin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
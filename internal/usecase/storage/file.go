@@ -0,0 +1,98 @@
+// Package storage implements blob stores for files the application generates or receives.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// File stores every blob as a file below a directory, keys are relative paths.
+type File struct {
+	dir string
+}
+
+// NewFile -.
+func NewFile(dir string) *File {
+	return &File{dir: dir}
+}
+
+// Put -.
+func (s *File) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return fmt.Errorf("File - Put - os.MkdirAll: %w", err)
+	}
+
+	// Write to a temporary file first so that a blob is never read half written
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("File - Put - os.CreateTemp: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("File - Put - io.Copy: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf("File - Put - os.Rename: %w", err)
+	}
+	return nil
+}
+
+// Open -.
+func (s *File) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, entity.ErrBlobNotFound
+		}
+		return nil, fmt.Errorf("File - Open - os.Open: %w", err)
+	}
+	return f, nil
+}
+
+// Delete removes the blob, deleting a blob that does not exist is not an error.
+func (s *File) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("File - Delete - os.Remove: %w", err)
+	}
+	return nil
+}
+
+// path resolves the key inside the directory and refuses keys that would escape it
+func (s *File) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("File - invalid key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
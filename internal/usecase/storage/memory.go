@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+// Memory keeps blobs in memory, for local runs and tests.
+type Memory struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+// NewMemory -.
+func NewMemory() *Memory {
+	return &Memory{blobs: make(map[string][]byte)}
+}
+
+// Put -.
+func (s *Memory) Put(ctx context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("Memory - Put - io.ReadAll: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blobs[key] = data
+	return nil
+}
+
+// Open -.
+func (s *Memory) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, entity.ErrBlobNotFound
+	}
+	return memoryBlob{bytes.NewReader(data)}, nil
+}
+
+// Delete -.
+func (s *Memory) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.blobs, key)
+	return nil
+}
+
+// memoryBlob is seekable like the files of the File store, so that readers can tell its size.
+type memoryBlob struct {
+	*bytes.Reader
+}
+
+// Close -.
+func (memoryBlob) Close() error {
+	return nil
+}
+
+// Keys returns the keys of every stored blob.
+func (s *Memory) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.blobs))
+	for key := range s.blobs {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -5,16 +5,19 @@ import (
 	"fmt"
 
 	"github.com/maxyong7/chat-messaging-app/internal/entity"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type LoginUseCase struct {
-	repo UserRepo
+	repo                 UserRepo
+	hasher               PasswordHasher
+	requireVerifiedEmail bool
 }
 
-func NewAuth(r UserRepo) *LoginUseCase {
+func NewAuth(r UserRepo, h PasswordHasher, requireVerifiedEmail bool) *LoginUseCase {
 	return &LoginUseCase{
-		repo: r,
+		repo:                 r,
+		hasher:               h,
+		requireVerifiedEmail: requireVerifiedEmail,
 	}
 }
 
@@ -42,8 +45,18 @@ func (uc *LoginUseCase) VerifyCredentials(ctx context.Context, userCredentials e
 	// }
 
 	// Verify if password matches
-	match := verifyPassword(userCredentials.Password, userInfo.Password)
+	match := verifyPassword(uc.hasher, userCredentials.Password, userInfo.Password)
 	if match {
+		// Return error if the account may not log in. Will be handled by controller
+		err = checkAccountState(uc.requireVerifiedEmail, userInfo)
+		if err != nil {
+			return "", false, err
+		}
+
+		// The password is only known here, so this is when a hash of an outdated algorithm or cost can be upgraded
+		if uc.hasher.NeedsRehash(userInfo.Password) {
+			uc.rehashPassword(ctx, userInfo.UserUuid, userCredentials.Password, userInfo.Password)
+		}
 		return userInfo.UserUuid, true, nil
 	}
 	// Return error if password does not match found. Will be handled by controller
@@ -51,8 +64,13 @@ func (uc *LoginUseCase) VerifyCredentials(ctx context.Context, userCredentials e
 }
 
 func (uc *LoginUseCase) RegisterUser(ctx context.Context, userRegistration entity.UserRegistration) error {
+	// Uploaded images can only become an avatar through an upload. Will be handled by controller
+	if entity.IsImageURL(userRegistration.Avatar) {
+		return entity.ErrInvalidAvatar
+	}
+
 	// Hash password before storing into database
-	hashedPassword, err := hashPassword(userRegistration.Password)
+	hashedPassword, err := uc.hasher.Hash(userRegistration.Password)
 	if err != nil {
 		return err
 	}
@@ -85,12 +103,35 @@ func (uc *LoginUseCase) RegisterUser(ctx context.Context, userRegistration entit
 	return nil
 }
 
-func hashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
+// rehashPassword replaces the stored hash with one of the current algorithm and parameters.
+// Failing to do so does not fail the login, the old hash keeps working and is upgraded on a later login.
+func (uc *LoginUseCase) rehashPassword(ctx context.Context, userUUID string, password string, oldHash string) {
+	newHash, err := uc.hasher.Hash(password)
+	if err != nil {
+		return
+	}
+
+	// Only replaces the hash that was verified, a password changed in the meantime is kept
+	_ = uc.repo.ReplacePasswordHash(ctx, userUUID, oldHash, newHash)
+}
+
+// checkAccountState returns an error if the account may not log in, whichever way the user logs in.
+// A pending deletion is only cancelled once the login is complete and a session is created.
+func checkAccountState(requireVerifiedEmail bool, userInfo *entity.UserCredentialsDTO) error {
+	// Return error if the email has to be verified before logging in. Will be handled by controller
+	if requireVerifiedEmail && userInfo.VerifiedAt == nil {
+		return entity.ErrEmailNotVerified
+	}
+
+	// Return error if an admin suspended the account. Will be handled by controller
+	if userInfo.SuspendedAt != nil {
+		return entity.ErrAccountSuspended
+	}
+	return nil
 }
 
-func verifyPassword(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+// verifyPassword reports whether the password matches the stored hash, hashes of an unknown format never match
+func verifyPassword(hasher PasswordHasher, password string, hash string) bool {
+	match, err := hasher.Verify(password, hash)
+	return err == nil && match
 }
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+)
+
+const (
+	// Shorter queries match too many users to be useful
+	_searchMinQueryLength = 2
+	_searchMaxQueryLength = 64
+	_searchDefaultLimit   = 20
+	_searchMaxLimit       = 50
+)
+
+type UserSearchUseCase struct {
+	repo    UserSearchRepo
+	limiter *rateLimiter
+}
+
+// NewUserSearch -.
+// Each user can search rateLimit times per rateWindow, a rateLimit of zero disables the limit.
+func NewUserSearch(r UserSearchRepo, rateLimit int, rateWindow time.Duration) *UserSearchUseCase {
+	return &UserSearchUseCase{
+		repo:    r,
+		limiter: newRateLimiter(rateLimit, rateWindow),
+	}
+}
+
+// SearchUsers returns a page of users whose username, first or last name start with or resemble the query.
+func (uc *UserSearchUseCase) SearchUsers(ctx context.Context, query entity.UserSearchQuery) (entity.UserSearchPage, error) {
+	// Return error if the user searched too often. Will be handled by controller
+	if !uc.limiter.allow(query.UserUUID, time.Now()) {
+		return entity.UserSearchPage{}, entity.ErrTooManyRequests
+	}
+
+	// Matching is case insensitive
+	query.Query = strings.ToLower(strings.TrimSpace(query.Query))
+
+	// Return error if the query is too short to be useful. Will be handled by controller
+	length := utf8.RuneCountInString(query.Query)
+	if length < _searchMinQueryLength {
+		return entity.UserSearchPage{}, entity.ErrInvalidSearchQuery
+	}
+	if length > _searchMaxQueryLength {
+		query.Query = string([]rune(query.Query)[:_searchMaxQueryLength])
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = _searchDefaultLimit
+	}
+	if query.Limit > _searchMaxLimit {
+		query.Limit = _searchMaxLimit
+	}
+	limit := query.Limit
+
+	// One more result than requested tells whether there is a next page
+	query.Limit++
+
+	// Search users from user search data repository by querying 'user_credentials' and 'user_info' tables
+	results, err := uc.repo.SearchUsers(ctx, query)
+	if err != nil {
+		return entity.UserSearchPage{}, fmt.Errorf("UserSearchUseCase - SearchUsers - uc.repo.SearchUsers: %w", err)
+	}
+
+	page := entity.UserSearchPage{Results: results, Limit: limit}
+	if len(results) > limit {
+		page.Results = results[:limit]
+		last := page.Results[limit-1]
+		page.NextCursor = &entity.UserSearchCursor{Score: last.Score, UserUUID: last.UserUUID}
+	}
+	return page, nil
+}
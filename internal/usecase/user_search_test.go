@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxyong7/chat-messaging-app/internal/entity"
+	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+)
+
+func TestUserSearchUseCase_SearchUsers(t *testing.T) {
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                   // Name of the test case
+		query      entity.UserSearchQuery                   // Input search query
+		setupMocks func(mockRepo *mocks.MockUserSearchRepo) // Function to set up mock behavior
+		want       entity.UserSearchPage                    // Expected page
+		wantErr    error                                    // Expected error, if any
+		wantAnyErr bool                                     // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:  "success - next page available",
+			query: entity.UserSearchQuery{UserUUID: testUserUUID, Query: "  JoH ", Limit: 2},
+			setupMocks: func(mockRepo *mocks.MockUserSearchRepo) {
+				mockRepo.EXPECT().SearchUsers(gomock.Any(), entity.UserSearchQuery{UserUUID: testUserUUID, Query: "joh", Limit: 3}).
+					Return([]entity.UserSearchResult{
+						{UserUUID: "user_1", Score: 1.5},
+						{UserUUID: "user_2", Score: 1.2},
+						{UserUUID: "user_3", Score: 0.4},
+					}, nil)
+			},
+			want: entity.UserSearchPage{
+				Results:    []entity.UserSearchResult{{UserUUID: "user_1", Score: 1.5}, {UserUUID: "user_2", Score: 1.2}},
+				NextCursor: &entity.UserSearchCursor{Score: 1.2, UserUUID: "user_2"},
+				Limit:      2,
+			},
+		},
+		{
+			name:  "success - last page with default limit",
+			query: entity.UserSearchQuery{UserUUID: testUserUUID, Query: "john", Cursor: &entity.UserSearchCursor{Score: 1.2, UserUUID: "user_2"}},
+			setupMocks: func(mockRepo *mocks.MockUserSearchRepo) {
+				mockRepo.EXPECT().SearchUsers(gomock.Any(), entity.UserSearchQuery{
+					UserUUID: testUserUUID,
+					Query:    "john",
+					Cursor:   &entity.UserSearchCursor{Score: 1.2, UserUUID: "user_2"},
+					Limit:    _searchDefaultLimit + 1,
+				}).Return([]entity.UserSearchResult{{UserUUID: "user_3", Score: 0.4}}, nil)
+			},
+			want: entity.UserSearchPage{
+				Results: []entity.UserSearchResult{{UserUUID: "user_3", Score: 0.4}},
+				Limit:   _searchDefaultLimit,
+			},
+		},
+		{
+			name:  "success - limit capped",
+			query: entity.UserSearchQuery{UserUUID: testUserUUID, Query: "john", Limit: 1000},
+			setupMocks: func(mockRepo *mocks.MockUserSearchRepo) {
+				mockRepo.EXPECT().SearchUsers(gomock.Any(), entity.UserSearchQuery{UserUUID: testUserUUID, Query: "john", Limit: _searchMaxLimit + 1}).
+					Return(nil, nil)
+			},
+			want: entity.UserSearchPage{Limit: _searchMaxLimit},
+		},
+		{
+			name:    "error - query too short",
+			query:   entity.UserSearchQuery{UserUUID: testUserUUID, Query: " j "},
+			wantErr: entity.ErrInvalidSearchQuery,
+		},
+		{
+			name:  "error searching users",
+			query: entity.UserSearchQuery{UserUUID: testUserUUID, Query: "john"},
+			setupMocks: func(mockRepo *mocks.MockUserSearchRepo) {
+				mockRepo.EXPECT().SearchUsers(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create a mock instance of the repository
+			mockRepo := mocks.NewMockUserSearchRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo)
+			}
+
+			uc := NewUserSearch(mockRepo, 0, time.Minute)
+
+			// Call the method under test
+			got, err := uc.SearchUsers(context.Background(), tt.query)
+			if tt.wantErr != nil || tt.wantAnyErr {
+				if err == nil || (tt.wantErr != nil && err != tt.wantErr) {
+					t.Errorf("UserSearchUseCase.SearchUsers() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UserSearchUseCase.SearchUsers() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserSearchUseCase.SearchUsers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserSearchUseCase_SearchUsersRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserSearchRepo(ctrl)
+	mockRepo.EXPECT().SearchUsers(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+
+	uc := NewUserSearch(mockRepo, 2, time.Hour)
+	query := entity.UserSearchQuery{UserUUID: testUserUUID, Query: "john"}
+
+	// The limit allows two searches per window
+	for i := 0; i < 2; i++ {
+		if _, err := uc.SearchUsers(context.Background(), query); err != nil {
+			t.Fatalf("UserSearchUseCase.SearchUsers() search %d error = %v", i+1, err)
+		}
+	}
+	if _, err := uc.SearchUsers(context.Background(), query); err != entity.ErrTooManyRequests {
+		t.Errorf("UserSearchUseCase.SearchUsers() error = %v, want %v", err, entity.ErrTooManyRequests)
+	}
+
+	// Other users have their own limit
+	mockRepo.EXPECT().SearchUsers(gomock.Any(), gomock.Any()).Return(nil, nil)
+	if _, err := uc.SearchUsers(context.Background(), entity.UserSearchQuery{UserUUID: "other_user", Query: "john"}); err != nil {
+		t.Errorf("UserSearchUseCase.SearchUsers() other user error = %v", err)
+	}
+}
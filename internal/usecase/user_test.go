@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/maxyong7/chat-messaging-app/internal/entity"
 	mocks "github.com/maxyong7/chat-messaging-app/internal/usecase/mocks"
+	"github.com/maxyong7/chat-messaging-app/pkg/password"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -26,6 +28,8 @@ func TestLoginUseCase_VerifyCredentials(t *testing.T) {
 		wantUUID   string                             // The expected UUID returned on success
 		wantMatch  bool                               // Whether the credentials are expected to match
 		wantErr    bool                               // Whether the test expects an error to occur
+
+		requireVerifiedEmail bool // Whether login requires a verified email
 	}
 
 	// Example hashed password for testing
@@ -56,6 +60,26 @@ func TestLoginUseCase_VerifyCredentials(t *testing.T) {
 			wantMatch: true,
 			wantErr:   false,
 		},
+		{
+			name: "success - password alone keeps scheduled account deletion",
+			args: args{
+				ctx: context.Background(),
+				userCredentials: entity.UserCredentials{
+					Username: "testuser",
+					Password: "password123",
+				},
+			},
+			setupMocks: func(mockRepo *mocks.MockUserRepo) {
+				deleteAfter := time.Now().Add(time.Hour)
+				mockRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Username: "testuser", Password: "password123"}).
+					Return(&entity.UserCredentialsDTO{UserUuid: "user_uuid_1234", Password: string(hashedPassword), DeleteAfter: &deleteAfter}, nil)
+				// The deletion is cancelled when the session is created, after the second factor
+			},
+			wantUUID:  "user_uuid_1234",
+			wantMatch: true,
+			wantErr:   false,
+		},
 		{
 			name: "error - user not found", // Test case for when the user is not found
 			args: args{
@@ -102,6 +126,50 @@ func TestLoginUseCase_VerifyCredentials(t *testing.T) {
 			wantMatch: false,
 			wantErr:   true,
 		},
+		{
+			name: "error - email not verified", // Test case for when login requires a verified email
+			args: args{
+				ctx: context.Background(),
+				userCredentials: entity.UserCredentials{
+					Username: "testuser",
+					Password: "password123",
+				},
+			},
+			// This function sets up the mock to simulate a user that has not verified their email
+			setupMocks: func(mockRepo *mocks.MockUserRepo) {
+				userCredentialsDTO := entity.UserCredentialsDTO{
+					Username: "testuser",
+					Password: "password123",
+				}
+				mockRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), userCredentialsDTO).
+					Return(&entity.UserCredentialsDTO{UserUuid: "user_uuid_1234", Password: string(hashedPassword)}, nil)
+			},
+			wantUUID:             "",
+			wantMatch:            false,
+			wantErr:              true,
+			requireVerifiedEmail: true,
+		},
+		{
+			name: "error - account suspended", // Test case for when an admin suspended the account
+			args: args{
+				ctx: context.Background(),
+				userCredentials: entity.UserCredentials{
+					Username: "testuser",
+					Password: "password123",
+				},
+			},
+			// This function sets up the mock to simulate a suspended user
+			setupMocks: func(mockRepo *mocks.MockUserRepo) {
+				suspendedAt := time.Now()
+				mockRepo.EXPECT().
+					GetUserCredentials(gomock.Any(), entity.UserCredentialsDTO{Username: "testuser", Password: "password123"}).
+					Return(&entity.UserCredentialsDTO{UserUuid: "user_uuid_1234", Password: string(hashedPassword), SuspendedAt: &suspendedAt}, nil)
+			},
+			wantUUID:  "",
+			wantMatch: false,
+			wantErr:   true,
+		},
 	}
 
 	// Iterate over each test case and run it
@@ -121,7 +189,9 @@ func TestLoginUseCase_VerifyCredentials(t *testing.T) {
 
 			// Create an instance of LoginUseCase using the mock repository
 			uc := &LoginUseCase{
-				repo: mockRepo,
+				repo:                 mockRepo,
+				hasher:               password.NewBcrypt(bcrypt.DefaultCost),
+				requireVerifiedEmail: tt.requireVerifiedEmail,
 			}
 
 			// Call the method under test with the provided arguments
@@ -237,6 +307,24 @@ func TestLoginUseCase_RegisterUser(t *testing.T) {
 			},
 			wantErr: true, // The test expects an error to occur
 		},
+		{
+			name: "error - uploaded image as avatar", // Test case for an avatar pointing at an uploaded image
+			args: args{
+				ctx: context.Background(),
+				userRegistration: entity.UserRegistration{
+					UserCredentials: entity.UserCredentials{
+						Username: "newuser",
+						Password: "newpassword",
+						Email:    "newuser@example.com",
+					},
+					FirstName: "New",
+					LastName:  "User",
+					Avatar:    entity.ImageURL("other_image_uuid"),
+				},
+			},
+			// The user is not stored, so no repository call is expected
+			wantErr: true, // The test expects an error to occur
+		},
 	}
 
 	// Iterate over each test case and run it
@@ -256,7 +344,8 @@ func TestLoginUseCase_RegisterUser(t *testing.T) {
 
 			// Create an instance of LoginUseCase using the mock repository
 			uc := &LoginUseCase{
-				repo: mockRepo,
+				repo:   mockRepo,
+				hasher: password.NewBcrypt(bcrypt.DefaultCost),
 			}
 
 			// Call the method under test with the provided arguments
@@ -269,3 +358,81 @@ func TestLoginUseCase_RegisterUser(t *testing.T) {
 		})
 	}
 }
+
+func TestLoginUseCase_VerifyCredentialsRehash(t *testing.T) {
+	argon2idHasher := password.NewArgon2id(password.Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1})
+	bcryptHasher := password.NewBcrypt(bcrypt.MinCost)
+
+	bcryptHash, _ := bcryptHasher.Hash("password123")
+	argon2idHash, _ := argon2idHasher.Hash("password123")
+	outdatedArgon2idHash, _ := password.NewArgon2id(password.Argon2idParams{Memory: 512, Iterations: 1, Parallelism: 1}).Hash("password123")
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string // Name of the test case
+		storedHash string // Hash stored in 'user_credentials' table
+		password   string // Password used to log in
+		wantRehash bool   // Whether the stored hash is expected to be replaced
+		wantMatch  bool   // Whether the credentials are expected to match
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:       "bcrypt hash upgraded to argon2id",
+			storedHash: bcryptHash,
+			password:   "password123",
+			wantRehash: true,
+			wantMatch:  true,
+		},
+		{
+			name:       "argon2id hash with outdated parameters upgraded",
+			storedHash: outdatedArgon2idHash,
+			password:   "password123",
+			wantRehash: true,
+			wantMatch:  true,
+		},
+		{
+			name:       "current hash kept",
+			storedHash: argon2idHash,
+			password:   "password123",
+			wantMatch:  true,
+		},
+		{
+			name:       "outdated hash kept on wrong password",
+			storedHash: bcryptHash,
+			password:   "wrongpassword",
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepo(ctrl)
+			mockRepo.EXPECT().GetUserCredentials(gomock.Any(), gomock.Any()).
+				Return(&entity.UserCredentialsDTO{UserUuid: testUserUUID, Password: tt.storedHash}, nil)
+			if tt.wantRehash {
+				mockRepo.EXPECT().ReplacePasswordHash(gomock.Any(), testUserUUID, tt.storedHash, gomock.Any()).
+					Do(func(_ context.Context, _ string, _ string, newHash string) {
+						// The new hash is created by the current algorithm with its current parameters
+						if argon2idHasher.NeedsRehash(newHash) || !verifyPassword(argon2idHasher, tt.password, newHash) {
+							t.Errorf("LoginUseCase.VerifyCredentials() stored hash %q, want an argon2id hash of the password", newHash)
+						}
+					}).
+					Return(nil)
+			}
+
+			uc := NewAuth(mockRepo, password.New(argon2idHasher, bcryptHasher), false)
+
+			// Call the method under test
+			_, gotMatch, _ := uc.VerifyCredentials(context.Background(), entity.UserCredentials{Username: "testuser", Password: tt.password})
+			if gotMatch != tt.wantMatch {
+				t.Errorf("LoginUseCase.VerifyCredentials() gotMatch = %v, want %v", gotMatch, tt.wantMatch)
+			}
+		})
+	}
+}
@@ -3,18 +3,28 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/maxyong7/chat-messaging-app/internal/entity"
 )
 
+const (
+	_maxStatusEmojiLength = 16
+	_maxStatusTextLength  = 100
+)
+
 type UserProfileUseCase struct {
-	repo UserRepo
+	repo         UserRepo
+	contactsRepo ContactsRepo
 }
 
 // New -.
-func NewUserProfile(r UserRepo) *UserProfileUseCase {
+func NewUserProfile(r UserRepo, contactsRepo ContactsRepo) *UserProfileUseCase {
 	return &UserProfileUseCase{
-		repo: r,
+		repo:         r,
+		contactsRepo: contactsRepo,
 	}
 }
 
@@ -33,10 +43,27 @@ func (uc *UserProfileUseCase) GetUserProfile(ctx context.Context, userUUID strin
 		return userProfileEntity, entity.ErrUserNotFound
 	}
 
+	// The background job may not have cleared an expired status yet
+	if userProfileEntity.Status.Expired(time.Now()) {
+		userProfileEntity.Status = nil
+	}
+
 	return userProfileEntity, nil
 }
 
 func (uc *UserProfileUseCase) UpdateUserProfile(ctx context.Context, userProfile entity.UserProfile) error {
+	// An uploaded image can only be kept as avatar, not set. Returned error will be handled by controller
+	if entity.IsImageURL(userProfile.Avatar) {
+		// Get user profile from user data repository by querying 'user_info' table
+		current, err := uc.repo.GetUserProfile(ctx, userProfile.UserUUID)
+		if err != nil {
+			return fmt.Errorf("UserProfileUseCase - UpdateUserProfile - GetUserProfile: %w", err)
+		}
+		if current == nil || current.Avatar != userProfile.Avatar {
+			return entity.ErrInvalidAvatar
+		}
+	}
+
 	// Convert user profile entity object into userProfileDTO
 	userProfileDTO := entity.UserProfileDTO(userProfile)
 
@@ -47,3 +74,70 @@ func (uc *UserProfileUseCase) UpdateUserProfile(ctx context.Context, userProfile
 	}
 	return nil
 }
+
+// SetStatus replaces the custom status of the user and returns the change to push to their contacts.
+func (uc *UserProfileUseCase) SetStatus(ctx context.Context, userUUID string, status entity.UserStatus) (entity.StatusChange, error) {
+	status.Emoji = strings.TrimSpace(status.Emoji)
+	status.Text = strings.TrimSpace(status.Text)
+
+	// Return error if the status is empty, too long or already expired. Will be handled by controller
+	if status.Emoji == "" && status.Text == "" ||
+		utf8.RuneCountInString(status.Emoji) > _maxStatusEmojiLength ||
+		utf8.RuneCountInString(status.Text) > _maxStatusTextLength ||
+		status.Expired(time.Now()) {
+		return entity.StatusChange{}, entity.ErrInvalidStatus
+	}
+
+	// Update the status in 'user_info' table using user data repository
+	err := uc.repo.UpdateUserStatus(ctx, userUUID, &status)
+	if err != nil {
+		return entity.StatusChange{}, fmt.Errorf("UserProfileUseCase - SetStatus - uc.repo.UpdateUserStatus: %w", err)
+	}
+
+	return uc.buildStatusChange(ctx, userUUID, &status)
+}
+
+// ClearStatus removes the custom status of the user and returns the change to push to their contacts.
+func (uc *UserProfileUseCase) ClearStatus(ctx context.Context, userUUID string) (entity.StatusChange, error) {
+	// Clear the status in 'user_info' table using user data repository
+	err := uc.repo.UpdateUserStatus(ctx, userUUID, nil)
+	if err != nil {
+		return entity.StatusChange{}, fmt.Errorf("UserProfileUseCase - ClearStatus - uc.repo.UpdateUserStatus: %w", err)
+	}
+
+	return uc.buildStatusChange(ctx, userUUID, nil)
+}
+
+// ClearExpiredStatuses removes the custom statuses that have expired and returns the changes to push to the contacts of their users.
+func (uc *UserProfileUseCase) ClearExpiredStatuses(ctx context.Context) ([]entity.StatusChange, error) {
+	// Clear the expired statuses in 'user_info' table using user data repository
+	userUUIDs, err := uc.repo.ClearExpiredStatuses(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("UserProfileUseCase - ClearExpiredStatuses - uc.repo.ClearExpiredStatuses: %w", err)
+	}
+
+	changes := make([]entity.StatusChange, 0, len(userUUIDs))
+	for _, userUUID := range userUUIDs {
+		change, err := uc.buildStatusChange(ctx, userUUID, nil)
+		if err != nil {
+			return changes, fmt.Errorf("UserProfileUseCase - ClearExpiredStatuses - uc.buildStatusChange: %w", err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// buildStatusChange adds the contacts who have to be told about the status.
+func (uc *UserProfileUseCase) buildStatusChange(ctx context.Context, userUUID string, status *entity.UserStatus) (entity.StatusChange, error) {
+	// Get the contacts from contacts data repository by querying 'contacts' table
+	contactUUIDs, err := uc.contactsRepo.GetContactUUIDs(ctx, userUUID)
+	if err != nil {
+		return entity.StatusChange{}, fmt.Errorf("UserProfileUseCase - buildStatusChange - uc.contactsRepo.GetContactUUIDs: %w", err)
+	}
+
+	return entity.StatusChange{
+		UserUUID:     userUUID,
+		Status:       status,
+		ContactUUIDs: contactUUIDs,
+	}, nil
+}
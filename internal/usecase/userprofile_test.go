@@ -3,7 +3,9 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/maxyong7/chat-messaging-app/internal/entity"
@@ -190,6 +192,47 @@ func TestUserProfileUseCase_UpdateUserProfile(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "success - uploaded avatar kept", // Test case for a profile update that keeps the uploaded avatar
+			args: args{
+				ctx: context.Background(),
+				userProfile: entity.UserProfile{
+					UserUUID:  "user_uuid_1234",
+					FirstName: "Updated",
+					LastName:  "User",
+					Avatar:    entity.ImageURL("own_image_uuid"),
+				},
+			},
+			// This function sets up the mock to return the uploaded avatar as the current one
+			setupMocks: func(mockRepo *mocks.MockUserRepo) {
+				mockRepo.EXPECT().
+					GetUserProfile(gomock.Any(), "user_uuid_1234").
+					Return(&entity.UserProfileDTO{UserUUID: "user_uuid_1234", Avatar: entity.ImageURL("own_image_uuid")}, nil)
+				mockRepo.EXPECT().
+					UpdateUserProfile(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error - uploaded image of another user", // Test case for an avatar pointing at an image the user did not upload
+			args: args{
+				ctx: context.Background(),
+				userProfile: entity.UserProfile{
+					UserUUID:  "user_uuid_1234",
+					FirstName: "Updated",
+					LastName:  "User",
+					Avatar:    entity.ImageURL("other_image_uuid"),
+				},
+			},
+			// This function sets up the mock to return a different current avatar, the profile is not updated
+			setupMocks: func(mockRepo *mocks.MockUserRepo) {
+				mockRepo.EXPECT().
+					GetUserProfile(gomock.Any(), "user_uuid_1234").
+					Return(&entity.UserProfileDTO{UserUUID: "user_uuid_1234", Avatar: entity.ImageURL("own_image_uuid")}, nil)
+			},
+			wantErr: true,
+		},
 	}
 
 	// Iterate over each test case and run it
@@ -222,3 +265,107 @@ func TestUserProfileUseCase_UpdateUserProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestUserProfileUseCase_SetStatus(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	// Define the structure of each test case
+	type testCase struct {
+		name       string                                                                       // Name of the test case
+		status     entity.UserStatus                                                            // Input status
+		setupMocks func(mockRepo *mocks.MockUserRepo, mockContactsRepo *mocks.MockContactsRepo) // Function to set up mock behavior
+		wantErr    error                                                                        // Expected sentinel error
+		wantAnyErr bool                                                                         // Whether any error is expected
+	}
+
+	// List of test cases to run
+	tests := []testCase{
+		{
+			name:   "success - status with expiry",
+			status: entity.UserStatus{Emoji: "📅", Text: " In a meeting ", ExpiresAt: &future},
+			setupMocks: func(mockRepo *mocks.MockUserRepo, mockContactsRepo *mocks.MockContactsRepo) {
+				mockRepo.EXPECT().UpdateUserStatus(gomock.Any(), testUserUUID, &entity.UserStatus{Emoji: "📅", Text: "In a meeting", ExpiresAt: &future}).Return(nil)
+				mockContactsRepo.EXPECT().GetContactUUIDs(gomock.Any(), testUserUUID).Return([]string{"contact-uuid"}, nil)
+			},
+		},
+		{
+			name:    "empty status",
+			status:  entity.UserStatus{Text: "  "},
+			wantErr: entity.ErrInvalidStatus,
+		},
+		{
+			name:    "text too long",
+			status:  entity.UserStatus{Text: strings.Repeat("a", _maxStatusTextLength+1)},
+			wantErr: entity.ErrInvalidStatus,
+		},
+		{
+			name:    "already expired",
+			status:  entity.UserStatus{Text: "Out for lunch", ExpiresAt: &past},
+			wantErr: entity.ErrInvalidStatus,
+		},
+		{
+			name:   "error storing status",
+			status: entity.UserStatus{Text: "Out for lunch"},
+			setupMocks: func(mockRepo *mocks.MockUserRepo, mockContactsRepo *mocks.MockContactsRepo) {
+				mockRepo.EXPECT().UpdateUserStatus(gomock.Any(), testUserUUID, gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	// Iterate over each test case
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new mock controller
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Create mock instances of the repositories
+			mockRepo := mocks.NewMockUserRepo(ctrl)
+			mockContactsRepo := mocks.NewMockContactsRepo(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo, mockContactsRepo)
+			}
+
+			uc := NewUserProfile(mockRepo, mockContactsRepo)
+
+			// Call the method under test
+			change, err := uc.SetStatus(context.Background(), testUserUUID, tt.status)
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("UserProfileUseCase.SetStatus() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if (err != nil) != tt.wantAnyErr {
+				t.Errorf("UserProfileUseCase.SetStatus() error = %v, wantAnyErr %v", err, tt.wantAnyErr)
+				return
+			}
+			if err == nil && (change.UserUUID != testUserUUID || change.Status == nil || len(change.ContactUUIDs) != 1) {
+				t.Errorf("UserProfileUseCase.SetStatus() = %+v", change)
+			}
+		})
+	}
+}
+
+func TestUserProfileUseCase_ClearExpiredStatuses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	mockContactsRepo := mocks.NewMockContactsRepo(ctrl)
+	mockRepo.EXPECT().ClearExpiredStatuses(gomock.Any(), gomock.Any()).Return([]string{testUserUUID}, nil)
+	mockContactsRepo.EXPECT().GetContactUUIDs(gomock.Any(), testUserUUID).Return([]string{"contact-uuid"}, nil)
+
+	uc := NewUserProfile(mockRepo, mockContactsRepo)
+
+	// Every cleared status is pushed to the contacts of its user as a removal
+	changes, err := uc.ClearExpiredStatuses(context.Background())
+	if err != nil || len(changes) != 1 {
+		t.Fatalf("UserProfileUseCase.ClearExpiredStatuses() = %+v, %v", changes, err)
+	}
+	if changes[0].Status != nil || changes[0].ContactUUIDs[0] != "contact-uuid" {
+		t.Errorf("UserProfileUseCase.ClearExpiredStatuses() change = %+v", changes[0])
+	}
+}
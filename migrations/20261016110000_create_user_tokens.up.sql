@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    user_uuid TEXT NOT NULL,
+    purpose TEXT NOT NULL,
+    payload TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user_uuid_purpose ON user_tokens (user_uuid, purpose);
@@ -0,0 +1,4 @@
+ALTER TABLE user_credentials ADD verified_at TIMESTAMPTZ;
+
+-- Accounts created before verification existed keep being able to log in
+UPDATE user_credentials SET verified_at = CURRENT_TIMESTAMP WHERE verified_at IS NULL;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_uuid TEXT PRIMARY KEY,
+    secret TEXT NOT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    confirmed_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_uuid TEXT NOT NULL,
+    code_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    used_at TIMESTAMPTZ,
+    CONSTRAINT idx_mfa_recovery_codes_user_code UNIQUE (user_uuid, code_hash)
+);
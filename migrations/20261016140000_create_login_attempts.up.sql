@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS login_attempts (
+    attempt_key TEXT PRIMARY KEY,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    attempt_key TEXT NOT NULL,
+    identifier TEXT,
+    ip_address TEXT,
+    failures INTEGER NOT NULL,
+    locked_until TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_lockouts_created_at ON login_lockouts (created_at);
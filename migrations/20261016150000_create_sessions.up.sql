@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS sessions (
+    session_uuid TEXT PRIMARY KEY,
+    user_uuid TEXT NOT NULL,
+    device_name TEXT,
+    user_agent TEXT,
+    ip_address TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_uuid ON sessions (user_uuid);
+
+-- Sessions created before this migration have no device information
+INSERT INTO sessions (session_uuid, user_uuid, created_at, last_seen_at, expires_at, revoked_at)
+SELECT session_uuid, MIN(user_uuid), MIN(created_at), MAX(created_at), MAX(expires_at),
+    CASE WHEN BOOL_AND(revoked_at IS NOT NULL) THEN MAX(revoked_at) END
+FROM refresh_tokens
+GROUP BY session_uuid
+ON CONFLICT (session_uuid) DO NOTHING;
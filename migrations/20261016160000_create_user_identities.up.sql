@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS user_identities (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    issuer TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    user_uuid TEXT NOT NULL,
+    email TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (issuer, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_uuid ON user_identities (user_uuid);
+
+CREATE TABLE IF NOT EXISTS sso_login_states (
+    state_hash TEXT PRIMARY KEY,
+    nonce TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_credentials_delete_after;
+ALTER TABLE user_credentials DROP COLUMN IF EXISTS delete_after;
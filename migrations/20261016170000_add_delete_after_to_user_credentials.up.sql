@@ -0,0 +1,4 @@
+-- Set while an account waits out its deletion grace period
+ALTER TABLE user_credentials ADD delete_after TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_user_credentials_delete_after ON user_credentials (delete_after) WHERE delete_after IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_messages_user_uuid_created_at;
+DROP TABLE IF EXISTS data_exports;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS data_exports (
+    export_uuid TEXT PRIMARY KEY,
+    user_uuid TEXT NOT NULL,
+    status TEXT NOT NULL,
+    storage_key TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_uuid ON data_exports (user_uuid);
+CREATE INDEX IF NOT EXISTS idx_data_exports_expires_at ON data_exports (expires_at);
+
+-- Sent messages are exported in (created_at, message_uuid) order
+CREATE INDEX IF NOT EXISTS idx_messages_user_uuid_created_at ON messages (user_uuid, created_at, message_uuid);
@@ -0,0 +1,11 @@
+-- Usernames a user gave up, they stay reserved for that user until reserved_until
+CREATE TABLE IF NOT EXISTS username_history (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_uuid TEXT NOT NULL,
+    username TEXT NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reserved_until TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_username_history_username ON username_history (LOWER(username), reserved_until);
+CREATE INDEX IF NOT EXISTS idx_username_history_user_uuid ON username_history (user_uuid);
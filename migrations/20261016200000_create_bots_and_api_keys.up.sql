@@ -0,0 +1,27 @@
+-- Bots are users without credentials, their profile is stored in 'user_info' under bot_uuid
+CREATE TABLE IF NOT EXISTS bots (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    bot_uuid TEXT NOT NULL UNIQUE,
+    owner_uuid TEXT NOT NULL,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_bots_owner_uuid ON bots (owner_uuid);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    key_uuid TEXT NOT NULL UNIQUE,
+    bot_uuid TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    key_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    conversation_uuids TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_bot_uuid ON api_keys (bot_uuid);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS privacy_settings;
+
+DROP INDEX IF EXISTS idx_user_info_last_name_trgm;
+DROP INDEX IF EXISTS idx_user_info_first_name_trgm;
+DROP INDEX IF EXISTS idx_user_credentials_username_trgm;
@@ -0,0 +1,13 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Trigram indexes serve both the prefix and the fuzzy matches of the user search
+CREATE INDEX IF NOT EXISTS idx_user_credentials_username_trgm ON user_credentials USING GIN (LOWER(username) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_user_info_first_name_trgm ON user_info USING GIN (LOWER(first_name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_user_info_last_name_trgm ON user_info USING GIN (LOWER(last_name) gin_trgm_ops);
+
+-- Users without a row use the defaults
+CREATE TABLE IF NOT EXISTS privacy_settings (
+    user_uuid TEXT PRIMARY KEY,
+    discoverable BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,6 @@
+-- Whether a user is online is only known by the server holding their websocket connections,
+-- the table keeps when they were last seen for when they are offline
+CREATE TABLE IF NOT EXISTS user_presence (
+    user_uuid TEXT PRIMARY KEY,
+    last_seen_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_user_info_status_expires_at;
+
+ALTER TABLE user_info DROP COLUMN IF EXISTS status_expires_at;
+ALTER TABLE user_info DROP COLUMN IF EXISTS status_text;
+ALTER TABLE user_info DROP COLUMN IF EXISTS status_emoji;
@@ -0,0 +1,7 @@
+-- The custom status is cleared by setting every column to NULL
+ALTER TABLE user_info ADD COLUMN IF NOT EXISTS status_emoji TEXT;
+ALTER TABLE user_info ADD COLUMN IF NOT EXISTS status_text TEXT;
+ALTER TABLE user_info ADD COLUMN IF NOT EXISTS status_expires_at TIMESTAMPTZ;
+
+-- The background job looks for the statuses that have expired
+CREATE INDEX IF NOT EXISTS idx_user_info_status_expires_at ON user_info (status_expires_at) WHERE status_expires_at IS NOT NULL;
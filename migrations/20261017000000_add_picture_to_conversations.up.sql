@@ -0,0 +1,2 @@
+-- Group chats reference their uploaded picture like users reference their avatar
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS picture TEXT;
@@ -0,0 +1,5 @@
+ALTER TABLE privacy_settings
+    DROP COLUMN IF EXISTS show_presence,
+    DROP COLUMN IF EXISTS read_receipts,
+    DROP COLUMN IF EXISTS who_can_add_to_groups,
+    DROP COLUMN IF EXISTS who_can_add_contact;
@@ -0,0 +1,5 @@
+ALTER TABLE privacy_settings
+    ADD COLUMN IF NOT EXISTS who_can_add_contact TEXT NOT NULL DEFAULT 'everyone',
+    ADD COLUMN IF NOT EXISTS who_can_add_to_groups TEXT NOT NULL DEFAULT 'everyone',
+    ADD COLUMN IF NOT EXISTS read_receipts BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS show_presence BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS admin_audit_log;
+
+ALTER TABLE user_credentials
+    DROP COLUMN IF EXISTS suspension_reason,
+    DROP COLUMN IF EXISTS suspended_at,
+    DROP COLUMN IF EXISTS role;
@@ -0,0 +1,20 @@
+-- Admins are promoted by hand, e.g. UPDATE user_credentials SET role = 'admin' WHERE username = '...'
+ALTER TABLE user_credentials
+    ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'user',
+    ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS suspension_reason TEXT NOT NULL DEFAULT '';
+
+-- Every admin action is kept, also after the admin or the target is deleted
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    audit_uuid TEXT NOT NULL UNIQUE,
+    admin_uuid TEXT NOT NULL,
+    action TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_uuid TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_created_at ON admin_audit_log (created_at);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_target ON admin_audit_log (target_type, target_uuid);
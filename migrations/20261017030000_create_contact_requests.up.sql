@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS contact_requests (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    request_uuid TEXT NOT NULL UNIQUE,
+    from_user_uuid TEXT NOT NULL,
+    to_user_uuid TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMPTZ
+);
+
+-- A user can only have one pending request to the same user, answered requests are kept as history
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contact_requests_pending ON contact_requests (from_user_uuid, to_user_uuid) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_contact_requests_to_user_uuid ON contact_requests (to_user_uuid) WHERE status = 'pending';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_contacts_labels;
+
+ALTER TABLE contacts
+    DROP COLUMN IF EXISTS labels,
+    DROP COLUMN IF EXISTS favourite,
+    DROP COLUMN IF EXISTS nickname;
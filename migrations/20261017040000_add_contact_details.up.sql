@@ -0,0 +1,7 @@
+-- Nicknames, favourites and labels are set by the user on their own row, the contact does not see them
+ALTER TABLE contacts
+    ADD COLUMN IF NOT EXISTS nickname TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS favourite BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS labels TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_contacts_labels ON contacts USING GIN (labels);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_participants_conversation_uuid;
+DROP INDEX IF EXISTS idx_participants_user_uuid;
+DROP INDEX IF EXISTS idx_contacts_contact_user_uuid;
+DROP INDEX IF EXISTS idx_contacts_user_uuid;
+
+DROP TABLE IF EXISTS contact_suggestion_state;
+DROP TABLE IF EXISTS contact_suggestions;
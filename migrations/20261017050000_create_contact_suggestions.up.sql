@@ -0,0 +1,20 @@
+-- Suggestions are computed per user and kept until a contact or group change around the user invalidates them
+CREATE TABLE IF NOT EXISTS contact_suggestions (
+    user_uuid TEXT NOT NULL,
+    suggested_user_uuid TEXT NOT NULL,
+    mutual_contacts INT NOT NULL DEFAULT 0,
+    shared_groups INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_uuid, suggested_user_uuid)
+);
+
+CREATE TABLE IF NOT EXISTS contact_suggestion_state (
+    user_uuid TEXT PRIMARY KEY,
+    computed_at TIMESTAMPTZ NOT NULL,
+    invalidated_at TIMESTAMPTZ
+);
+
+-- Suggestions are computed from the contacts and the group chats around the user
+CREATE INDEX IF NOT EXISTS idx_contacts_user_uuid ON contacts (user_uuid);
+CREATE INDEX IF NOT EXISTS idx_contacts_contact_user_uuid ON contacts (contact_user_uuid);
+CREATE INDEX IF NOT EXISTS idx_participants_user_uuid ON participants (user_uuid);
+CREATE INDEX IF NOT EXISTS idx_participants_conversation_uuid ON participants (conversation_uuid);
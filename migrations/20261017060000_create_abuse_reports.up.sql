@@ -0,0 +1,24 @@
+-- The reported message is copied at report time, its sender can delete it afterwards
+CREATE TABLE IF NOT EXISTS abuse_reports (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    report_uuid TEXT NOT NULL UNIQUE,
+    reporter_uuid TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_uuid TEXT NOT NULL,
+    conversation_uuid TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    message_sender_uuid TEXT NOT NULL DEFAULT '',
+    message_content TEXT NOT NULL DEFAULT '',
+    message_created_at TIMESTAMPTZ,
+    status TEXT NOT NULL DEFAULT 'open',
+    reviewer_uuid TEXT NOT NULL DEFAULT '',
+    resolution_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A user can only have one unhandled report of the same target, handled reports are kept as history
+CREATE UNIQUE INDEX IF NOT EXISTS idx_abuse_reports_pending ON abuse_reports (reporter_uuid, target_type, target_uuid) WHERE status IN ('open', 'in_review');
+CREATE INDEX IF NOT EXISTS idx_abuse_reports_status ON abuse_reports (status, id);
+CREATE INDEX IF NOT EXISTS idx_abuse_reports_target ON abuse_reports (target_type, target_uuid);
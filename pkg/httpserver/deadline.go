@@ -0,0 +1,27 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type responseWriterKey struct{}
+
+// ExposeResponseWriter keeps the server's response writer in the request context, so that handlers
+// behind a router that wraps the writer can still reach it through SetWriteDeadline.
+func ExposeResponseWriter(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), responseWriterKey{}, w)))
+	})
+}
+
+// SetWriteDeadline moves the write deadline of the response to r, for responses that take longer
+// to send than the server's WriteTimeout allows.
+func SetWriteDeadline(r *http.Request, deadline time.Time) error {
+	w, ok := r.Context().Value(responseWriterKey{}).(http.ResponseWriter)
+	if !ok {
+		return http.ErrNotSupported
+	}
+	return http.NewResponseController(w).SetWriteDeadline(deadline)
+}
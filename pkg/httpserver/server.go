@@ -24,7 +24,7 @@ type Server struct {
 // New -.
 func New(handler http.Handler, opts ...Option) *Server {
 	httpServer := &http.Server{
-		Handler:      handler,
+		Handler:      ExposeResponseWriter(handler),
 		ReadTimeout:  _defaultReadTimeout,
 		WriteTimeout: _defaultWriteTimeout,
 		Addr:         _defaultAddr,
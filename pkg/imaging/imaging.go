@@ -0,0 +1,70 @@
+// Package imaging crops and scales images with the standard library only.
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Flatten draws the image over an opaque background, so that it can be encoded in formats without transparency.
+func Flatten(src image.Image, background color.Color) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Over)
+	return dst
+}
+
+// Thumbnail crops the largest centered square out of the image and scales it to size x size pixels.
+// Every pixel of the thumbnail is the average of the pixels it covers, which keeps downscaled images smooth.
+func Thumbnail(src *image.RGBA, size int) *image.RGBA {
+	bounds := src.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0, y1 := span(crop.Min.Y, side, size, y)
+		for x := 0; x < size; x++ {
+			x0, x1 := span(crop.Min.X, side, size, x)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				i := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(src.Pix[i])
+					g += uint64(src.Pix[i+1])
+					b += uint64(src.Pix[i+2])
+					a += uint64(src.Pix[i+3])
+					n++
+					i += 4
+				}
+			}
+
+			j := dst.PixOffset(x, y)
+			dst.Pix[j] = uint8(r / n)
+			dst.Pix[j+1] = uint8(g / n)
+			dst.Pix[j+2] = uint8(b / n)
+			dst.Pix[j+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// span returns the source pixels [from, to) covered by pixel i of a scaled line of size pixels.
+// It covers at least one pixel, so that images smaller than the thumbnail are scaled up.
+func span(min, side, size, i int) (int, int) {
+	from := min + i*side/size
+	to := min + (i+1)*side/size
+	if to <= from {
+		to = from + 1
+	}
+	return from, to
+}
@@ -0,0 +1,119 @@
+package jwtsigner
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// Supported algorithms.
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+// Key -.
+type Key struct {
+	ID     string
+	Method jwt.SigningMethod
+
+	// signKey is nil for keys that are only kept to verify tokens issued before a rotation
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// JWKSet -.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is the public part of a key as defined in RFC 7517.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// NewKey creates a key from its id, algorithm and key material.
+// For HS256 the material is the shared secret. For RS256 and EdDSA it is a PEM encoded
+// private key, or a public key if the key is only used to verify tokens.
+func NewKey(id string, algorithm string, material []byte) (*Key, error) {
+	if id == "" {
+		return nil, fmt.Errorf("jwtsigner - NewKey - key id is required")
+	}
+	if len(material) == 0 {
+		return nil, fmt.Errorf("jwtsigner - NewKey - key %q has no key material", id)
+	}
+
+	key := &Key{ID: id}
+
+	switch algorithm {
+	case HS256:
+		key.Method = jwt.SigningMethodHS256
+		key.signKey = material
+		key.verifyKey = material
+	case RS256:
+		key.Method = jwt.SigningMethodRS256
+		if privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(material); err == nil {
+			key.signKey = privateKey
+			key.verifyKey = &privateKey.PublicKey
+			break
+		}
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(material)
+		if err != nil {
+			return nil, fmt.Errorf("jwtsigner - NewKey - key %q - jwt.ParseRSAPublicKeyFromPEM: %w", id, err)
+		}
+		key.verifyKey = publicKey
+	case EdDSA:
+		key.Method = jwt.SigningMethodEdDSA
+		if privateKey, err := jwt.ParseEdPrivateKeyFromPEM(material); err == nil {
+			key.signKey = privateKey
+			key.verifyKey = privateKey.(ed25519.PrivateKey).Public()
+			break
+		}
+		publicKey, err := jwt.ParseEdPublicKeyFromPEM(material)
+		if err != nil {
+			return nil, fmt.Errorf("jwtsigner - NewKey - key %q - jwt.ParseEdPublicKeyFromPEM: %w", id, err)
+		}
+		key.verifyKey = publicKey
+	default:
+		return nil, fmt.Errorf("jwtsigner - NewKey - key %q has unsupported algorithm %q", id, algorithm)
+	}
+
+	return key, nil
+}
+
+func (k *Key) jwk() (JWK, bool) {
+	jwk := JWK{
+		Use: "sig",
+		Alg: k.Method.Alg(),
+		Kid: k.ID,
+	}
+
+	switch publicKey := k.verifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	default:
+		return JWK{}, false
+	}
+
+	return jwk, true
+}
@@ -0,0 +1,90 @@
+// Package jwtsigner implements signing and verification of JWTs with a set of rotatable keys.
+package jwtsigner
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang-jwt/jwt"
+)
+
+var (
+	// ErrUnknownKey is returned when a token references a key id that is not configured.
+	ErrUnknownKey = errors.New("unknown signing key")
+	// ErrUnexpectedAlgorithm is returned when a token's algorithm does not match its key.
+	ErrUnexpectedAlgorithm = errors.New("unexpected signing method")
+)
+
+// Signer -.
+type Signer struct {
+	active *Key
+	keys   map[string]*Key
+}
+
+// New creates a Signer that signs with the key identified by activeKeyID
+// and verifies tokens signed by any of the given keys.
+func New(activeKeyID string, keys ...*Key) (*Signer, error) {
+	s := &Signer{
+		keys: make(map[string]*Key, len(keys)),
+	}
+
+	for _, key := range keys {
+		if _, ok := s.keys[key.ID]; ok {
+			return nil, fmt.Errorf("jwtsigner - New - duplicate key id %q", key.ID)
+		}
+		s.keys[key.ID] = key
+	}
+
+	active, ok := s.keys[activeKeyID]
+	if !ok {
+		return nil, fmt.Errorf("jwtsigner - New - active key %q: %w", activeKeyID, ErrUnknownKey)
+	}
+	if active.signKey == nil {
+		return nil, fmt.Errorf("jwtsigner - New - active key %q has no private key", activeKeyID)
+	}
+	s.active = active
+
+	return s, nil
+}
+
+// Sign signs the claims with the active key and sets its id in the 'kid' header.
+func (s *Signer) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.active.Method, claims)
+	token.Header["kid"] = s.active.ID
+	return token.SignedString(s.active.signKey)
+}
+
+// Parse verifies the token with the key named in its 'kid' header.
+func (s *Signer) Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.keys[kid]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+
+		// The algorithm must match the key, otherwise a public key could be used as an HMAC secret
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, ErrUnexpectedAlgorithm
+		}
+		return key.verifyKey, nil
+	})
+}
+
+// JWKS returns the public keys of every asymmetric key so other services can verify tokens.
+// HMAC secrets are never published.
+func (s *Signer) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range s.keys {
+		if jwk, ok := key.jwk(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+
+	// Keep the output stable between calls so that it can be cached by clients
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].Kid < set.Keys[j].Kid
+	})
+	return set
+}
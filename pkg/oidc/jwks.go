@@ -0,0 +1,79 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// jsonWebKeySet is a key set as defined in RFC 7517.
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKeys returns the signing keys of the set by key id. Keys of unsupported types are skipped.
+func (s jsonWebKeySet) publicKeys() map[string]interface{} {
+	keys := make(map[string]interface{}, len(s.Keys))
+	for _, k := range s.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		switch k.Kty {
+		case "RSA":
+			n, errN := base64.RawURLEncoding.DecodeString(k.N)
+			e, errE := base64.RawURLEncoding.DecodeString(k.E)
+			if errN != nil || errE != nil {
+				continue
+			}
+			keys[k.Kid] = &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			}
+		case "EC":
+			curve := ellipticCurve(k.Crv)
+			x, errX := base64.RawURLEncoding.DecodeString(k.X)
+			y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+			if curve == nil || errX != nil || errY != nil {
+				continue
+			}
+			key := &ecdsa.PublicKey{
+				Curve: curve,
+				X:     new(big.Int).SetBytes(x),
+				Y:     new(big.Int).SetBytes(y),
+			}
+			if !curve.IsOnCurve(key.X, key.Y) {
+				continue
+			}
+			keys[k.Kid] = key
+		}
+	}
+	return keys
+}
+
+func ellipticCurve(crv string) elliptic.Curve {
+	switch crv {
+	case "P-256":
+		return elliptic.P256()
+	case "P-384":
+		return elliptic.P384()
+	case "P-521":
+		return elliptic.P521()
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+// Package oidc implements the OpenID Connect authorization code flow with PKCE against a single identity provider.
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	_defaultTimeout = 10 * time.Second
+	// Unknown key ids trigger a refetch of the provider's keys, but not more often than this
+	_keysRefreshInterval = time.Minute
+)
+
+var (
+	// ErrInvalidIDToken is returned when the ID token fails verification.
+	ErrInvalidIDToken = errors.New("invalid id token")
+	// ErrTokenExchange is returned when the provider refuses to exchange the authorization code.
+	ErrTokenExchange = errors.New("token exchange failed")
+)
+
+// Config -.
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// HTTPClient is used for every request to the provider. Defaults to a client with a 10 second timeout.
+	HTTPClient *http.Client
+}
+
+// Claims are the identity claims of a verified ID token.
+type Claims struct {
+	Issuer            string `json:"iss"`
+	Subject           string `json:"sub"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	GivenName         string `json:"given_name"`
+	FamilyName        string `json:"family_name"`
+	PreferredUsername string `json:"preferred_username"`
+	Picture           string `json:"picture"`
+}
+
+// Provider -.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+
+	mu            sync.Mutex
+	metadata      *metadata
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+// metadata is the part of the discovery document used by the flow.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// New creates a Provider. The discovery document is fetched on first use,
+// so the application can start while the provider is unavailable.
+func New(cfg Config) *Provider {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: _defaultTimeout}
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	cfg.IssuerURL = strings.TrimSuffix(cfg.IssuerURL, "/")
+
+	return &Provider{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+// CodeChallenge returns the S256 PKCE challenge of a code verifier.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL returns the URL of the provider's login page.
+func (p *Provider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(p.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(md.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return md.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange redeems the authorization code and returns the claims of the verified ID token.
+func (p *Provider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*Claims, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, md.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("oidc - Exchange - http.NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc - Exchange - p.client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc - Exchange - status %d: %w", resp.StatusCode, ErrTokenExchange)
+	}
+
+	var tokenResponse struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return nil, fmt.Errorf("oidc - Exchange - json.Decode: %w", err)
+	}
+	if tokenResponse.IDToken == "" {
+		return nil, fmt.Errorf("oidc - Exchange - no id_token in response: %w", ErrTokenExchange)
+	}
+
+	return p.VerifyIDToken(ctx, tokenResponse.IDToken, nonce)
+}
+
+// VerifyIDToken checks the signature, issuer, audience, expiry and nonce of an ID token.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*Claims, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mapClaims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, mapClaims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := p.key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+
+		// The algorithm must match the key type, 'none' and HMAC are never accepted
+		switch key.(type) {
+		case *rsa.PublicKey:
+			if _, ok := token.Method.(*jwt.SigningMethodRSA); ok {
+				return key, nil
+			}
+		case *ecdsa.PublicKey:
+			if _, ok := token.Method.(*jwt.SigningMethodECDSA); ok {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unexpected signing method %q", token.Method.Alg())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("oidc - VerifyIDToken - %v: %w", err, ErrInvalidIDToken)
+	}
+
+	if iss, _ := mapClaims["iss"].(string); iss != md.Issuer {
+		return nil, fmt.Errorf("oidc - VerifyIDToken - unexpected issuer %q: %w", iss, ErrInvalidIDToken)
+	}
+	if !hasAudience(mapClaims["aud"], p.cfg.ClientID) {
+		return nil, fmt.Errorf("oidc - VerifyIDToken - token is not issued for this client: %w", ErrInvalidIDToken)
+	}
+	if _, ok := mapClaims["exp"]; !ok {
+		return nil, fmt.Errorf("oidc - VerifyIDToken - token has no expiry: %w", ErrInvalidIDToken)
+	}
+	// The nonce ties the token to the login that was started by this client
+	if tokenNonce, _ := mapClaims["nonce"].(string); tokenNonce != nonce {
+		return nil, fmt.Errorf("oidc - VerifyIDToken - nonce mismatch: %w", ErrInvalidIDToken)
+	}
+
+	raw, err := json.Marshal(mapClaims)
+	if err != nil {
+		return nil, fmt.Errorf("oidc - VerifyIDToken - json.Marshal: %w", err)
+	}
+	var claims Claims
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return nil, fmt.Errorf("oidc - VerifyIDToken - json.Unmarshal: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("oidc - VerifyIDToken - token has no subject: %w", ErrInvalidIDToken)
+	}
+
+	return &claims, nil
+}
+
+// discover fetches and caches the provider's discovery document.
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	var md metadata
+	err := p.getJSON(ctx, p.cfg.IssuerURL+"/.well-known/openid-configuration", &md)
+	if err != nil {
+		return nil, fmt.Errorf("oidc - discover: %w", err)
+	}
+
+	// The document must belong to the configured issuer, otherwise ID tokens of another issuer would be accepted
+	if strings.TrimSuffix(md.Issuer, "/") != p.cfg.IssuerURL {
+		return nil, fmt.Errorf("oidc - discover - issuer %q does not match %q", md.Issuer, p.cfg.IssuerURL)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc - discover - incomplete discovery document")
+	}
+
+	p.metadata = &md
+	return p.metadata, nil
+}
+
+// key returns the provider's public key with the given id, refetching the key set if it is unknown.
+func (p *Provider) key(ctx context.Context, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	// Providers rotate keys, so an unknown key id means the cached set is stale
+	if time.Since(p.keysFetchedAt) < _keysRefreshInterval {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	var set jsonWebKeySet
+	err := p.getJSON(ctx, p.metadata.JWKSURI, &set)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = set.publicKeys()
+	p.keysFetchedAt = time.Now()
+
+	key, ok := p.keys[kid]
+	if !ok {
+		// Providers with a single key may leave out the key id
+		if kid == "" && len(p.keys) == 1 {
+			for _, key := range p.keys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// hasAudience reports whether the 'aud' claim, a string or a list of strings, contains the client id.
+func hasAudience(aud interface{}, clientID string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
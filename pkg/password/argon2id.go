@@ -0,0 +1,95 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	_argon2idSaltLength = 16
+	_argon2idKeyLength  = 32
+)
+
+// Argon2idParams -.
+// Memory is in KiB.
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// Argon2id hashes passwords with Argon2id. Hashes are stored in the PHC string format:
+// '$argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<hash>'.
+type Argon2id struct {
+	params Argon2idParams
+}
+
+// NewArgon2id -.
+func NewArgon2id(params Argon2idParams) *Argon2id {
+	return &Argon2id{params: params}
+}
+
+// Hash -.
+func (a *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, _argon2idSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.params.Iterations, a.params.Memory, a.params.Parallelism, _argon2idKeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, a.params.Memory, a.params.Iterations, a.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify -.
+func (a *Argon2id) Verify(password string, encoded string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+// NeedsRehash -.
+func (a *Argon2id) NeedsRehash(encoded string) bool {
+	params, _, _, err := decodeArgon2id(encoded)
+	return err != nil || params != a.params
+}
+
+// decodeArgon2id parses a PHC string created by Argon2id.Hash
+func decodeArgon2id(encoded string) (Argon2idParams, []byte, []byte, error) {
+	// The leading '$' results in an empty first part
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return Argon2idParams{}, nil, nil, ErrUnsupportedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Argon2idParams{}, nil, nil, ErrUnsupportedHash
+	}
+
+	var params Argon2idParams
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return Argon2idParams{}, nil, nil, ErrUnsupportedHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2idParams{}, nil, nil, ErrUnsupportedHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Argon2idParams{}, nil, nil, ErrUnsupportedHash
+	}
+
+	return params, salt, key, nil
+}
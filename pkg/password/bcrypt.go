@@ -0,0 +1,43 @@
+package password
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Bcrypt hashes passwords with bcrypt. Hashes are stored in the modular crypt format ('$2a$<cost>$...'),
+// which the PHC string format keeps as is for bcrypt.
+type Bcrypt struct {
+	cost int
+}
+
+// NewBcrypt -.
+func NewBcrypt(cost int) *Bcrypt {
+	return &Bcrypt{cost: cost}
+}
+
+// Hash -.
+func (b *Bcrypt) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	return string(hash), err
+}
+
+// Verify -.
+func (b *Bcrypt) Verify(password string, encoded string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+		return false, nil
+	default:
+		return false, ErrUnsupportedHash
+	}
+}
+
+// NeedsRehash -.
+func (b *Bcrypt) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != b.cost
+}
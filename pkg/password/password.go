@@ -0,0 +1,58 @@
+// Package password hashes passwords into self-describing strings, so that the algorithm and its parameters
+// can be changed without invalidating the hashes that are already stored.
+package password
+
+import (
+	"errors"
+)
+
+// ErrUnsupportedHash is returned when a hash was not created by any of the configured algorithms.
+var ErrUnsupportedHash = errors.New("password: unsupported hash format")
+
+// Hasher hashes and verifies passwords.
+type Hasher interface {
+	// Hash returns the encoded hash of the password, including the algorithm, its parameters and the salt.
+	Hash(password string) (string, error)
+	// Verify reports whether the password matches the encoded hash.
+	// It returns ErrUnsupportedHash if the hash was created by another algorithm.
+	Verify(password string, encoded string) (bool, error)
+	// NeedsRehash reports whether the encoded hash was created by another algorithm or with other parameters.
+	NeedsRehash(encoded string) bool
+}
+
+// Upgrading hashes new passwords with the current hasher and still verifies hashes of the previous ones,
+// which are reported as needing a rehash.
+type Upgrading struct {
+	current  Hasher
+	previous []Hasher
+}
+
+// New returns a hasher that hashes with current and verifies with current and previous.
+func New(current Hasher, previous ...Hasher) *Upgrading {
+	return &Upgrading{
+		current:  current,
+		previous: previous,
+	}
+}
+
+// Hash -.
+func (h *Upgrading) Hash(password string) (string, error) {
+	return h.current.Hash(password)
+}
+
+// Verify -.
+func (h *Upgrading) Verify(password string, encoded string) (bool, error) {
+	for _, hasher := range append([]Hasher{h.current}, h.previous...) {
+		ok, err := hasher.Verify(password, encoded)
+		if errors.Is(err, ErrUnsupportedHash) {
+			continue
+		}
+		return ok, err
+	}
+	return false, ErrUnsupportedHash
+}
+
+// NeedsRehash -.
+func (h *Upgrading) NeedsRehash(encoded string) bool {
+	return h.current.NeedsRehash(encoded)
+}